@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/spf13/cobra"
+)
+
+// defaultDecisions is the number of decisions listed by default.
+const defaultDecisions = 20
+
+var (
+	limitFlag = commandLineFlag{
+		name:      "limit",
+		shorthand: "l",
+		usage:     fmt.Sprintf("number of decisions to list (default %d)", defaultDecisions),
+	}
+	decisionFlag = commandLineFlag{
+		name:  "decision",
+		usage: "list only the decisions of the kind, e.g. skipped-running",
+	}
+	decidedBetweenFlag = commandLineFlag{
+		name:      "between",
+		shorthand: "b",
+		usage:     "list the decisions made between the times, e.g. 2024-01-01,2024-01-07",
+	}
+)
+
+func decisionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "decisions [flags] /path/to/spec.yaml",
+		Short: "List the decisions the scheduler made on the firings of the DAG",
+		Long:  `dagu decisions [--limit=<N>] [--decision=<kind>] [--between=<from>,<to>] /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runDecisions),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{limitFlag, decisionFlag, decidedBetweenFlag})
+
+	return cmd
+}
+
+func runDecisions(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	dagStore, err := setup.dagStore()
+	if err != nil {
+		logger.Error(ctx, "Failed to initialize DAG store", "err", err)
+		return fmt.Errorf("failed to initialize DAG store: %w", err)
+	}
+
+	dag, err := dagStore.GetMetadata(ctx, args[0])
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "path", args[0], "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	filter := persistence.DecisionFilter{DAG: dag.ID(), Limit: defaultDecisions}
+	filter.Decision, _ = cmd.Flags().GetString("decision")
+
+	if limit, _ := cmd.Flags().GetString("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit <= 0 {
+			return fmt.Errorf("--limit must be a positive number: %s", limit)
+		}
+	}
+
+	if between, _ := cmd.Flags().GetString("between"); between != "" {
+		location := setup.cfg.Location
+		if location == nil {
+			location = time.Local
+		}
+		if filter.From, filter.To, err = parseBetween(between, location); err != nil {
+			return err
+		}
+	}
+
+	decisions, err := setup.decisionStore().Query(ctx, filter)
+	if err != nil {
+		logger.Error(ctx, "Failed to query the decisions", "dag", dag.ID(), "err", err)
+		return fmt.Errorf("failed to query the decisions: %w", err)
+	}
+
+	for _, d := range decisions {
+		args := []any{
+			"scheduledTime", stringutil.FormatTime(d.ScheduledTime),
+			"time", stringutil.FormatTime(d.Timestamp),
+			"type", d.Type,
+			"decision", d.Decision,
+		}
+		if d.Outcome != "" {
+			args = append(args, "outcome", d.Outcome)
+		}
+		if d.Reason != "" {
+			args = append(args, "reason", d.Reason)
+		}
+		logger.Info(ctx, "Decision", args...)
+	}
+	if len(decisions) == 0 {
+		logger.Info(ctx, "No decisions recorded", "dag", dag.ID())
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence/local"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecisionsCommand(t *testing.T) {
+	th := testSetup(t)
+	ctx := th.Context
+
+	id, err := th.Client.CreateDAG(ctx, "decisions-cmd")
+	require.NoError(t, err)
+	dagFile := filepath.Join(th.Config.Paths.DAGsDir, id+".yaml")
+
+	store := local.NewDecisionStore(filepath.Join(th.Config.Paths.DataDir, "decisions"), 0)
+	scheduled := time.Date(2030, 1, 1, 3, 0, 0, 0, time.UTC)
+	for _, decision := range []model.SchedulerDecision{
+		{ScheduledTime: scheduled, DAG: id, Type: "start", Decision: model.DecisionStarted},
+		{ScheduledTime: scheduled, DAG: id, Type: "start", Decision: model.DecisionStarted, Outcome: model.OutcomeFailed, Reason: "exit status 1"},
+		{ScheduledTime: scheduled.Add(time.Hour), DAG: id, Type: "start", Decision: model.DecisionSkippedRunning, Reason: "job already running"},
+	} {
+		require.NoError(t, store.Append(ctx, decision))
+	}
+
+	t.Run("List", func(t *testing.T) {
+		th.RunCommand(t, decisionsCmd(), cmdTest{
+			args:        []string{"decisions", dagFile},
+			expectedOut: []string{"decision=started", "outcome=failed", "decision=skipped-running", `reason="job already running"`},
+		})
+	})
+	t.Run("FilterByDecision", func(t *testing.T) {
+		th.RunCommand(t, decisionsCmd(), cmdTest{
+			args:        []string{"decisions", "--decision=started", dagFile},
+			expectedOut: []string{"decision=started"},
+		})
+	})
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/dagu-org/dagu/internal/agent"
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
 	"github.com/spf13/cobra"
 )
 
@@ -36,6 +37,7 @@ func runDry(cmd *cobra.Command, args []string) error {
 
 	loadOpts := []digraph.LoadOption{
 		digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig),
+		digraph.WithDAGsDir(setup.cfg.Paths.DAGsDir),
 	}
 
 	var params string
@@ -68,7 +70,14 @@ func runDry(cmd *cobra.Command, args []string) error {
 	}
 	defer logFile.Close()
 
-	ctx = setup.loggerContextWithFile(ctx, false, logFile)
+	ctx = setup.loggerContextWithFile(ctx, false, logFile, nil)
+
+	// Print the spec with the templates expanded to check what is run.
+	spec, err := digraph.Expand(dag.Location)
+	if err != nil {
+		return fmt.Errorf("failed to expand DAG %s: %w", dag.Name, err)
+	}
+	logger.Write(ctx, string(spec))
 
 	dagStore, err := setup.dagStore()
 	if err != nil {
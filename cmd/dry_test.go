@@ -23,6 +23,11 @@ func TestDryCommand(t *testing.T) {
 				args:        []string{"dry", th.DAG(t, "cmd/dry_with_params.yaml").Location, "--", "p5", "p6"},
 				expectedOut: []string{`[1=p5 2=p6]`},
 			},
+			{
+				name:        "DryRunDAGWithTemplates",
+				args:        []string{"dry", th.DAG(t, "cmd/dry_with_templates.yaml").Location},
+				expectedOut: []string{"command: echo hello templates", "Dry-run finished"},
+			},
 		}
 
 		for _, tc := range tests {
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/topology"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var formatFlag = commandLineFlag{
+	name:         "format",
+	shorthand:    "f",
+	defaultValue: topology.FormatASCII,
+	usage:        "output format: dot, mermaid or ascii",
+}
+
+func graphCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "graph [flags] /path/to/spec.yaml",
+		Short: "Render the dependency graph of the steps of the DAG",
+		Long:  `dagu graph [--format=dot|mermaid|ascii] [--request-id=<request-id>] [--expand] /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runGraph),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{
+		formatFlag,
+		withUsage(requestIDFlag, "request ID of the run to colour the steps by their status"),
+	})
+	cmd.Flags().BoolP("expand", "e", false, "expand the sub workflows recursively")
+
+	return cmd
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	dag, err := digraph.Load(ctx, args[0], digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig), digraph.WithDAGsDir(setup.cfg.Paths.DAGsDir))
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "path", args[0], "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	var opts topology.Options
+
+	if requestID, _ := cmd.Flags().GetString("request-id"); requestID != "" {
+		cli, err := setup.client()
+		if err != nil {
+			logger.Error(ctx, "failed to initialize client", "err", err)
+			return fmt.Errorf("failed to initialize client: %w", err)
+		}
+		status, err := cli.GetStatusByRequestID(ctx, dag, requestID)
+		if err != nil {
+			logger.Error(ctx, "Failed to retrieve the status of the run", "requestID", requestID, "err", err)
+			return fmt.Errorf("failed to retrieve the status of the run %s: %w", requestID, err)
+		}
+		opts.Status = status
+	}
+
+	if expand, _ := cmd.Flags().GetBool("expand"); expand {
+		dagStore, err := setup.dagStore()
+		if err != nil {
+			logger.Error(ctx, "Failed to initialize DAG store", "err", err)
+			return fmt.Errorf("failed to initialize DAG store: %w", err)
+		}
+		opts.LoadDAG = func(name string) (*digraph.DAG, error) {
+			return dagStore.GetDetails(ctx, name)
+		}
+	}
+
+	graph, err := topology.Build(dag, opts)
+	if err != nil {
+		logger.Error(ctx, "Failed to build the graph", "dag", dag.Name, "err", err)
+		return fmt.Errorf("failed to build the graph: %w", err)
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	return topology.Render(cmd.OutOrStdout(), graph, format)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphCommand(t *testing.T) {
+	th := testSetup(t)
+	ctx := th.Context
+
+	_, err := th.Client.CreateDAG(ctx, "graph-child")
+	require.NoError(t, err)
+
+	id, err := th.Client.CreateDAG(ctx, "graph-parent")
+	require.NoError(t, err)
+	require.NoError(t, th.Client.UpdateDAG(ctx, id, `steps:
+  - name: first
+    command: "true"
+  - name: second
+    run: graph-child
+    depends: first
+handlerOn:
+  exit:
+    command: "true"
+`, persistence.RevisionInfo{}))
+	dagFile := filepath.Join(th.Config.Paths.DAGsDir, id+".yaml")
+
+	// runGraph runs the command and returns the graph written to the
+	// standard output.
+	runGraph := func(t *testing.T, args ...string) string {
+		t.Helper()
+
+		var out bytes.Buffer
+		cmdRoot := &cobra.Command{Use: "root"}
+		cmdRoot.AddCommand(graphCmd())
+		cmdRoot.SetOut(&out)
+		cmdRoot.SetArgs(append([]string{"graph"}, args...))
+		require.NoError(t, cmdRoot.ExecuteContext(ctx))
+		return out.String()
+	}
+
+	t.Run("ASCII", func(t *testing.T) {
+		out := runGraph(t, dagFile)
+		require.Contains(t, out, "├── first\n")
+		require.Contains(t, out, "├── second (run: graph-child) <- first\n")
+		require.Contains(t, out, "    └── on exit: onExit\n")
+	})
+	t.Run("Expand", func(t *testing.T) {
+		out := runGraph(t, "--format=dot", "--expand", dagFile)
+		require.Contains(t, out, `subgraph "cluster_second/" {`)
+	})
+	t.Run("Status", func(t *testing.T) {
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+
+		status, err := th.Client.GetStatus(ctx, dagFile)
+		require.NoError(t, err)
+
+		out := runGraph(t, "--format=mermaid", "--request-id="+status.Status.RequestID, dagFile)
+		require.Contains(t, out, "class n0,n1,n2 finished")
+	})
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/logstream"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/spf13/cobra"
+	"golang.org/x/text/encoding"
+)
+
+var (
+	logsStepFlag = commandLineFlag{
+		name:  "step",
+		usage: "name of the step (default: the scheduler log of the run)",
+	}
+	logsTailFlag = commandLineFlag{
+		name:  "tail",
+		usage: "number of the last lines to print (default: all)",
+	}
+	logsSinceFlag = commandLineFlag{
+		name:  "since",
+		usage: "only print the lines written since the time (RFC3339) or the duration ago, e.g. 10m",
+	}
+	logsGrepFlag = commandLineFlag{
+		name:  "grep",
+		usage: "only print the lines matching the regular expression",
+	}
+)
+
+func logsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs [flags] /path/to/spec.yaml",
+		Short: "Print the log of a step or the scheduler log of a DAG run",
+		Long:  `dagu logs [--step=<name>] [--request-id=<request-id>] [--tail=<N>] [--since=<time>] [--grep=<pattern>] [--stderr] [--follow] /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runLogs),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{
+		logsStepFlag,
+		withUsage(requestIDFlag, "request ID of the run (default: the latest run)"),
+		logsTailFlag,
+		logsSinceFlag,
+		logsGrepFlag,
+	})
+	cmd.Flags().Bool("stderr", false, "print the file of the standard error of the step instead of the log")
+	cmd.Flags().BoolP("follow", "f", false, "print the lines written until the run finishes")
+
+	return cmd
+}
+
+func runLogs(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	step, _ := cmd.Flags().GetString("step")
+	stderr, _ := cmd.Flags().GetBool("stderr")
+	follow, _ := cmd.Flags().GetBool("follow")
+	if stderr && step == "" {
+		return errors.New("--stderr requires --step")
+	}
+	filter, err := parseLogFilter(cmd)
+	if err != nil {
+		return err
+	}
+
+	dag, err := digraph.Load(ctx, args[0], digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig), digraph.WithDAGsDir(setup.cfg.Paths.DAGsDir))
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "path", args[0], "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	requestID, _ := cmd.Flags().GetString("request-id")
+	if requestID == "" {
+		latest, err := cli.GetLatestStatus(ctx, dag)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve the latest status: %w", err)
+		}
+		if latest.RequestID == "" {
+			return fmt.Errorf("no runs of the DAG %s", dag.Name)
+		}
+		requestID = latest.RequestID
+	}
+
+	status, err := cli.GetStatusByRequestID(ctx, dag, requestID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve the status of the run %s: %w", requestID, err)
+	}
+	if step != "" && status.NodeByName(step) == nil {
+		return fmt.Errorf("step not found: %s", step)
+	}
+
+	// logFile returns the file to print in the status of the run and the
+	// time it started to be written.
+	logFile := func(s *model.Status) (string, time.Time) {
+		if step == "" {
+			startedAt, _ := stringutil.ParseTime(s.StartedAt)
+			return s.Log, startedAt
+		}
+		node := s.NodeByName(step)
+		startedAt, _ := stringutil.ParseTime(node.StartedAt)
+		if stderr {
+			return stderrFile(node), startedAt
+		}
+		return node.Log, startedAt
+	}
+	if stderr && stderrFile(status.NodeByName(step)) == "" {
+		return fmt.Errorf("the standard error of the step %s is not written to a file", step)
+	}
+
+	p := &logPrinter{
+		out:     cmd.OutOrStdout(),
+		decoder: logstream.NewDecoder(setup.cfg.UI.LogEncodingCharset),
+		filter:  filter,
+	}
+
+	// Print the lines written so far.
+	file, startedAt := logFile(status)
+	filter.last = startedAt
+	var offset int64
+	if file != "" {
+		var lines []string
+		if err := logstream.Follow(ctx, file, logstream.Options{Done: func() bool { return true }}, func(line logstream.Line) error {
+			offset = line.Offset
+			if text, ok := p.match(line.Text); ok {
+				lines = append(lines, text)
+				if filter.tail > 0 && len(lines) > filter.tail {
+					lines = lines[1:]
+				}
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to read the log %s: %w", file, err)
+		}
+		for _, text := range lines {
+			if _, err := fmt.Fprintln(p.out, text); err != nil {
+				return err
+			}
+		}
+	}
+	if !follow {
+		return nil
+	}
+
+	// Follow the lines written while the run is live. The status of the
+	// live run is read from the agent through its socket.
+	return logstream.FollowSource(ctx, func() (string, bool) {
+		current, err := cli.GetCurrentStatus(ctx, dag)
+		if err != nil || current.RequestID != requestID || current.Status != scheduler.StatusRunning {
+			return file, true
+		}
+		if file == "" {
+			file, filter.last = logFile(current)
+		}
+		return file, false
+	}, logstream.Options{Offset: offset}, func(line logstream.Line) error {
+		if text, ok := p.match(line.Text); ok {
+			_, err := fmt.Fprintln(p.out, text)
+			return err
+		}
+		return nil
+	})
+}
+
+// stderrFile returns the file of the standard error of the step, or an empty
+// string if it is not written to a file.
+func stderrFile(node *model.Node) string {
+	file := node.Step.Stderr
+	if file == "" || filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(node.Step.Dir, file)
+}
+
+// logFilter selects the lines of the log to print.
+type logFilter struct {
+	tail  int
+	since time.Time
+	grep  *regexp.Regexp
+	// last is the time of the last line with a timestamp. The lines without
+	// a timestamp are considered written at the time.
+	last time.Time
+}
+
+func parseLogFilter(cmd *cobra.Command) (*logFilter, error) {
+	f := &logFilter{}
+
+	if value, _ := cmd.Flags().GetString("tail"); value != "" {
+		tail, err := strconv.Atoi(value)
+		if err != nil || tail <= 0 {
+			return nil, fmt.Errorf("--tail must be a positive number: %s", value)
+		}
+		f.tail = tail
+	}
+
+	if value, _ := cmd.Flags().GetString("since"); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			f.since = time.Now().Add(-d)
+		} else if t, err := time.Parse(time.RFC3339, value); err == nil {
+			f.since = t
+		} else {
+			return nil, fmt.Errorf("--since must be a time in RFC3339 or a duration: %s", value)
+		}
+	}
+
+	if value, _ := cmd.Flags().GetString("grep"); value != "" {
+		re, err := regexp.Compile(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --grep pattern: %w", err)
+		}
+		f.grep = re
+	}
+
+	return f, nil
+}
+
+// logPrinter decodes and filters the lines of the log.
+type logPrinter struct {
+	out     io.Writer
+	decoder *encoding.Decoder
+	filter  *logFilter
+}
+
+// match returns the decoded line and whether it passes the filter.
+func (p *logPrinter) match(text string) (string, bool) {
+	if p.decoder != nil {
+		if decoded, err := p.decoder.String(text); err == nil {
+			text = decoded
+		}
+	}
+
+	f := p.filter
+	if t, ok := lineTime(text); ok {
+		f.last = t
+	}
+	if !f.since.IsZero() && f.last.Before(f.since) {
+		return "", false
+	}
+	if f.grep != nil && !f.grep.MatchString(text) {
+		return "", false
+	}
+	return text, true
+}
+
+// lineTime returns the timestamp of the line written by the structured
+// logger in JSON or text, or at the beginning of the line in RFC3339.
+func lineTime(text string) (time.Time, bool) {
+	var value string
+	switch {
+	case strings.HasPrefix(text, "{"):
+		var v struct {
+			Time string `json:"time"`
+		}
+		if err := json.Unmarshal([]byte(text), &v); err != nil {
+			return time.Time{}, false
+		}
+		value = v.Time
+	case strings.HasPrefix(text, "time="):
+		value, _, _ = strings.Cut(strings.TrimPrefix(text, "time="), " ")
+	default:
+		value, _, _ = strings.Cut(text, " ")
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	return t, err == nil
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogsCommand(t *testing.T) {
+	th := testHelper{Helper: test.Setup(t, test.WithCaptureLoggingOutput(), test.WithDAGsDir(t.TempDir()))}
+	ctx := th.Context
+
+	id, err := th.Client.CreateDAG(ctx, "logs-cmd")
+	require.NoError(t, err)
+	require.NoError(t, th.Client.UpdateDAG(ctx, id, `steps:
+  - name: hello
+    command: echo hello logs
+  - name: count
+    dir: `+t.TempDir()+`
+    stderr: err.txt
+    command: sh -c 'echo one; echo two; echo three; echo error >&2'
+    depends: hello
+`, persistence.RevisionInfo{}))
+	dagFile := filepath.Join(th.Config.Paths.DAGsDir, id+".yaml")
+
+	th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+
+	// runLogs runs the command and returns the standard output.
+	runLogs := func(t *testing.T, args ...string) string {
+		t.Helper()
+
+		var out bytes.Buffer
+		cmdRoot := &cobra.Command{Use: "root"}
+		cmdRoot.AddCommand(logsCmd())
+		cmdRoot.SetOut(&out)
+		cmdRoot.SetArgs(append([]string{"logs"}, args...))
+		require.NoError(t, cmdRoot.ExecuteContext(ctx))
+		return out.String()
+	}
+
+	t.Run("Step", func(t *testing.T) {
+		require.Equal(t, "hello logs\n", runLogs(t, "--step=hello", dagFile))
+	})
+	t.Run("Follow", func(t *testing.T) {
+		// The run has finished, so the command returns at the end of the log.
+		require.Equal(t, "hello logs\n", runLogs(t, "-f", "--step=hello", dagFile))
+	})
+	t.Run("Tail", func(t *testing.T) {
+		require.Equal(t, "two\nthree\n", runLogs(t, "--step=count", "--tail=2", dagFile))
+	})
+	t.Run("Grep", func(t *testing.T) {
+		require.Equal(t, "one\ntwo\n", runLogs(t, "--step=count", "--grep=^(one|two)$", dagFile))
+	})
+	t.Run("Since", func(t *testing.T) {
+		require.Empty(t, runLogs(t, "--step=count", "--since=2099-01-01T00:00:00Z", dagFile))
+		require.Equal(t, "hello logs\n", runLogs(t, "--step=hello", "--since=1h", dagFile))
+	})
+	t.Run("Stderr", func(t *testing.T) {
+		require.Equal(t, "error\n", runLogs(t, "--step=count", "--stderr", dagFile))
+	})
+	t.Run("RequestID", func(t *testing.T) {
+		dag, err := th.Client.GetStatus(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "hello logs\n", runLogs(t, "--step=hello", "--request-id="+dag.Status.RequestID, dagFile))
+	})
+}
+
+func TestLineTime(t *testing.T) {
+	for _, text := range []string{
+		`{"time":"2024-02-01T10:00:00.5Z","level":"INFO","msg":"started"}`,
+		`time=2024-02-01T10:00:00.5Z level=INFO msg=started`,
+		`2024-02-01T10:00:00.5Z started`,
+	} {
+		tm, ok := lineTime(text)
+		require.True(t, ok, text)
+		require.Equal(t, time.Date(2024, 2, 1, 10, 0, 0, 5e8, time.UTC), tm.UTC())
+	}
+
+	_, ok := lineTime("started")
+	require.False(t, ok)
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/dagu-org/dagu/internal/build"
+	"github.com/dagu-org/dagu/internal/cmdutil"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +16,9 @@ var (
 		Short: "YAML-based DAG scheduling tool.",
 		Long:  `YAML-based DAG scheduling tool.`,
 	}
+	// internalInvocation is the value of the hidden flag of the commands
+	// spawned by dagu itself. See cmdutil.MarkInternalInvocation.
+	internalInvocation string
 )
 
 func main() {
@@ -26,6 +30,9 @@ func main() {
 func init() {
 	build.Version = version
 
+	rootCmd.PersistentFlags().StringVar(&internalInvocation, cmdutil.InternalInvocationFlag, "", "")
+	_ = rootCmd.PersistentFlags().MarkHidden(cmdutil.InternalInvocationFlag)
+
 	registerCommands()
 }
 
@@ -40,4 +47,12 @@ func registerCommands() {
 	rootCmd.AddCommand(schedulerCmd())
 	rootCmd.AddCommand(retryCmd())
 	rootCmd.AddCommand(startAllCmd())
+	rootCmd.AddCommand(revisionsCmd())
+	rootCmd.AddCommand(scheduleCmd())
+	rootCmd.AddCommand(decisionsCmd())
+	rootCmd.AddCommand(graphCmd())
+	rootCmd.AddCommand(validateCmd())
+	rootCmd.AddCommand(statsCmd())
+	rootCmd.AddCommand(secretCmd())
+	rootCmd.AddCommand(logsCmd())
 }
@@ -46,7 +46,7 @@ func runRestart(cmd *cobra.Command, args []string) error {
 	ctx := setup.loggerContext(cmd.Context(), quiet)
 
 	specFilePath := args[0]
-	dag, err := digraph.Load(ctx, specFilePath, digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig))
+	dag, err := digraph.Load(ctx, specFilePath, digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig), digraph.WithDAGsDir(setup.cfg.Paths.DAGsDir))
 	if err != nil {
 		logger.Error(ctx, "Failed to load DAG", "path", specFilePath, "err", err)
 		return fmt.Errorf("failed to load DAG from %s: %w", specFilePath, err)
@@ -82,6 +82,7 @@ func handleRestartProcess(ctx context.Context, setup *setup, dag *digraph.DAG, q
 
 	loadOpts := []digraph.LoadOption{
 		digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig),
+		digraph.WithDAGsDir(setup.cfg.Paths.DAGsDir),
 	}
 	if status.Params != "" {
 		// backward compatibility
@@ -112,12 +113,17 @@ func executeDAG(ctx context.Context, cli client.Client, setup *setup,
 	if err != nil {
 		return fmt.Errorf("failed to initialize log file: %w", err)
 	}
-	defer logFile.Close()
+	defer setup.closeLogFile(ctx, logFile)
 
-	ctx = setup.loggerContextWithFile(ctx, quiet, logFile)
+	shipper := setup.logShipper(ctx, dag, requestID)
+	defer setup.closeLogShipper(ctx, shipper)
+
+	ctx = setup.loggerContextWithFile(ctx, quiet, logFile, shipper)
 
 	logger.Info(ctx, "DAG restart initiated", "DAG", dag.Name, "requestID", requestID, "logFile", logFile.Name())
 
+	setup.recordAudit(ctx, model.AuditActionRestart, dag, requestID)
+
 	dagStore, err := setup.dagStore()
 	if err != nil {
 		logger.Error(ctx, "Failed to initialize DAG store", "err", err)
@@ -132,7 +138,7 @@ func executeDAG(ctx context.Context, cli client.Client, setup *setup,
 		cli,
 		dagStore,
 		setup.historyStore(),
-		agent.Options{Dry: false})
+		setup.agentOptions(dag, shipper))
 
 	listenSignals(ctx, agentInstance)
 	if err := agentInstance.Run(ctx); err != nil {
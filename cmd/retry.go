@@ -70,6 +70,7 @@ func runRetry(cmd *cobra.Command, args []string) error {
 
 	loadOpts := []digraph.LoadOption{
 		digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig),
+		digraph.WithDAGsDir(setup.cfg.Paths.DAGsDir),
 	}
 
 	if status.Status.Params != "" {
@@ -107,11 +108,16 @@ func executeRetry(ctx context.Context, dag *digraph.DAG, setup *setup, originalS
 	if err != nil {
 		return fmt.Errorf("failed to initialize log file for DAG %s: %w", dag.Name, err)
 	}
-	defer logFile.Close()
+	defer setup.closeLogFile(ctx, logFile)
+
+	shipper := setup.logShipper(ctx, dag, newRequestID)
+	defer setup.closeLogShipper(ctx, shipper)
 
 	logger.Info(ctx, "DAG retry initiated", "DAG", dag.Name, "originalRequestID", originalStatus.Status.RequestID, "newRequestID", newRequestID, "logFile", logFile.Name())
 
-	ctx = setup.loggerContextWithFile(ctx, quiet, logFile)
+	ctx = setup.loggerContextWithFile(ctx, quiet, logFile, shipper)
+
+	setup.recordAudit(ctx, model.AuditActionRetry, dag, originalStatus.Status.RequestID)
 
 	dagStore, err := setup.dagStore()
 	if err != nil {
@@ -125,6 +131,8 @@ func executeRetry(ctx context.Context, dag *digraph.DAG, setup *setup, originalS
 		return fmt.Errorf("failed to initialize client: %w", err)
 	}
 
+	opts := setup.agentOptions(dag, shipper)
+	opts.RetryTarget = &originalStatus.Status
 	agentInstance := agent.New(
 		newRequestID,
 		dag,
@@ -133,7 +141,7 @@ func executeRetry(ctx context.Context, dag *digraph.DAG, setup *setup, originalS
 		cli,
 		dagStore,
 		setup.historyStore(),
-		agent.Options{RetryTarget: &originalStatus.Status},
+		opts,
 	)
 
 	listenSignals(ctx, agentInstance)
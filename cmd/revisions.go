@@ -0,0 +1,191 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/spf13/cobra"
+)
+
+var messageFlag = commandLineFlag{
+	name:      "message",
+	shorthand: "m",
+	usage:     "description of the change",
+}
+
+func revisionsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revisions",
+		Short: "Manage revisions of DAG specs",
+		Long:  `dagu revisions [list|diff|rollback] /path/to/spec.yaml`,
+	}
+
+	cmd.AddCommand(revisionsListCmd())
+	cmd.AddCommand(revisionsDiffCmd())
+	cmd.AddCommand(revisionsRollbackCmd())
+
+	return cmd
+}
+
+func revisionsListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list /path/to/spec.yaml",
+		Short: "List revisions of the DAG spec",
+		Long:  `dagu revisions list /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runRevisionsList),
+	}
+
+	initCommonFlags(cmd, nil)
+
+	return cmd
+}
+
+func runRevisionsList(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	revisions, err := cli.ListDAGRevisions(ctx, args[0])
+	if err != nil {
+		logger.Error(ctx, "Failed to list revisions", "dag", args[0], "err", err)
+		return fmt.Errorf("failed to list revisions: %w", err)
+	}
+
+	for _, revision := range revisions {
+		logger.Info(ctx, "Revision",
+			"hash", revision.ShortHash(),
+			"time", stringutil.FormatTime(revision.Timestamp),
+			"author", revision.Author,
+			"message", revision.Message,
+		)
+	}
+
+	return nil
+}
+
+func revisionsDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff /path/to/spec.yaml <revision> [<revision>]",
+		Short: "Show changes between revisions of the DAG spec",
+		Long: `dagu revisions diff /path/to/spec.yaml <revision> [<revision>]
+
+Compares the revision with the current spec if the second revision is omitted.`,
+		Args: cobra.RangeArgs(2, 3),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runRevisionsDiff),
+	}
+
+	initCommonFlags(cmd, nil)
+
+	return cmd
+}
+
+func runRevisionsDiff(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	var to string
+	if len(args) > 2 {
+		to = args[2]
+	}
+
+	diff, err := cli.DiffDAGRevisions(ctx, args[0], args[1], to)
+	if err != nil {
+		logger.Error(ctx, "Failed to diff revisions", "dag", args[0], "err", err)
+		return fmt.Errorf("failed to diff revisions: %w", err)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), diff)
+
+	return nil
+}
+
+func revisionsRollbackCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rollback [flags] /path/to/spec.yaml <revision>",
+		Short: "Restore the DAG spec to a revision",
+		Long:  `dagu revisions rollback /path/to/spec.yaml <revision> --message="Revert broken change"`,
+		Args:  cobra.ExactArgs(2),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runRevisionsRollback),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{messageFlag})
+
+	return cmd
+}
+
+func runRevisionsRollback(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	message, err := cmd.Flags().GetString("message")
+	if err != nil {
+		return fmt.Errorf("failed to get message: %w", err)
+	}
+
+	dagStore, err := setup.dagStore()
+	if err != nil {
+		logger.Error(ctx, "Failed to initialize DAG store", "err", err)
+		return fmt.Errorf("failed to initialize DAG store: %w", err)
+	}
+
+	dag, err := dagStore.GetMetadata(ctx, args[0])
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "path", args[0], "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	cli, err := setup.client(withDAGStore(dagStore))
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	if err := cli.RollbackDAG(ctx, args[0], args[1], persistence.RevisionInfo{
+		Author:  osUsername(),
+		Message: message,
+	}); err != nil {
+		logger.Error(ctx, "Failed to roll back", "dag", args[0], "revision", args[1], "err", err)
+		return fmt.Errorf("failed to roll back to %s: %w", args[1], err)
+	}
+
+	setup.recordAudit(ctx, model.AuditActionRollback, dag, "")
+	logger.Info(ctx, "Rolled back", "dag", dag.Name, "revision", args[1])
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevisionsCommand(t *testing.T) {
+	th := testSetup(t)
+	ctx := th.Context
+
+	id, err := th.Client.CreateDAG(ctx, "revisions-cmd")
+	require.NoError(t, err)
+	dagFile := filepath.Join(th.Config.Paths.DAGsDir, id+".yaml")
+
+	original, err := os.ReadFile(dagFile)
+	require.NoError(t, err)
+	originalHash := model.ShortRevisionHash(model.NewRevisionHash(original))
+
+	updated := `steps:
+  - name: step1
+    command: echo updated
+`
+	require.NoError(t, th.Client.UpdateDAG(ctx, id, updated, persistence.RevisionInfo{
+		Author:  "alice",
+		Message: "Update the command",
+	}))
+
+	t.Run("List", func(t *testing.T) {
+		th.RunCommand(t, revisionsCmd(), cmdTest{
+			args: []string{"revisions", "list", dagFile},
+			expectedOut: []string{
+				"hash=" + originalHash,
+				"author=alice",
+				`message="Update the command"`,
+			},
+		})
+	})
+	t.Run("Rollback", func(t *testing.T) {
+		th.RunCommand(t, revisionsCmd(), cmdTest{
+			args:        []string{"revisions", "rollback", "--message=Revert", dagFile, originalHash},
+			expectedOut: []string{"Rolled back"},
+		})
+
+		spec, err := os.ReadFile(dagFile)
+		require.NoError(t, err)
+		require.Equal(t, string(original), string(spec))
+
+		revisions, err := th.Client.ListDAGRevisions(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, "Revert", revisions[0].Message)
+	})
+	t.Run("SpecRevisionInStatus", func(t *testing.T) {
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+
+		status, err := th.Client.GetStatus(ctx, dagFile)
+		require.NoError(t, err)
+		require.Equal(t, model.NewRevisionHash(original), status.Status.SpecRevision)
+	})
+	t.Run("SpecChangedOutsideStore", func(t *testing.T) {
+		edited := `steps:
+  - name: step1
+    command: echo edited
+`
+		require.NoError(t, os.WriteFile(dagFile, []byte(edited), 0600))
+
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+
+		// The run records the edited spec as a revision to look it up.
+		status, err := th.Client.GetStatus(ctx, dagFile)
+		require.NoError(t, err)
+		require.Equal(t, model.NewRevisionHash([]byte(edited)), status.Status.SpecRevision)
+
+		_, spec, err := th.Client.GetDAGRevision(ctx, id, status.Status.SpecRevision)
+		require.NoError(t, err)
+		require.Equal(t, edited, spec)
+
+		// Running the same spec again does not add a revision.
+		revisions, err := th.Client.ListDAGRevisions(ctx, id)
+		require.NoError(t, err)
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+		again, err := th.Client.ListDAGRevisions(ctx, id)
+		require.NoError(t, err)
+		require.Len(t, again, len(revisions))
+	})
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/scheduler"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// defaultNextFirings is the number of firings listed by default.
+	defaultNextFirings = 10
+	// maxBetweenFirings is the number of firings listed at most for
+	// --between without --next, e.g. for a minutely schedule over a year.
+	maxBetweenFirings = 1000
+)
+
+var (
+	nextFlag = commandLineFlag{
+		name:      "next",
+		shorthand: "n",
+		usage:     fmt.Sprintf("number of upcoming firings to list (default %d)", defaultNextFirings),
+	}
+	betweenFlag = commandLineFlag{
+		name:      "between",
+		shorthand: "b",
+		usage:     "list the firings between the times, e.g. 2024-01-01,2024-01-07",
+	}
+)
+
+var errInvalidBetween = errors.New("--between must be two times separated by a comma")
+
+func scheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "List upcoming scheduled runs of the DAGs",
+		Long:  `dagu schedule [--dags=<DAGs dir>] [--next=<N>] [--between=<from>,<to>]`,
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, []string{"dags"})
+		},
+		RunE: wrapRunE(runSchedule),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{dagsFlag, nextFlag, betweenFlag})
+
+	return cmd
+}
+
+func runSchedule(cmd *cobra.Command, _ []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	if dagsDir, _ := cmd.Flags().GetString("dags"); dagsDir != "" {
+		setup.cfg.Paths.DAGsDir = dagsDir
+	}
+
+	location := setup.cfg.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	from, until := time.Now().In(location), time.Time{}
+	if between, _ := cmd.Flags().GetString("between"); between != "" {
+		if from, until, err = parseBetween(between, location); err != nil {
+			return err
+		}
+	}
+
+	var limit int
+	if next, _ := cmd.Flags().GetString("next"); next != "" {
+		if limit, err = strconv.Atoi(next); err != nil || limit <= 0 {
+			return fmt.Errorf("--next must be a positive number: %s", next)
+		}
+	} else if until.IsZero() {
+		limit = defaultNextFirings
+	} else {
+		limit = maxBetweenFirings
+	}
+
+	cli, err := setup.client()
+	if err != nil {
+		logger.Error(ctx, "failed to initialize client", "err", err)
+		return fmt.Errorf("failed to initialize client: %w", err)
+	}
+
+	manager, err := scheduler.LoadDAGJobManager(ctx, setup.cfg.Paths.DAGsDir, cli)
+	if err != nil {
+		return err
+	}
+
+	firings, err := scheduler.Firings(ctx, manager, from, until, limit)
+	if err != nil {
+		return fmt.Errorf("failed to compute the schedule: %w", err)
+	}
+
+	for _, f := range firings {
+		args := []any{
+			"time", stringutil.FormatTime(f.Time),
+			"type", strings.ToLower(f.Type.String()),
+			"dag", f.DAG.ID(),
+		}
+		if f.Location != nil {
+			args = append(args, "dagTime", stringutil.FormatTime(f.Time.In(f.Location)), "timezone", f.Location.String())
+		}
+		if f.Delay > 0 {
+			args = append(args, "startAt", stringutil.FormatTime(f.Time.Add(f.Delay)))
+		}
+		if f.SkipReason != "" {
+			args = append(args, "skipped", f.SkipReason)
+		}
+		logger.Info(ctx, "Scheduled", args...)
+	}
+	if len(firings) == 0 {
+		logger.Info(ctx, "No scheduled runs")
+	}
+	if next, _ := cmd.Flags().GetString("next"); next == "" && len(firings) == maxBetweenFirings {
+		logger.Warn(ctx, "Only the first firings are listed; narrow --between or set --next", "limit", maxBetweenFirings)
+	}
+
+	return nil
+}
+
+// Layouts accepted by --between in addition to RFC 3339.
+var betweenLayouts = []string{
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseBetween parses the time range "<from>,<to>" in the location. A date
+// without the time of day as the end covers the whole day.
+func parseBetween(value string, location *time.Location) (time.Time, time.Time, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return time.Time{}, time.Time{}, errInvalidBetween
+	}
+	from, _, err := parseTimeIn(strings.TrimSpace(parts[0]), location)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	until, dateOnly, err := parseTimeIn(strings.TrimSpace(parts[1]), location)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if dateOnly {
+		until = until.AddDate(0, 0, 1).Add(-time.Second)
+	}
+	if until.Before(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w: %s is before %s", errInvalidBetween, parts[1], parts[0])
+	}
+	return from, until, nil
+}
+
+func parseTimeIn(value string, location *time.Location) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.In(location), false, nil
+	}
+	for _, layout := range betweenLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, layout == "2006-01-02", nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("%w: invalid time %q", errInvalidBetween, value)
+}
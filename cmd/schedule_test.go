@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleCommand(t *testing.T) {
+	th := testSetup(t)
+	ctx := th.Context
+
+	id, err := th.Client.CreateDAG(ctx, "team-a/schedule-cmd")
+	require.NoError(t, err)
+	require.NoError(t, th.Client.UpdateDAG(ctx, id, `schedule:
+  start: "0 * * * *"
+  stop: "CRON_TZ=Asia/Tokyo 30 9 * * *"
+steps:
+  - name: step1
+    command: "true"
+`, persistence.RevisionInfo{}))
+
+	t.Run("Next", func(t *testing.T) {
+		th.RunCommand(t, scheduleCmd(), cmdTest{
+			args:        []string{"schedule", "--next", "2"},
+			expectedOut: []string{"dag=team-a/schedule-cmd", "type=start"},
+		})
+	})
+	t.Run("Between", func(t *testing.T) {
+		th.RunCommand(t, scheduleCmd(), cmdTest{
+			args:        []string{"schedule", "--between", "2030-01-01T00:00:00Z,2030-01-01T00:30:00Z"},
+			expectedOut: []string{"type=stop", "dagTime=2030-01-01T09:30:00+09:00", "timezone=Asia/Tokyo"},
+		})
+	})
+	t.Run("BetweenCapped", func(t *testing.T) {
+		// The hourly schedule fires more often than listed over a year.
+		th.RunCommand(t, scheduleCmd(), cmdTest{
+			args:        []string{"schedule", "--between", "2030-01-01,2030-12-31"},
+			expectedOut: []string{"Only the first firings are listed", "limit=1000"},
+		})
+	})
+}
+
+func TestParseBetween(t *testing.T) {
+	from, until, err := parseBetween("2024-01-01,2024-01-07", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), from)
+	require.Equal(t, time.Date(2024, 1, 7, 23, 59, 59, 0, time.UTC), until)
+
+	from, until, err = parseBetween("2024-01-01 09:00, 2024-01-01T10:00:00Z", time.UTC)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), from)
+	require.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), until)
+
+	_, _, err = parseBetween("2024-01-01", time.UTC)
+	require.ErrorIs(t, err, errInvalidBetween)
+	_, _, err = parseBetween("2024-01-02,2024-01-01T00:00:00Z", time.UTC)
+	require.ErrorIs(t, err, errInvalidBetween)
+}
@@ -37,6 +37,10 @@ func runScheduler(cmd *cobra.Command, _ []string) error {
 
 	logger.Info(ctx, "Scheduler initialization", "specsDirectory", setup.cfg.Paths.DAGsDir, "logFormat", setup.cfg.LogFormat)
 
+	if err := setup.startGitSync(ctx); err != nil {
+		return err
+	}
+
 	scheduler, err := setup.scheduler()
 	if err != nil {
 		return fmt.Errorf("failed to initialize scheduler: %w", err)
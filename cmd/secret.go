@@ -0,0 +1,208 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/dagu-org/dagu/internal/secrets"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/spf13/cobra"
+)
+
+var (
+	secretDAGFlag = commandLineFlag{
+		name:  "dag",
+		usage: "ID of the DAG the secret is scoped to",
+	}
+	secretGroupFlag = commandLineFlag{
+		name:  "group",
+		usage: "group of the DAGs the secret is scoped to",
+	}
+)
+
+func secretCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secret",
+		Short: "Manage secrets in the encrypted local secret store",
+		Long:  `dagu secret [set|get|list|delete] [--dag=<ID>|--group=<name>] [name] [value]`,
+	}
+
+	cmd.AddCommand(secretSetCmd())
+	cmd.AddCommand(secretGetCmd())
+	cmd.AddCommand(secretListCmd())
+	cmd.AddCommand(secretDeleteCmd())
+
+	return cmd
+}
+
+func secretSetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set [flags] NAME [VALUE]",
+		Short: "Set a secret, reading the value from stdin if it is not given",
+		Long:  `dagu secret set [--dag=<ID>|--group=<name>] NAME [VALUE]`,
+		Args:  cobra.RangeArgs(1, 2),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runSecretSet),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{secretDAGFlag, secretGroupFlag})
+
+	return cmd
+}
+
+func runSecretSet(cmd *cobra.Command, args []string) error {
+	store, scope, err := openSecretStore(cmd)
+	if err != nil {
+		return err
+	}
+
+	var value string
+	if len(args) > 1 {
+		value = args[1]
+	} else {
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return fmt.Errorf("failed to read the value from stdin: %w", err)
+		}
+		value = strings.TrimRight(string(data), "\r\n")
+	}
+	if value == "" {
+		return errors.New("the value of the secret is empty")
+	}
+
+	if err := store.Set(scope, args[0], value); err != nil {
+		return fmt.Errorf("failed to set the secret: %w", err)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Secret %s set in the scope %s\n", args[0], scope)
+	return nil
+}
+
+func secretGetCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get [flags] NAME",
+		Short: "Print the value of a secret",
+		Long:  `dagu secret get [--dag=<ID>|--group=<name>] NAME`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runSecretGet),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{secretDAGFlag, secretGroupFlag})
+
+	return cmd
+}
+
+func runSecretGet(cmd *cobra.Command, args []string) error {
+	store, scope, err := openSecretStore(cmd)
+	if err != nil {
+		return err
+	}
+	value, err := store.Get(scope, args[0])
+	if err != nil {
+		return fmt.Errorf("failed to get the secret: %w", err)
+	}
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), value)
+	return nil
+}
+
+func secretListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list [flags]",
+		Short: "List the secrets without their values",
+		Long:  `dagu secret list [--dag=<ID>|--group=<name>]`,
+		Args:  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runSecretList),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{secretDAGFlag, secretGroupFlag})
+
+	return cmd
+}
+
+func runSecretList(cmd *cobra.Command, _ []string) error {
+	store, scope, err := openSecretStore(cmd)
+	if err != nil {
+		return err
+	}
+	// All the scopes are listed unless a scope is given.
+	if !cmd.Flags().Changed("dag") && !cmd.Flags().Changed("group") {
+		scope = ""
+	}
+
+	entries, err := store.List(scope)
+	if err != nil {
+		return fmt.Errorf("failed to list the secrets: %w", err)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SCOPE\tNAME\tUPDATED")
+	for _, e := range entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", e.Scope, e.Name, stringutil.FormatTime(e.UpdatedAt))
+	}
+	return w.Flush()
+}
+
+func secretDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete [flags] NAME",
+		Short: "Delete a secret",
+		Long:  `dagu secret delete [--dag=<ID>|--group=<name>] NAME`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runSecretDelete),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{secretDAGFlag, secretGroupFlag})
+
+	return cmd
+}
+
+func runSecretDelete(cmd *cobra.Command, args []string) error {
+	store, scope, err := openSecretStore(cmd)
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(scope, args[0]); err != nil {
+		return fmt.Errorf("failed to delete the secret: %w", err)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Secret %s deleted from the scope %s\n", args[0], scope)
+	return nil
+}
+
+// openSecretStore returns the secret store and the scope given by the flags.
+func openSecretStore(cmd *cobra.Command) (*secrets.Store, string, error) {
+	setup, err := createSetup()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	dagID, _ := cmd.Flags().GetString("dag")
+	group, _ := cmd.Flags().GetString("group")
+	scope := secrets.ScopeGlobal
+	switch {
+	case dagID != "" && group != "":
+		return nil, "", errors.New("--dag and --group cannot be used together")
+	case dagID != "":
+		scope = secrets.DAGScope(dagID)
+	case group != "":
+		scope = secrets.GroupScope(group)
+	}
+
+	store, err := setup.secretStore()
+	if err != nil {
+		return nil, "", err
+	}
+	return store, scope, nil
+}
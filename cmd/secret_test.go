@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/secrets"
+	"github.com/dagu-org/dagu/internal/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretCommand(t *testing.T) {
+	th := test.Setup(t)
+	t.Setenv(secrets.EnvKeyLocalKey, "secret-key")
+
+	// runSecret runs the command and returns the standard output.
+	runSecret := func(t *testing.T, stdin string, args ...string) string {
+		t.Helper()
+
+		var out bytes.Buffer
+		cmdRoot := &cobra.Command{Use: "root"}
+		cmdRoot.AddCommand(secretCmd())
+		cmdRoot.SetOut(&out)
+		cmdRoot.SetIn(strings.NewReader(stdin))
+		cmdRoot.SetArgs(append([]string{"secret"}, args...))
+		require.NoError(t, cmdRoot.ExecuteContext(th.Context))
+		return out.String()
+	}
+
+	// runSecretErr runs the command of the function expected to fail, as the
+	// command exits the process on failure.
+	runSecretErr := func(t *testing.T, cmd *cobra.Command, run func(*cobra.Command, []string) error, args ...string) error {
+		t.Helper()
+
+		require.NoError(t, cmd.ParseFlags(args))
+		cmd.SetContext(th.Context)
+		return run(cmd, cmd.Flags().Args())
+	}
+
+	runSecret(t, "", "set", "db_password", "global-password")
+	runSecret(t, "etl-password\n", "set", "--dag=etl", "db_password")
+	runSecret(t, "", "set", "--group=reports", "api_key", "reports-key")
+
+	t.Run("Get", func(t *testing.T) {
+		require.Equal(t, "global-password\n", runSecret(t, "", "get", "db_password"))
+		require.Equal(t, "etl-password\n", runSecret(t, "", "get", "--dag=etl", "db_password"))
+
+		err := runSecretErr(t, secretGetCmd(), runSecretGet, "--dag=etl", "api_key")
+		require.ErrorIs(t, err, secrets.ErrNotFound)
+	})
+	t.Run("List", func(t *testing.T) {
+		out := runSecret(t, "", "list")
+		require.Regexp(t, `(?m)^dag:etl\s+db_password\s+`, out)
+		require.Regexp(t, `(?m)^global\s+db_password\s+`, out)
+		require.Regexp(t, `(?m)^group:reports\s+api_key\s+`, out)
+		require.NotContains(t, out, "password\n")
+		require.NotContains(t, out, "-password")
+
+		out = runSecret(t, "", "list", "--group=reports")
+		require.NotContains(t, out, "db_password")
+	})
+	t.Run("Delete", func(t *testing.T) {
+		runSecret(t, "", "delete", "--group=reports", "api_key")
+
+		err := runSecretErr(t, secretGetCmd(), runSecretGet, "--group=reports", "api_key")
+		require.ErrorIs(t, err, secrets.ErrNotFound)
+	})
+	t.Run("InvalidFlags", func(t *testing.T) {
+		err := runSecretErr(t, secretGetCmd(), runSecretGet, "--dag=etl", "--group=reports", "db_password")
+		require.Error(t, err)
+	})
+	t.Run("NoKey", func(t *testing.T) {
+		t.Setenv(secrets.EnvKeyLocalKey, "")
+		err := runSecretErr(t, secretGetCmd(), runSecretGet, "db_password")
+		require.ErrorIs(t, err, secrets.ErrNoKey)
+	})
+}
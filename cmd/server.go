@@ -35,6 +35,10 @@ func runServer(cmd *cobra.Command, _ []string) error {
 
 	logger.Info(ctx, "Server initialization", "host", setup.cfg.Host, "port", setup.cfg.Port)
 
+	if err := setup.startGitSync(ctx); err != nil {
+		return err
+	}
+
 	server, err := setup.server(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to initialize server: %w", err)
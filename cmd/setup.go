@@ -3,27 +3,36 @@ package main
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/signal"
+	"os/user"
 	"path/filepath"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/dagu-org/dagu/internal/agent"
 	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/cmdutil"
 	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/digraph"
+	dagscheduler "github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/fileutil"
 	"github.com/dagu-org/dagu/internal/frontend"
 	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/logfile"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/logsink"
 	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/filecache"
+	"github.com/dagu-org/dagu/internal/persistence/gitstore"
 	"github.com/dagu-org/dagu/internal/persistence/jsondb"
 	"github.com/dagu-org/dagu/internal/persistence/local"
 	"github.com/dagu-org/dagu/internal/persistence/local/storage"
 	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/dagu-org/dagu/internal/scheduler"
+	"github.com/dagu-org/dagu/internal/secrets"
 	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -55,7 +64,12 @@ func createSetup() (*setup, error) {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	return &setup{cfg: cfg}, nil
+	s := &setup{cfg: cfg}
+	if err := s.resolveConfigSecrets(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return s, nil
 }
 
 func setupWithConfig(cfg *config.Config) *setup {
@@ -76,7 +90,10 @@ func (s *setup) loggerContext(ctx context.Context, quiet bool) context.Context {
 	return logger.WithLogger(ctx, logger.NewLogger(opts...))
 }
 
-func (s *setup) loggerContextWithFile(ctx context.Context, quiet bool, f *os.File) context.Context {
+// loggerContextWithFile returns the context with the logger writing to the
+// file. The records are shipped to the log sinks as well if the shipper is
+// given.
+func (s *setup) loggerContextWithFile(ctx context.Context, quiet bool, f *os.File, shipper *logsink.Shipper) context.Context {
 	var opts []logger.Option
 	if quiet {
 		opts = append(opts, logger.WithQuiet())
@@ -84,6 +101,9 @@ func (s *setup) loggerContextWithFile(ctx context.Context, quiet bool, f *os.Fil
 	if f != nil {
 		opts = append(opts, logger.WithWriter(f))
 	}
+	if shipper != nil {
+		opts = append(opts, logger.WithHandler(shipper.Handler(slog.LevelInfo)))
+	}
 	return logger.WithLogger(ctx, logger.NewLogger(opts...))
 }
 
@@ -139,7 +159,10 @@ func (s *setup) client(opts ...clientOption) (client.Client, error) {
 func (s *setup) server(ctx context.Context) (*server.Server, error) {
 	dagCache := filecache.New[*digraph.DAG](0, time.Hour*12)
 	dagCache.StartEviction(ctx)
-	dagStore := s.dagStoreWithCache(dagCache)
+	dagStore, err := s.dagStoreWithCache(dagCache)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize DAG store: %w", err)
+	}
 
 	historyCache := filecache.New[*model.Status](0, time.Hour*12)
 	historyCache.StartEviction(ctx)
@@ -149,7 +172,7 @@ func (s *setup) server(ctx context.Context) (*server.Server, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize client: %w", err)
 	}
-	return frontend.New(s.cfg, cli), nil
+	return frontend.New(s.cfg, cli, s.auditStore(), s.decisionStore()), nil
 }
 
 func (s *setup) scheduler() (*scheduler.Scheduler, error) {
@@ -158,7 +181,7 @@ func (s *setup) scheduler() (*scheduler.Scheduler, error) {
 		return nil, fmt.Errorf("failed to initialize client: %w", err)
 	}
 
-	manager := scheduler.NewDAGJobManager(s.cfg.Paths.DAGsDir, cli, s.cfg.Paths.Executable, s.cfg.WorkDir)
+	manager := scheduler.NewDAGJobManager(s.cfg.Paths.DAGsDir, cli, s.cfg.Paths.Executable, s.cfg.WorkDir, s.decisionStore())
 	return scheduler.New(s.cfg, manager), nil
 }
 
@@ -171,11 +194,134 @@ func (s *setup) dagStore() (persistence.DAGStore, error) {
 		}
 	}
 
-	return local.NewDAGStore(s.cfg.Paths.DAGsDir), nil
+	return s.withGitSync(local.NewDAGStore(s.cfg.Paths.DAGsDir,
+		local.WithRevisionsDir(s.revisionsDir()),
+	))
+}
+
+func (s *setup) dagStoreWithCache(cache *filecache.Cache[*digraph.DAG]) (persistence.DAGStore, error) {
+	return s.withGitSync(local.NewDAGStore(s.cfg.Paths.DAGsDir,
+		local.WithFileCache(cache),
+		local.WithRevisionsDir(s.revisionsDir()),
+	))
+}
+
+// withGitSync wraps the DAG store to commit the changes in git if git sync
+// is enabled.
+func (s *setup) withGitSync(store persistence.DAGStore) (persistence.DAGStore, error) {
+	if s.cfg.GitSync == nil || !s.cfg.GitSync.Enabled {
+		return store, nil
+	}
+	gitStore, err := s.gitStore(context.Background(), store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize git sync: %w", err)
+	}
+	return gitStore, nil
+}
+
+func (s *setup) gitStore(ctx context.Context, store persistence.DAGStore) (*gitstore.DAGStore, error) {
+	cfg := s.cfg.GitSync
+	opts := []gitstore.Option{gitstore.WithPush(cfg.Push)}
+	if cfg.Remote != "" {
+		opts = append(opts, gitstore.WithRemote(cfg.Remote))
+	}
+	if cfg.Branch != "" {
+		opts = append(opts, gitstore.WithBranch(cfg.Branch))
+	}
+	if cfg.PullInterval > 0 {
+		opts = append(opts, gitstore.WithPullInterval(cfg.PullInterval))
+	}
+	if cfg.AuthorName != "" {
+		opts = append(opts, gitstore.WithAuthor(cfg.AuthorName, cfg.AuthorEmail))
+	}
+	return gitstore.New(ctx, s.cfg.Paths.DAGsDir, store, opts...)
+}
+
+// startGitSync starts pulling the changes of the DAGs directory from the
+// remote periodically if git sync is enabled.
+func (s *setup) startGitSync(ctx context.Context) error {
+	if s.cfg.GitSync == nil || !s.cfg.GitSync.Enabled {
+		return nil
+	}
+	gitStore, err := s.gitStore(ctx, local.NewDAGStore(s.cfg.Paths.DAGsDir))
+	if err != nil {
+		return fmt.Errorf("failed to initialize git sync: %w", err)
+	}
+	logger.Info(ctx, "Git sync enabled", "dir", s.cfg.Paths.DAGsDir)
+	gitStore.Start(ctx)
+	return nil
+}
+
+// secretResolver returns a resolver of the references to secrets with the
+// providers configured. The secrets of the local store are looked up in the
+// scopes of the DAG, if any, and then in the global scope.
+func (s *setup) secretResolver(dag *digraph.DAG) *secrets.Resolver {
+	cfg := s.cfg.Secrets
+	var scopes []string
+	if dag != nil {
+		scopes = append(scopes, secrets.DAGScope(dag.ID()))
+		if dag.Group != "" {
+			scopes = append(scopes, secrets.GroupScope(dag.Group))
+		}
+	}
+	return secrets.NewResolver(map[string]secrets.Provider{
+		"env":   secrets.NewEnvProvider(),
+		"file":  secrets.NewFileProvider(cfg.FileDir),
+		"local": secrets.NewLocalProvider(cfg.LocalFile, cfg.KeyFile, scopes...),
+		"vault": secrets.NewVaultProvider(secrets.VaultConfig{
+			Address:       cfg.Vault.Address,
+			Token:         cfg.Vault.Token,
+			Namespace:     cfg.Vault.Namespace,
+			SkipTLSVerify: cfg.Vault.SkipTLSVerify,
+		}),
+	})
+}
+
+// secretStore returns the encrypted local secret store.
+func (s *setup) secretStore() (*secrets.Store, error) {
+	key, err := secrets.LoadKey(s.cfg.Secrets.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	return secrets.NewStore(s.cfg.Secrets.LocalFile, key), nil
 }
 
-func (s *setup) dagStoreWithCache(cache *filecache.Cache[*digraph.DAG]) persistence.DAGStore {
-	return local.NewDAGStore(s.cfg.Paths.DAGsDir, local.WithFileCache(cache))
+// resolveConfigSecrets resolves the references to secrets in the passwords
+// and tokens of the configuration.
+func (s *setup) resolveConfigSecrets(ctx context.Context) error {
+	fields := []*string{
+		&s.cfg.Auth.Basic.Password,
+		&s.cfg.Auth.Token.Value,
+		&s.cfg.Auth.OIDC.ClientSecret,
+		&s.cfg.Auth.OIDC.SessionSecret,
+	}
+	for i := range s.cfg.RemoteNodes {
+		fields = append(fields,
+			&s.cfg.RemoteNodes[i].BasicAuthPassword,
+			&s.cfg.RemoteNodes[i].AuthToken,
+		)
+	}
+
+	var resolver *secrets.Resolver
+	for _, field := range fields {
+		if !secrets.HasReference(*field) {
+			continue
+		}
+		if resolver == nil {
+			resolver = s.secretResolver(nil)
+		}
+		value, err := resolver.Resolve(ctx, *field)
+		if err != nil {
+			return err
+		}
+		*field = value
+	}
+	return nil
+}
+
+// revisionsDir returns the directory to keep the revisions of DAG specs.
+func (s *setup) revisionsDir() string {
+	return filepath.Join(s.cfg.Paths.DataDir, "revisions")
 }
 
 func (s *setup) historyStore() persistence.HistoryStore {
@@ -191,6 +337,61 @@ func (s *setup) historyStoreWithCache(cache *filecache.Cache[*model.Status]) per
 	)
 }
 
+func (s *setup) auditStore() persistence.AuditStore {
+	return local.NewAuditStore(s.cfg.Paths.AdminLogsDir)
+}
+
+func (s *setup) decisionStore() persistence.DecisionStore {
+	var retentionDays int
+	if s.cfg.Scheduler != nil {
+		retentionDays = s.cfg.Scheduler.DecisionRetentionDays
+	}
+	return local.NewDecisionStore(filepath.Join(s.cfg.Paths.DataDir, "decisions"), retentionDays)
+}
+
+// recordAudit appends an entry for an action run from the command line.
+// Commands spawned by dagu itself are not recorded as they were either
+// recorded by the caller already or not initiated by a user.
+func (s *setup) recordAudit(ctx context.Context, action string, dag *digraph.DAG, requestID string) {
+	s.recordAuditEntry(ctx, model.AuditEntry{
+		Action:    action,
+		DAG:       dag.ID(),
+		RequestID: requestID,
+	})
+}
+
+// recordAuditEntry records the entry with the source and the actor of the
+// command.
+func (s *setup) recordAuditEntry(ctx context.Context, entry model.AuditEntry) {
+	if isInternalInvocation() {
+		return
+	}
+
+	entry.Timestamp = time.Now()
+	entry.Source = model.AuditSourceCLI
+	entry.Actor = osUsername()
+	if err := s.auditStore().Append(ctx, entry); err != nil {
+		logger.Warn(ctx, "Failed to record audit log", "action", entry.Action, "err", err)
+	}
+}
+
+// isInternalInvocation reports whether the command was spawned by dagu
+// itself. The token of the invocation is verified once per process.
+var isInternalInvocation = sync.OnceValue(func() bool {
+	return internalInvocation != "" && cmdutil.VerifyInternalInvocation(internalInvocation)
+})
+
+// osUsername returns the name of the user running the command.
+func osUsername() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
 func (s *setup) openLogFile(
 	ctx context.Context,
 	prefix string,
@@ -223,6 +424,158 @@ func (s *setup) openLogFile(
 	return createLogFile(filepath.Join(outputDir, filename))
 }
 
+// closeLogFile closes the scheduler log of the run. It compresses the log
+// and deletes the oldest logs beyond the quota of the log directory if they
+// are configured.
+func (s *setup) closeLogFile(ctx context.Context, f *os.File) {
+	_ = f.Close()
+
+	if s.cfg.Logs.Compress {
+		if err := logfile.Compress(f.Name()); err != nil {
+			logger.Warn(ctx, "Failed to compress the log", "file", f.Name(), "err", err)
+		}
+	}
+
+	if s.cfg.Logs.QuotaBytes > 0 {
+		logDir, err := cmdutil.EvalString(ctx, s.cfg.Paths.LogDir)
+		if err != nil {
+			logger.Warn(ctx, "Failed to expand log directory", "err", err)
+			return
+		}
+		opts := logfile.QuotaOptions{
+			ExcludeDirs: []string{s.cfg.Paths.AdminLogsDir},
+			InUse:       s.runningLogs(ctx),
+		}
+		if _, err := logfile.EnforceQuota(logDir, s.cfg.Logs.QuotaBytes, opts); err != nil {
+			logger.Warn(ctx, "Failed to delete the logs beyond the quota", "dir", logDir, "err", err)
+		}
+	}
+}
+
+// runningLogs returns a function reporting whether the file is a log of a
+// running DAG run, which must not be deleted. The running runs are looked
+// up the first time it is called.
+func (s *setup) runningLogs(ctx context.Context) func(path string) bool {
+	var (
+		once  sync.Once
+		files map[string]bool
+	)
+	return func(path string) bool {
+		once.Do(func() {
+			files = make(map[string]bool)
+			cli, err := s.client()
+			if err != nil {
+				logger.Warn(ctx, "Failed to initialize client", "err", err)
+				return
+			}
+			statuses, _, err := cli.GetAllStatus(ctx)
+			if err != nil {
+				logger.Warn(ctx, "Failed to read the status of the DAGs", "err", err)
+			}
+			for _, st := range statuses {
+				if st.Status.Status != dagscheduler.StatusRunning {
+					continue
+				}
+				files[filepath.Clean(st.Status.Log)] = true
+				for _, node := range st.Status.Nodes {
+					if node.Log != "" {
+						files[filepath.Clean(node.Log)] = true
+					}
+				}
+			}
+		})
+		return files[filepath.Clean(path)]
+	}
+}
+
+// logShipperTimeout is the time to send the logs waiting to the log sinks
+// when the run finishes.
+const logShipperTimeout = 10 * time.Second
+
+// logShipper returns the shipper of the logs of the run to the log sinks,
+// or nil if no sinks are configured.
+func (s *setup) logShipper(ctx context.Context, dag *digraph.DAG, requestID string) *logsink.Shipper {
+	if len(s.cfg.Logs.Sinks) == 0 {
+		return nil
+	}
+	shipper := logsink.NewShipper(map[string]string{
+		logsink.LabelDAG:       dag.Name,
+		logsink.LabelRequestID: requestID,
+	})
+	for i, cfg := range s.cfg.Logs.Sinks {
+		sink, err := newLogSink(cfg)
+		if err != nil {
+			logger.Warn(ctx, "Failed to initialize log sink", "sink", i, "type", cfg.Type, "err", err)
+			continue
+		}
+		shipper.Add(sink, logsink.Options{
+			Name:          fmt.Sprintf("%s sink %d", cfg.Type, i),
+			Labels:        cfg.Labels,
+			BufferSize:    cfg.BufferSize,
+			BatchSize:     cfg.BatchSize,
+			FlushInterval: cfg.FlushInterval,
+			MaxRetries:    cfg.MaxRetries,
+			RetryInterval: cfg.RetryInterval,
+		})
+	}
+	if shipper.Len() == 0 {
+		return nil
+	}
+	return shipper
+}
+
+func newLogSink(cfg config.LogSinkConfig) (logsink.Sink, error) {
+	switch cfg.Type {
+	case config.LogSinkSyslog:
+		return logsink.NewSyslog(logsink.SyslogConfig{
+			Network:  cfg.Network,
+			Address:  cfg.Address,
+			Facility: cfg.Facility,
+			AppName:  cfg.AppName,
+		})
+	case config.LogSinkLoki:
+		return logsink.NewLoki(logsink.LokiConfig{
+			URL:     cfg.URL,
+			Headers: cfg.Headers,
+		})
+	case config.LogSinkFile:
+		return logsink.NewFile(logsink.FileConfig{
+			Path:       cfg.Path,
+			MaxSize:    cfg.MaxSizeBytes,
+			MaxBackups: cfg.MaxBackups,
+		})
+	default:
+		return nil, fmt.Errorf("invalid type: %q", cfg.Type)
+	}
+}
+
+// closeLogShipper sends the logs waiting to the log sinks and closes them.
+func (s *setup) closeLogShipper(ctx context.Context, shipper *logsink.Shipper) {
+	if shipper == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), logShipperTimeout)
+	defer cancel()
+	if err := shipper.Close(ctx); err != nil {
+		logger.Warn(ctx, "Failed to ship the logs to the log sinks", "err", err)
+	}
+}
+
+// agentOptions returns the options of the agent running the DAG. The step
+// logs are shipped by the shipper if it is given.
+func (s *setup) agentOptions(dag *digraph.DAG, shipper *logsink.Shipper) agent.Options {
+	return agent.Options{
+		Secrets: s.secretResolver(dag),
+		LogLimit: dagscheduler.LogLimit{
+			MaxSize:      s.cfg.Logs.MaxSizeBytes,
+			TruncateHead: s.cfg.Logs.Truncate == digraph.LogTruncateHead,
+		},
+		CompressLogs: s.cfg.Logs.Compress,
+		LogShipper:   shipper,
+		Cgroup:       s.cfg.Resources.Cgroup,
+	}
+}
+
 // generateRequestID generates a new request ID.
 // For simplicity, we use UUIDs as request IDs.
 func generateRequestID() (string, error) {
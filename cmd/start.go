@@ -2,13 +2,16 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/agent"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/spf13/cobra"
 )
 
@@ -31,6 +34,7 @@ func startCmd() *cobra.Command {
 func initStartFlags(cmd *cobra.Command) {
 	initCommonFlags(cmd, []commandLineFlag{paramsFlag, withUsage(requestIDFlag, "request ID for the DAG execution")})
 	cmd.Flags().BoolP("quiet", "q", false, "suppress output")
+	cmd.Flags().String("scheduled-time", "", "time the run was scheduled for (RFC3339)")
 }
 
 func runStart(cmd *cobra.Command, args []string) error {
@@ -49,10 +53,19 @@ func runStart(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get request ID: %w", err)
 	}
 
+	var scheduledTime time.Time
+	if v, _ := cmd.Flags().GetString("scheduled-time"); v != "" {
+		scheduledTime, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("invalid scheduled time %q: %w", v, err)
+		}
+	}
+
 	ctx := setup.loggerContext(cmd.Context(), quiet)
 
 	loadOpts := []digraph.LoadOption{
 		digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig),
+		digraph.WithDAGsDir(setup.cfg.Paths.DAGsDir),
 	}
 
 	var params string
@@ -68,10 +81,10 @@ func runStart(cmd *cobra.Command, args []string) error {
 		loadOpts = append(loadOpts, digraph.WithParams(removeQuotes(params)))
 	}
 
-	return executeDag(ctx, setup, args[0], loadOpts, quiet, requestID)
+	return executeDag(ctx, setup, args[0], loadOpts, quiet, requestID, scheduledTime)
 }
 
-func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []digraph.LoadOption, quiet bool, requestID string) error {
+func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []digraph.LoadOption, quiet bool, requestID string, scheduledTime time.Time) error {
 	dag, err := digraph.Load(ctx, specPath, loadOpts...)
 	if err != nil {
 		logger.Error(ctx, "Failed to load DAG", "path", specPath, "err", err)
@@ -93,12 +106,17 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 		logger.Error(ctx, "failed to initialize log file", "DAG", dag.Name, "err", err)
 		return fmt.Errorf("failed to initialize log file for DAG %s: %w", dag.Name, err)
 	}
-	defer logFile.Close()
+	defer setup.closeLogFile(ctx, logFile)
 
-	ctx = setup.loggerContextWithFile(ctx, quiet, logFile)
+	shipper := setup.logShipper(ctx, dag, requestID)
+	defer setup.closeLogShipper(ctx, shipper)
+
+	ctx = setup.loggerContextWithFile(ctx, quiet, logFile, shipper)
 
 	logger.Info(ctx, "DAG execution initiated", "DAG", dag.Name, "requestID", requestID, "logFile", logFile.Name())
 
+	setup.recordAudit(ctx, model.AuditActionStart, dag, requestID)
+
 	dagStore, err := setup.dagStore()
 	if err != nil {
 		logger.Error(ctx, "Failed to initialize DAG store", "err", err)
@@ -111,6 +129,9 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 		return fmt.Errorf("failed to initialize client: %w", err)
 	}
 
+	opts := setup.agentOptions(dag, shipper)
+	opts.ScheduledTime = scheduledTime
+
 	agentInstance := agent.New(
 		requestID,
 		dag,
@@ -119,7 +140,7 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 		cli,
 		dagStore,
 		setup.historyStore(),
-		agent.Options{},
+		opts,
 	)
 
 	listenSignals(ctx, agentInstance)
@@ -128,6 +149,9 @@ func executeDag(ctx context.Context, setup *setup, specPath string, loadOpts []d
 		logger.Error(ctx, "Failed to execute DAG", "DAG", dag.Name, "requestID", requestID, "err", err)
 
 		if quiet {
+			if errors.Is(err, digraph.ErrConditionNotMet) {
+				os.Exit(digraph.ExitCodeConditionNotMet)
+			}
 			os.Exit(1)
 		} else {
 			agentInstance.PrintSummary(ctx)
@@ -2,6 +2,10 @@ package main
 
 import (
 	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStartCommand(t *testing.T) {
@@ -38,3 +42,20 @@ func TestStartCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestStartCommand_ScheduledTime(t *testing.T) {
+	th := testSetup(t)
+
+	dagFile := th.DAG(t, "cmd/start.yaml")
+	th.RunCommand(t, startCmd(), cmdTest{
+		args:        []string{"start", "--scheduled-time=2020-01-01T09:00:00Z", dagFile.Location},
+		expectedOut: []string{"Step execution started"},
+	})
+	dagFile.AssertLatestStatus(t, scheduler.StatusSuccess)
+
+	status, err := th.Client.GetLatestStatus(th.Context, dagFile.DAG)
+	require.NoError(t, err)
+	scheduledTime, err := time.Parse(time.RFC3339, status.ScheduledTime)
+	require.NoError(t, err)
+	require.True(t, scheduledTime.Equal(time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)))
+}
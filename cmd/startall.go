@@ -34,6 +34,10 @@ func runStartAll(cmd *cobra.Command, _ []string) error {
 
 	ctx := setup.loggerContext(cmd.Context(), false)
 
+	if err := setup.startGitSync(ctx); err != nil {
+		return err
+	}
+
 	scheduler, err := setup.scheduler()
 	if err != nil {
 		return fmt.Errorf("failed to initialize scheduler: %w", err)
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stats"
+	"github.com/spf13/cobra"
+)
+
+// defaultStatsRuns is the number of the recent runs aggregated by default.
+const defaultStatsRuns = 30
+
+var (
+	statsLimitFlag = commandLineFlag{
+		name:      "limit",
+		shorthand: "l",
+		usage:     fmt.Sprintf("number of recent runs to aggregate (default %d)", defaultStatsRuns),
+	}
+	statsFormatFlag = commandLineFlag{
+		name:         "format",
+		shorthand:    "f",
+		defaultValue: "text",
+		usage:        "output format: text or json",
+	}
+)
+
+func statsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats [flags] /path/to/spec.yaml",
+		Short: "Show the durations and the resource usage of the steps over the recent runs",
+		Long:  `dagu stats [--limit=<N>] [--format=text|json] /path/to/spec.yaml`,
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runStats),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{statsLimitFlag, statsFormatFlag})
+
+	return cmd
+}
+
+func runStats(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), false)
+
+	limit := defaultStatsRuns
+	if value, _ := cmd.Flags().GetString("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit <= 0 {
+			return fmt.Errorf("--limit must be a positive number: %s", value)
+		}
+	}
+
+	format, _ := cmd.Flags().GetString("format")
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unknown format %q (must be text or json)", format)
+	}
+
+	dag, err := digraph.Load(ctx, args[0], digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig), digraph.WithDAGsDir(setup.cfg.Paths.DAGsDir))
+	if err != nil {
+		logger.Error(ctx, "Failed to load DAG", "path", args[0], "err", err)
+		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
+	}
+
+	var statuses []*model.Status
+	for _, file := range setup.historyStore().ReadStatusRecent(ctx, dag.Location, limit) {
+		statuses = append(statuses, &file.Status)
+	}
+	steps := stats.Steps(statuses)
+
+	if format == "json" {
+		if steps == nil {
+			steps = []stats.Step{}
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(steps)
+	}
+
+	if len(steps) == 0 {
+		logger.Info(ctx, "No runs recorded", "dag", dag.Name)
+		return nil
+	}
+	return writeStats(cmd.OutOrStdout(), len(statuses), steps)
+}
+
+func writeStats(out io.Writer, runs int, steps []stats.Step) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Statistics of the last %d runs (p50 / p95 / max)\n\n", runs)
+	fmt.Fprintln(w, "STEP\tRUNS\tFAILED\tDURATION\tCPU TIME\tPEAK RSS")
+	for _, s := range steps {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\n",
+			s.Name, s.Runs, s.Failures,
+			formatSummary(s.Duration, formatDuration),
+			formatSummary(s.CPUTime, formatDuration),
+			formatSummary(s.PeakRSS, formatBytes),
+		)
+	}
+	return w.Flush()
+}
+
+func formatSummary(s *stats.Summary, format func(int64) string) string {
+	if s == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s / %s / %s", format(s.P50), format(s.P95), format(s.Max))
+}
+
+func formatDuration(d int64) string {
+	duration := time.Duration(d)
+	if duration < time.Second {
+		return duration.Round(time.Millisecond).String()
+	}
+	return duration.Round(100 * time.Millisecond).String()
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", value, "KMGT"[exp])
+}
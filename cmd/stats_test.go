@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/stats"
+	"github.com/dagu-org/dagu/internal/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsCommand(t *testing.T) {
+	// The DAGs directory is set explicitly as the config loaded by the
+	// other commands run in the same process may change the default.
+	th := testHelper{Helper: test.Setup(t, test.WithCaptureLoggingOutput(), test.WithDAGsDir(t.TempDir()))}
+	ctx := th.Context
+
+	id, err := th.Client.CreateDAG(ctx, "stats-cmd")
+	require.NoError(t, err)
+	require.NoError(t, th.Client.UpdateDAG(ctx, id, `steps:
+  - name: first
+    command: "true"
+  - name: second
+    command: "true"
+    depends: first
+`, persistence.RevisionInfo{}))
+	dagFile := filepath.Join(th.Config.Paths.DAGsDir, id+".yaml")
+
+	for i := 0; i < 2; i++ {
+		th.RunCommand(t, startCmd(), cmdTest{args: []string{"start", dagFile}})
+	}
+
+	// runStats runs the command and returns the standard output.
+	runStats := func(t *testing.T, args ...string) string {
+		t.Helper()
+
+		var out bytes.Buffer
+		cmdRoot := &cobra.Command{Use: "root"}
+		cmdRoot.AddCommand(statsCmd())
+		cmdRoot.SetOut(&out)
+		cmdRoot.SetArgs(append([]string{"stats"}, args...))
+		require.NoError(t, cmdRoot.ExecuteContext(ctx))
+		return out.String()
+	}
+
+	t.Run("Text", func(t *testing.T) {
+		out := runStats(t, dagFile)
+		require.Contains(t, out, "Statistics of the last 2 runs")
+		require.Regexp(t, `(?m)^first\s+2\s+0\s+`, out)
+		require.Regexp(t, `(?m)^second\s+2\s+0\s+`, out)
+	})
+	t.Run("JSON", func(t *testing.T) {
+		var steps []stats.Step
+		require.NoError(t, json.Unmarshal([]byte(runStats(t, "--format=json", "--limit=1", dagFile)), &steps))
+		require.Len(t, steps, 2)
+		require.Equal(t, "first", steps[0].Name)
+		require.Equal(t, 1, steps[0].Runs)
+		require.NotNil(t, steps[0].PeakRSS)
+		require.Positive(t, steps[0].PeakRSS.Max)
+	})
+}
@@ -1,18 +1,24 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/scheduler/lease"
+	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/spf13/cobra"
 )
 
 func statusCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "status /path/to/spec.yaml",
+		Use:   "status [--watch] /path/to/spec.yaml",
 		Short: "Display current status of the DAG",
-		Long:  `dagu status /path/to/spec.yaml`,
+		Long:  `dagu status [--watch] /path/to/spec.yaml`,
 		Args:  cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			return bindCommonFlags(cmd, nil)
@@ -21,6 +27,7 @@ func statusCmd() *cobra.Command {
 	}
 
 	initCommonFlags(cmd, nil)
+	cmd.Flags().BoolP("watch", "w", false, "display the changes of the status until the running DAG finishes")
 
 	return cmd
 }
@@ -33,7 +40,7 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	ctx := setup.loggerContext(cmd.Context(), false)
 
-	dag, err := digraph.Load(ctx, args[0], digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig))
+	dag, err := digraph.Load(ctx, args[0], digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig), digraph.WithDAGsDir(setup.cfg.Paths.DAGsDir))
 	if err != nil {
 		logger.Error(ctx, "Failed to load DAG", "path", args[0], "err", err)
 		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
@@ -53,5 +60,61 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	logger.Info(ctx, "Current status", "pid", status.PID, "status", status.Status)
 
+	if cfg := setup.cfg.Scheduler; cfg != nil && cfg.HA {
+		logSchedulerLeader(ctx, cfg.LeaseDir)
+	}
+
+	watch, err := cmd.Flags().GetBool("watch")
+	if err != nil {
+		return fmt.Errorf("failed to get watch flag: %w", err)
+	}
+	if watch {
+		return watchStatus(ctx, cli, dag)
+	}
+
 	return nil
 }
+
+// watchEventsTimeout is the timeout of a request waiting for the events.
+const watchEventsTimeout = time.Second * 30
+
+// watchStatus logs the events of the running DAG until it finishes.
+func watchStatus(ctx context.Context, cli client.Client, dag *digraph.DAG) error {
+	var since int64
+	for {
+		list, err := cli.GetEvents(ctx, dag, since, watchEventsTimeout)
+		if errors.Is(err, client.ErrDAGNotRunning) {
+			return nil
+		}
+		if err != nil {
+			logger.Error(ctx, "Failed to retrieve events", "dag", dag.Name, "err", err)
+			return fmt.Errorf("failed to retrieve events: %w", err)
+		}
+		for _, e := range list.Events {
+			if e.Node != nil {
+				logger.Info(ctx, "Step status", "step", e.Node.Step.Name, "status", e.Node.StatusText)
+				continue
+			}
+			logger.Info(ctx, "DAG status", "requestId", e.RequestID, "status", e.StatusText)
+		}
+		if list.Finished {
+			return nil
+		}
+		since = list.Seq
+	}
+}
+
+// logSchedulerLeader logs the scheduler instance holding the lease when the
+// scheduler runs in high availability mode.
+func logSchedulerLeader(ctx context.Context, leaseDir string) {
+	l, err := lease.Read(leaseDir)
+	if err != nil {
+		logger.Warn(ctx, "Failed to read the scheduler lease", "err", err)
+		return
+	}
+	if l == nil || l.Expired(time.Now()) {
+		logger.Info(ctx, "Scheduler leader", "leader", "none")
+		return
+	}
+	logger.Info(ctx, "Scheduler leader", "leader", l.Holder, "token", l.Token, "expiresAt", stringutil.FormatTime(l.ExpiresAt))
+}
@@ -41,4 +41,27 @@ func TestStatusCommand(t *testing.T) {
 		th.RunCommand(t, stopCmd(), cmdTest{args: args})
 		<-done
 	})
+
+	t.Run("WatchStatus", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAG(t, "cmd/status_watch.yaml")
+
+		done := make(chan struct{})
+		go func() {
+			// Start a DAG to watch the status.
+			args := []string{"start", dagFile.Location}
+			th.RunCommand(t, startCmd(), cmdTest{args: args})
+			close(done)
+		}()
+
+		dagFile.AssertLatestStatus(t, scheduler.StatusRunning)
+
+		// Watch the status until the DAG finishes.
+		th.RunCommand(t, statusCmd(), cmdTest{
+			args:        []string{"status", "--watch", dagFile.Location},
+			expectedOut: []string{"status=finished"},
+		})
+		<-done
+	})
 }
@@ -1,18 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/spf13/cobra"
 )
 
 func stopCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "stop /path/to/spec.yaml",
-		Short: "Stop the running DAG",
-		Long:  `dagu stop /path/to/spec.yaml`,
+		Use:   "stop [--step=<name> [--mark=failed|skipped]] /path/to/spec.yaml",
+		Short: "Stop the running DAG or one of its steps",
+		Long:  `dagu stop [--step=<name> [--mark=failed|skipped]] /path/to/spec.yaml`,
 		Args:  cobra.ExactArgs(1),
 		PreRunE: func(cmd *cobra.Command, _ []string) error {
 			return bindCommonFlags(cmd, nil)
@@ -21,6 +25,8 @@ func stopCmd() *cobra.Command {
 	}
 
 	initCommonFlags(cmd, nil)
+	cmd.Flags().String("step", "", "stop only the running step, letting the DAG continue")
+	cmd.Flags().String("mark", "failed", "status of the stopped step: failed or skipped")
 
 	return cmd
 }
@@ -33,25 +39,70 @@ func runStop(cmd *cobra.Command, args []string) error {
 
 	ctx := setup.loggerContext(cmd.Context(), false)
 
-	dag, err := digraph.Load(cmd.Context(), args[0], digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig))
+	dag, err := digraph.Load(cmd.Context(), args[0], digraph.WithBaseConfig(setup.cfg.Paths.BaseConfig), digraph.WithDAGsDir(setup.cfg.Paths.DAGsDir))
 	if err != nil {
 		logger.Error(ctx, "Failed to load DAG", "err", err)
 		return fmt.Errorf("failed to load DAG from %s: %w", args[0], err)
 	}
 
-	logger.Info(ctx, "DAG is stopping", "dag", dag.Name)
-
 	cli, err := setup.client()
 	if err != nil {
 		logger.Error(ctx, "failed to initialize client", "err", err)
 		return fmt.Errorf("failed to initialize client: %w", err)
 	}
 
+	step, err := cmd.Flags().GetString("step")
+	if err != nil {
+		return fmt.Errorf("failed to get step flag: %w", err)
+	}
+	if step != "" {
+		return runStopStep(ctx, cmd, setup, cli, dag, step)
+	}
+
+	logger.Info(ctx, "DAG is stopping", "dag", dag.Name)
+
 	if err := cli.Stop(cmd.Context(), dag); err != nil {
 		logger.Error(ctx, "Failed to stop DAG", "dag", dag.Name, "err", err)
 		return fmt.Errorf("failed to stop DAG: %w", err)
 	}
 
+	setup.recordAudit(ctx, model.AuditActionStop, dag, "")
+
 	logger.Info(ctx, "DAG stopped", "dag", dag.Name)
 	return nil
 }
+
+// runStopStep stops the step of the running DAG, marking it failed or
+// skipped.
+func runStopStep(ctx context.Context, cmd *cobra.Command, setup *setup, cli client.Client, dag *digraph.DAG, step string) error {
+	mark, err := cmd.Flags().GetString("mark")
+	if err != nil {
+		return fmt.Errorf("failed to get mark flag: %w", err)
+	}
+	var status scheduler.NodeStatus
+	switch mark {
+	case "failed":
+		status = scheduler.NodeStatusError
+	case "skipped":
+		status = scheduler.NodeStatusSkipped
+	default:
+		return fmt.Errorf("invalid mark %q: must be failed or skipped", mark)
+	}
+
+	logger.Info(ctx, "Step is stopping", "dag", dag.Name, "step", step)
+
+	if err := cli.StopStep(ctx, dag, step, status); err != nil {
+		logger.Error(ctx, "Failed to stop step", "dag", dag.Name, "step", step, "err", err)
+		return fmt.Errorf("failed to stop step %q: %w", step, err)
+	}
+
+	setup.recordAuditEntry(ctx, model.AuditEntry{
+		Action: model.AuditActionStopStep,
+		DAG:    dag.ID(),
+		Step:   step,
+		Detail: fmt.Sprintf("marked %s", status),
+	})
+
+	logger.Info(ctx, "Step stopped", "dag", dag.Name, "step", step, "status", status)
+	return nil
+}
@@ -50,4 +50,34 @@ func TestStopCommand(t *testing.T) {
 		dagFile.AssertLatestStatus(t, scheduler.StatusCancel)
 		<-done
 	})
+
+	t.Run("StopStep", func(t *testing.T) {
+		th := testSetup(t)
+
+		dagFile := th.DAG(t, "cmd/stop_step.yaml")
+
+		done := make(chan struct{})
+		go func() {
+			// Start the DAG whose step to stop.
+			args := []string{"start", dagFile.Location}
+			th.RunCommand(t, startCmd(), cmdTest{args: args})
+			close(done)
+		}()
+
+		// Wait for the step running.
+		require.Eventually(t, func() bool {
+			status, err := th.Client.GetCurrentStatus(th.Context, dagFile.DAG)
+			return err == nil && len(status.Nodes) > 0 &&
+				status.Nodes[0].Status == scheduler.NodeStatusRunning
+		}, waitForStatusTimeout, statusCheckInterval)
+
+		// Stop the step, skipping it.
+		th.RunCommand(t, stopCmd(), cmdTest{
+			args:        []string{"stop", "--step", "1", "--mark", "skipped", dagFile.Location},
+			expectedOut: []string{"Step stopped"}})
+
+		// The DAG continues with the next step.
+		<-done
+		dagFile.AssertLatestStatus(t, scheduler.StatusSuccess)
+	})
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dagu-org/dagu/internal/digraph/lint"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+var lintFormatFlag = commandLineFlag{
+	name:         "format",
+	shorthand:    "f",
+	defaultValue: lint.FormatText,
+	usage:        "output format: text, json or sarif",
+}
+
+func validateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [flags] /path/to/spec.yaml [/path/to/spec.yaml ...]",
+		Short: "Check the DAG files for errors without running them",
+		Long:  `dagu validate [--format=text|json|sarif] [--strict] /path/to/spec.yaml [/path/to/spec.yaml ...]`,
+		Args:  cobra.MinimumNArgs(1),
+		PreRunE: func(cmd *cobra.Command, _ []string) error {
+			return bindCommonFlags(cmd, nil)
+		},
+		RunE: wrapRunE(runValidate),
+	}
+
+	initCommonFlags(cmd, []commandLineFlag{lintFormatFlag})
+	cmd.Flags().Bool("strict", false, "exit with a non-zero status on warnings as well as errors")
+
+	return cmd
+}
+
+func runValidate(cmd *cobra.Command, args []string) error {
+	setup, err := createSetup()
+	if err != nil {
+		return fmt.Errorf("failed to create setup: %w", err)
+	}
+
+	ctx := setup.loggerContext(cmd.Context(), true)
+
+	format, _ := cmd.Flags().GetString("format")
+	strict, _ := cmd.Flags().GetBool("strict")
+
+	opts := lint.Options{
+		BaseConfig: setup.cfg.Paths.BaseConfig,
+		DAGsDir:    setup.cfg.Paths.DAGsDir,
+	}
+
+	var diagnostics []lint.Diagnostic
+	for _, file := range args {
+		found, err := lint.File(ctx, file, opts)
+		if err != nil {
+			logger.Error(ctx, "Failed to validate DAG", "path", file, "err", err)
+			return fmt.Errorf("failed to validate DAG %s: %w", file, err)
+		}
+		diagnostics = append(diagnostics, found...)
+	}
+
+	if err := lint.Write(cmd.OutOrStdout(), diagnostics, format); err != nil {
+		return err
+	}
+
+	for _, d := range diagnostics {
+		if d.Severity == lint.SeverityError || (d.Severity == lint.SeverityWarning && strict) {
+			os.Exit(1)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCommand(t *testing.T) {
+	th := testSetup(t)
+
+	// The command exits with a non-zero status on errors, so only the valid
+	// DAG and the template library are validated here. The checks are
+	// tested in the lint package.
+	dagFile := test.TestdataPath(t, filepath.Join("lint", "valid.yaml"))
+	libraryFile := test.TestdataPath(t, filepath.Join("lint", "template_library.yaml"))
+
+	for _, tc := range []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "text", args: []string{"--format=text", dagFile}, expected: ""},
+		{name: "json", args: []string{"--format=json", dagFile}, expected: "[]\n"},
+		{
+			name:     "TemplateLibrary",
+			args:     []string{"--strict", libraryFile},
+			expected: libraryFile + ":1:1: note: the file is a template library and is not run as a DAG (template)\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			cmdRoot := &cobra.Command{Use: "root"}
+			cmdRoot.AddCommand(validateCmd())
+			cmdRoot.SetOut(&out)
+			cmdRoot.SetArgs(append([]string{"validate"}, tc.args...))
+			require.NoError(t, cmdRoot.ExecuteContext(th.Context))
+			require.Equal(t, tc.expected, out.String())
+		})
+	}
+}
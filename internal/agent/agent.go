@@ -6,9 +6,12 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"regexp"
 	"runtime/debug"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -16,13 +19,19 @@ import (
 	"time"
 
 	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/cmdutil"
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/executor"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logfile"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/logsink"
 	"github.com/dagu-org/dagu/internal/mailer"
 	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/secrets"
 	"github.com/dagu-org/dagu/internal/sock"
+	"github.com/dagu-org/dagu/internal/stringutil"
 )
 
 // Agent is responsible for running the DAG and handling communication
@@ -42,8 +51,20 @@ type Agent struct {
 	reporter     *reporter
 	historyStore persistence.HistoryStore
 	socketServer *sock.Server
+	secrets      *secrets.Resolver
 	logDir       string
 	logFile      string
+	logLimit     scheduler.LogLimit
+	compressLogs bool
+	logShipper   *logsink.Shipper
+	cgroup       string
+	events       *eventLog
+
+	// specRevision is the revision hash of the DAG spec being run.
+	specRevision string
+
+	// scheduledTime is the time the run was scheduled for, if any.
+	scheduledTime time.Time
 
 	// requestID is request ID to identify DAG execution uniquely.
 	// The request ID can be used for history lookup, retry, etc.
@@ -63,6 +84,23 @@ type Options struct {
 	// If it's specified the agent will execute the DAG with the same
 	// configuration as the specified history.
 	RetryTarget *model.Status
+	// Secrets resolves the references to secrets in the DAG. The resolved
+	// values are masked in the step logs and the status.
+	Secrets *secrets.Resolver
+	// LogLimit is the limit of the size of the step logs. The logMaxSize
+	// and logTruncate of the DAG take precedence.
+	LogLimit scheduler.LogLimit
+	// CompressLogs compresses the step logs with gzip when the run finishes.
+	CompressLogs bool
+	// LogShipper ships the step logs to the log sinks in addition to the
+	// log files.
+	LogShipper *logsink.Shipper
+	// ScheduledTime is the time the run was scheduled for by the scheduler.
+	// A retry keeps the scheduled time of the retried run.
+	ScheduledTime time.Time
+	// Cgroup is the cgroup v2 delegated to dagu, in which the cgroup of the
+	// run is created to limit the resources of the steps.
+	Cgroup string
 }
 
 // New creates a new Agent.
@@ -76,28 +114,55 @@ func New(
 	historyStore persistence.HistoryStore,
 	opts Options,
 ) *Agent {
+	scheduledTime := opts.ScheduledTime
+	if scheduledTime.IsZero() && opts.RetryTarget != nil && opts.RetryTarget.ScheduledTime != "" {
+		scheduledTime, _ = stringutil.ParseTime(opts.RetryTarget.ScheduledTime)
+	}
 	return &Agent{
-		requestID:    requestID,
-		dag:          dag,
-		dry:          opts.Dry,
-		retryTarget:  opts.RetryTarget,
-		logDir:       logDir,
-		logFile:      logFile,
-		client:       cli,
-		dagStore:     dagStore,
-		historyStore: historyStore,
+		requestID:     requestID,
+		dag:           dag,
+		dry:           opts.Dry,
+		retryTarget:   opts.RetryTarget,
+		secrets:       opts.Secrets,
+		logLimit:      opts.LogLimit,
+		compressLogs:  opts.CompressLogs,
+		logShipper:    opts.LogShipper,
+		cgroup:        opts.Cgroup,
+		scheduledTime: scheduledTime,
+		events:        newEventLog(),
+		logDir:        logDir,
+		logFile:       logFile,
+		client:        cli,
+		dagStore:      dagStore,
+		historyStore:  historyStore,
 	}
 }
 
 // Run setups the scheduler and runs the DAG.
 func (a *Agent) Run(ctx context.Context) error {
+	// Keep the key of the secret store from the processes of the steps.
+	secrets.TakeKeyEnv()
+
+	if a.secrets != nil {
+		ctx = secrets.WithResolver(ctx, a.secrets)
+	}
+	if a.logShipper != nil {
+		ctx = logsink.WithShipper(ctx, a.logShipper)
+	}
+
 	if err := a.setup(ctx); err != nil {
 		return err
 	}
 
+	params, err := a.resolveSecrets(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Create a new context for the DAG execution
 	dbClient := newDBClient(a.historyStore, a.dagStore)
-	ctx = digraph.NewContext(ctx, a.dag, dbClient, a.requestID, a.logFile, a.dag.Params)
+	ctx = digraph.NewContext(ctx, a.dag, dbClient, a.requestID, a.logFile, params)
+	ctx = digraph.WithContext(ctx, digraph.GetContext(ctx).WithLogicalTime(a.logicalTime()))
 
 	// It should not run the DAG if the condition is unmet.
 	if err := a.checkPreconditions(ctx); err != nil {
@@ -116,6 +181,14 @@ func (a *Agent) Run(ctx context.Context) error {
 		return err
 	}
 
+	// The steps limiting the resources run in the cgroups created in the
+	// cgroup of the run.
+	ctx, releaseCgroup, err := executor.SetupRunCgroup(ctx, a.cgroup, a.requestID, a.steps())
+	if err != nil {
+		return err
+	}
+	defer releaseCgroup(ctx)
+
 	// Make a connection to the database.
 	// It should close the connection to the history database when the DAG
 	// execution is finished.
@@ -168,12 +241,29 @@ func (a *Agent) Run(ctx context.Context) error {
 			if err := a.historyStore.Write(ctx, status); err != nil {
 				logger.Error(ctx, "Failed to write status", "err", err)
 			}
+			a.events.publish(a.Status)
 			if err := a.reporter.reportStep(ctx, a.dag, status, node); err != nil {
 				logger.Error(ctx, "Failed to report step", "err", err)
 			}
 		}
 	})
 
+	// Publish the events of the steps started, of which the scheduler does
+	// not notify, until the DAG execution is finished.
+	stopEvents := make(chan struct{})
+	go execWithRecovery(ctx, func() {
+		ticker := time.NewTicker(eventInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				a.events.publish(a.Status)
+			case <-stopEvents:
+				return
+			}
+		}
+	})
+
 	// Write the first status just after the start to store the running status.
 	// If the DAG is already finished, skip it.
 	go execWithRecovery(ctx, func() {
@@ -189,6 +279,7 @@ func (a *Agent) Run(ctx context.Context) error {
 	// Start the DAG execution.
 	logger.Info(ctx, "DAG execution started", "reqId", a.requestID, "name", a.dag.Name, "params", a.dag.Params)
 	lastErr := a.scheduler.Schedule(ctx, a.graph, done)
+	close(stopEvents)
 
 	// Update the finished status to the history database.
 	finishedStatus := a.Status()
@@ -196,6 +287,8 @@ func (a *Agent) Run(ctx context.Context) error {
 	if err := a.historyStore.Write(ctx, a.Status()); err != nil {
 		logger.Error(ctx, "Status write failed", "err", err)
 	}
+	a.events.publish(a.Status)
+	a.events.finish()
 
 	// Send the execution report if necessary.
 	a.lastErr = lastErr
@@ -203,6 +296,11 @@ func (a *Agent) Run(ctx context.Context) error {
 		logger.Error(ctx, "Mail notification failed", "err", err)
 	}
 
+	// Compress the step logs after they have been attached to the mail.
+	if a.compressLogs {
+		a.compressStepLogs(ctx, finishedStatus)
+	}
+
 	// Mark the agent finished.
 	a.finished.Store(true)
 
@@ -210,6 +308,22 @@ func (a *Agent) Run(ctx context.Context) error {
 	return lastErr
 }
 
+// compressStepLogs compresses the logs of the steps of the finished run.
+// The readers of the logs decompress them transparently.
+func (a *Agent) compressStepLogs(ctx context.Context, status model.Status) {
+	nodes := slices.Clone(status.Nodes)
+	for _, n := range []*model.Node{status.OnSuccess, status.OnFailure, status.OnCancel, status.OnExit} {
+		if n != nil {
+			nodes = append(nodes, n)
+		}
+	}
+	for _, n := range nodes {
+		if err := logfile.Compress(n.Log); err != nil {
+			logger.Warn(ctx, "Failed to compress the step log", "step", n.Step.Name, "file", n.Log, "err", err)
+		}
+	}
+}
+
 func (a *Agent) PrintSummary(ctx context.Context) {
 	status := a.Status()
 	summary := a.reporter.getSummary(ctx, status, a.lastErr)
@@ -217,6 +331,20 @@ func (a *Agent) PrintSummary(ctx context.Context) {
 }
 
 // Status collects the current running status of the DAG and returns it.
+// logicalTime returns the time the run is for. It is the scheduled time of
+// a scheduled run and the start time of the retried run for a retry.
+func (a *Agent) logicalTime() time.Time {
+	if !a.scheduledTime.IsZero() {
+		return a.scheduledTime
+	}
+	if a.retryTarget != nil {
+		if t, err := stringutil.ParseTime(a.retryTarget.StartedAt); err == nil && !t.IsZero() {
+			return t
+		}
+	}
+	return time.Now()
+}
+
 func (a *Agent) Status() model.Status {
 	// Lock to avoid race condition.
 	a.lock.RLock()
@@ -229,7 +357,7 @@ func (a *Agent) Status() model.Status {
 	}
 
 	// Create the status object to record the current status.
-	return model.NewStatusFactory(a.dag).
+	status := model.NewStatusFactory(a.dag).
 		Create(
 			a.requestID,
 			schedulerStatus,
@@ -238,11 +366,71 @@ func (a *Agent) Status() model.Status {
 			model.WithFinishedAt(a.graph.FinishAt()),
 			model.WithNodes(a.graph.NodeData()),
 			model.WithLogFilePath(a.logFile),
+			model.WithSpecRevision(a.specRevision),
+			model.WithScheduledTime(a.scheduledTime),
 			model.WithOnExitNode(a.scheduler.HandlerNode(digraph.HandlerOnExit)),
 			model.WithOnSuccessNode(a.scheduler.HandlerNode(digraph.HandlerOnSuccess)),
 			model.WithOnFailureNode(a.scheduler.HandlerNode(digraph.HandlerOnFailure)),
 			model.WithOnCancelNode(a.scheduler.HandlerNode(digraph.HandlerOnCancel)),
 		)
+	if a.secrets == nil {
+		return status
+	}
+	return maskStatus(status, a.secrets.Masker())
+}
+
+// maskStatus replaces the values of the secrets in the status, e.g. in the
+// evaluated arguments and the outputs of the steps, with the mask.
+func maskStatus(status model.Status, masker *secrets.Masker) model.Status {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return status
+	}
+	if data, err = masker.MaskJSON(data); err != nil {
+		return status
+	}
+	var masked model.Status
+	if err := json.Unmarshal(data, &masked); err != nil {
+		return status
+	}
+	return masked
+}
+
+// resolveSecrets resolves the references to secrets in the environment
+// variables of the DAG and returns the parameters with the references
+// resolved. The parameters of the DAG keep the references so that the
+// status and retries do not hold the values.
+func (a *Agent) resolveSecrets(ctx context.Context) ([]string, error) {
+	if a.secrets == nil {
+		return a.dag.Params, nil
+	}
+
+	env := make([]string, len(a.dag.Env))
+	for i, kv := range a.dag.Env {
+		key, value, _ := strings.Cut(kv, "=")
+		if secrets.HasReference(value) {
+			resolved, err := a.secrets.Resolve(ctx, value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve the environment variable %s: %w", key, err)
+			}
+			if err := os.Setenv(key, resolved); err != nil {
+				return nil, err
+			}
+			kv = key + "=" + resolved
+		}
+		env[i] = kv
+	}
+	a.dag.Env = env
+
+	params := make([]string, len(a.dag.Params))
+	for i, param := range a.dag.Params {
+		resolved, err := a.secrets.Resolve(ctx, param)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve the parameter %q: %w", param, err)
+		}
+		params[i] = resolved
+	}
+	return params, nil
 }
 
 // Signal sends the signal to the processes running
@@ -253,10 +441,23 @@ func (a *Agent) Signal(ctx context.Context, sig os.Signal) {
 // wait before read the running status
 const waitForRunning = time.Millisecond * 100
 
+// eventInterval is the interval to check the changes of the status which
+// the scheduler does not notify of, e.g. the steps started.
+const eventInterval = time.Millisecond * 500
+
+// The timeouts of the requests waiting for the events.
+const (
+	defaultEventsTimeout = time.Second * 30
+	maxEventsTimeout     = time.Minute
+)
+
 // Simple regular expressions for request routing
 var (
-	statusRe = regexp.MustCompile(`^/status[/]?$`)
-	stopRe   = regexp.MustCompile(`^/stop[/]?$`)
+	statusRe    = regexp.MustCompile(`^/status[/]?$`)
+	stopRe      = regexp.MustCompile(`^/stop[/]?$`)
+	stepStopRe  = regexp.MustCompile(`^/steps/([^/]+)/stop[/]?$`)
+	stepRetryRe = regexp.MustCompile(`^/steps/([^/]+)/retry[/]?$`)
+	eventsRe    = regexp.MustCompile(`^/events[/]?$`)
 )
 
 // HandleHTTP handles HTTP requests via unix socket.
@@ -283,6 +484,73 @@ func (a *Agent) HandleHTTP(ctx context.Context) sock.HTTPHandlerFunc {
 				logger.Info(ctx, "Stop request received")
 				a.signal(ctx, syscall.SIGTERM, true)
 			}()
+		case r.Method == http.MethodPost && stepStopRe.MatchString(r.URL.EscapedPath()):
+			// Stop a running step without stopping the DAG execution.
+			name, err := url.PathUnescape(stepStopRe.FindStringSubmatch(r.URL.EscapedPath())[1])
+			if err != nil {
+				encodeError(w, &httpError{Code: http.StatusBadRequest, Message: err.Error()})
+				return
+			}
+			status := scheduler.NodeStatusError
+			switch s := r.URL.Query().Get("status"); s {
+			case "", scheduler.NodeStatusError.String():
+			case scheduler.NodeStatusSkipped.String():
+				status = scheduler.NodeStatusSkipped
+			default:
+				encodeError(w, &httpError{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid status: %s", s)})
+				return
+			}
+			logger.Info(ctx, "Step stop request received", "step", name, "status", status)
+			if err := a.stopStep(ctx, name, status); err != nil {
+				encodeError(w, stepError(err))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("OK"))
+		case r.Method == http.MethodPost && stepRetryRe.MatchString(r.URL.EscapedPath()):
+			// Retry a failed step while the DAG execution is running.
+			name, err := url.PathUnescape(stepRetryRe.FindStringSubmatch(r.URL.EscapedPath())[1])
+			if err != nil {
+				encodeError(w, &httpError{Code: http.StatusBadRequest, Message: err.Error()})
+				return
+			}
+			logger.Info(ctx, "Step retry request received", "step", name)
+			if err := a.scheduler.RetryNode(ctx, a.graph, name); err != nil {
+				encodeError(w, stepError(err))
+				return
+			}
+			a.events.publish(a.Status)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("OK"))
+		case r.Method == http.MethodGet && eventsRe.MatchString(r.URL.Path):
+			// Return the events after the sequence number, waiting for
+			// them until the timeout if there are none.
+			query := r.URL.Query()
+			var since int64
+			if v := query.Get("since"); v != "" {
+				n, err := strconv.ParseInt(v, 10, 64)
+				if err != nil {
+					encodeError(w, &httpError{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid since: %s", v)})
+					return
+				}
+				since = n
+			}
+			timeout := defaultEventsTimeout
+			if v := query.Get("timeout"); v != "" {
+				n, err := strconv.Atoi(v)
+				if err != nil || n < 0 {
+					encodeError(w, &httpError{Code: http.StatusBadRequest, Message: fmt.Sprintf("invalid timeout: %s", v)})
+					return
+				}
+				timeout = min(time.Duration(n)*time.Second, maxEventsTimeout)
+			}
+			eventsJSON, err := json.Marshal(a.events.wait(r.Context(), since, timeout))
+			if err != nil {
+				encodeError(w, err)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(eventsJSON)
 		default:
 			// Unknown request
 			encodeError(
@@ -292,6 +560,50 @@ func (a *Agent) HandleHTTP(ctx context.Context) sock.HTTPHandlerFunc {
 	}
 }
 
+// stopStep stops the running step with the status. The step is killed if
+// it does not terminate in the max cleanup time of the DAG.
+func (a *Agent) stopStep(ctx context.Context, name string, status scheduler.NodeStatus) error {
+	if err := a.scheduler.StopNode(ctx, a.graph, name, syscall.SIGTERM, true, status); err != nil {
+		return err
+	}
+	go execWithRecovery(ctx, func() {
+		deadline := time.Now().Add(a.dag.MaxCleanUpTime)
+		for time.Now().Before(deadline) {
+			time.Sleep(waitForRunning)
+			if !a.isStepRunning(name) {
+				return
+			}
+		}
+		logger.Info(ctx, "Sending KILL signal to the step", "step", name)
+		_ = a.scheduler.StopNode(ctx, a.graph, name, syscall.SIGKILL, false, status)
+	})
+	return nil
+}
+
+// isStepRunning reports whether the step is running.
+func (a *Agent) isStepRunning(name string) bool {
+	for _, node := range a.graph.NodeData() {
+		if node.Step.Name == name {
+			return node.State.Status == scheduler.NodeStatusRunning
+		}
+	}
+	return false
+}
+
+// stepError returns the error of a request for a step to the HTTP client.
+func stepError(err error) error {
+	switch {
+	case errors.Is(err, scheduler.ErrStepNotFound):
+		return &httpError{Code: http.StatusNotFound, Message: err.Error()}
+	case errors.Is(err, scheduler.ErrStepNotRunning),
+		errors.Is(err, scheduler.ErrStepNotFailed),
+		errors.Is(err, scheduler.ErrDAGNotRunning):
+		return &httpError{Code: http.StatusBadRequest, Message: err.Error()}
+	default:
+		return err
+	}
+}
+
 // setup the agent instance for DAG execution.
 func (a *Agent) setup(ctx context.Context) error {
 	// Lock to prevent race condition.
@@ -299,17 +611,46 @@ func (a *Agent) setup(ctx context.Context) error {
 	defer a.lock.Unlock()
 
 	a.scheduler = a.newScheduler()
-	mailer := mailer.New(mailer.Config{
+	mailerConfig, err := cmdutil.EvalStringFields(ctx, mailer.Config{
 		Host:     a.dag.SMTP.Host,
 		Port:     a.dag.SMTP.Port,
 		Username: a.dag.SMTP.Username,
 		Password: a.dag.SMTP.Password,
-	})
-	a.reporter = newReporter(mailer)
+	}, cmdutil.OnlyReplaceVars())
+	if err != nil {
+		return fmt.Errorf("failed to evaluate the SMTP config: %w", err)
+	}
+	a.reporter = newReporter(mailer.New(mailerConfig))
+	a.specRevision = a.readSpecRevision(ctx)
 
 	return a.setupGraph(ctx)
 }
 
+// readSpecRevision returns the revision hash of the DAG spec. A retry keeps
+// the revision of the original run as it runs the same step definitions.
+// The spec is saved as a revision if it was changed outside the DAG store,
+// e.g. in an editor or by git pull, so that the revision of the run can be
+// looked up.
+func (a *Agent) readSpecRevision(ctx context.Context) string {
+	if a.retryTarget != nil && a.retryTarget.SpecRevision != "" {
+		return a.retryTarget.SpecRevision
+	}
+	if a.dag.Location == "" {
+		return ""
+	}
+	spec, err := os.ReadFile(a.dag.Location)
+	if err != nil {
+		logger.Warn(ctx, "Failed to read the DAG spec", "file", a.dag.Location, "err", err)
+		return ""
+	}
+	if a.dagStore != nil {
+		if err := a.dagStore.EnsureRevision(ctx, a.dag.Location, spec); err != nil {
+			logger.Warn(ctx, "Failed to save the revision of the DAG spec", "file", a.dag.Location, "err", err)
+		}
+	}
+	return model.NewRevisionHash(spec)
+}
+
 // newScheduler creates a scheduler instance for the DAG execution.
 func (a *Agent) newScheduler() *scheduler.Scheduler {
 	cfg := &scheduler.Config{
@@ -319,6 +660,13 @@ func (a *Agent) newScheduler() *scheduler.Scheduler {
 		Delay:         a.dag.Delay,
 		Dry:           a.dry,
 		ReqID:         a.requestID,
+		LogLimit:      a.logLimit,
+	}
+	if a.dag.LogMaxSize > 0 {
+		cfg.LogLimit.MaxSize = a.dag.LogMaxSize
+	}
+	if a.dag.LogTruncate != "" {
+		cfg.LogLimit.TruncateHead = a.dag.LogTruncate == digraph.LogTruncateHead
 	}
 
 	if a.dag.HandlerOn.Exit != nil {
@@ -407,6 +755,17 @@ func (a *Agent) signal(ctx context.Context, sig os.Signal, allowOverride bool) {
 	}
 }
 
+// steps returns the steps of the DAG including the handlers.
+func (a *Agent) steps() []digraph.Step {
+	steps := append([]digraph.Step{}, a.dag.Steps...)
+	for _, handler := range []*digraph.Step{a.dag.HandlerOn.Failure, a.dag.HandlerOn.Success, a.dag.HandlerOn.Cancel, a.dag.HandlerOn.Exit} {
+		if handler != nil {
+			steps = append(steps, *handler)
+		}
+	}
+	return steps
+}
+
 // setupGraph setups the DAG graph. If is retry execution, it loads nodes
 // from the retry node so that it runs the same DAG as the previous run.
 func (a *Agent) setupGraph(ctx context.Context) error {
@@ -512,7 +871,7 @@ func encodeError(w http.ResponseWriter, err error) {
 	if errors.As(err, &httpErr) {
 		http.Error(w, httpErr.Error(), httpErr.Code)
 	} else {
-		http.Error(w, httpErr.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
@@ -555,9 +914,74 @@ func (o *dbClient) GetStatus(ctx context.Context, name string, requestID string)
 		}
 	}
 
-	return &digraph.Status{
+	result := &digraph.Status{
 		Outputs: outputVariables,
 		Name:    status.Status.Name,
 		Params:  status.Status.Params,
-	}, nil
+	}
+
+	// The usage of the run is aggregated from the steps including the
+	// handlers. The steps may run in parallel, so the peak RSS is the
+	// largest one of the steps rather than the sum.
+	nodes := append([]*model.Node{}, status.Status.Nodes...)
+	for _, node := range []*model.Node{status.Status.OnExit, status.Status.OnSuccess, status.Status.OnFailure, status.Status.OnCancel} {
+		if node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	for _, node := range nodes {
+		result.PeakRSS = max(result.PeakRSS, node.PeakRSS)
+		result.UserTime += time.Duration(node.UserTime) * time.Millisecond
+		result.SystemTime += time.Duration(node.SystemTime) * time.Millisecond
+	}
+
+	return result, nil
+}
+
+// statusesPageSize is the number of the recent runs read first by
+// GetStatusesOn. It is doubled until the runs before the date are read.
+const statusesPageSize = 100
+
+// GetStatusesOn implements digraph.DBClient.
+func (o *dbClient) GetStatusesOn(ctx context.Context, name string, date time.Time) ([]*digraph.Status, error) {
+	var ret []*digraph.Status
+	y, m, d := date.Date()
+	for _, file := range o.readStatusesSince(ctx, name, time.Date(y, m, d, 0, 0, 0, 0, date.Location())) {
+		// A scheduled run is for the date of the scheduled time.
+		value := file.Status.StartedAt
+		if file.Status.ScheduledTime != "" {
+			value = file.Status.ScheduledTime
+		}
+		runAt, err := stringutil.ParseTime(value)
+		if err != nil || runAt.IsZero() {
+			continue
+		}
+		if ry, rm, rd := runAt.In(date.Location()).Date(); ry != y || rm != m || rd != d {
+			continue
+		}
+		ret = append(ret, &digraph.Status{
+			Name:       file.Status.Name,
+			Params:     file.Status.Params,
+			RequestID:  file.Status.RequestID,
+			StatusText: file.Status.StatusText,
+			StartedAt:  file.Status.StartedAt,
+			Success:    file.Status.Status == scheduler.StatusSuccess,
+		})
+	}
+	return ret, nil
+}
+
+// readStatusesSince reads the recent runs of the DAG, newest first, until the
+// runs started before the time are included or all the runs are read.
+func (o *dbClient) readStatusesSince(ctx context.Context, name string, since time.Time) []model.StatusFile {
+	for limit := statusesPageSize; ; limit *= 2 {
+		files := o.historyStore.ReadStatusRecent(ctx, name, limit)
+		if len(files) < limit {
+			return files
+		}
+		startedAt, err := stringutil.ParseTime(files[len(files)-1].Status.StartedAt)
+		if err == nil && !startedAt.IsZero() && startedAt.Before(since) {
+			return files
+		}
+	}
 }
@@ -1,16 +1,25 @@
 package agent_test
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/agent"
 	"github.com/dagu-org/dagu/internal/test"
 
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/logfile"
+	"github.com/dagu-org/dagu/internal/logsink"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/secrets"
 	"github.com/stretchr/testify/require"
 )
 
@@ -140,6 +149,101 @@ func TestAgent_Run(t *testing.T) {
 		// Check if the exit handler is executed
 		require.Equal(t, scheduler.NodeStatusSuccess.String(), status.OnExit.Status.String())
 	})
+	t.Run("Secrets", func(t *testing.T) {
+		th := test.Setup(t)
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("token-value\n"), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "password"), []byte("password-value"), 0600))
+		resolver := secrets.NewResolver(map[string]secrets.Provider{
+			"file": secrets.NewFileProvider(dir),
+		})
+
+		dag := th.DAG(t, "agent/secrets.yaml")
+		dagAgent := dag.Agent(test.WithAgentOptions(agent.Options{Secrets: resolver}))
+		dagAgent.RunSuccess(t)
+
+		// The values are masked in the status and the log of the step
+		status := dagAgent.Status()
+		require.Equal(t, []string{"PASSWORD=${secret:file/password}"}, status.ParamsList)
+		require.NotContains(t, status.Nodes[0].Step.Args, "token-value")
+
+		outputs, ok := status.Nodes[0].Step.OutputVariables.Load("OUT")
+		require.True(t, ok)
+		require.Equal(t, "OUT=token=***** password=*****", outputs)
+
+		data, err := os.ReadFile(status.Nodes[0].Log)
+		require.NoError(t, err)
+		require.Equal(t, "token=***** password=*****\n", string(data))
+	})
+	t.Run("LogLimit", func(t *testing.T) {
+		th := test.Setup(t)
+
+		// The logMaxSize of the DAG takes precedence over the option.
+		dag := th.DAG(t, "agent/log_limit.yaml")
+		dagAgent := dag.Agent(test.WithAgentOptions(agent.Options{
+			LogLimit:     scheduler.LogLimit{MaxSize: 1024},
+			CompressLogs: true,
+		}))
+		dagAgent.RunSuccess(t)
+
+		// The log is compressed and read transparently.
+		status := dagAgent.Status()
+		require.NoFileExists(t, status.Nodes[0].Log)
+		require.FileExists(t, status.Nodes[0].Log+logfile.CompressedExt)
+
+		data, err := logfile.ReadFile(status.Nodes[0].Log)
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(string(data), "first\nse\n[dagu] the rest of the log is truncated"), string(data))
+	})
+	t.Run("LogShipper", func(t *testing.T) {
+		th := test.Setup(t)
+
+		file := filepath.Join(t.TempDir(), "logs.jsonl")
+		sink, err := logsink.NewFile(logsink.FileConfig{Path: file})
+		require.NoError(t, err)
+		shipper := logsink.NewShipper(map[string]string{logsink.LabelDAG: "log_sink"})
+		shipper.Add(sink, logsink.Options{})
+
+		dag := th.DAG(t, "agent/log_sink.yaml")
+		dagAgent := dag.Agent(test.WithAgentOptions(agent.Options{LogShipper: shipper}))
+		dagAgent.RunSuccess(t)
+		require.NoError(t, shipper.Close(th.Context))
+
+		// The lines of the step log are shipped with the labels, followed
+		// by the final status of the step.
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, lines, 2)
+		require.Contains(t, lines[0], `"msg":"hello"`)
+		require.Contains(t, lines[0], `"dag":"log_sink"`)
+		require.Contains(t, lines[0], `"step":"1"`)
+		require.Contains(t, lines[0], `"status":"running"`)
+		require.Contains(t, lines[1], `"msg":"Step execution finished"`)
+		require.Contains(t, lines[1], `"step":"1"`)
+		require.Contains(t, lines[1], `"status":"finished"`)
+	})
+}
+
+func TestAgent_SecretsKey(t *testing.T) {
+	th := test.Setup(t)
+
+	t.Setenv(secrets.EnvKeyLocalKey, "secrets-key-value")
+	t.Setenv(secrets.EnvKeyLocalKeyFile, filepath.Join(t.TempDir(), "key"))
+
+	dag := th.DAG(t, "agent/secrets_key.yaml")
+	dagAgent := dag.Agent()
+	dagAgent.RunSuccess(t)
+
+	// The key is not passed to the steps but can still be read by the agent.
+	dag.AssertOutputs(t, map[string]any{
+		"OUT":         "none",
+		"SUBSTITUTED": "0",
+	})
+	key, err := secrets.LoadKey("")
+	require.NoError(t, err)
+	require.Equal(t, "secrets-key-value", string(key))
 }
 
 func TestAgent_DryRun(t *testing.T) {
@@ -279,6 +383,176 @@ func TestAgent_HandleHTTP(t *testing.T) {
 		<-done
 		dag.AssertLatestStatus(t, scheduler.StatusCancel)
 	})
+	t.Run("HTTP_StopStep", func(t *testing.T) {
+		th := test.Setup(t)
+
+		// Start a DAG with a long-running step
+		dag := th.DAG(t, "agent/handle_http_stop_step.yaml")
+		dagAgent := dag.Agent()
+
+		done := make(chan struct{})
+		go func() {
+			dagAgent.RunSuccess(t)
+			close(done)
+		}()
+
+		// Wait for the step to start
+		require.Eventually(t, func() bool {
+			return dagAgent.Status().Nodes[0].Status == scheduler.NodeStatusRunning
+		}, time.Second*5, time.Millisecond*100)
+
+		// Request with an invalid status
+		w := mockResponseWriter{}
+		dagAgent.HandleHTTP(th.Context)(&w, &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Path: "/steps/1/stop", RawQuery: "status=success"},
+		})
+		require.Equal(t, http.StatusBadRequest, w.status)
+
+		// Request for an unknown step
+		w = mockResponseWriter{}
+		dagAgent.HandleHTTP(th.Context)(&w, &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Path: "/steps/unknown/stop"},
+		})
+		require.Equal(t, http.StatusNotFound, w.status)
+
+		// Stop the step without stopping the DAG
+		w = mockResponseWriter{}
+		dagAgent.HandleHTTP(th.Context)(&w, &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Path: "/steps/1/stop", RawQuery: "status=skipped"},
+		})
+		require.Equal(t, http.StatusOK, w.status)
+		require.Equal(t, "OK", w.body)
+
+		<-done
+		status := dagAgent.Status()
+		require.Equal(t, scheduler.NodeStatusSkipped, status.Nodes[0].Status)
+		require.Equal(t, scheduler.NodeStatusSuccess, status.Nodes[1].Status)
+	})
+	t.Run("HTTP_RetryStep", func(t *testing.T) {
+		th := test.Setup(t)
+
+		// Start a DAG with a step failing until the flag file exists
+		dag := th.DAG(t, "agent/handle_http_retry_step.yaml")
+		dagAgent := dag.Agent()
+
+		done := make(chan struct{})
+		go func() {
+			dagAgent.RunSuccess(t)
+			close(done)
+		}()
+
+		// Wait for the step to fail
+		require.Eventually(t, func() bool {
+			node := dagAgent.Status().Nodes[0]
+			return node.Status == scheduler.NodeStatusError && node.FinishedAt != "-"
+		}, time.Second*5, time.Millisecond*100)
+
+		// The step not failed cannot be retried
+		w := mockResponseWriter{}
+		dagAgent.HandleHTTP(th.Context)(&w, &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Path: "/steps/3/retry"},
+		})
+		require.Equal(t, http.StatusBadRequest, w.status)
+
+		// Retry the step after creating the flag file
+		require.NoError(t, os.WriteFile(dagAgent.Status().Log+".retry", nil, 0600))
+		w = mockResponseWriter{}
+		dagAgent.HandleHTTP(th.Context)(&w, &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Path: "/steps/1/retry"},
+		})
+		require.Equal(t, http.StatusOK, w.status)
+
+		// The DAG succeeds with the steps depending on the retried one
+		<-done
+		status := dagAgent.Status()
+		require.Equal(t, scheduler.NodeStatusSuccess, status.Nodes[0].Status)
+		require.Equal(t, scheduler.NodeStatusSuccess, status.Nodes[1].Status)
+	})
+	t.Run("HTTP_Events", func(t *testing.T) {
+		th := test.Setup(t)
+
+		dag := th.DAG(t, "agent/handle_http_stop_step.yaml")
+		dagAgent := dag.Agent()
+
+		done := make(chan struct{})
+		go func() {
+			dagAgent.RunSuccess(t)
+			close(done)
+		}()
+
+		events := func(since int64, timeout string) model.EventList {
+			t.Helper()
+
+			w := mockResponseWriter{}
+			dagAgent.HandleHTTP(th.Context)(&w, &http.Request{
+				Method: "GET",
+				URL:    &url.URL{Path: "/events", RawQuery: fmt.Sprintf("since=%d&timeout=%s", since, timeout)},
+			})
+			require.Equal(t, http.StatusOK, w.status)
+			var list model.EventList
+			require.NoError(t, json.Unmarshal([]byte(w.body), &list))
+			return list
+		}
+
+		// The events of the start of the DAG and the steps
+		var (
+			seq     int64
+			running = map[string]bool{}
+		)
+		require.Eventually(t, func() bool {
+			list := events(seq, "1")
+			for _, e := range list.Events {
+				if e.Node != nil && e.Node.Status == scheduler.NodeStatusRunning {
+					running[e.Node.Step.Name] = true
+				}
+			}
+			seq = list.Seq
+			return running["1"] && running["2"]
+		}, time.Second*5, time.Millisecond*100)
+
+		// Stop the step to finish the DAG
+		w := mockResponseWriter{}
+		dagAgent.HandleHTTP(th.Context)(&w, &http.Request{
+			Method: "POST",
+			URL:    &url.URL{Path: "/steps/1/stop", RawQuery: "status=skipped"},
+		})
+		require.Equal(t, http.StatusOK, w.status)
+
+		// Wait for the events until the DAG finishes
+		var last model.Event
+		for {
+			list := events(seq, "10")
+			seq = list.Seq
+			if len(list.Events) > 0 {
+				last = list.Events[len(list.Events)-1]
+			}
+			if list.Finished {
+				break
+			}
+			require.NotEmpty(t, list.Events)
+		}
+		require.Nil(t, last.Node)
+		require.Equal(t, scheduler.StatusSuccess, last.Status)
+		<-done
+
+		// No events follow the last one
+		list := events(seq, "10")
+		require.Empty(t, list.Events)
+		require.True(t, list.Finished)
+
+		// An invalid sequence number
+		w = mockResponseWriter{}
+		dagAgent.HandleHTTP(th.Context)(&w, &http.Request{
+			Method: "GET",
+			URL:    &url.URL{Path: "/events", RawQuery: "since=x"},
+		})
+		require.Equal(t, http.StatusBadRequest, w.status)
+	})
 }
 
 // Assert that mockResponseWriter implements http.ResponseWriter
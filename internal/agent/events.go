@@ -0,0 +1,156 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stringutil"
+)
+
+// maxEvents is the number of the latest events kept for the clients.
+const maxEvents = 1000
+
+// eventLog records the changes of the status of the run for the clients
+// waiting for them on GET /events.
+type eventLog struct {
+	mu       sync.Mutex
+	events   []model.Event
+	seq      int64
+	status   scheduler.Status
+	nodes    map[string]nodeState
+	finished bool
+	// notify is closed and replaced when events are recorded.
+	notify chan struct{}
+}
+
+// nodeState is the part of the state of a step whose change is an event.
+type nodeState struct {
+	status     scheduler.NodeStatus
+	startedAt  string
+	finishedAt string
+	retriedAt  string
+	doneCount  int
+}
+
+func newEventLog() *eventLog {
+	return &eventLog{
+		nodes:  make(map[string]nodeState),
+		notify: make(chan struct{}),
+	}
+}
+
+// publish records the changes of the current status since the last one
+// published. The status is read under the lock so that the changes are
+// recorded in order.
+func (l *eventLog) publish(current func() model.Status) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.finished {
+		return
+	}
+	status := current()
+
+	now := stringutil.FormatTime(time.Now())
+	n := len(l.events)
+	add := func(node *model.Node) {
+		l.seq++
+		l.events = append(l.events, model.Event{
+			Seq:        l.seq,
+			Time:       now,
+			RequestID:  status.RequestID,
+			Status:     status.Status,
+			StatusText: status.StatusText,
+			Node:       node,
+		})
+	}
+
+	nodes := status.Nodes
+	for _, node := range []*model.Node{status.OnSuccess, status.OnFailure, status.OnCancel, status.OnExit} {
+		if node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	for _, node := range nodes {
+		state := nodeState{
+			status:     node.Status,
+			startedAt:  node.StartedAt,
+			finishedAt: node.FinishedAt,
+			retriedAt:  node.RetriedAt,
+			doneCount:  node.DoneCount,
+		}
+		if state != l.nodes[node.Step.Name] {
+			l.nodes[node.Step.Name] = state
+			add(node)
+		}
+	}
+	if status.Status != l.status {
+		l.status = status.Status
+		add(nil)
+	}
+
+	if len(l.events) == n {
+		return
+	}
+	if len(l.events) > maxEvents {
+		l.events = l.events[len(l.events)-maxEvents:]
+	}
+	close(l.notify)
+	l.notify = make(chan struct{})
+}
+
+// finish marks the run finished after the last events are published. The
+// clients get the events without waiting afterwards.
+func (l *eventLog) finish() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.finished {
+		return
+	}
+	l.finished = true
+	close(l.notify)
+}
+
+// wait returns the events after the sequence number. It waits for the events
+// until the timeout if there are none. All the events kept are returned if
+// the sequence number is beyond the last event, e.g. of a previous run.
+func (l *eventLog) wait(ctx context.Context, since int64, timeout time.Duration) model.EventList {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		l.mu.Lock()
+		list := model.EventList{Events: l.after(since), Seq: l.seq, Finished: l.finished}
+		notify := l.notify
+		l.mu.Unlock()
+
+		if len(list.Events) > 0 || list.Finished {
+			return list
+		}
+		select {
+		case <-notify:
+		case <-timer.C:
+			return list
+		case <-ctx.Done():
+			return list
+		}
+	}
+}
+
+// after returns the events kept after the sequence number.
+func (l *eventLog) after(since int64) []model.Event {
+	if since > l.seq || since < 0 {
+		since = 0
+	}
+	events := []model.Event{}
+	for _, e := range l.events {
+		if e.Seq > since {
+			events = append(events, e)
+		}
+	}
+	return events
+}
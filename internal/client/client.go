@@ -2,14 +2,19 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"os/exec"
-	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/dagu-org/dagu/internal/cmdutil"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/fileutil"
@@ -18,6 +23,8 @@ import (
 	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/dagu-org/dagu/internal/sock"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/google/uuid"
 )
 
 // New creates a new Client instance.
@@ -46,6 +53,9 @@ type client struct {
 	flagStore    persistence.FlagStore
 	executable   string
 	workDir      string
+	// historyMu serializes the writes to the history store, which has a
+	// single writer at a time.
+	historyMu sync.Mutex
 }
 
 var (
@@ -103,6 +113,62 @@ func (e *client) Stop(ctx context.Context, dag *digraph.DAG) error {
 	return err
 }
 
+// ErrDAGNotRunning is returned by the requests for the steps of the running
+// DAG when the DAG is not running.
+var ErrDAGNotRunning = errors.New("the DAG is not running")
+
+// The timeouts of the requests to the running DAG.
+const (
+	stepRequestTimeout = time.Second * 3
+	// eventsTimeoutMargin is added to the timeout of the request for the
+	// events to receive the response the agent sends at the timeout.
+	eventsTimeoutMargin = time.Second * 3
+)
+
+func (e *client) StopStep(ctx context.Context, dag *digraph.DAG, step string, status scheduler.NodeStatus) error {
+	logger.Info(ctx, "Stopping step", "name", dag.Name, "step", step, "status", status)
+	addr := dag.SockAddr()
+	if !fileutil.FileExists(addr) {
+		return ErrDAGNotRunning
+	}
+	query := url.Values{"status": {status.String()}}
+	client := sock.NewClient(addr)
+	_, err := client.Do("POST", "/steps/"+url.PathEscape(step)+"/stop?"+query.Encode(), stepRequestTimeout)
+	return err
+}
+
+func (e *client) RetryStep(ctx context.Context, dag *digraph.DAG, step string) error {
+	logger.Info(ctx, "Retrying step", "name", dag.Name, "step", step)
+	addr := dag.SockAddr()
+	if !fileutil.FileExists(addr) {
+		return ErrDAGNotRunning
+	}
+	client := sock.NewClient(addr)
+	_, err := client.Do("POST", "/steps/"+url.PathEscape(step)+"/retry", stepRequestTimeout)
+	return err
+}
+
+func (*client) GetEvents(_ context.Context, dag *digraph.DAG, since int64, timeout time.Duration) (*model.EventList, error) {
+	addr := dag.SockAddr()
+	if !fileutil.FileExists(addr) {
+		return nil, ErrDAGNotRunning
+	}
+	query := url.Values{
+		"since":   {strconv.FormatInt(since, 10)},
+		"timeout": {strconv.Itoa(int(timeout / time.Second))},
+	}
+	client := sock.NewClient(addr)
+	ret, err := client.Do("GET", "/events?"+query.Encode(), timeout+eventsTimeoutMargin)
+	if err != nil {
+		return nil, err
+	}
+	var events model.EventList
+	if err := json.Unmarshal([]byte(ret), &events); err != nil {
+		return nil, fmt.Errorf("failed to parse the events: %w", err)
+	}
+	return &events, nil
+}
+
 func (e *client) StartAsync(ctx context.Context, dag *digraph.DAG, opts StartOptions) {
 	go func() {
 		if err := e.Start(ctx, dag, opts); err != nil {
@@ -120,6 +186,9 @@ func (e *client) Start(_ context.Context, dag *digraph.DAG, opts StartOptions) e
 	if opts.Quiet {
 		args = append(args, "-q")
 	}
+	if !opts.ScheduledTime.IsZero() {
+		args = append(args, "--scheduled-time="+opts.ScheduledTime.Format(time.RFC3339))
+	}
 	args = append(args, dag.Location)
 	// nolint:gosec
 	cmd := exec.Command(e.executable, args...)
@@ -128,12 +197,24 @@ func (e *client) Start(_ context.Context, dag *digraph.DAG, opts StartOptions) e
 	cmd.Env = os.Environ()
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
+	closeToken, err := cmdutil.MarkInternalInvocation(cmd)
+	if err != nil {
+		return err
+	}
 
-	err := cmd.Start()
+	err = cmd.Start()
+	closeToken()
 	if err != nil {
 		return err
 	}
-	return cmd.Wait()
+	if err := cmd.Wait(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == digraph.ExitCodeConditionNotMet {
+			return fmt.Errorf("%w: %s", digraph.ErrConditionNotMet, dag.Name)
+		}
+		return err
+	}
+	return nil
 }
 
 func (e *client) Restart(_ context.Context, dag *digraph.DAG, opts RestartOptions) error {
@@ -147,7 +228,12 @@ func (e *client) Restart(_ context.Context, dag *digraph.DAG, opts RestartOption
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: 0}
 	cmd.Dir = e.workDir
 	cmd.Env = os.Environ()
-	err := cmd.Start()
+	closeToken, err := cmdutil.MarkInternalInvocation(cmd)
+	if err != nil {
+		return err
+	}
+	err = cmd.Start()
+	closeToken()
 	if err != nil {
 		return err
 	}
@@ -163,7 +249,12 @@ func (e *client) Retry(_ context.Context, dag *digraph.DAG, requestID string) er
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true, Pgid: 0}
 	cmd.Dir = e.workDir
 	cmd.Env = os.Environ()
-	err := cmd.Start()
+	closeToken, err := cmdutil.MarkInternalInvocation(cmd)
+	if err != nil {
+		return err
+	}
+	err = cmd.Start()
+	closeToken()
 	if err != nil {
 		return err
 	}
@@ -250,8 +341,87 @@ func (e *client) UpdateStatus(ctx context.Context, dag *digraph.DAG, status mode
 	return e.historyStore.Update(ctx, dag.Location, status.RequestID, status)
 }
 
-func (e *client) UpdateDAG(ctx context.Context, id string, spec string) error {
-	return e.dagStore.UpdateSpec(ctx, id, []byte(spec))
+func (e *client) RecordSkipped(ctx context.Context, dag *digraph.DAG, scheduledAt time.Time, reason string) error {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return fmt.Errorf("failed to generate request ID: %w", err)
+	}
+	requestID := id.String()
+
+	status := model.NewStatusFactory(dag).CreateDefault()
+	status.RequestID = requestID
+	status.Status = scheduler.StatusSkipped
+	status.StatusText = scheduler.StatusSkipped.String()
+	status.StartedAt = model.FormatTime(scheduledAt)
+	status.FinishedAt = model.FormatTime(scheduledAt)
+	status.SkipReason = reason
+
+	e.historyMu.Lock()
+	defer e.historyMu.Unlock()
+
+	if err := e.historyStore.Open(ctx, dag.Location, scheduledAt, requestID); err != nil {
+		return fmt.Errorf("failed to open the history: %w", err)
+	}
+	if err := e.historyStore.Write(ctx, status); err != nil {
+		_ = e.historyStore.Close(ctx)
+		return fmt.Errorf("failed to write the status: %w", err)
+	}
+	return e.historyStore.Close(ctx)
+}
+
+func (e *client) UpdateDAG(ctx context.Context, id string, spec string, info persistence.RevisionInfo) error {
+	return e.dagStore.UpdateSpec(ctx, id, []byte(spec), info)
+}
+
+func (e *client) ListDAGRevisions(ctx context.Context, id string) ([]model.Revision, error) {
+	return e.dagStore.ListRevisions(ctx, id)
+}
+
+func (e *client) GetDAGRevision(ctx context.Context, id, hash string) (*model.Revision, string, error) {
+	revision, spec, err := e.dagStore.GetRevision(ctx, id, hash)
+	if err != nil {
+		return nil, "", err
+	}
+	return revision, string(spec), nil
+}
+
+// DiffDAGRevisions returns the unified diff between two revisions of a DAG.
+// The current spec is compared if to is empty.
+func (e *client) DiffDAGRevisions(ctx context.Context, id, from, to string) (string, error) {
+	fromRevision, fromSpec, err := e.GetDAGRevision(ctx, id, from)
+	if err != nil {
+		return "", err
+	}
+
+	toName := "current"
+	var toSpec string
+	if to == "" {
+		toSpec, err = e.dagStore.GetSpec(ctx, id)
+	} else {
+		var toRevision *model.Revision
+		toRevision, toSpec, err = e.GetDAGRevision(ctx, id, to)
+		if toRevision != nil {
+			toName = toRevision.ShortHash()
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return stringutil.UnifiedDiff(fromSpec, toSpec, fromRevision.ShortHash(), toName)
+}
+
+// RollbackDAG restores the spec of a DAG to the given revision. The rollback
+// is saved as a new revision.
+func (e *client) RollbackDAG(ctx context.Context, id, hash string, info persistence.RevisionInfo) error {
+	revision, spec, err := e.GetDAGRevision(ctx, id, hash)
+	if err != nil {
+		return err
+	}
+	if info.Message == "" {
+		info.Message = fmt.Sprintf("Rollback to %s", revision.ShortHash())
+	}
+	return e.dagStore.UpdateSpec(ctx, id, []byte(spec), info)
 }
 
 func (e *client) DeleteDAG(ctx context.Context, name, loc string) error {
@@ -341,9 +511,11 @@ func (e *client) GetStatus(ctx context.Context, id string) (DAGStatus, error) {
 		_, err = scheduler.NewExecutionGraph(dag.Steps...)
 	}
 	latestStatus, _ := e.GetLatestStatus(ctx, dag)
-	return newDAGStatus(
+	ret := newDAGStatus(
 		dag, latestStatus, e.IsSuspended(ctx, id), err,
-	), err
+	)
+	ret.CommitSHA = e.commitSHA(ctx, dag)
+	return ret, err
 }
 
 func (e *client) ToggleSuspend(_ context.Context, id string, suspend bool) error {
@@ -352,14 +524,27 @@ func (e *client) ToggleSuspend(_ context.Context, id string, suspend bool) error
 
 func (e *client) readStatus(ctx context.Context, dag *digraph.DAG) (DAGStatus, error) {
 	latestStatus, err := e.GetLatestStatus(ctx, dag)
-	id := strings.TrimSuffix(
-		filepath.Base(dag.Location),
-		filepath.Ext(dag.Location),
+
+	ret := newDAGStatus(
+		dag, latestStatus, e.IsSuspended(ctx, dag.ID()), err,
 	)
+	ret.CommitSHA = e.commitSHA(ctx, dag)
+	return ret, err
+}
 
-	return newDAGStatus(
-		dag, latestStatus, e.IsSuspended(ctx, id), err,
-	), err
+// commitSHA returns the last commit that changed the DAG if the DAG store
+// is backed by version control.
+func (e *client) commitSHA(ctx context.Context, dag *digraph.DAG) string {
+	versioned, ok := e.dagStore.(persistence.VersionedDAGStore)
+	if !ok || dag.Location == "" {
+		return ""
+	}
+	sha, err := versioned.CommitSHA(ctx, dag.Location)
+	if err != nil {
+		logger.Warn(ctx, "Failed to get the commit SHA", "dag", dag.Name, "err", err)
+		return ""
+	}
+	return sha
 }
 
 func (*client) emptyDAGIfNil(dag *digraph.DAG, dagLocation string) *digraph.DAG {
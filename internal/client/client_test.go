@@ -13,6 +13,7 @@ import (
 	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/dagu-org/dagu/internal/sock"
 	"github.com/dagu-org/dagu/internal/test"
@@ -146,6 +147,50 @@ func TestClient_RunDAG(t *testing.T) {
 
 		dag.AssertLatestStatus(t, scheduler.StatusCancel)
 	})
+	t.Run("StopStep", func(t *testing.T) {
+		dag := th.DAG(t, filepath.Join("client", "stop_step.yaml"))
+		ctx := th.Context
+		cli := th.Client
+
+		// The requests for the steps fail if the DAG is not running.
+		err := cli.StopStep(ctx, dag.DAG, "1", scheduler.NodeStatusSkipped)
+		require.ErrorIs(t, err, client.ErrDAGNotRunning)
+
+		cli.StartAsync(ctx, dag.DAG, client.StartOptions{})
+
+		// Wait for the event of the step started.
+		var seq int64
+		require.Eventually(t, func() bool {
+			list, err := cli.GetEvents(ctx, dag.DAG, seq, time.Second)
+			if err != nil {
+				return false
+			}
+			seq = list.Seq
+			for _, e := range list.Events {
+				if e.Node != nil && e.Node.Step.Name == "1" && e.Node.Status == scheduler.NodeStatusRunning {
+					return true
+				}
+			}
+			return false
+		}, time.Second*10, time.Millisecond*100)
+
+		// The running step cannot be retried.
+		err = cli.RetryStep(ctx, dag.DAG, "1")
+		var respErr *sock.ResponseError
+		require.ErrorAs(t, err, &respErr)
+		require.Equal(t, http.StatusBadRequest, respErr.StatusCode)
+
+		// Stop the step without stopping the DAG.
+		err = cli.StopStep(ctx, dag.DAG, "1", scheduler.NodeStatusSkipped)
+		require.NoError(t, err)
+
+		dag.AssertLatestStatus(t, scheduler.StatusSuccess)
+
+		status, err := cli.GetLatestStatus(ctx, dag.DAG)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.NodeStatusSkipped, status.Nodes[0].Status)
+		require.Equal(t, scheduler.NodeStatusSkipped, status.Nodes[1].Status)
+	})
 	t.Run("Restart", func(t *testing.T) {
 		dag := th.DAG(t, filepath.Join("client", "restart.yaml"))
 		ctx := th.Context
@@ -188,6 +233,81 @@ func TestClient_RunDAG(t *testing.T) {
 	})
 }
 
+func TestClient_Revisions(t *testing.T) {
+	t.Parallel()
+
+	th := test.Setup(t)
+	ctx := th.Context
+	cli := th.Client
+
+	id, err := cli.CreateDAG(ctx, "revisions-dag")
+	require.NoError(t, err)
+	created, err := cli.GetDAGSpec(ctx, id)
+	require.NoError(t, err)
+
+	updated := `steps:
+  - name: step1
+    command: echo updated
+`
+	err = cli.UpdateDAG(ctx, id, updated, persistence.RevisionInfo{
+		Author:  "alice",
+		Message: "Change the command",
+	})
+	require.NoError(t, err)
+
+	t.Run("List", func(t *testing.T) {
+		revisions, err := cli.ListDAGRevisions(ctx, id)
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+
+		// Newest first
+		require.Equal(t, model.NewRevisionHash([]byte(updated)), revisions[0].Hash)
+		require.Equal(t, "alice", revisions[0].Author)
+		require.Equal(t, "Change the command", revisions[0].Message)
+		require.Equal(t, model.NewRevisionHash([]byte(created)), revisions[1].Hash)
+	})
+	t.Run("Get", func(t *testing.T) {
+		hash := model.NewRevisionHash([]byte(created))
+		revision, spec, err := cli.GetDAGRevision(ctx, id, model.ShortRevisionHash(hash))
+		require.NoError(t, err)
+		require.Equal(t, hash, revision.Hash)
+		require.Equal(t, created, spec)
+
+		_, _, err = cli.GetDAGRevision(ctx, id, "0000000000")
+		require.ErrorIs(t, err, persistence.ErrRevisionNotFound)
+	})
+	t.Run("Diff", func(t *testing.T) {
+		hash := model.NewRevisionHash([]byte(created))
+		diff, err := cli.DiffDAGRevisions(ctx, id, hash, "")
+		require.NoError(t, err)
+		require.Contains(t, diff, "-    command: echo hello")
+		require.Contains(t, diff, "+    command: echo updated")
+	})
+	t.Run("Rollback", func(t *testing.T) {
+		hash := model.NewRevisionHash([]byte(created))
+		err := cli.RollbackDAG(ctx, id, hash, persistence.RevisionInfo{Author: "bob"})
+		require.NoError(t, err)
+
+		spec, err := cli.GetDAGSpec(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, created, spec)
+
+		revisions, err := cli.ListDAGRevisions(ctx, id)
+		require.NoError(t, err)
+		require.Len(t, revisions, 3)
+		require.Equal(t, hash, revisions[0].Hash)
+		require.Equal(t, "bob", revisions[0].Author)
+		require.Equal(t, "Rollback to "+model.ShortRevisionHash(hash), revisions[0].Message)
+	})
+	t.Run("Rename", func(t *testing.T) {
+		require.NoError(t, cli.Rename(ctx, id, "revisions-dag-renamed"))
+
+		revisions, err := cli.ListDAGRevisions(ctx, "revisions-dag-renamed")
+		require.NoError(t, err)
+		require.Len(t, revisions, 3)
+	})
+}
+
 func TestClient_UpdateDAG(t *testing.T) {
 	t.Parallel()
 
@@ -204,7 +324,7 @@ steps:
     command: "true"
 `
 		// Update Error: the DAG does not exist
-		err := cli.UpdateDAG(ctx, "non-existing-dag", validDAG)
+		err := cli.UpdateDAG(ctx, "non-existing-dag", validDAG, persistence.RevisionInfo{})
 		require.Error(t, err)
 
 		// create a new DAG file
@@ -212,7 +332,7 @@ steps:
 		require.NoError(t, err)
 
 		// Update the DAG
-		err = cli.UpdateDAG(ctx, id, validDAG)
+		err = cli.UpdateDAG(ctx, id, validDAG, persistence.RevisionInfo{})
 		require.NoError(t, err)
 
 		// Check the content of the DAG file
@@ -231,7 +351,7 @@ steps:
 `
 		id, err := cli.CreateDAG(ctx, "test")
 		require.NoError(t, err)
-		err = cli.UpdateDAG(ctx, id, spec)
+		err = cli.UpdateDAG(ctx, id, spec, persistence.RevisionInfo{})
 		require.NoError(t, err)
 
 		// check file
@@ -332,7 +452,7 @@ func TestClient_GetTagList(t *testing.T) {
 		} else {
 			spec = "tags: tag2,tag3\nsteps:\n  - name: step1\n    command: echo hello\n"
 		}
-		if err = cli.UpdateDAG(ctx, id, spec); err != nil {
+		if err = cli.UpdateDAG(ctx, id, spec, persistence.RevisionInfo{}); err != nil {
 			t.Fatal(err)
 		}
 	}
@@ -351,3 +471,42 @@ func TestClient_GetTagList(t *testing.T) {
 	require.True(t, mapTags["tag2"])
 	require.True(t, mapTags["tag3"])
 }
+
+func TestClient_Namespaces(t *testing.T) {
+	th := test.Setup(t)
+
+	ctx := th.Context
+	cli := th.Client
+
+	// Two teams have the DAG with the same name.
+	for _, id := range []string{"team-a/etl", "team-b/etl"} {
+		created, err := cli.CreateDAG(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, id, created)
+		require.FileExists(t, filepath.Join(th.Config.Paths.DAGsDir, id+".yaml"))
+	}
+
+	require.NoError(t, cli.ToggleSuspend(ctx, "team-a/etl", true))
+
+	statuses, errs, err := cli.GetAllStatus(ctx)
+	require.NoError(t, err)
+	require.Empty(t, errs)
+
+	suspended := make(map[string]bool)
+	for _, status := range statuses {
+		suspended[status.File] = status.Suspended
+	}
+	require.Equal(t, map[string]bool{
+		"team-a/etl.yaml": true,
+		"team-b/etl.yaml": false,
+	}, suspended)
+
+	status, err := cli.GetStatus(ctx, "team-b/etl")
+	require.NoError(t, err)
+	require.Equal(t, "team-b", status.DAG.Namespace)
+	require.Equal(t, "team-b/etl", status.DAG.ID())
+	require.False(t, status.Suspended)
+
+	_, err = cli.CreateDAG(ctx, "../outside")
+	require.Error(t, err)
+}
@@ -2,9 +2,12 @@ package client
 
 import (
 	"context"
+	"path"
 	"path/filepath"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/dags"
 	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/model"
@@ -16,6 +19,14 @@ type Client interface {
 	Grep(ctx context.Context, pattern string) ([]*persistence.GrepResult, []string, error)
 	Rename(ctx context.Context, oldID, newID string) error
 	Stop(ctx context.Context, dag *digraph.DAG) error
+	// StopStep stops the running step of the running DAG without stopping
+	// the DAG. The step finishes with the status, either failed or skipped.
+	StopStep(ctx context.Context, dag *digraph.DAG, step string, status scheduler.NodeStatus) error
+	// RetryStep runs the failed step of the running DAG again.
+	RetryStep(ctx context.Context, dag *digraph.DAG, step string) error
+	// GetEvents returns the events of the running DAG after the sequence
+	// number, waiting for them until the timeout if there are none.
+	GetEvents(ctx context.Context, dag *digraph.DAG, since int64, timeout time.Duration) (*model.EventList, error)
 	StartAsync(ctx context.Context, dag *digraph.DAG, opts StartOptions)
 	Start(ctx context.Context, dag *digraph.DAG, opts StartOptions) error
 	Restart(ctx context.Context, dag *digraph.DAG, opts RestartOptions) error
@@ -25,7 +36,14 @@ type Client interface {
 	GetLatestStatus(ctx context.Context, dag *digraph.DAG) (model.Status, error)
 	GetRecentHistory(ctx context.Context, dag *digraph.DAG, n int) []model.StatusFile
 	UpdateStatus(ctx context.Context, dag *digraph.DAG, status model.Status) error
-	UpdateDAG(ctx context.Context, id string, spec string) error
+	// RecordSkipped records the scheduled run that is not started in the
+	// history with the reason.
+	RecordSkipped(ctx context.Context, dag *digraph.DAG, scheduledAt time.Time, reason string) error
+	UpdateDAG(ctx context.Context, id string, spec string, info persistence.RevisionInfo) error
+	ListDAGRevisions(ctx context.Context, id string) ([]model.Revision, error)
+	GetDAGRevision(ctx context.Context, id, hash string) (*model.Revision, string, error)
+	DiffDAGRevisions(ctx context.Context, id, from, to string) (string, error)
+	RollbackDAG(ctx context.Context, id, hash string, info persistence.RevisionInfo) error
 	DeleteDAG(ctx context.Context, id, loc string) error
 	GetAllStatus(ctx context.Context) (statuses []DAGStatus, errs []string, err error)
 	GetAllStatusPagination(ctx context.Context, params dags.ListDAGsParams) ([]DAGStatus, *DagListPaginationSummaryResult, error)
@@ -38,6 +56,8 @@ type Client interface {
 type StartOptions struct {
 	Params string
 	Quiet  bool
+	// ScheduledTime is the time the run was scheduled for, if any.
+	ScheduledTime time.Time
 }
 
 type RestartOptions struct {
@@ -52,6 +72,9 @@ type DAGStatus struct {
 	Suspended bool
 	Error     error
 	ErrorT    *string
+	// CommitSHA is the last commit that changed the DAG if the DAGs
+	// directory is managed by git.
+	CommitSHA string
 }
 
 type DagListPaginationSummaryResult struct {
@@ -63,7 +86,7 @@ func newDAGStatus(
 	dag *digraph.DAG, status model.Status, suspended bool, err error,
 ) DAGStatus {
 	ret := DAGStatus{
-		File:      filepath.Base(dag.Location),
+		File:      path.Join(dag.Namespace, filepath.Base(dag.Location)),
 		Dir:       filepath.Dir(dag.Location),
 		DAG:       dag,
 		Status:    status,
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/secrets"
 	"github.com/itchyny/gojq"
 )
 
@@ -18,6 +19,8 @@ type EvalOptions struct {
 	ExpandEnv  bool
 	Substitute bool
 	Variables  []map[string]string
+	// KeepSecrets keeps the references to secrets instead of resolving them.
+	KeepSecrets bool
 }
 
 type EvalOption func(*EvalOptions)
@@ -40,6 +43,14 @@ func WithoutSubstitute() EvalOption {
 	}
 }
 
+// WithoutSecrets keeps the references to secrets as they are, e.g. to pass
+// them to a sub workflow resolving them by itself.
+func WithoutSecrets() EvalOption {
+	return func(opts *EvalOptions) {
+		opts.KeepSecrets = true
+	}
+}
+
 func OnlyReplaceVars() EvalOption {
 	return func(opts *EvalOptions) {
 		opts.ExpandEnv = false
@@ -92,7 +103,7 @@ func EvalString(ctx context.Context, input string, opts ...EvalOption) (string,
 	for _, opt := range opts {
 		opt(options)
 	}
-	value := input
+	value, refs := protectSecrets(input)
 	for _, vars := range options.Variables {
 		value = ExpandReferences(ctx, value, vars)
 		value = replaceVars(value, vars)
@@ -107,7 +118,7 @@ func EvalString(ctx context.Context, input string, opts ...EvalOption) (string,
 	if options.ExpandEnv {
 		value = os.ExpandEnv(value)
 	}
-	return value, nil
+	return restoreSecrets(ctx, value, refs, options)
 }
 
 // EvalIntString substitutes environment variables and commands in the input string
@@ -116,7 +127,7 @@ func EvalIntString(ctx context.Context, input string, opts ...EvalOption) (int,
 	for _, opt := range opts {
 		opt(options)
 	}
-	value := input
+	value, refs := protectSecrets(input)
 	for _, vars := range options.Variables {
 		value = ExpandReferences(ctx, value, vars)
 		value = replaceVars(value, vars)
@@ -128,6 +139,10 @@ func EvalIntString(ctx context.Context, input string, opts ...EvalOption) (int,
 	if err != nil {
 		return 0, err
 	}
+	value, err = restoreSecrets(ctx, value, refs, options)
+	if err != nil {
+		return 0, err
+	}
 	v, err := strconv.Atoi(value)
 	if err != nil {
 		return 0, fmt.Errorf("failed to convert %q to int: %w", value, err)
@@ -170,7 +185,7 @@ func processStructFields(ctx context.Context, v reflect.Value, opts *EvalOptions
 		// nolint:exhaustive
 		switch field.Kind() {
 		case reflect.String:
-			value := field.String()
+			value, refs := protectSecrets(field.String())
 			for _, vars := range opts.Variables {
 				value = replaceVars(value, vars)
 			}
@@ -187,6 +202,11 @@ func processStructFields(ctx context.Context, v reflect.Value, opts *EvalOptions
 				value = os.ExpandEnv(value)
 			}
 
+			value, err := restoreSecrets(ctx, value, refs, opts)
+			if err != nil {
+				return fmt.Errorf("field %q: %w", t.Field(i).Name, err)
+			}
+
 			field.SetString(value)
 
 		case reflect.Struct:
@@ -311,6 +331,45 @@ func replaceVars(template string, vars map[string]string) string {
 	})
 }
 
+// secretPlaceholder matches the placeholders of the references to secrets
+// protected during the evaluation.
+var secretPlaceholder = regexp.MustCompile("\uE000([0-9]+)\uE001")
+
+// protectSecrets replaces the references to secrets with placeholders so
+// that they are not expanded as variables during the evaluation.
+func protectSecrets(s string) (string, []string) {
+	if !secrets.HasReference(s) {
+		return s, nil
+	}
+	var refs []string
+	s = secrets.ReplaceReferences(s, func(ref string) string {
+		refs = append(refs, ref)
+		return fmt.Sprintf("\uE000%d\uE001", len(refs)-1)
+	})
+	return s, refs
+}
+
+// restoreSecrets puts the references to secrets back in place of the
+// placeholders and resolves them with the resolver of the context. The
+// references are kept as they are if the context has no resolver, e.g.
+// when the DAG is loaded, so that they are resolved only when it runs.
+func restoreSecrets(ctx context.Context, s string, refs []string, opts *EvalOptions) (string, error) {
+	if len(refs) > 0 {
+		s = secretPlaceholder.ReplaceAllStringFunc(s, func(match string) string {
+			i, _ := strconv.Atoi(secretPlaceholder.FindStringSubmatch(match)[1])
+			if i < len(refs) {
+				return refs[i]
+			}
+			return match
+		})
+	}
+	resolver := secrets.FromContext(ctx)
+	if resolver == nil || opts.KeepSecrets || !secrets.HasReference(s) {
+		return s, nil
+	}
+	return resolver.Resolve(ctx, s)
+}
+
 func newEvalOptions() *EvalOptions {
 	return &EvalOptions{
 		ExpandEnv:  true,
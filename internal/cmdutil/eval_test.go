@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/dagu-org/dagu/internal/secrets"
 	"github.com/stretchr/testify/require"
 )
 
@@ -343,3 +344,39 @@ func TestExpandReferences(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalString_Secrets(t *testing.T) {
+	t.Setenv("EVAL_TEST_SECRET", "secret-value")
+	t.Setenv("EVAL_TEST_USER", "admin")
+
+	input := "$EVAL_TEST_USER:${secret:env/EVAL_TEST_SECRET}"
+
+	t.Run("KeepWithoutResolver", func(t *testing.T) {
+		got, err := EvalString(context.Background(), input)
+		require.NoError(t, err)
+		require.Equal(t, "admin:${secret:env/EVAL_TEST_SECRET}", got)
+	})
+
+	resolver := secrets.NewResolver(map[string]secrets.Provider{"env": secrets.NewEnvProvider()})
+	ctx := secrets.WithResolver(context.Background(), resolver)
+
+	t.Run("Resolve", func(t *testing.T) {
+		got, err := EvalString(ctx, input)
+		require.NoError(t, err)
+		require.Equal(t, "admin:secret-value", got)
+	})
+	t.Run("WithoutSecrets", func(t *testing.T) {
+		got, err := EvalString(ctx, input, WithoutSecrets())
+		require.NoError(t, err)
+		require.Equal(t, "admin:${secret:env/EVAL_TEST_SECRET}", got)
+	})
+	t.Run("Fields", func(t *testing.T) {
+		got, err := EvalStringFields(ctx, struct{ Password string }{Password: input})
+		require.NoError(t, err)
+		require.Equal(t, "admin:secret-value", got.Password)
+	})
+	t.Run("Error", func(t *testing.T) {
+		_, err := EvalString(ctx, "${secret:env/EVAL_TEST_NOT_SET}")
+		require.ErrorIs(t, err, secrets.ErrNotFound)
+	})
+}
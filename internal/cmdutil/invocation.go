@@ -0,0 +1,99 @@
+package cmdutil
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// InternalInvocationFlag is the hidden flag of the dagu commands spawned by
+// dagu itself (the scheduler, the web server, sub workflows), so that they
+// can be told apart from the commands run by a user.
+const InternalInvocationFlag = "internal-invocation"
+
+// tokenSize is the size of the random token of an internal invocation.
+const tokenSize = 16
+
+// MarkInternalInvocation marks the dagu command as spawned by dagu itself.
+// It passes a random token both in the hidden flag, right after the
+// subcommand, and through a pipe inherited by the command. The flag alone
+// does not mark a command, so a user or a step cannot forge it by setting
+// the flag or an environment variable. It returns the function to close the
+// end of the pipe of the parent once the command has started.
+func MarkInternalInvocation(cmd *exec.Cmd) (func(), error) {
+	token := make([]byte, tokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return nil, fmt.Errorf("failed to generate the token: %w", err)
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a pipe: %w", err)
+	}
+	defer func() {
+		_ = w.Close()
+	}()
+	// The token fits in the buffer of the pipe.
+	if _, err := w.Write(token); err != nil {
+		_ = r.Close()
+		return nil, fmt.Errorf("failed to write the token: %w", err)
+	}
+
+	// The inherited files start after stdin, stdout and stderr.
+	cmd.ExtraFiles = append(cmd.ExtraFiles, r)
+	fd := 2 + len(cmd.ExtraFiles)
+	flag := fmt.Sprintf("--%s=%d:%s", InternalInvocationFlag, fd, hex.EncodeToString(token))
+
+	// The flag is placed before the other arguments as the arguments after
+	// "--" are the parameters of the DAG.
+	args := append([]string{}, cmd.Args[:min(2, len(cmd.Args))]...)
+	args = append(args, flag)
+	cmd.Args = append(args, cmd.Args[min(2, len(cmd.Args)):]...)
+
+	return func() {
+		_ = r.Close()
+	}, nil
+}
+
+// VerifyInternalInvocation reports whether the value of the hidden flag
+// matches the token passed through the inherited pipe, i.e. the command was
+// spawned by dagu itself. It can be called only once per process as it
+// consumes the token.
+func VerifyInternalInvocation(value string) bool {
+	fdStr, tokenHex, ok := strings.Cut(value, ":")
+	if !ok {
+		return false
+	}
+	fd, err := strconv.Atoi(fdStr)
+	if err != nil || fd < 3 {
+		return false
+	}
+	token, err := hex.DecodeString(tokenHex)
+	if err != nil || len(token) != tokenSize {
+		return false
+	}
+
+	// The file descriptor is only read if it is a pipe, as it may be any
+	// file of the process, e.g. of the runtime, when the flag is forged.
+	var stat syscall.Stat_t
+	if err := syscall.Fstat(fd, &stat); err != nil || uint32(stat.Mode)&syscall.S_IFMT != syscall.S_IFIFO {
+		return false
+	}
+	defer func() {
+		_ = syscall.Close(fd)
+	}()
+	got := make([]byte, tokenSize)
+	for n := 0; n < tokenSize; {
+		m, err := syscall.Read(fd, got[n:])
+		if err != nil || m <= 0 {
+			return false
+		}
+		n += m
+	}
+	return subtle.ConstantTimeCompare(got, token) == 1
+}
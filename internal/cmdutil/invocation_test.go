@@ -0,0 +1,48 @@
+package cmdutil
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInternalInvocation(t *testing.T) {
+	mark := func(t *testing.T) (*exec.Cmd, string) {
+		t.Helper()
+
+		cmd := exec.Command("dagu", "start", "--", "p1")
+		closeToken, err := MarkInternalInvocation(cmd)
+		require.NoError(t, err)
+		t.Cleanup(closeToken)
+
+		// The flag is placed right after the subcommand.
+		require.Len(t, cmd.Args, 5)
+		require.Equal(t, []string{"dagu", "start"}, cmd.Args[:2])
+		require.Equal(t, []string{"--", "p1"}, cmd.Args[3:])
+		value, ok := strings.CutPrefix(cmd.Args[2], "--"+InternalInvocationFlag+"=")
+		require.True(t, ok)
+		require.True(t, strings.HasPrefix(value, "3:"))
+		require.Len(t, cmd.ExtraFiles, 1)
+
+		// Read the pipe in this process as the command would do with fd 3.
+		_, token, _ := strings.Cut(value, ":")
+		return cmd, fmt.Sprintf("%d:%s", cmd.ExtraFiles[0].Fd(), token)
+	}
+
+	t.Run("Verified", func(t *testing.T) {
+		_, value := mark(t)
+		require.True(t, VerifyInternalInvocation(value))
+	})
+	t.Run("WrongToken", func(t *testing.T) {
+		_, value := mark(t)
+		fd, _, _ := strings.Cut(value, ":")
+		require.False(t, VerifyInternalInvocation(fd+":"+strings.Repeat("00", tokenSize)))
+	})
+	t.Run("FlagOnly", func(t *testing.T) {
+		require.False(t, VerifyInternalInvocation("1"))
+		require.False(t, VerifyInternalInvocation("0:"+strings.Repeat("00", tokenSize)))
+	})
+}
@@ -6,13 +6,15 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+
+	"github.com/dagu-org/dagu/internal/secrets"
 )
 
 // runCommand executes cmdStr in a shell, capturing stdout (and ignoring stderr).
 func runCommand(cmdStr string) (string, error) {
 	sh := GetShellCommand("")
 	cmd := exec.Command(sh, "-c", cmdStr)
-	cmd.Env = os.Environ()
+	cmd.Env = secrets.WithoutKeyEnv(os.Environ())
 
 	var stdout bytes.Buffer
 	var stderr bytes.Buffer
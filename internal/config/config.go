@@ -21,6 +21,9 @@ type Config struct {
 	APIBaseURL string `mapstructure:"apiBaseURL"`
 	WorkDir    string `mapstructure:"workDir"`
 	Headless   bool   `mapstructure:"headless"`
+	// TrustedProxies are the IP addresses or CIDR ranges of the reverse
+	// proxies whose X-Forwarded-For and X-Real-IP headers are trusted.
+	TrustedProxies []string `mapstructure:"trustedProxies"`
 
 	// Authentication
 	Auth Auth `mapstructure:"auth"`
@@ -80,12 +83,183 @@ type Config struct {
 
 	// TLS configuration
 	TLS *TLSConfig `mapstructure:"tls"`
+
+	// GitSync configuration
+	GitSync *GitSyncConfig `mapstructure:"gitSync"`
+
+	// Scheduler configuration
+	Scheduler *SchedulerConfig `mapstructure:"scheduler"`
+
+	// Secrets configuration
+	Secrets SecretsConfig `mapstructure:"secrets"`
+
+	// Logs configuration
+	Logs LogsConfig `mapstructure:"logs"`
+
+	// Resources configuration
+	Resources ResourcesConfig `mapstructure:"resources"`
+}
+
+// ResourcesConfig represents the settings to enforce the resource limits of
+// the steps.
+type ResourcesConfig struct {
+	// Cgroup is the cgroup v2 delegated to dagu, e.g.
+	// /sys/fs/cgroup/system.slice/dagu.service. The cgroups of the runs
+	// limiting the memory, the CPU or the number of processes of their steps
+	// are created in it. It must be writable and have no processes.
+	Cgroup string `mapstructure:"cgroup"`
+}
+
+// LogsConfig represents the limits of the log files written by the DAG runs
+// under Paths.LogDir.
+type LogsConfig struct {
+	// MaxSize is the maximum size of the log of a step, e.g. 100Mi. It is
+	// unlimited if empty. The logMaxSize of the DAG takes precedence.
+	MaxSize string `mapstructure:"maxSize"`
+	// Truncate is the part of the log dropped when it exceeds MaxSize:
+	// "tail" (default) or "head".
+	Truncate string `mapstructure:"truncate"`
+	// Compress compresses the logs with gzip when the run finishes.
+	Compress bool `mapstructure:"compress"`
+	// Quota is the maximum total size of the files under Paths.LogDir, e.g.
+	// 10Gi. The oldest files are deleted when a run finishes beyond it. It
+	// is unlimited if empty.
+	Quota string `mapstructure:"quota"`
+
+	// Sinks are the log stores the step logs and the scheduler logs of the
+	// runs are shipped to in addition to the log files.
+	Sinks []LogSinkConfig `mapstructure:"sinks"`
+
+	// MaxSizeBytes and QuotaBytes are MaxSize and Quota in bytes.
+	MaxSizeBytes int64 `mapstructure:"-"`
+	QuotaBytes   int64 `mapstructure:"-"`
+}
+
+// The types of the log sinks.
+const (
+	LogSinkSyslog = "syslog"
+	LogSinkLoki   = "loki"
+	LogSinkFile   = "file"
+)
+
+// LogSinkConfig represents a log store the logs of the runs are shipped to.
+// The logs are labeled with the DAG, the request ID, the step and the status.
+type LogSinkConfig struct {
+	// Type is the type of the sink: "syslog", "loki" or "file".
+	Type string `mapstructure:"type"`
+	// Labels are added to the labels of all the logs.
+	Labels map[string]string `mapstructure:"labels"`
+
+	// Network is the network of the syslog server: "udp" (default) or "tcp".
+	Network string `mapstructure:"network"`
+	// Address is the address of the syslog server, e.g. localhost:514.
+	Address string `mapstructure:"address"`
+	// Facility is the syslog facility, e.g. local0 (default "user").
+	Facility string `mapstructure:"facility"`
+	// AppName is the syslog APP-NAME (default "dagu").
+	AppName string `mapstructure:"appName"`
+
+	// URL is the push endpoint of Loki, e.g. http://localhost:3100/loki/api/v1/push.
+	URL string `mapstructure:"url"`
+	// Headers are added to the requests to Loki, e.g. X-Scope-OrgID.
+	Headers map[string]string `mapstructure:"headers"`
+
+	// Path is the JSON lines file of the file sink.
+	Path string `mapstructure:"path"`
+	// MaxSize is the size beyond which the file is rotated, e.g. 100Mi
+	// (default 100Mi).
+	MaxSize string `mapstructure:"maxSize"`
+	// MaxBackups is the number of the rotated files kept (default 5).
+	MaxBackups int `mapstructure:"maxBackups"`
+
+	// BufferSize is the maximum number of the logs waiting to be sent
+	// (default 10000). The logs are dropped beyond it so that a slow sink
+	// never blocks the steps.
+	BufferSize int `mapstructure:"bufferSize"`
+	// BatchSize is the maximum number of the logs sent at once (default 100).
+	BatchSize int `mapstructure:"batchSize"`
+	// FlushInterval is the interval to send the logs waiting (default 1s).
+	FlushInterval time.Duration `mapstructure:"flushInterval"`
+	// MaxRetries is the number of the retries of a failed batch (default 3).
+	MaxRetries int `mapstructure:"maxRetries"`
+	// RetryInterval is the interval before the first retry, doubling on
+	// each retry (default 1s).
+	RetryInterval time.Duration `mapstructure:"retryInterval"`
+
+	// MaxSizeBytes is MaxSize in bytes.
+	MaxSizeBytes int64 `mapstructure:"-"`
+}
+
+// SecretsConfig represents the configuration of the providers resolving the
+// references to secrets, e.g. ${secret:vault/secret/data/db#password}.
+type SecretsConfig struct {
+	// FileDir is the base directory of the paths of the file provider.
+	// The paths are absolute if it is empty.
+	FileDir string `mapstructure:"fileDir"`
+	// LocalFile is the encrypted file of the local provider (default
+	// secrets.enc in the data directory).
+	LocalFile string `mapstructure:"localFile"`
+	// KeyFile is the file holding the key of the local provider. The
+	// DAGU_SECRETS_KEY environment variable takes precedence.
+	KeyFile string `mapstructure:"keyFile"`
+	// Vault is the configuration of the HashiCorp Vault compatible provider.
+	Vault VaultConfig `mapstructure:"vault"`
+}
+
+// VaultConfig represents the configuration of the HashiCorp Vault
+// compatible secret provider.
+type VaultConfig struct {
+	// Address is the URL of the server (default VAULT_ADDR).
+	Address string `mapstructure:"address"`
+	// Token is the token to authenticate with (default VAULT_TOKEN).
+	Token string `mapstructure:"token"`
+	// Namespace is the namespace of Vault Enterprise (default VAULT_NAMESPACE).
+	Namespace     string `mapstructure:"namespace"`
+	SkipTLSVerify bool   `mapstructure:"skipTLSVerify"`
+}
+
+// SchedulerConfig represents the configuration of the scheduler.
+type SchedulerConfig struct {
+	// HA enables active/passive high availability. The instances sharing the
+	// lease directory elect a leader and only the leader runs the jobs.
+	HA bool `mapstructure:"ha"`
+	// LeaseDir is the shared directory holding the lease file (default the data directory).
+	LeaseDir string `mapstructure:"leaseDir"`
+	// LeasePeriod is the time the lease lasts without renewal (default 30s,
+	// at least 1s). A standby takes over within 1.25 times of it after the
+	// leader stops renewing the lease.
+	LeasePeriod time.Duration `mapstructure:"leasePeriod"`
+	// MaxConcurrentStarts is the maximum number of DAGs started on schedule
+	// in the same second. The others wait for the following seconds. It is
+	// unlimited if zero.
+	MaxConcurrentStarts int `mapstructure:"maxConcurrentStarts"`
+	// DecisionRetentionDays is the number of days the decisions made on the
+	// firings are kept (default 30).
+	DecisionRetentionDays int `mapstructure:"decisionRetentionDays"`
+}
+
+// GitSyncConfig represents the configuration to use the DAGs directory as a
+// git working copy kept in sync with a remote repository.
+type GitSyncConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Remote is the name of the remote to pull from and push to (default "origin").
+	Remote string `mapstructure:"remote"`
+	// Branch is the remote branch to sync with (default the current branch).
+	Branch string `mapstructure:"branch"`
+	// PullInterval is the interval to pull changes from the remote (default 1m).
+	PullInterval time.Duration `mapstructure:"pullInterval"`
+	// Push enables pushing the commits made on changes to the remote.
+	Push bool `mapstructure:"push"`
+	// AuthorName and AuthorEmail identify the committer (default "dagu").
+	AuthorName  string `mapstructure:"authorName"`
+	AuthorEmail string `mapstructure:"authorEmail"`
 }
 
 // Auth represents the authentication configuration
 type Auth struct {
 	Basic AuthBasic `mapstructure:"basic"`
 	Token AuthToken `mapstructure:"token"`
+	OIDC  AuthOIDC  `mapstructure:"oidc"`
 }
 
 // AuthBasic represents the basic authentication configuration
@@ -101,6 +275,50 @@ type AuthToken struct {
 	Value   string `mapstructure:"value"`
 }
 
+// AuthOIDC represents the OpenID Connect authentication configuration
+type AuthOIDC struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	Issuer       string `mapstructure:"issuer"`
+	ClientID     string `mapstructure:"clientId"`
+	ClientSecret string `mapstructure:"clientSecret"`
+	// ClientURL is the external URL of the server used to build the redirect URL.
+	ClientURL string `mapstructure:"clientUrl"`
+	// Scopes defaults to openid, profile and email.
+	Scopes []string `mapstructure:"scopes"`
+	// Audiences are accepted in addition to the client ID when validating bearer tokens.
+	Audiences []string `mapstructure:"audiences"`
+	// RolesClaim is the claim holding the groups or roles of the user
+	// (default "groups"). Nested claims can be referenced with dots (e.g. "realm_access.roles").
+	RolesClaim string `mapstructure:"rolesClaim"`
+	// Roles maps a role (admin, operator, viewer) to the claim values granting it.
+	// When empty, every authenticated user is granted the admin role.
+	Roles map[string][]string `mapstructure:"roles"`
+	// DefaultRole is granted to users not matching any of the roles.
+	DefaultRole string `mapstructure:"defaultRole"`
+	// SessionSecret is used to sign session cookies. It is required so that
+	// the sessions are valid across restarts and instances.
+	SessionSecret string `mapstructure:"sessionSecret"`
+	// SessionTTL is the lifetime of a login session (default 24h).
+	SessionTTL time.Duration `mapstructure:"sessionTTL"`
+}
+
+// Roles that can be granted to users authenticated by OIDC.
+// Each role includes the permissions of the roles below it.
+const (
+	RoleAdmin    = "admin"
+	RoleOperator = "operator"
+	RoleViewer   = "viewer"
+)
+
+func isValidRole(role string) bool {
+	switch role {
+	case RoleAdmin, RoleOperator, RoleViewer:
+		return true
+	default:
+		return false
+	}
+}
+
 // Paths represents the file system paths configuration
 type PathsConfig struct {
 	DAGsDir         string `mapstructure:"dagsDir"`
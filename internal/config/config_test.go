@@ -398,6 +398,59 @@ func TestConfigLoader_ValidateConfig(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid OIDC auth",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.Auth.OIDC = AuthOIDC{
+					Enabled:       true,
+					Issuer:        "https://accounts.example.com",
+					ClientID:      "dagu",
+					ClientURL:     "http://localhost:8080",
+					SessionSecret: "session-secret",
+					Roles:         map[string][]string{RoleAdmin: {"admins"}},
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "OIDC auth without session secret",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.UI.MaxDashboardPageLimit = 100
+				cfg.Auth.OIDC = AuthOIDC{
+					Enabled:   true,
+					Issuer:    "https://accounts.example.com",
+					ClientID:  "dagu",
+					ClientURL: "http://localhost:8080",
+				}
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid OIDC auth",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.Auth.OIDC.Enabled = true
+				cfg.Auth.OIDC.ClientID = "dagu"
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid OIDC role",
+			setup: func(cfg *Config) {
+				cfg.Port = 8080
+				cfg.Auth.OIDC = AuthOIDC{
+					Enabled:   true,
+					Issuer:    "https://accounts.example.com",
+					ClientID:  "dagu",
+					ClientURL: "http://localhost:8080",
+					Roles:     map[string][]string{"superuser": {"admins"}},
+				}
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid TLS config",
 			setup: func(cfg *Config) {
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"log"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/adrg/xdg"
 	"github.com/dagu-org/dagu/internal/build"
+	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/spf13/viper"
 )
 
@@ -73,6 +75,13 @@ func (l *ConfigLoader) Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to set timezone: %w", err)
 	}
 
+	l.setSchedulerDefaults(&cfg)
+	l.setSecretsDefaults(&cfg)
+
+	if err := l.setLogSizes(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
 	// Validate the configuration
 	if err := l.validateConfig(&cfg); err != nil {
 		return nil, fmt.Errorf("invalid configuration: %w", err)
@@ -162,6 +171,7 @@ func (l *ConfigLoader) bindEnvironmentVariables() {
 	l.bindEnv("port", "PORT")
 	l.bindEnv("debug", "DEBUG")
 	l.bindEnv("headless", "HEADLESS")
+	l.bindEnv("trustedProxies", "TRUSTED_PROXIES")
 
 	// UI configurations
 	l.bindEnv("ui.maxDashboardPageLimit", "UI_MAX_DASHBOARD_PAGE_LIMIT")
@@ -181,6 +191,12 @@ func (l *ConfigLoader) bindEnvironmentVariables() {
 	l.bindEnv("auth.basic.password", "AUTH_BASIC_PASSWORD")
 	l.bindEnv("auth.token.enabled", "AUTH_TOKEN_ENABLED")
 	l.bindEnv("auth.token.value", "AUTH_TOKEN")
+	l.bindEnv("auth.oidc.enabled", "AUTH_OIDC_ENABLED")
+	l.bindEnv("auth.oidc.issuer", "AUTH_OIDC_ISSUER")
+	l.bindEnv("auth.oidc.clientId", "AUTH_OIDC_CLIENT_ID")
+	l.bindEnv("auth.oidc.clientSecret", "AUTH_OIDC_CLIENT_SECRET")
+	l.bindEnv("auth.oidc.clientUrl", "AUTH_OIDC_CLIENT_URL")
+	l.bindEnv("auth.oidc.sessionSecret", "AUTH_OIDC_SESSION_SECRET")
 
 	// Authentication configurations (legacy)
 	l.bindEnv("auth.basic.enabled", "IS_BASICAUTH")
@@ -189,6 +205,37 @@ func (l *ConfigLoader) bindEnvironmentVariables() {
 	l.bindEnv("auth.token.enabled", "IS_AUTHTOKEN")
 	l.bindEnv("auth.token.value", "AUTHTOKEN")
 
+	// Git sync configurations
+	l.bindEnv("gitSync.enabled", "GIT_SYNC_ENABLED")
+	l.bindEnv("gitSync.remote", "GIT_SYNC_REMOTE")
+	l.bindEnv("gitSync.branch", "GIT_SYNC_BRANCH")
+	l.bindEnv("gitSync.pullInterval", "GIT_SYNC_PULL_INTERVAL")
+	l.bindEnv("gitSync.push", "GIT_SYNC_PUSH")
+
+	// Scheduler configurations
+	l.bindEnv("scheduler.ha", "SCHEDULER_HA")
+	l.bindEnv("scheduler.leaseDir", "SCHEDULER_LEASE_DIR")
+	l.bindEnv("scheduler.leasePeriod", "SCHEDULER_LEASE_PERIOD")
+	l.bindEnv("scheduler.maxConcurrentStarts", "SCHEDULER_MAX_CONCURRENT_STARTS")
+	l.bindEnv("scheduler.decisionRetentionDays", "SCHEDULER_DECISION_RETENTION_DAYS")
+
+	// Logs configurations
+	l.bindEnv("logs.maxSize", "LOGS_MAX_SIZE")
+	l.bindEnv("logs.truncate", "LOGS_TRUNCATE")
+	l.bindEnv("logs.compress", "LOGS_COMPRESS")
+	l.bindEnv("logs.quota", "LOGS_QUOTA")
+
+	// Resources configurations
+	l.bindEnv("resources.cgroup", "RESOURCES_CGROUP")
+
+	// Secrets configurations
+	l.bindEnv("secrets.fileDir", "SECRETS_FILE_DIR")
+	l.bindEnv("secrets.localFile", "SECRETS_LOCAL_FILE")
+	l.bindEnv("secrets.keyFile", "SECRETS_KEY_FILE")
+	l.bindEnv("secrets.vault.address", "SECRETS_VAULT_ADDRESS")
+	l.bindEnv("secrets.vault.token", "SECRETS_VAULT_TOKEN")
+	l.bindEnv("secrets.vault.namespace", "SECRETS_VAULT_NAMESPACE")
+
 	// TLS configurations
 	l.bindEnv("tls.certFile", "CERT_FILE")
 	l.bindEnv("tls.keyFile", "KEY_FILE")
@@ -302,6 +349,54 @@ func (l *ConfigLoader) setExecutableDefault() error {
 	return nil
 }
 
+const (
+	// defaultLeasePeriod is the default lease period of the scheduler leader.
+	defaultLeasePeriod = 30 * time.Second
+	// minLeasePeriod is the shortest lease period allowed, as the lease is
+	// renewed four times per period.
+	minLeasePeriod = time.Second
+)
+
+func (l *ConfigLoader) setSchedulerDefaults(cfg *Config) {
+	if cfg.Scheduler == nil {
+		return
+	}
+	if cfg.Scheduler.LeaseDir == "" {
+		cfg.Scheduler.LeaseDir = cfg.Paths.DataDir
+	}
+	if cfg.Scheduler.LeasePeriod == 0 {
+		cfg.Scheduler.LeasePeriod = defaultLeasePeriod
+	}
+}
+
+func (l *ConfigLoader) setSecretsDefaults(cfg *Config) {
+	if cfg.Secrets.LocalFile == "" {
+		cfg.Secrets.LocalFile = filepath.Join(cfg.Paths.DataDir, "secrets.enc")
+	}
+}
+
+func (l *ConfigLoader) setLogSizes(cfg *Config) error {
+	var err error
+	if cfg.Logs.MaxSize != "" {
+		if cfg.Logs.MaxSizeBytes, err = stringutil.ParseSize(cfg.Logs.MaxSize); err != nil {
+			return fmt.Errorf("invalid logs max size %q: %w", cfg.Logs.MaxSize, err)
+		}
+	}
+	if cfg.Logs.Quota != "" {
+		if cfg.Logs.QuotaBytes, err = stringutil.ParseSize(cfg.Logs.Quota); err != nil {
+			return fmt.Errorf("invalid logs quota %q: %w", cfg.Logs.Quota, err)
+		}
+	}
+	for i, sink := range cfg.Logs.Sinks {
+		if sink.MaxSize != "" {
+			if cfg.Logs.Sinks[i].MaxSizeBytes, err = stringutil.ParseSize(sink.MaxSize); err != nil {
+				return fmt.Errorf("invalid max size %q of log sink %d: %w", sink.MaxSize, i, err)
+			}
+		}
+	}
+	return nil
+}
+
 func (l *ConfigLoader) validateConfig(cfg *Config) error {
 	if cfg.Port < 0 || cfg.Port > 65535 {
 		return fmt.Errorf("invalid port number: %d", cfg.Port)
@@ -315,6 +410,34 @@ func (l *ConfigLoader) validateConfig(cfg *Config) error {
 		return fmt.Errorf("auth token enabled but token is not set")
 	}
 
+	if cfg.Auth.OIDC.Enabled {
+		if cfg.Auth.OIDC.Issuer == "" || cfg.Auth.OIDC.ClientID == "" || cfg.Auth.OIDC.ClientURL == "" {
+			return fmt.Errorf("OIDC auth enabled but issuer, client ID or client URL is not set")
+		}
+		// The sessions must stay valid across restarts and the instances
+		// behind a load balancer.
+		if cfg.Auth.OIDC.SessionSecret == "" {
+			return fmt.Errorf("OIDC auth enabled but session secret is not set")
+		}
+		for role := range cfg.Auth.OIDC.Roles {
+			if !isValidRole(role) {
+				return fmt.Errorf("invalid OIDC role: %s", role)
+			}
+		}
+		if cfg.Auth.OIDC.DefaultRole != "" && !isValidRole(cfg.Auth.OIDC.DefaultRole) {
+			return fmt.Errorf("invalid OIDC default role: %s", cfg.Auth.OIDC.DefaultRole)
+		}
+	}
+
+	for _, proxy := range cfg.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err == nil {
+			continue
+		}
+		if _, err := netip.ParseAddr(proxy); err != nil {
+			return fmt.Errorf("invalid trusted proxy: %s", proxy)
+		}
+	}
+
 	if cfg.TLS != nil {
 		if cfg.TLS.CertFile == "" || cfg.TLS.KeyFile == "" {
 			return fmt.Errorf("TLS configuration incomplete: both cert and key files are required")
@@ -325,6 +448,38 @@ func (l *ConfigLoader) validateConfig(cfg *Config) error {
 		return fmt.Errorf("invalid port number: %d", cfg.Port)
 	}
 
+	if cfg.Scheduler != nil && cfg.Scheduler.LeasePeriod < minLeasePeriod {
+		return fmt.Errorf("invalid scheduler lease period: %s (must be at least %s)", cfg.Scheduler.LeasePeriod, minLeasePeriod)
+	}
+	if cfg.Scheduler != nil && cfg.Scheduler.MaxConcurrentStarts < 0 {
+		return fmt.Errorf("invalid scheduler max concurrent starts: %d", cfg.Scheduler.MaxConcurrentStarts)
+	}
+	if cfg.Scheduler != nil && cfg.Scheduler.DecisionRetentionDays < 0 {
+		return fmt.Errorf("invalid scheduler decision retention days: %d", cfg.Scheduler.DecisionRetentionDays)
+	}
+
+	if cfg.Logs.Truncate != "" && cfg.Logs.Truncate != "tail" && cfg.Logs.Truncate != "head" {
+		return fmt.Errorf("invalid logs truncate: %s", cfg.Logs.Truncate)
+	}
+	for i, sink := range cfg.Logs.Sinks {
+		switch sink.Type {
+		case LogSinkSyslog:
+			if sink.Address == "" {
+				return fmt.Errorf("log sink %d: address is required for syslog", i)
+			}
+		case LogSinkLoki:
+			if sink.URL == "" {
+				return fmt.Errorf("log sink %d: url is required for loki", i)
+			}
+		case LogSinkFile:
+			if sink.Path == "" {
+				return fmt.Errorf("log sink %d: path is required for file", i)
+			}
+		default:
+			return fmt.Errorf("log sink %d: invalid type: %q", i, sink.Type)
+		}
+	}
+
 	if cfg.UI.MaxDashboardPageLimit < 1 {
 		return fmt.Errorf("invalid max dashboard page limit: %d", cfg.UI.MaxDashboardPageLimit)
 	}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -235,6 +236,170 @@ func TestConfigLoader_BindEnvironmentVariables(t *testing.T) {
 	}
 }
 
+func TestConfigLoader_SchedulerHA(t *testing.T) {
+	_ = setupTestEnv(t)
+
+	os.Setenv("DAGU_SCHEDULER_HA", "true")
+	t.Cleanup(func() {
+		os.Unsetenv("DAGU_SCHEDULER_HA")
+	})
+
+	loader := NewConfigLoader()
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if cfg.Scheduler == nil || !cfg.Scheduler.HA {
+		t.Fatal("Scheduler.HA = false, want true")
+	}
+	if cfg.Scheduler.LeaseDir != cfg.Paths.DataDir {
+		t.Errorf("Scheduler.LeaseDir = %v, want %v", cfg.Scheduler.LeaseDir, cfg.Paths.DataDir)
+	}
+	if cfg.Scheduler.LeasePeriod != defaultLeasePeriod {
+		t.Errorf("Scheduler.LeasePeriod = %v, want %v", cfg.Scheduler.LeasePeriod, defaultLeasePeriod)
+	}
+}
+
+func TestConfigLoader_SchedulerLeasePeriod(t *testing.T) {
+	_ = setupTestEnv(t)
+
+	os.Setenv("DAGU_SCHEDULER_LEASE_PERIOD", "1s")
+	t.Cleanup(func() {
+		os.Unsetenv("DAGU_SCHEDULER_LEASE_PERIOD")
+	})
+
+	cfg, err := NewConfigLoader().Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Scheduler.LeasePeriod != time.Second {
+		t.Errorf("Scheduler.LeasePeriod = %v, want 1s", cfg.Scheduler.LeasePeriod)
+	}
+
+	for _, period := range []string{"999ms", "3ns", "-1s"} {
+		os.Setenv("DAGU_SCHEDULER_LEASE_PERIOD", period)
+		if _, err := NewConfigLoader().Load(); err == nil {
+			t.Errorf("Load() error = nil, want an error for %s", period)
+		}
+	}
+}
+
+func TestConfigLoader_SchedulerMaxConcurrentStarts(t *testing.T) {
+	_ = setupTestEnv(t)
+
+	os.Setenv("DAGU_SCHEDULER_MAX_CONCURRENT_STARTS", "5")
+	t.Cleanup(func() {
+		os.Unsetenv("DAGU_SCHEDULER_MAX_CONCURRENT_STARTS")
+	})
+
+	loader := NewConfigLoader()
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Scheduler.MaxConcurrentStarts != 5 {
+		t.Errorf("Scheduler.MaxConcurrentStarts = %v, want 5", cfg.Scheduler.MaxConcurrentStarts)
+	}
+
+	os.Setenv("DAGU_SCHEDULER_MAX_CONCURRENT_STARTS", "-1")
+	if _, err := NewConfigLoader().Load(); err == nil {
+		t.Error("Load() error = nil, want an error for a negative value")
+	}
+}
+
+func TestConfigLoader_Logs(t *testing.T) {
+	_ = setupTestEnv(t)
+
+	os.Setenv("DAGU_LOGS_MAX_SIZE", "10Mi")
+	os.Setenv("DAGU_LOGS_QUOTA", "1G")
+	os.Setenv("DAGU_LOGS_COMPRESS", "true")
+	t.Cleanup(func() {
+		os.Unsetenv("DAGU_LOGS_MAX_SIZE")
+		os.Unsetenv("DAGU_LOGS_QUOTA")
+		os.Unsetenv("DAGU_LOGS_COMPRESS")
+	})
+
+	cfg, err := NewConfigLoader().Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Logs.MaxSizeBytes != 10<<20 {
+		t.Errorf("Logs.MaxSizeBytes = %v, want %v", cfg.Logs.MaxSizeBytes, 10<<20)
+	}
+	if cfg.Logs.QuotaBytes != 1000*1000*1000 {
+		t.Errorf("Logs.QuotaBytes = %v, want %v", cfg.Logs.QuotaBytes, 1000*1000*1000)
+	}
+	if !cfg.Logs.Compress {
+		t.Error("Logs.Compress = false, want true")
+	}
+
+	os.Setenv("DAGU_LOGS_QUOTA", "lots")
+	if _, err := NewConfigLoader().Load(); err == nil {
+		t.Error("Load() error = nil, want an error for an invalid quota")
+	}
+}
+
+func TestConfigLoader_LogSinks(t *testing.T) {
+	tmpDir := setupTestEnv(t)
+
+	configFile := filepath.Join(tmpDir, ".config", "dagu", "config.yaml")
+	testConfig := []byte(`
+logs:
+  sinks:
+    - type: syslog
+      network: tcp
+      address: localhost:514
+    - type: loki
+      url: http://localhost:3100/loki/api/v1/push
+      headers:
+        X-Scope-OrgID: tenant
+      labels:
+        env: prod
+      flushInterval: 5s
+    - type: file
+      path: /var/log/dagu/logs.jsonl
+      maxSize: 10Mi
+      maxBackups: 3
+`)
+	if err := os.WriteFile(configFile, testConfig, 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := NewConfigLoader().Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	sinks := cfg.Logs.Sinks
+	if len(sinks) != 3 {
+		t.Fatalf("len(Logs.Sinks) = %v, want 3", len(sinks))
+	}
+	if sinks[0].Type != LogSinkSyslog || sinks[0].Network != "tcp" || sinks[0].Address != "localhost:514" {
+		t.Errorf("Logs.Sinks[0] = %+v", sinks[0])
+	}
+	// The keys are in lower case as viper reads them, which the header
+	// names are insensitive to.
+	if sinks[1].Headers["x-scope-orgid"] != "tenant" {
+		t.Errorf("Logs.Sinks[1].Headers = %v", sinks[1].Headers)
+	}
+	if sinks[1].Labels["env"] != "prod" {
+		t.Errorf("Logs.Sinks[1].Labels = %v", sinks[1].Labels)
+	}
+	if sinks[1].FlushInterval != 5*time.Second {
+		t.Errorf("Logs.Sinks[1].FlushInterval = %v, want 5s", sinks[1].FlushInterval)
+	}
+	if sinks[2].MaxSizeBytes != 10<<20 || sinks[2].MaxBackups != 3 {
+		t.Errorf("Logs.Sinks[2] = %+v", sinks[2])
+	}
+
+	if err := os.WriteFile(configFile, []byte("logs:\n  sinks:\n    - type: kafka\n"), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	if _, err := NewConfigLoader().Load(); err == nil {
+		t.Error("Load() error = nil, want an error for an invalid sink type")
+	}
+}
+
 func TestConfigLoader_DefaultValues(t *testing.T) {
 	_ = setupTestEnv(t)
 
@@ -2,6 +2,7 @@ package digraph
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/dagu-org/dagu/internal/cmdutil"
 	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/go-viper/mapstructure/v2"
 	"github.com/joho/godotenv"
 	"golang.org/x/sys/unix"
@@ -49,17 +51,27 @@ type BuildOpts struct {
 	ParametersList []string
 	// NoEval specifies whether to evaluate dynamic fields.
 	NoEval bool
+	// ImportDir specifies the directory to resolve relative imports in
+	// YAML data. It is ignored when the DAG is loaded from a file.
+	ImportDir string
+	// DAGsDir specifies the DAGs directory to determine the namespace.
+	DAGsDir string
 }
 
 var builderRegistry = []builderEntry{
 	{metadata: true, name: "env", fn: buildEnvs},
+	{metadata: true, name: "timezone", fn: buildTimezone},
 	{metadata: true, name: "schedule", fn: buildSchedule},
+	{metadata: true, name: "scheduleJitter", fn: buildScheduleJitter},
+	{metadata: true, name: "calendar", fn: buildCalendar},
+	{metadata: true, name: "blackoutWindows", fn: buildBlackoutWindows},
 	{metadata: true, name: "skipIfSuccessful", fn: skipIfSuccessful},
 	{metadata: true, name: "params", fn: buildParams},
 	{name: "dotenv", fn: buildDotenv},
 	{name: "mailOn", fn: buildMailOn},
 	{name: "steps", fn: buildSteps},
 	{name: "logDir", fn: buildLogDir},
+	{name: "logMaxSize", fn: buildLogMaxSize},
 	{name: "handlers", fn: buildHandlers},
 	{name: "smtpConfig", fn: buildSMTPConfig},
 	{name: "errMailConfig", fn: buildErrMailConfig},
@@ -80,6 +92,10 @@ var stepBuilderRegistry = []stepBuilderEntry{
 	{name: "command", fn: buildCommand},
 	{name: "depends", fn: buildDepends},
 	{name: "subworkflow", fn: buildSubWorkflow},
+	{name: "waitFor", fn: buildWaitFor},
+	{name: "resources", fn: buildResources},
+	{name: "logFormat", fn: buildLogFormat},
+	{name: "runAs", fn: buildRunAs},
 	{name: "continueOn", fn: buildContinueOn},
 	{name: "retryPolicy", fn: buildRetryPolicy},
 	{name: "repeatPolicy", fn: buildRepeatPolicy},
@@ -223,18 +239,60 @@ func buildSchedule(_ BuildContext, spec *definition, dag *DAG) error {
 
 	// Parse each schedule as a cron expression.
 	var err error
-	dag.Schedule, err = buildScheduler(starts)
+	dag.Schedule, err = buildScheduler(starts, dag.Timezone)
 	if err != nil {
 		return err
 	}
-	dag.StopSchedule, err = buildScheduler(stops)
+	dag.StopSchedule, err = buildScheduler(stops, dag.Timezone)
 	if err != nil {
 		return err
 	}
-	dag.RestartSchedule, err = buildScheduler(restarts)
+	dag.RestartSchedule, err = buildScheduler(restarts, dag.Timezone)
 	return err
 }
 
+// buildScheduleJitter parses the schedule jitter given as a duration string
+// or as seconds.
+func buildScheduleJitter(_ BuildContext, spec *definition, dag *DAG) error {
+	if spec.ScheduleJitter == nil {
+		return nil
+	}
+	jitter, err := parseDuration(spec.ScheduleJitter)
+	if err != nil {
+		return wrapError("scheduleJitter", spec.ScheduleJitter, fmt.Errorf("%w: %s", ErrInvalidScheduleJitter, err))
+	}
+	if jitter < 0 {
+		return wrapError("scheduleJitter", spec.ScheduleJitter, fmt.Errorf("%w: must not be negative", ErrInvalidScheduleJitter))
+	}
+	dag.ScheduleJitter = jitter
+	return nil
+}
+
+// parseDuration parses a duration given as a string, e.g. "5m", or as an
+// integer in seconds.
+func parseDuration(value any) (time.Duration, error) {
+	switch v := value.(type) {
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case string:
+		return time.ParseDuration(v)
+	default:
+		return 0, errors.New("must be a duration or seconds")
+	}
+}
+
+// buildTimezone validates the timezone to evaluate the schedule in.
+func buildTimezone(_ BuildContext, spec *definition, dag *DAG) error {
+	if spec.Timezone == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(spec.Timezone); err != nil {
+		return wrapError("timezone", spec.Timezone, fmt.Errorf("%w: %s", ErrInvalidTimezone, err))
+	}
+	dag.Timezone = spec.Timezone
+	return nil
+}
+
 func buildDotenv(ctx BuildContext, spec *definition, dag *DAG) error {
 	switch v := spec.Dotenv.(type) {
 	case nil:
@@ -314,6 +372,25 @@ func buildLogDir(_ BuildContext, spec *definition, dag *DAG) (err error) {
 	return err
 }
 
+// buildLogMaxSize parses the maximum size of the logs of the steps and the
+// part of the log to drop when it is exceeded.
+func buildLogMaxSize(_ BuildContext, spec *definition, dag *DAG) error {
+	if spec.LogMaxSize != nil {
+		size, err := parseSize(spec.LogMaxSize)
+		if err != nil {
+			return wrapError("logMaxSize", spec.LogMaxSize, fmt.Errorf("%w: %s", ErrInvalidLogMaxSize, err))
+		}
+		dag.LogMaxSize = size
+	}
+	switch spec.LogTruncate {
+	case "", LogTruncateTail, LogTruncateHead:
+		dag.LogTruncate = spec.LogTruncate
+	default:
+		return wrapError("logTruncate", spec.LogTruncate, ErrInvalidLogTruncate)
+	}
+	return nil
+}
+
 // buildHandlers builds the handlers for the DAG.
 // The handlers are executed when the DAG is stopped, succeeded, failed, or
 // cancelled.
@@ -590,6 +667,12 @@ func buildContinueOn(_ BuildContext, def stepDef, step *Step) error {
 	}
 	step.ContinueOn.Output = output
 
+	errorLog, err := parseStringOrArray(def.ContinueOn.ErrorLog)
+	if err != nil {
+		return wrapError("continueOn.errorLog", def.ContinueOn.ErrorLog, ErrErrorLogMustBeStringOrArray)
+	}
+	step.ContinueOn.ErrorLog = errorLog
+
 	return nil
 }
 
@@ -613,6 +696,12 @@ func buildRetryPolicy(_ BuildContext, def stepDef, step *Step) error {
 		default:
 			return wrapError("retryPolicy.IntervalSec", v, fmt.Errorf("invalid type: %T", v))
 		}
+
+		errorLog, err := parseStringOrArray(def.RetryPolicy.ErrorLog)
+		if err != nil {
+			return wrapError("retryPolicy.errorLog", def.RetryPolicy.ErrorLog, ErrErrorLogMustBeStringOrArray)
+		}
+		step.RetryPolicy.ErrorLog = errorLog
 	}
 	return nil
 }
@@ -675,6 +764,163 @@ func buildSubWorkflow(_ BuildContext, def stepDef, step *Step) error {
 	return nil
 }
 
+// defaultPokeInterval is the default interval to check the status of the
+// DAG to wait for.
+const defaultPokeInterval = time.Minute
+
+// buildWaitFor parses the definition of the DAG run to wait for and sets
+// the step fields.
+func buildWaitFor(_ BuildContext, def stepDef, step *Step) error {
+	if def.WaitFor == nil {
+		return nil
+	}
+	if def.WaitFor.DAG == "" {
+		return wrapError("waitFor.dag", def.WaitFor.DAG, fmt.Errorf("%w: dag is required", ErrInvalidWaitFor))
+	}
+	if def.Run != "" {
+		return wrapError("waitFor", def.WaitFor.DAG, fmt.Errorf("%w: cannot be used with run", ErrInvalidWaitFor))
+	}
+
+	waitFor := &WaitFor{
+		DAG:          def.WaitFor.DAG,
+		PokeInterval: defaultPokeInterval,
+		SoftFail:     def.WaitFor.SoftFail,
+	}
+	for _, field := range []struct {
+		name  string
+		value any
+		dest  *time.Duration
+	}{
+		{"offset", def.WaitFor.Offset, &waitFor.Offset},
+		{"timeout", def.WaitFor.Timeout, &waitFor.Timeout},
+		{"pokeInterval", def.WaitFor.PokeInterval, &waitFor.PokeInterval},
+	} {
+		if field.value == nil {
+			continue
+		}
+		d, err := parseDuration(field.value)
+		if err != nil {
+			return wrapError("waitFor."+field.name, field.value, fmt.Errorf("%w: %s", ErrInvalidWaitFor, err))
+		}
+		*field.dest = d
+	}
+	if waitFor.Timeout < 0 || waitFor.PokeInterval <= 0 {
+		return wrapError("waitFor", def.WaitFor.DAG, fmt.Errorf("%w: timeout must not be negative and pokeInterval must be positive", ErrInvalidWaitFor))
+	}
+
+	step.WaitFor = waitFor
+	step.ExecutorConfig.Type = ExecutorTypeWaitFor
+	step.Command = commandWaitFor
+	step.Args = []string{waitFor.DAG}
+	step.CmdWithArgs = fmt.Sprintf("%s %s", commandWaitFor, waitFor.DAG)
+	return nil
+}
+
+// commandWaitFor is not an actual command but is shown for the step.
+const commandWaitFor = "waitFor"
+
+// buildLogFormat sets the format of the lines written to the stdout.
+func buildLogFormat(_ BuildContext, def stepDef, step *Step) error {
+	switch def.LogFormat {
+	case "", LogFormatText, LogFormatJSON:
+		step.LogFormat = def.LogFormat
+		return nil
+	default:
+		return wrapError("logFormat", def.LogFormat, ErrInvalidLogFormat)
+	}
+}
+
+// buildResources parses the limits of the resources for the step.
+func buildResources(_ BuildContext, def stepDef, step *Step) error {
+	if def.Resources == nil {
+		return nil
+	}
+
+	resources := &Resources{
+		Pids:   def.Resources.Pids,
+		Nofile: def.Resources.Nofile,
+	}
+	if def.Resources.Memory != nil {
+		memory, err := parseSize(def.Resources.Memory)
+		if err != nil {
+			return wrapError("resources.memory", def.Resources.Memory, fmt.Errorf("%w: %s", ErrInvalidResources, err))
+		}
+		resources.Memory = memory
+	}
+	if def.Resources.CPU != nil {
+		cpu, err := parseCPU(def.Resources.CPU)
+		if err != nil {
+			return wrapError("resources.cpu", def.Resources.CPU, fmt.Errorf("%w: %s", ErrInvalidResources, err))
+		}
+		resources.CPU = cpu
+	}
+	if resources.Pids < 0 || resources.Nofile < 0 {
+		return wrapError("resources", def.Resources, fmt.Errorf("%w: pids and nofile must not be negative", ErrInvalidResources))
+	}
+
+	step.Resources = resources
+	return nil
+}
+
+// parseSize parses a size in bytes, or with a unit, e.g. 512M or 1Gi.
+func parseSize(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		if v <= 0 {
+			return 0, errors.New("must be positive")
+		}
+		return int64(v), nil
+	case string:
+		return stringutil.ParseSize(v)
+	default:
+		return 0, errors.New("must be a size in bytes or with a unit")
+	}
+}
+
+// parseCPU parses a number of CPUs, e.g. 2, 0.5 or 500m.
+func parseCPU(value any) (float64, error) {
+	var cpu float64
+	switch v := value.(type) {
+	case int:
+		cpu = float64(v)
+	case float64:
+		cpu = v
+	case string:
+		var err error
+		if millis, ok := strings.CutSuffix(v, "m"); ok {
+			cpu, err = strconv.ParseFloat(millis, 64)
+			cpu /= 1000
+		} else {
+			cpu, err = strconv.ParseFloat(v, 64)
+		}
+		if err != nil {
+			return 0, errors.New("must be a number of CPUs, e.g. 0.5 or 500m")
+		}
+	default:
+		return 0, errors.New("must be a number of CPUs")
+	}
+	if cpu <= 0 {
+		return 0, errors.New("must be positive")
+	}
+	return cpu, nil
+}
+
+// buildRunAs parses the user and the group in the form of "user" or
+// "user:group".
+func buildRunAs(_ BuildContext, def stepDef, step *Step) error {
+	if def.RunAs == "" {
+		return nil
+	}
+
+	user, group, _ := strings.Cut(def.RunAs, ":")
+	if user == "" || strings.Contains(group, ":") {
+		return wrapError("runAs", def.RunAs, fmt.Errorf("%w: must be user or user:group", ErrInvalidRunAs))
+	}
+
+	step.RunAs = &RunAs{User: user, Group: group}
+	return nil
+}
+
 const (
 	executorKeyType   = "type"
 	executorKeyConfig = "config"
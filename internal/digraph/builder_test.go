@@ -123,6 +123,13 @@ func TestBuild(t *testing.T) {
 		th := testLoad(t, "hist_retention_days.yaml")
 		assert.Equal(t, 365, th.HistRetentionDays)
 	})
+	t.Run("LogMaxSize", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "log_max_size.yaml")
+		assert.Equal(t, int64(10<<20), th.LogMaxSize)
+		assert.Equal(t, digraph.LogTruncateHead, th.LogTruncate)
+	})
 	t.Run("CleanUpTime", func(t *testing.T) {
 		t.Parallel()
 
@@ -173,6 +180,51 @@ func TestBuild(t *testing.T) {
 				dag:         "invalid_schedule.yaml",
 				expectedErr: digraph.ErrInvalidSchedule,
 			},
+			{
+				name:        "InvalidTimezone",
+				dag:         "invalid_timezone.yaml",
+				expectedErr: digraph.ErrInvalidTimezone,
+			},
+			{
+				name:        "InvalidScheduleJitter",
+				dag:         "invalid_schedule_jitter.yaml",
+				expectedErr: digraph.ErrInvalidScheduleJitter,
+			},
+			{
+				name:        "InvalidWaitFor",
+				dag:         "invalid_wait_for.yaml",
+				expectedErr: digraph.ErrInvalidWaitFor,
+			},
+			{
+				name:        "InvalidResources",
+				dag:         "invalid_resources.yaml",
+				expectedErr: digraph.ErrInvalidResources,
+			},
+			{
+				name:        "InvalidLogFormat",
+				dag:         "invalid_log_format.yaml",
+				expectedErr: digraph.ErrInvalidLogFormat,
+			},
+			{
+				name:        "InvalidLogMaxSize",
+				dag:         "invalid_log_max_size.yaml",
+				expectedErr: digraph.ErrInvalidLogMaxSize,
+			},
+			{
+				name:        "InvalidExcludeDate",
+				dag:         "invalid_exclude_date.yaml",
+				expectedErr: digraph.ErrInvalidExcludeDate,
+			},
+			{
+				name:        "InvalidBlackoutWindow",
+				dag:         "invalid_blackout_window.yaml",
+				expectedErr: digraph.ErrInvalidBlackoutWindow,
+			},
+			{
+				name:        "InvalidRunOn",
+				dag:         "invalid_run_on.yaml",
+				expectedErr: digraph.ErrInvalidRunOn,
+			},
 			{
 				name:        "NoCommand",
 				dag:         "invalid_no_command.yaml",
@@ -303,6 +355,116 @@ func TestBuildSchedule(t *testing.T) {
 	}
 }
 
+func TestBuildTimezone(t *testing.T) {
+	t.Parallel()
+
+	th := testLoad(t, "schedule_with_timezone.yaml")
+	assert.Equal(t, "America/New_York", th.Timezone)
+	require.Len(t, th.Schedule, 2)
+
+	// The expression is kept as written.
+	assert.Equal(t, "0 9 * * *", th.Schedule[0].Expression)
+	assert.Equal(t, "America/New_York", th.Schedule[0].Location().String())
+
+	// CRON_TZ in the expression takes precedence over the timezone of the DAG.
+	assert.Equal(t, "Asia/Tokyo", th.Schedule[1].Location().String())
+
+	require.Len(t, th.StopSchedule, 1)
+	assert.Equal(t, "America/New_York", th.StopSchedule[0].Location().String())
+
+	// 09:00 in New York is 14:00 UTC in winter.
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Date(2024, 1, 1, 14, 0, 0, 0, time.UTC), th.Schedule[0].Parsed.Next(now))
+	// 09:00 in Tokyo is 00:00 UTC.
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), th.Schedule[1].Parsed.Next(now))
+}
+
+func TestBuildScheduleJitter(t *testing.T) {
+	t.Parallel()
+
+	th := testLoad(t, "schedule_with_jitter.yaml")
+	assert.Equal(t, 5*time.Minute, th.ScheduleJitter)
+}
+
+func TestBuildCalendar(t *testing.T) {
+	t.Parallel()
+
+	th := testLoad(t, "valid_calendar.yaml", withBuildOpts(digraph.BuildOpts{
+		ImportDir: test.TestdataPath(t, "digraph"),
+	}))
+	assert.Equal(t, []string{"holidays.txt", "holidays.ics"}, th.Calendar)
+	assert.Equal(t, []digraph.ExcludeDate{
+		{Date: "2024-01-01", Description: "New Year's Day", Calendar: "holidays.txt"},
+		{Date: "2024-12-25", Calendar: "holidays.txt"},
+		{Date: "2024-12-30", Description: "Year-end holidays", Calendar: "holidays.txt"},
+		{Date: "2024-12-31", Description: "Year-end holidays", Calendar: "holidays.txt"},
+		// The end of an all-day event is exclusive.
+		{Date: "2024-04-29", Description: "Golden Week", Calendar: "holidays.ics"},
+		{Date: "2024-04-30", Description: "Golden Week", Calendar: "holidays.ics"},
+		{Date: "2024-07-15", Description: "Marine Day", Calendar: "holidays.ics"},
+		// 22:00 in New York is 12:00 on the next day in Tokyo.
+		{Date: "2025-01-01", Description: "New Year", Calendar: "holidays.ics"},
+		{Date: "2024-08-13"},
+	}, th.ExcludeDates)
+	require.Len(t, th.BlackoutWindows, 2)
+	assert.Equal(t, []time.Weekday{time.Friday, time.Saturday}, th.BlackoutWindows[0].Days)
+
+	for _, tc := range []struct {
+		time   time.Time
+		reason string
+	}{
+		{time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), "excluded date 2024-01-01 (New Year's Day) in calendar holidays.txt"},
+		{time.Date(2024, 4, 30, 9, 0, 0, 0, time.UTC), "excluded date 2024-04-30 (Golden Week) in calendar holidays.ics"},
+		{time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), ""},
+		{time.Date(2024, 8, 13, 9, 0, 0, 0, time.UTC), "excluded date 2024-08-13"},
+		// 2024-05-03 is a Friday.
+		{time.Date(2024, 5, 3, 23, 30, 0, 0, time.UTC), "blackout window 23:00-01:00 on Fri,Sat"},
+		{time.Date(2024, 5, 5, 0, 30, 0, 0, time.UTC), "blackout window 23:00-01:00 on Fri,Sat"},
+		{time.Date(2024, 5, 6, 0, 30, 0, 0, time.UTC), ""},
+		{time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC), "blackout window 12:00-13:00"},
+		{time.Date(2024, 5, 6, 13, 0, 0, 0, time.UTC), ""},
+	} {
+		assert.Equal(t, tc.reason, th.SkipReason(tc.time), tc.time)
+	}
+}
+
+func TestBuildRunOn(t *testing.T) {
+	t.Parallel()
+
+	th := testLoad(t, "valid_run_on.yaml")
+	assert.Equal(t, digraph.RunOnLastBusinessDayOfMonth, th.RunOn)
+
+	// 2024-05-31 is a Friday but excluded, so Thursday is the last
+	// business day of the month.
+	for _, tc := range []struct {
+		time   time.Time
+		reason string
+	}{
+		{time.Date(2024, 5, 30, 18, 0, 0, 0, time.UTC), ""},
+		{time.Date(2024, 5, 29, 18, 0, 0, 0, time.UTC), "not the last business day of the month"},
+		{time.Date(2024, 5, 31, 18, 0, 0, 0, time.UTC), "excluded date 2024-05-31"},
+		// 2024-06-30 is a Sunday.
+		{time.Date(2024, 6, 28, 18, 0, 0, 0, time.UTC), ""},
+		{time.Date(2024, 6, 30, 18, 0, 0, 0, time.UTC), "not the last business day of the month"},
+	} {
+		assert.Equal(t, tc.reason, th.SkipReason(tc.time), tc.time)
+	}
+
+	businessDays := *th.DAG
+	businessDays.RunOn = digraph.RunOnBusinessDays
+	for _, tc := range []struct {
+		time   time.Time
+		reason string
+	}{
+		{time.Date(2024, 5, 29, 18, 0, 0, 0, time.UTC), ""},
+		{time.Date(2024, 6, 1, 18, 0, 0, 0, time.UTC), "not a business day"},
+		{time.Date(2024, 6, 2, 18, 0, 0, 0, time.UTC), "not a business day"},
+		{time.Date(2024, 6, 3, 18, 0, 0, 0, time.UTC), ""},
+	} {
+		assert.Equal(t, tc.reason, businessDays.SkipReason(tc.time), tc.time)
+	}
+}
+
 func TestBuildStep(t *testing.T) {
 	t.Parallel()
 	t.Run("ValidCommand", func(t *testing.T) {
@@ -371,6 +533,42 @@ func TestBuildStep(t *testing.T) {
 			"param1=value1 param2=value2",
 		}, th.Steps[0].Args)
 	})
+	t.Run("WaitFor", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "wait_for.yaml")
+		assert.Len(t, th.Steps, 1)
+		assert.Equal(t, "waitFor", th.Steps[0].ExecutorConfig.Type)
+		assert.Equal(t, &digraph.WaitFor{
+			DAG:          "upstream",
+			Offset:       -24 * time.Hour,
+			Timeout:      2 * time.Hour,
+			PokeInterval: 30 * time.Second,
+			SoftFail:     true,
+		}, th.Steps[0].WaitFor)
+	})
+	t.Run("Resources", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "resources.yaml")
+		assert.Len(t, th.Steps, 1)
+		assert.Equal(t, &digraph.Resources{
+			Memory: 512 << 20,
+			CPU:    0.5,
+			Pids:   100,
+			Nofile: 1024,
+		}, th.Steps[0].Resources)
+		assert.Equal(t, &digraph.RunAs{User: "builder", Group: "staff"}, th.Steps[0].RunAs)
+	})
+	t.Run("LogFormat", func(t *testing.T) {
+		t.Parallel()
+
+		th := testLoad(t, "log_format.yaml")
+		assert.Len(t, th.Steps, 1)
+		assert.Equal(t, digraph.LogFormatJSON, th.Steps[0].LogFormat)
+		assert.Equal(t, []string{"re:^not found"}, th.Steps[0].ContinueOn.ErrorLog)
+		assert.Equal(t, []string{"connection refused", "re:timed? out"}, th.Steps[0].RetryPolicy.ErrorLog)
+	})
 	t.Run("ContinueOn", func(t *testing.T) {
 		t.Parallel()
 
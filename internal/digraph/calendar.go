@@ -0,0 +1,458 @@
+package digraph
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout is the layout of the dates in excludeDates and calendar files.
+const dateLayout = "2006-01-02"
+
+// Rules of the days to run the DAG on schedule.
+const (
+	// RunOnBusinessDays runs the DAG from Monday to Friday except the
+	// excluded dates.
+	RunOnBusinessDays = "businessDays"
+	// RunOnLastBusinessDayOfMonth runs the DAG on the last business day of
+	// each month.
+	RunOnLastBusinessDayOfMonth = "lastBusinessDayOfMonth"
+)
+
+// ExcludeDate is a date to skip the schedule on.
+type ExcludeDate struct {
+	// Date is the date in the format "2006-01-02".
+	Date string `json:"Date"`
+	// Description is the description of the date, e.g. the name of the
+	// holiday in the calendar file. This is optional.
+	Description string `json:"Description,omitempty"`
+	// Calendar is the calendar file the date is read from, if any.
+	Calendar string `json:"Calendar,omitempty"`
+}
+
+// BlackoutWindow is a period of the day to skip the schedule in.
+type BlackoutWindow struct {
+	// Start is the start time of the window in the format "15:04".
+	Start string `json:"Start"`
+	// End is the end time of the window in the format "15:04". The window
+	// ends on the next day if it is not after the start time.
+	End string `json:"End"`
+	// Days is the days of the week the window starts on. The window applies
+	// to every day if it is empty.
+	Days []time.Weekday `json:"Days,omitempty"`
+}
+
+// String returns the window as written, e.g. "23:00-01:00 on Fri".
+func (w BlackoutWindow) String() string {
+	if len(w.Days) == 0 {
+		return w.Start + "-" + w.End
+	}
+	var days []string
+	for _, d := range w.Days {
+		days = append(days, d.String()[:3])
+	}
+	return fmt.Sprintf("%s-%s on %s", w.Start, w.End, strings.Join(days, ","))
+}
+
+// contains reports whether the time is in the window.
+func (w BlackoutWindow) contains(t time.Time) bool {
+	start, err1 := parseClock(w.Start)
+	end, err2 := parseClock(w.End)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	minute := t.Hour()*60 + t.Minute()
+	if start < end {
+		return minute >= start && minute < end && w.onDay(t.Weekday())
+	}
+	// The window crosses midnight.
+	if minute >= start {
+		return w.onDay(t.Weekday())
+	}
+	return minute < end && w.onDay((t.Weekday()+6)%7)
+}
+
+func (w BlackoutWindow) onDay(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// SkipReason returns the reason to skip the schedule at the time because of
+// the excluded dates or the blackout windows of the DAG, or an empty string
+// if the DAG can be started. The time must be in the timezone of the schedule.
+func (d *DAG) SkipReason(t time.Time) string {
+	date := t.Format(dateLayout)
+	for _, e := range d.ExcludeDates {
+		if e.Date != date {
+			continue
+		}
+		reason := "excluded date " + date
+		if e.Description != "" {
+			reason += " (" + e.Description + ")"
+		}
+		if e.Calendar != "" {
+			reason += " in calendar " + e.Calendar
+		}
+		return reason
+	}
+	for _, w := range d.BlackoutWindows {
+		if w.contains(t) {
+			return "blackout window " + w.String()
+		}
+	}
+	switch d.RunOn {
+	case RunOnBusinessDays:
+		if !d.isBusinessDay(t) {
+			return "not a business day"
+		}
+	case RunOnLastBusinessDayOfMonth:
+		if !d.isLastBusinessDayOfMonth(t) {
+			return "not the last business day of the month"
+		}
+	}
+	return ""
+}
+
+// isBusinessDay reports whether the day of the time is from Monday to
+// Friday and not an excluded date.
+func (d *DAG) isBusinessDay(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	date := t.Format(dateLayout)
+	for _, e := range d.ExcludeDates {
+		if e.Date == date {
+			return false
+		}
+	}
+	return true
+}
+
+// isLastBusinessDayOfMonth reports whether the day of the time is the last
+// business day of its month.
+func (d *DAG) isLastBusinessDayOfMonth(t time.Time) bool {
+	if !d.isBusinessDay(t) {
+		return false
+	}
+	for next := t.AddDate(0, 0, 1); next.Month() == t.Month(); next = next.AddDate(0, 0, 1) {
+		if d.isBusinessDay(next) {
+			return false
+		}
+	}
+	return true
+}
+
+// buildCalendar reads the dates in the calendar files and excludeDates.
+func buildCalendar(ctx BuildContext, spec *definition, dag *DAG) error {
+	var files []string
+	switch v := spec.Calendar.(type) {
+	case nil:
+	case string:
+		files = append(files, v)
+	case []any:
+		for _, f := range v {
+			s, ok := f.(string)
+			if !ok {
+				return wrapError("calendar", f, fmt.Errorf("%w: must be a string", ErrInvalidCalendar))
+			}
+			files = append(files, s)
+		}
+	default:
+		return wrapError("calendar", v, fmt.Errorf("%w: must be a string or an array of strings", ErrInvalidCalendar))
+	}
+
+	// The times of the events in iCalendar files are converted to the dates
+	// in the timezone of the DAG.
+	location := time.Local
+	if spec.Timezone != "" {
+		if loc, err := time.LoadLocation(spec.Timezone); err == nil {
+			location = loc
+		}
+	}
+
+	for _, file := range files {
+		dates, err := readCalendar(calendarPath(ctx, file), location)
+		if err != nil {
+			return wrapError("calendar", file, fmt.Errorf("%w: %s", ErrInvalidCalendar, err))
+		}
+		for i := range dates {
+			dates[i].Calendar = file
+		}
+		dag.Calendar = append(dag.Calendar, file)
+		dag.ExcludeDates = append(dag.ExcludeDates, dates...)
+	}
+
+	for _, date := range spec.ExcludeDates {
+		if _, err := time.Parse(dateLayout, date); err != nil {
+			return wrapError("excludeDates", date, ErrInvalidExcludeDate)
+		}
+		dag.ExcludeDates = append(dag.ExcludeDates, ExcludeDate{Date: date})
+	}
+
+	switch spec.RunOn {
+	case "", RunOnBusinessDays, RunOnLastBusinessDayOfMonth:
+		dag.RunOn = spec.RunOn
+	default:
+		return wrapError("runOn", spec.RunOn, fmt.Errorf("%w: must be %s or %s", ErrInvalidRunOn, RunOnBusinessDays, RunOnLastBusinessDayOfMonth))
+	}
+
+	return nil
+}
+
+// calendarPath resolves the relative path of a calendar file against the
+// DAGs directory, or the directory of the DAG file if it is not known.
+func calendarPath(ctx BuildContext, file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+	switch {
+	case ctx.opts.DAGsDir != "":
+		return filepath.Join(ctx.opts.DAGsDir, file)
+	case ctx.file != "":
+		return filepath.Join(filepath.Dir(ctx.file), file)
+	default:
+		return filepath.Join(ctx.opts.ImportDir, file)
+	}
+}
+
+// readCalendar reads the dates in the calendar file. A file with the .ics
+// extension is read as iCalendar; otherwise, each line has one or more dates
+// in the format "2006-01-02" separated by spaces or commas, optionally
+// followed by the description.
+func readCalendar(file string, location *time.Location) ([]ExcludeDate, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(file), ".ics") {
+		return parseICS(data, location)
+	}
+
+	var ret []ExcludeDate
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(strings.ReplaceAll(text, ",", " "))
+		n := 0
+		for n < len(fields) && isDate(fields[n]) {
+			n++
+		}
+		if n == 0 {
+			return nil, fmt.Errorf("line %d: invalid date %q", line, fields[0])
+		}
+		desc := strings.Join(fields[n:], " ")
+		for _, date := range fields[:n] {
+			ret = append(ret, ExcludeDate{Date: date, Description: desc})
+		}
+	}
+	return ret, scanner.Err()
+}
+
+func isDate(value string) bool {
+	_, err := time.Parse(dateLayout, value)
+	return err == nil
+}
+
+// parseICS reads the dates of the events in the iCalendar data. An all-day
+// event spanning multiple days excludes each of the days. An event with the
+// time excludes the day it starts on in the location. Recurrence rules are
+// not expanded.
+func parseICS(data []byte, location *time.Location) ([]ExcludeDate, error) {
+	// Unfold the lines continued with a leading space or tab.
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	text = strings.NewReplacer("\n ", "", "\n\t", "").Replace(text)
+
+	var (
+		ret        []ExcludeDate
+		inEvent    bool
+		start, end time.Time
+		summary    string
+	)
+	for _, line := range strings.Split(text, "\n") {
+		name, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		name, params, _ := strings.Cut(name, ";")
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end, summary = true, time.Time{}, time.Time{}, ""
+			}
+		case "DTSTART", "DTEND":
+			if !inEvent {
+				continue
+			}
+			t, err := parseICSDate(value, icsParam(params, "TZID"), location)
+			if err != nil {
+				return nil, err
+			}
+			if strings.EqualFold(name, "DTSTART") {
+				start = t
+			} else if len(value) == 8 {
+				// The end of an all-day event is exclusive.
+				end = t
+			}
+		case "SUMMARY":
+			if inEvent {
+				summary = value
+			}
+		case "END":
+			if !strings.EqualFold(value, "VEVENT") || !inEvent {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				return nil, fmt.Errorf("event %q has no DTSTART", summary)
+			}
+			for d := start; d.Equal(start) || d.Before(end); d = d.AddDate(0, 0, 1) {
+				ret = append(ret, ExcludeDate{Date: d.Format(dateLayout), Description: summary})
+			}
+		}
+	}
+	return ret, nil
+}
+
+// icsParam returns the value of the parameter of the property, e.g. TZID of
+// "TZID=Europe/Paris;VALUE=DATE-TIME".
+func icsParam(params, name string) string {
+	for _, param := range strings.Split(params, ";") {
+		key, value, _ := strings.Cut(param, "=")
+		if strings.EqualFold(key, name) {
+			return strings.Trim(value, `"`)
+		}
+	}
+	return ""
+}
+
+// parseICSDate parses an iCalendar DATE or DATE-TIME value and returns the
+// date. The time of a DATE-TIME value is in UTC if it ends with "Z", in the
+// timezone of the TZID parameter if any, and in the location otherwise; the
+// date is the one in the location.
+func parseICSDate(value, tzid string, location *time.Location) (time.Time, error) {
+	if len(value) == 8 {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q", value)
+		}
+		return t, nil
+	}
+
+	tz := location
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		tz = time.UTC
+		value = strings.TrimSuffix(value, "Z")
+	case tzid != "":
+		loc, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid TZID %q: %w", tzid, err)
+		}
+		tz = loc
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, tz)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date-time %q", value)
+	}
+	t = t.In(location)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// buildBlackoutWindows validates the blackout windows.
+func buildBlackoutWindows(_ BuildContext, spec *definition, dag *DAG) error {
+	for _, def := range spec.BlackoutWindows {
+		start, err := parseClock(def.Start)
+		if err != nil {
+			return wrapError("blackoutWindows.start", def.Start, fmt.Errorf("%w: %s", ErrInvalidBlackoutWindow, err))
+		}
+		end, err := parseClock(def.End)
+		if err != nil {
+			return wrapError("blackoutWindows.end", def.End, fmt.Errorf("%w: %s", ErrInvalidBlackoutWindow, err))
+		}
+		if start == end {
+			return wrapError("blackoutWindows", def, fmt.Errorf("%w: start and end must differ", ErrInvalidBlackoutWindow))
+		}
+		days, err := parseWeekdays(def.Days)
+		if err != nil {
+			return wrapError("blackoutWindows.days", def.Days, fmt.Errorf("%w: %s", ErrInvalidBlackoutWindow, err))
+		}
+		dag.BlackoutWindows = append(dag.BlackoutWindows, BlackoutWindow{
+			Start: def.Start,
+			End:   def.End,
+			Days:  days,
+		})
+	}
+	return nil
+}
+
+// parseClock parses the time of day in the format "15:04" and returns the
+// minutes since midnight.
+func parseClock(value string) (int, error) {
+	h, m, ok := strings.Cut(value, ":")
+	if !ok {
+		return 0, fmt.Errorf("time must be in the format HH:MM: %q", value)
+	}
+	hour, err1 := strconv.Atoi(h)
+	minute, err2 := strconv.Atoi(m)
+	if err1 != nil || err2 != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, fmt.Errorf("time must be in the format HH:MM: %q", value)
+	}
+	return hour*60 + minute, nil
+}
+
+// weekdays maps the names of the days of the week to the values.
+var weekdays = map[string]time.Weekday{}
+
+func init() {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		weekdays[name] = d
+		weekdays[name[:3]] = d
+	}
+}
+
+// parseWeekdays parses the days of the week given as a comma-separated
+// string or an array, e.g. "sat,sun" or ["friday"].
+func parseWeekdays(value any) ([]time.Weekday, error) {
+	var names []string
+	switch v := value.(type) {
+	case nil:
+	case string:
+		names = strings.Split(v, ",")
+	case []any:
+		for _, d := range v {
+			s, ok := d.(string)
+			if !ok {
+				return nil, fmt.Errorf("day must be a string: %v", d)
+			}
+			names = append(names, s)
+		}
+	default:
+		return nil, fmt.Errorf("days must be a string or an array of strings")
+	}
+
+	var ret []time.Weekday
+	for _, name := range names {
+		day, ok := weekdays[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("invalid day %q", name)
+		}
+		ret = append(ret, day)
+	}
+	return ret, nil
+}
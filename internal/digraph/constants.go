@@ -14,3 +14,8 @@ const (
 	EnvKeyDAGStepName      = "DAG_STEP_NAME"
 	EnvKeyDAGStepLogPath   = "DAG_STEP_LOG_PATH"
 )
+
+// ExitCodeConditionNotMet is the exit code of the start command when the
+// preconditions of the DAG are not met, so that the caller can tell it apart
+// from a failed run.
+const ExitCodeConditionNotMet = 3
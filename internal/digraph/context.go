@@ -4,9 +4,11 @@ import (
 	"context"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/cmdutil"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/secrets"
 )
 
 type Context struct {
@@ -14,6 +16,8 @@ type Context struct {
 	dag    *DAG
 	client DBClient
 	envs   map[string]string
+	// logicalTime is the time the run is for, e.g. the scheduled time.
+	logicalTime time.Time
 }
 
 func (c Context) GetDAGByName(name string) (*DAG, error) {
@@ -24,8 +28,34 @@ func (c Context) GetResult(name, requestID string) (*Status, error) {
 	return c.client.GetStatus(c.ctx, name, requestID)
 }
 
+// GetResultsOn returns the results of the runs of the DAG for the date of the
+// time, i.e. scheduled or started on the date, newest first.
+func (c Context) GetResultsOn(name string, date time.Time) ([]*Status, error) {
+	return c.client.GetStatusesOn(c.ctx, name, date)
+}
+
+// LogicalTime returns the time the run is for in the timezone of the DAG.
+// It is the scheduled time of a scheduled run and the start time otherwise.
+func (c Context) LogicalTime() time.Time {
+	t := c.logicalTime
+	if t.IsZero() {
+		t = time.Now()
+	}
+	if c.dag == nil {
+		return t
+	}
+	return t.In(c.dag.TimeLocation())
+}
+
+// WithLogicalTime returns the context with the time the run is for.
+func (c Context) WithLogicalTime(t time.Time) Context {
+	c.logicalTime = t
+	return c
+}
+
 func (c Context) AllEnvs() []string {
-	envs := os.Environ()
+	// The key of the secret store is not passed to the steps.
+	envs := secrets.WithoutKeyEnv(os.Environ())
 	envs = append(envs, c.dag.Env...)
 	for k, v := range c.envs {
 		envs = append(envs, k+"="+v)
@@ -108,7 +108,7 @@ func IsStepContext(ctx context.Context) bool {
 
 type stepCtxKey struct{}
 
-func EvalStringFields[T any](stepContext StepContext, obj T) (T, error) {
-	return cmdutil.EvalStringFields(stepContext.ctx, obj,
-		cmdutil.WithVariables(stepContext.outputVariables.Variables()))
+func EvalStringFields[T any](stepContext StepContext, obj T, opts ...cmdutil.EvalOption) (T, error) {
+	opts = append(opts, cmdutil.WithVariables(stepContext.outputVariables.Variables()))
+	return cmdutil.EvalStringFields(stepContext.ctx, obj, opts...)
 }
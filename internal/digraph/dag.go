@@ -4,6 +4,8 @@ import (
 	// nolint // gosec
 	"crypto/md5"
 	"fmt"
+	"hash/fnv"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -26,6 +28,9 @@ type DAG struct {
 	Group string `json:"Group"`
 	// Name is the name of the DAG. The default is the filename without the extension.
 	Name string `json:"Name"`
+	// Namespace is the directory of the DAG file relative to the DAGs
+	// directory, e.g. "team-a". It is empty for DAGs at the top level.
+	Namespace string `json:"Namespace,omitempty"`
 	// Dotenv is the path to the dotenv file. This is optional.
 	Dotenv []string `json:"Dotenv"`
 	// Tags contains the list of tags for the DAG. This is optional.
@@ -36,6 +41,24 @@ type DAG struct {
 	Schedule        []Schedule `json:"Schedule"`
 	StopSchedule    []Schedule `json:"StopSchedule"`
 	RestartSchedule []Schedule `json:"RestartSchedule"`
+	// Timezone is the timezone the schedule is evaluated in. The timezone of
+	// the scheduler is used if it is empty. This is optional.
+	Timezone string `json:"Timezone,omitempty"`
+	// ScheduleJitter is the maximum delay to start the DAG after the scheduled
+	// time. The delay is determined per DAG. This is optional.
+	ScheduleJitter time.Duration `json:"ScheduleJitter,omitempty"`
+	// Calendar contains the calendar files of the dates to skip the schedule
+	// on, e.g. public holidays. This is optional.
+	Calendar []string `json:"Calendar,omitempty"`
+	// ExcludeDates contains the dates to skip the schedule on, including the
+	// dates in the calendar files. This is optional.
+	ExcludeDates []ExcludeDate `json:"ExcludeDates,omitempty"`
+	// BlackoutWindows contains the periods to skip the schedule in. This is optional.
+	BlackoutWindows []BlackoutWindow `json:"BlackoutWindows,omitempty"`
+	// RunOn is the rule of the days to run on schedule, i.e. "businessDays"
+	// or "lastBusinessDayOfMonth". The business days are from Monday to
+	// Friday except the excluded dates. This is optional.
+	RunOn string `json:"RunOn,omitempty"`
 	// SkipIfSuccessful indicates whether to skip the DAG if it was successful previously.
 	// E.g., when the DAG has already been executed manually before the scheduled time.
 	SkipIfSuccessful bool `json:"SkipIfSuccessful"`
@@ -43,6 +66,11 @@ type DAG struct {
 	Env []string `json:"Env"`
 	// LogDir is the directory where the logs are stored.
 	LogDir string `json:"LogDir"`
+	// LogMaxSize is the maximum size in bytes of the log of a step. The log
+	// is not limited if it is zero.
+	LogMaxSize int64 `json:"LogMaxSize,omitempty"`
+	// LogTruncate is the part of the log dropped when it exceeds LogMaxSize.
+	LogTruncate string `json:"LogTruncate,omitempty"`
 	// DefaultParams contains the default parameters to be passed to the DAG.
 	DefaultParams string `json:"DefaultParams"`
 	// Params contains the list of parameters to be passed to the DAG.
@@ -83,6 +111,17 @@ type Schedule struct {
 	Parsed cron.Schedule `json:"-"`
 }
 
+// Location returns the timezone the schedule is evaluated in, specified
+// either by the timezone of the DAG or by CRON_TZ in the expression. It
+// returns nil if the schedule uses the timezone of the scheduler.
+func (s Schedule) Location() *time.Location {
+	spec, ok := s.Parsed.(*cron.SpecSchedule)
+	if !ok || spec.Location == time.Local {
+		return nil
+	}
+	return spec.Location
+}
+
 // HandlerOn contains the steps to be executed on different events in the DAG.
 type HandlerOn struct {
 	Failure *Step `json:"Failure"`
@@ -113,6 +152,15 @@ type MailConfig struct {
 	AttachLogs bool   `json:"AttachLogs"`
 }
 
+// The parts of the log of a step dropped when it exceeds the maximum size.
+const (
+	// LogTruncateTail keeps the beginning of the log and drops the rest.
+	LogTruncateTail = "tail"
+	// LogTruncateHead drops the beginning of the log and keeps the last
+	// lines.
+	LogTruncateHead = "head"
+)
+
 // HandlerType is the type of the handler.
 type HandlerType string
 
@@ -149,6 +197,62 @@ func (d *DAG) HasTag(tag string) bool {
 	return false
 }
 
+// ID returns the identifier of the DAG, the path of the DAG file relative
+// to the DAGs directory without the extension, e.g. "team-a/etl".
+func (d *DAG) ID() string {
+	name := strings.TrimSuffix(filepath.Base(d.Location), filepath.Ext(d.Location))
+	if d.Location == "" {
+		name = d.Name
+	}
+	if d.Namespace == "" {
+		return name
+	}
+	return path.Join(d.Namespace, name)
+}
+
+// Namespace returns the namespace of the DAG file in the DAGs directory.
+// It is empty if the file is at the top level or outside of the directory.
+func Namespace(dagsDir, file string) string {
+	if dagsDir == "" || !filepath.IsAbs(file) {
+		return ""
+	}
+	dir, err := filepath.Abs(dagsDir)
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(dir, filepath.Dir(file))
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// JitterDelay returns the delay to start the DAG after the scheduled time
+// within the schedule jitter. The delay is derived from the hash of the ID
+// so that it is the same for every run of the DAG.
+func (d *DAG) JitterDelay() time.Duration {
+	if d.ScheduleJitter < time.Second {
+		return 0
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(d.ID()))
+	seconds := hash.Sum64() % uint64(d.ScheduleJitter/time.Second)
+	return time.Duration(seconds) * time.Second
+}
+
+// TimeLocation returns the location of the timezone of the DAG, or the local
+// timezone if it is not set.
+func (d *DAG) TimeLocation() *time.Location {
+	if d.Timezone == "" {
+		return time.Local
+	}
+	location, err := time.LoadLocation(d.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
+
 // SockAddr returns the unix socket address for the DAG.
 // The address is used to communicate with the agent process.
 func (d *DAG) SockAddr() string {
@@ -3,6 +3,7 @@ package digraph_test
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/test"
@@ -36,3 +37,24 @@ func TestUnixSocket(t *testing.T) {
 		)
 	})
 }
+
+func TestJitterDelay(t *testing.T) {
+	t.Run("NoJitter", func(t *testing.T) {
+		dag := &digraph.DAG{Name: "etl"}
+		require.Zero(t, dag.JitterDelay())
+	})
+	t.Run("Deterministic", func(t *testing.T) {
+		delays := map[time.Duration]bool{}
+		for _, name := range []string{"a", "b", "c", "d", "e"} {
+			dag := &digraph.DAG{Name: name, ScheduleJitter: time.Hour}
+			delay := dag.JitterDelay()
+			require.Equal(t, delay, dag.JitterDelay())
+			require.GreaterOrEqual(t, delay, time.Duration(0))
+			require.Less(t, delay, time.Hour)
+			require.Zero(t, delay%time.Second)
+			delays[delay] = true
+		}
+		// The DAGs are spread over the jitter.
+		require.Greater(t, len(delays), 1)
+	})
+}
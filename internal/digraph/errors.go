@@ -38,6 +38,12 @@ var (
 	ErrInvalidSchedule                     = errors.New("invalid schedule")
 	ErrScheduleMustBeStringOrArray         = errors.New("schedule must be a string or an array of strings")
 	ErrInvalidScheduleType                 = errors.New("invalid schedule type")
+	ErrInvalidTimezone                     = errors.New("invalid timezone")
+	ErrInvalidScheduleJitter               = errors.New("invalid schedule jitter")
+	ErrInvalidCalendar                     = errors.New("invalid calendar")
+	ErrInvalidExcludeDate                  = errors.New("invalid exclude date")
+	ErrInvalidBlackoutWindow               = errors.New("invalid blackout window")
+	ErrInvalidRunOn                        = errors.New("invalid runOn")
 	ErrInvalidKeyType                      = errors.New("invalid key type")
 	ErrExecutorConfigMustBeString          = errors.New("executor config key must be string")
 	ErrDuplicateFunction                   = errors.New("duplicate function")
@@ -52,6 +58,13 @@ var (
 	ErrRequiredParameterNotFound           = errors.New("required parameter not found")
 	ErrScheduleKeyMustBeString             = errors.New("schedule key must be a string")
 	ErrInvalidSignal                       = errors.New("invalid signal")
+	ErrInvalidWaitFor                      = errors.New("invalid waitFor")
+	ErrInvalidResources                    = errors.New("invalid resources")
+	ErrInvalidLogMaxSize                   = errors.New("invalid logMaxSize")
+	ErrInvalidLogFormat                    = errors.New("logFormat must be \"text\" or \"json\"")
+	ErrErrorLogMustBeStringOrArray         = errors.New("errorLog must be a string or an array of strings")
+	ErrInvalidLogTruncate                  = errors.New("logTruncate must be \"tail\" or \"head\"")
+	ErrInvalidRunAs                        = errors.New("invalid runAs")
 	ErrInvalidEnvValue                     = errors.New("invalid value for env")
 	ErrArgsMustBeConvertibleToIntOrString  = errors.New("args must be convertible to either int or string")
 	ErrExecutorTypeMustBeString            = errors.New("executor.type value must be string")
@@ -16,6 +16,7 @@ import (
 
 var _ Executor = (*commandExecutor)(nil)
 var _ ExitCoder = (*commandExecutor)(nil)
+var _ UsageReporter = (*commandExecutor)(nil)
 
 type commandExecutor struct {
 	mu         sync.Mutex
@@ -23,6 +24,7 @@ type commandExecutor struct {
 	cmd        *exec.Cmd
 	scriptFile string
 	exitCode   int
+	usage      ResourceUsage
 }
 
 // ExitCode implements ExitCoder.
@@ -30,6 +32,11 @@ func (e *commandExecutor) ExitCode() int {
 	return e.exitCode
 }
 
+// Usage implements UsageReporter.
+func (e *commandExecutor) Usage() ResourceUsage {
+	return e.usage
+}
+
 func (e *commandExecutor) Run(ctx context.Context) error {
 	e.mu.Lock()
 
@@ -57,6 +64,19 @@ func (e *commandExecutor) Run(ctx context.Context) error {
 	}
 	e.cmd = cmd
 
+	limiter, err := newResourceLimiter(ctx, e.config.Resources)
+	if err != nil {
+		e.exitCode = 1
+		e.mu.Unlock()
+		return err
+	}
+	defer limiter.release(ctx)
+	if err := limiter.prepare(e.cmd); err != nil {
+		e.exitCode = 1
+		e.mu.Unlock()
+		return err
+	}
+
 	if err := e.cmd.Start(); err != nil {
 		e.exitCode = exitCodeFromError(err)
 		e.mu.Unlock()
@@ -64,7 +84,9 @@ func (e *commandExecutor) Run(ctx context.Context) error {
 	}
 	e.mu.Unlock()
 
-	if err := e.cmd.Wait(); err != nil {
+	err = e.cmd.Wait()
+	e.usage = limiter.usage(e.cmd.ProcessState)
+	if err != nil {
 		e.exitCode = exitCodeFromError(err)
 		return err
 	}
@@ -101,6 +123,8 @@ type commandConfig struct {
 	ShellCommandArgs string
 	Stdout           io.Writer
 	Stderr           io.Writer
+	Resources        *digraph.Resources
+	RunAs            *digraph.RunAs
 }
 
 func (cfg *commandConfig) newCmd(ctx context.Context, scriptFile string) (*exec.Cmd, error) {
@@ -140,6 +164,18 @@ func (cfg *commandConfig) newCmd(ctx context.Context, scriptFile string) (*exec.
 		Setpgid: true,
 		Pgid:    0,
 	}
+	if cfg.RunAs != nil {
+		if err := setCredential(cmd, cfg.RunAs); err != nil {
+			return nil, err
+		}
+		// The script file is only readable by the owner.
+		if scriptFile != "" {
+			credential := cmd.SysProcAttr.Credential
+			if err := os.Chown(scriptFile, int(credential.Uid), int(credential.Gid)); err != nil {
+				return nil, fmt.Errorf("failed to change the owner of the script file: %w", err)
+			}
+		}
+	}
 
 	return cmd, nil
 }
@@ -187,6 +223,8 @@ func createCommandConfig(ctx context.Context, step digraph.Step) (*commandConfig
 		Script:           step.Script,
 		ShellCommand:     shellCommand,
 		ShellCommandArgs: shellCmdArgs,
+		Resources:        step.Resources,
+		RunAs:            step.RunAs,
 	}, nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"sync"
 
 	"github.com/dagu-org/dagu/internal/digraph"
@@ -416,6 +417,39 @@ func newDocker(
 	return nil, errors.New("either containerName or image must be specified")
 }
 
+// validateDockerConfig checks the keys of the config of the docker executor
+// and of the configs of the container, the host, the network and the exec
+// passed to the Docker API.
+func validateDockerConfig(config map[string]any) error {
+	keys := make([]string, 0, len(config))
+	for key := range config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var err error
+		switch key {
+		case "image", "containerName", "autoRemove", "pull":
+		case "container":
+			err = decodeStrict(config[key], new(container.Config))
+		case "host":
+			err = decodeStrict(config[key], new(container.HostConfig))
+		case "network":
+			err = decodeStrict(config[key], new(network.NetworkingConfig))
+		case "exec":
+			err = decodeStrict(config[key], new(container.ExecOptions))
+		default:
+			err = fmt.Errorf("%w: %s", errUnknownConfigKey, key)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return nil
+}
+
 func init() {
 	Register("docker", newDocker)
+	RegisterValidator("docker", validateDockerConfig)
 }
@@ -6,8 +6,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/go-viper/mapstructure/v2"
 )
 
 type Executor interface {
@@ -21,13 +24,24 @@ type ExitCoder interface {
 	ExitCode() int
 }
 
+// UsageReporter is implemented by the executors which can report the
+// resources used by the last run.
+type UsageReporter interface {
+	Usage() ResourceUsage
+}
+
 type Creator func(ctx context.Context, step digraph.Step) (Executor, error)
 
 var (
-	executors          = make(map[string]Creator)
-	errInvalidExecutor = errors.New("invalid executor")
+	executors           = make(map[string]Creator)
+	errInvalidExecutor  = errors.New("invalid executor")
+	errUnknownConfigKey = errors.New("unknown keys in the executor config")
 )
 
+// ErrSkipped is returned by an executor to mark the step as skipped rather
+// than failed.
+var ErrSkipped = errors.New("step skipped")
+
 func NewExecutor(ctx context.Context, step digraph.Step) (Executor, error) {
 	f, ok := executors[step.ExecutorConfig.Type]
 	if ok {
@@ -39,3 +53,48 @@ func NewExecutor(ctx context.Context, step digraph.Step) (Executor, error) {
 func Register(name string, register Creator) {
 	executors[name] = register
 }
+
+// ConfigValidator checks the executor config of a step without creating the
+// executor, e.g. for unknown keys.
+type ConfigValidator func(config map[string]any) error
+
+var validators = make(map[string]ConfigValidator)
+
+// RegisterValidator registers the validator of the config of the executor.
+func RegisterValidator(name string, validator ConfigValidator) {
+	validators[name] = validator
+}
+
+// ValidateConfig validates the executor config of the step. It returns an
+// error if the executor is not known or the config has unknown keys.
+func ValidateConfig(step digraph.Step) error {
+	if _, ok := executors[step.ExecutorConfig.Type]; !ok {
+		return fmt.Errorf("%w: %s", errInvalidExecutor, step.ExecutorConfig.Type)
+	}
+	if validate, ok := validators[step.ExecutorConfig.Type]; ok && step.ExecutorConfig.Config != nil {
+		return validate(step.ExecutorConfig.Config)
+	}
+	return nil
+}
+
+// decodeStrict decodes the config into the result failing on the keys
+// which are not the fields of the result.
+func decodeStrict(config any, result any) error {
+	var md mapstructure.Metadata
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Metadata:         &md,
+		Result:           result,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create decoder: %w", err)
+	}
+	if err := decoder.Decode(config); err != nil {
+		return err
+	}
+	if len(md.Unused) > 0 {
+		sort.Strings(md.Unused)
+		return fmt.Errorf("%w: %s", errUnknownConfigKey, strings.Join(md.Unused, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package executor
+
+import (
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		typ     string
+		config  map[string]any
+		wantErr string
+	}{
+		{name: "Command", typ: "command"},
+		{name: "HTTP", typ: "http", config: map[string]any{"timeout": 10, "headers": map[string]any{"Accept": "application/json"}}},
+		{name: "HTTPUnknownKey", typ: "http", config: map[string]any{"timeot": 10}, wantErr: "timeot"},
+		{name: "Docker", typ: "docker", config: map[string]any{
+			"image":     "alpine",
+			"container": map[string]any{"env": []any{"FOO=BAR"}},
+			"host":      map[string]any{"binds": []any{"/app:/app"}},
+		}},
+		{name: "DockerUnknownKey", typ: "docker", config: map[string]any{"imag": "alpine"}, wantErr: "imag"},
+		{name: "DockerUnknownHostKey", typ: "docker", config: map[string]any{"host": map[string]any{"env": []any{"FOO=BAR"}}}, wantErr: "host: unknown keys in the executor config: env"},
+		{name: "SSH", typ: "ssh", config: map[string]any{"user": "dagu", "ip": "localhost", "port": 22}},
+		{name: "MailUnknownKey", typ: "mail", config: map[string]any{"form": "dagu@example.com"}, wantErr: "form"},
+		{name: "UnknownExecutor", typ: "unknown", wantErr: "invalid executor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step := digraph.Step{ExecutorConfig: digraph.ExecutorConfig{Type: tt.typ, Config: tt.config}}
+			err := ValidateConfig(step)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
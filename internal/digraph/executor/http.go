@@ -222,4 +222,7 @@ func decodeHTTPConfigFromString(ctx context.Context, s string, cfg *httpConfig)
 
 func init() {
 	Register("http", newHTTP)
+	RegisterValidator("http", func(config map[string]any) error {
+		return decodeStrict(config, new(httpConfig))
+	})
 }
@@ -106,4 +106,7 @@ func decodeJqConfig(dat map[string]any, cfg *jqConfig) error {
 
 func init() {
 	Register("jq", newJQ)
+	RegisterValidator("jq", func(config map[string]any) error {
+		return decodeStrict(config, new(jqConfig))
+	})
 }
@@ -110,4 +110,7 @@ func decodeMailConfig(dat map[string]any, cfg *mailConfig) error {
 
 func init() {
 	Register("mail", newMail)
+	RegisterValidator("mail", func(config map[string]any) error {
+		return decodeStrict(config, new(mailConfig))
+	})
 }
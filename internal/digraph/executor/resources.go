@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+)
+
+// ResourceUsage is the resources used by a run of a step.
+type ResourceUsage struct {
+	// PeakRSS is the peak resident set size in bytes.
+	PeakRSS int64
+	// UserTime is the CPU time spent in user mode.
+	UserTime time.Duration
+	// SystemTime is the CPU time spent in kernel mode.
+	SystemTime time.Duration
+}
+
+// usageFromProcessState returns the resources used by the process and its
+// waited children.
+func usageFromProcessState(state *os.ProcessState) ResourceUsage {
+	if state == nil {
+		return ResourceUsage{}
+	}
+	usage := ResourceUsage{
+		UserTime:   state.UserTime(),
+		SystemTime: state.SystemTime(),
+	}
+	if rusage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		usage.PeakRSS = maxRSSBytes(rusage)
+	}
+	return usage
+}
+
+// setCredential sets the user and the group to run the command as.
+func setCredential(cmd *exec.Cmd, runAs *digraph.RunAs) error {
+	u, err := lookupUser(runAs.User)
+	if err != nil {
+		return err
+	}
+	uid, err := strconv.ParseUint(u.Uid, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid uid of user %s: %w", runAs.User, err)
+	}
+
+	gid := u.Gid
+	if runAs.Group != "" {
+		g, err := lookupGroup(runAs.Group)
+		if err != nil {
+			return err
+		}
+		gid = g.Gid
+	}
+	g, err := strconv.ParseUint(gid, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid gid of group %s: %w", gid, err)
+	}
+
+	// The process gets the supplementary groups of the user, as a login
+	// shell does, rather than none.
+	groupIDs, err := u.GroupIds()
+	if err != nil {
+		return fmt.Errorf("failed to look up the groups of user %s: %w", runAs.User, err)
+	}
+	groups := make([]uint32, 0, len(groupIDs))
+	for _, id := range groupIDs {
+		group, err := strconv.ParseUint(id, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid gid of group %s: %w", id, err)
+		}
+		groups = append(groups, uint32(group))
+	}
+
+	cmd.SysProcAttr.Credential = &syscall.Credential{Uid: uint32(uid), Gid: uint32(g), Groups: groups}
+
+	// HOME and USER are inherited from the dagu process otherwise.
+	cmd.Env = append(cmd.Env, "HOME="+u.HomeDir, "USER="+u.Username)
+	return nil
+}
+
+// lookupUser looks up the user by the name or the ID.
+func lookupUser(name string) (*user.User, error) {
+	u, err := user.Lookup(name)
+	if err == nil {
+		return u, nil
+	}
+	if _, parseErr := strconv.ParseUint(name, 10, 32); parseErr == nil {
+		if u, idErr := user.LookupId(name); idErr == nil {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to look up user %s: %w", name, err)
+}
+
+// lookupGroup looks up the group by the name or the ID.
+func lookupGroup(name string) (*user.Group, error) {
+	g, err := user.LookupGroup(name)
+	if err == nil {
+		return g, nil
+	}
+	if _, parseErr := strconv.ParseUint(name, 10, 32); parseErr == nil {
+		if g, idErr := user.LookupGroupId(name); idErr == nil {
+			return g, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to look up group %s: %w", name, err)
+}
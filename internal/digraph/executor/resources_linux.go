@@ -0,0 +1,262 @@
+package executor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+// cgroupRoot is the mount point of the cgroup v2 hierarchy.
+const cgroupRoot = "/sys/fs/cgroup"
+
+// cpuPeriod is the period of cpu.max in microseconds.
+const cpuPeriod = 100000
+
+// runCgroupPrefix is the prefix of the names of the cgroups of the runs.
+const runCgroupPrefix = "dagu-run-"
+
+var errNoCgroupV2 = errors.New("cgroup v2 is not available")
+
+// runCgroupKey is the context key of the cgroup of the run.
+type runCgroupKey struct{}
+
+// resourceLimiter enforces the limits of the resources on the process of a
+// step. Memory, CPU and the number of processes are limited by a cgroup v2
+// created for the step in the cgroup of the run, which the process is
+// started in. The number of open files is limited by the rlimit set before
+// the command runs.
+type resourceLimiter struct {
+	resources digraph.Resources
+	// cgroup is the directory of the cgroup of the step, or empty if the
+	// step only limits the number of open files.
+	cgroup   string
+	cgroupFD *os.File
+}
+
+// newResourceLimiter returns a limiter for the resources, or nil if there
+// are no limits. It fails if the cgroup of the step cannot be created.
+func newResourceLimiter(ctx context.Context, resources *digraph.Resources) (*resourceLimiter, error) {
+	if resources == nil {
+		return nil, nil
+	}
+
+	l := &resourceLimiter{resources: *resources}
+	if !needsCgroup(*resources) {
+		return l, nil
+	}
+
+	parent, ok := ctx.Value(runCgroupKey{}).(string)
+	if !ok {
+		return nil, errors.New("no cgroup of the run to enforce the resource limits of the step")
+	}
+	dir, err := createCgroup(parent, *resources)
+	if err != nil {
+		return nil, err
+	}
+	fd, err := os.Open(dir)
+	if err != nil {
+		_ = os.Remove(dir)
+		return nil, fmt.Errorf("failed to open cgroup %s: %w", dir, err)
+	}
+	l.cgroup, l.cgroupFD = dir, fd
+	return l, nil
+}
+
+// SetupRunCgroup creates the cgroup of the run in the cgroup delegated to
+// dagu if any of the steps limits the memory, the CPU or the number of
+// processes, and returns the context to run the steps with and the function
+// to remove the cgroup when the run finishes. The delegated cgroup must be
+// writable and have no processes, as the controllers can only be enabled for
+// the children of a cgroup without processes, so it is not the cgroup of
+// the dagu process itself.
+func SetupRunCgroup(ctx context.Context, delegated, requestID string, steps []digraph.Step) (context.Context, func(context.Context), error) {
+	var resources digraph.Resources
+	for _, step := range steps {
+		if step.Resources == nil {
+			continue
+		}
+		resources.Memory = max(resources.Memory, step.Resources.Memory)
+		resources.CPU = max(resources.CPU, step.Resources.CPU)
+		resources.Pids = max(resources.Pids, step.Resources.Pids)
+	}
+	if !needsCgroup(resources) {
+		return ctx, func(context.Context) {}, nil
+	}
+	if delegated == "" {
+		return nil, nil, errors.New("the resource limits of the steps require a cgroup delegated to dagu, set resources.cgroup")
+	}
+
+	dir, err := createRunCgroup(delegated, requestID, resources)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create the cgroup of the run: %w", err)
+	}
+	release := func(ctx context.Context) {
+		if err := os.Remove(dir); err != nil {
+			logger.Warn(ctx, "Failed to remove the cgroup of the run", "cgroup", dir, "err", err)
+		}
+	}
+	return context.WithValue(ctx, runCgroupKey{}, dir), release, nil
+}
+
+// needsCgroup reports whether the resources are limited by a cgroup.
+func needsCgroup(resources digraph.Resources) bool {
+	return resources.Memory > 0 || resources.CPU > 0 || resources.Pids > 0
+}
+
+// createRunCgroup creates the cgroup of the run in the delegated cgroup and
+// enables the controllers for the cgroups of its steps.
+func createRunCgroup(delegated, requestID string, resources digraph.Resources) (string, error) {
+	if !filepath.IsAbs(delegated) {
+		delegated = filepath.Join(cgroupRoot, delegated)
+	}
+	if _, err := os.Stat(filepath.Join(delegated, "cgroup.controllers")); err != nil {
+		return "", fmt.Errorf("%w: %s", errNoCgroupV2, err)
+	}
+	procs, err := os.ReadFile(filepath.Join(delegated, "cgroup.procs"))
+	if err != nil {
+		return "", err
+	}
+	if len(bytes.TrimSpace(procs)) > 0 {
+		return "", fmt.Errorf("cgroup %s has processes, delegate a cgroup without processes to dagu", delegated)
+	}
+
+	var controllers []string
+	if resources.Memory > 0 {
+		controllers = append(controllers, "+memory")
+	}
+	if resources.CPU > 0 {
+		controllers = append(controllers, "+cpu")
+	}
+	if resources.Pids > 0 {
+		controllers = append(controllers, "+pids")
+	}
+	if err := writeCgroupFile(delegated, "cgroup.subtree_control", strings.Join(controllers, " ")); err != nil {
+		return "", fmt.Errorf("failed to enable the controllers of cgroup %s: %w", delegated, err)
+	}
+
+	dir := filepath.Join(delegated, runCgroupPrefix+requestID)
+	if err := os.Mkdir(dir, 0755); err != nil && !errors.Is(err, os.ErrExist) {
+		return "", fmt.Errorf("failed to create cgroup %s: %w", dir, err)
+	}
+	if err := writeCgroupFile(dir, "cgroup.subtree_control", strings.Join(controllers, " ")); err != nil {
+		_ = os.Remove(dir)
+		return "", fmt.Errorf("failed to enable the controllers of cgroup %s: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// createCgroup creates a cgroup for a step in the cgroup of the run and
+// sets the limits on it.
+func createCgroup(parent string, resources digraph.Resources) (string, error) {
+	dir, err := os.MkdirTemp(parent, "step-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a cgroup: %w", err)
+	}
+
+	limits := map[string]string{}
+	if resources.Memory > 0 {
+		limits["memory.max"] = strconv.FormatInt(resources.Memory, 10)
+	}
+	if resources.CPU > 0 {
+		limits["cpu.max"] = fmt.Sprintf("%d %d", int64(resources.CPU*cpuPeriod), cpuPeriod)
+	}
+	if resources.Pids > 0 {
+		limits["pids.max"] = strconv.Itoa(resources.Pids)
+	}
+	for file, value := range limits {
+		if err := writeCgroupFile(dir, file, value); err != nil {
+			_ = os.Remove(dir)
+			return "", fmt.Errorf("failed to set %s of cgroup %s: %w", file, dir, err)
+		}
+	}
+
+	return dir, nil
+}
+
+func writeCgroupFile(dir, file, value string) error {
+	return os.WriteFile(filepath.Join(dir, file), []byte(value), 0)
+}
+
+// prepare starts the command in the cgroup of the step. The rlimit of the
+// open files is set by running the command with prlimit of util-linux, so
+// that it is in effect before the command runs.
+func (l *resourceLimiter) prepare(cmd *exec.Cmd) error {
+	if l == nil {
+		return nil
+	}
+	if l.cgroupFD != nil {
+		cmd.SysProcAttr.UseCgroupFD = true
+		cmd.SysProcAttr.CgroupFD = int(l.cgroupFD.Fd())
+	}
+	if l.resources.Nofile > 0 {
+		prlimit, err := exec.LookPath("prlimit")
+		if err != nil {
+			return fmt.Errorf("prlimit is required to limit the open files: %w", err)
+		}
+		limit := fmt.Sprintf("--nofile=%d:%d", l.resources.Nofile, l.resources.Nofile)
+		cmd.Args = append([]string{prlimit, limit, "--", cmd.Path}, cmd.Args[1:]...)
+		cmd.Path = prlimit
+	}
+	return nil
+}
+
+// usage returns the resources used by the process. The usage of the cgroup
+// includes all the processes of the step.
+func (l *resourceLimiter) usage(state *os.ProcessState) ResourceUsage {
+	usage := usageFromProcessState(state)
+	if l == nil || l.cgroup == "" {
+		return usage
+	}
+
+	if data, err := os.ReadFile(filepath.Join(l.cgroup, "memory.peak")); err == nil {
+		if peak, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil {
+			usage.PeakRSS = peak
+		}
+	}
+	if data, err := os.ReadFile(filepath.Join(l.cgroup, "cpu.stat")); err == nil {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			key, value, _ := strings.Cut(scanner.Text(), " ")
+			usec, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "user_usec":
+				usage.UserTime = time.Duration(usec) * time.Microsecond
+			case "system_usec":
+				usage.SystemTime = time.Duration(usec) * time.Microsecond
+			}
+		}
+	}
+	return usage
+}
+
+// release removes the cgroup of the step.
+func (l *resourceLimiter) release(ctx context.Context) {
+	if l == nil || l.cgroup == "" {
+		return
+	}
+	_ = l.cgroupFD.Close()
+	if err := os.Remove(l.cgroup); err != nil {
+		logger.Warn(ctx, "Failed to remove the cgroup of the step", "cgroup", l.cgroup, "err", err)
+	}
+}
+
+// maxRSSBytes returns the maximum resident set size in bytes. It is in
+// kilobytes on Linux.
+func maxRSSBytes(rusage *syscall.Rusage) int64 {
+	return rusage.Maxrss * 1024
+}
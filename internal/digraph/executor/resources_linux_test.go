@@ -0,0 +1,129 @@
+package executor
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupRunCgroup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("NotDelegated", func(t *testing.T) {
+		steps := []digraph.Step{{Name: "a", Resources: &digraph.Resources{Memory: 64 << 20}}}
+		_, _, err := SetupRunCgroup(ctx, "", "req", steps)
+		require.Error(t, err)
+
+		// The number of open files is limited without a cgroup.
+		steps = []digraph.Step{{Name: "a", Resources: &digraph.Resources{Nofile: 64}}}
+		runCtx, release, err := SetupRunCgroup(ctx, "", "req", steps)
+		require.NoError(t, err)
+		release(runCtx)
+		require.Nil(t, runCtx.Value(runCgroupKey{}))
+
+		// The step cannot run without the cgroup of the run.
+		_, err = newResourceLimiter(ctx, &digraph.Resources{Pids: 16})
+		require.Error(t, err)
+	})
+	t.Run("RunCgroup", func(t *testing.T) {
+		// It changes the cgroups of the host, so it only runs on demand.
+		if os.Getenv("DAGU_TEST_CGROUP") == "" {
+			t.Skip("set DAGU_TEST_CGROUP to test the cgroups")
+		}
+		delegated := testCgroup(t)
+
+		steps := []digraph.Step{{Name: "a", Resources: &digraph.Resources{Pids: 16}}}
+		runCtx, release, err := SetupRunCgroup(ctx, delegated, "req", steps)
+		require.NoError(t, err)
+		run, ok := runCtx.Value(runCgroupKey{}).(string)
+		require.True(t, ok)
+		require.Equal(t, filepath.Join(delegated, runCgroupPrefix+"req"), run)
+
+		// The cgroup of the step is created in the cgroup of the run.
+		l, err := newResourceLimiter(runCtx, steps[0].Resources)
+		require.NoError(t, err)
+		require.Equal(t, run, filepath.Dir(l.cgroup))
+		pids, err := os.ReadFile(filepath.Join(l.cgroup, "pids.max"))
+		require.NoError(t, err)
+		require.Equal(t, "16", strings.TrimSpace(string(pids)))
+
+		l.release(runCtx)
+		release(runCtx)
+		require.NoDirExists(t, run)
+	})
+}
+
+// testCgroup creates a throwaway cgroup to delegate to dagu in the cgroup of
+// the test process. It skips the test if the cgroup cannot be created.
+func testCgroup(t *testing.T) string {
+	t.Helper()
+
+	data, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		t.Skip(err)
+	}
+	var current string
+	for _, line := range strings.Split(string(data), "\n") {
+		if path, ok := strings.CutPrefix(line, "0::"); ok {
+			current = filepath.Join(cgroupRoot, path)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(current, "cgroup.controllers")); current == "" || err != nil {
+		t.Skip(errNoCgroupV2)
+	}
+
+	dir, err := os.MkdirTemp(current, "dagu-test-")
+	if err != nil {
+		t.Skipf("failed to create a cgroup: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Remove(dir)
+	})
+	if err := writeCgroupFile(dir, "cgroup.subtree_control", "+pids"); err != nil {
+		t.Skipf("failed to enable the controllers of cgroup %s: %v", dir, err)
+	}
+	return dir
+}
+
+func TestResourceLimiter_Nofile(t *testing.T) {
+	if _, err := exec.LookPath("prlimit"); err != nil {
+		t.Skip(err)
+	}
+
+	// The limit is in effect when the command runs.
+	l := &resourceLimiter{resources: digraph.Resources{Nofile: 64}}
+	cmd := exec.Command("sh", "-c", "ulimit -Sn; ulimit -Hn")
+	cmd.SysProcAttr = &syscall.SysProcAttr{}
+	require.NoError(t, l.prepare(cmd))
+	out, err := cmd.Output()
+	require.NoError(t, err)
+	require.Equal(t, "64\n64\n", string(out))
+}
+
+func TestSetCredential(t *testing.T) {
+	u, err := user.Current()
+	require.NoError(t, err)
+	groupIDs, err := u.GroupIds()
+	require.NoError(t, err)
+
+	cmd := exec.Command("true")
+	cmd.SysProcAttr = &syscall.SysProcAttr{}
+	require.NoError(t, setCredential(cmd, &digraph.RunAs{User: u.Username}))
+
+	// The supplementary groups of the user are kept.
+	credential := cmd.SysProcAttr.Credential
+	require.Equal(t, u.Uid, strconv.FormatUint(uint64(credential.Uid), 10))
+	require.Len(t, credential.Groups, len(groupIDs))
+	for i, id := range groupIDs {
+		require.Equal(t, id, strconv.FormatUint(uint64(credential.Groups[i]), 10))
+	}
+}
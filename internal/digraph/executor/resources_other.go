@@ -0,0 +1,51 @@
+//go:build !linux
+
+package executor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+)
+
+// resourceLimiter does not enforce the limits of the resources as it
+// requires cgroups or prlimit which are only available on Linux.
+type resourceLimiter struct{}
+
+var errResourcesUnsupported = errors.New("the resource limits are only enforced on Linux")
+
+// SetupRunCgroup fails if any of the steps limits the resources as they
+// are only enforced on Linux.
+func SetupRunCgroup(ctx context.Context, _, _ string, steps []digraph.Step) (context.Context, func(context.Context), error) {
+	for _, step := range steps {
+		if step.Resources != nil {
+			return nil, nil, errResourcesUnsupported
+		}
+	}
+	return ctx, func(context.Context) {}, nil
+}
+
+func newResourceLimiter(_ context.Context, resources *digraph.Resources) (*resourceLimiter, error) {
+	if resources != nil {
+		return nil, errResourcesUnsupported
+	}
+	return nil, nil
+}
+
+func (*resourceLimiter) prepare(_ *exec.Cmd) error { return nil }
+
+func (*resourceLimiter) usage(state *os.ProcessState) ResourceUsage {
+	return usageFromProcessState(state)
+}
+
+func (*resourceLimiter) release(_ context.Context) {}
+
+// maxRSSBytes returns the maximum resident set size in bytes. It is in
+// bytes on macOS.
+func maxRSSBytes(rusage *syscall.Rusage) int64 {
+	return rusage.Maxrss
+}
@@ -193,4 +193,7 @@ func getPublicKeySigner(path string) (ssh.Signer, error) {
 
 func init() {
 	Register("ssh", newSSHExec)
+	RegisterValidator("ssh", func(config map[string]any) error {
+		return decodeStrict(config, new(sshExecConfigDefinition))
+	})
 }
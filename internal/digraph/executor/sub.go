@@ -10,12 +10,15 @@ import (
 	"sync"
 	"syscall"
 
+	"github.com/dagu-org/dagu/internal/cmdutil"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/dagu-org/dagu/internal/secrets"
 	"github.com/google/uuid"
 )
 
 var _ Executor = (*subWorkflow)(nil)
+var _ UsageReporter = (*subWorkflow)(nil)
 
 type subWorkflow struct {
 	subDAG    string
@@ -23,6 +26,7 @@ type subWorkflow struct {
 	lock      sync.Mutex
 	requestID string
 	writer    io.Writer
+	usage     ResourceUsage
 }
 
 var ErrWorkingDirNotExist = fmt.Errorf("working directory does not exist")
@@ -37,13 +41,15 @@ func newSubWorkflow(
 
 	stepContext := digraph.GetStepContext(ctx)
 
+	// The references to secrets in the parameters are resolved by the sub
+	// workflow so that the values are not passed in the command line.
 	config, err := digraph.EvalStringFields(stepContext, struct {
 		Name   string
 		Params string
 	}{
 		Name:   step.SubWorkflow.Name,
 		Params: step.SubWorkflow.Params,
-	})
+	}, cmdutil.WithoutSecrets())
 	if err != nil {
 		return nil, fmt.Errorf("failed to substitute string fields: %w", err)
 	}
@@ -78,6 +84,8 @@ func newSubWorkflow(
 	}
 	cmd.Dir = step.Dir
 	cmd.Env = append(cmd.Env, stepContext.AllEnvs()...)
+	// The sub workflow reads the secrets with the key of the parent.
+	cmd.Env = append(cmd.Env, secrets.KeyEnv()...)
 
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Setpgid: true,
@@ -93,21 +101,30 @@ func newSubWorkflow(
 
 func (e *subWorkflow) Run(ctx context.Context) error {
 	e.lock.Lock()
-	err := e.cmd.Start()
+	closeToken, err := cmdutil.MarkInternalInvocation(e.cmd)
+	if err == nil {
+		err = e.cmd.Start()
+		closeToken()
+	}
 	e.lock.Unlock()
 	if err != nil {
 		return err
 	}
+	stepContext := digraph.GetStepContext(ctx)
 	if err := e.cmd.Wait(); err != nil {
+		// The usage is recorded for the failed runs as well.
+		if result, resultErr := stepContext.GetResult(e.subDAG, e.requestID); resultErr == nil {
+			e.setUsage(result)
+		}
 		return err
 	}
 
 	// get results from the subworkflow
-	stepContext := digraph.GetStepContext(ctx)
 	result, err := stepContext.GetResult(e.subDAG, e.requestID)
 	if err != nil {
 		return fmt.Errorf("failed to collect result: %w", err)
 	}
+	e.setUsage(result)
 
 	jsonData, err := json.MarshalIndent(result, "", "  ")
 	if err != nil {
@@ -121,6 +138,20 @@ func (e *subWorkflow) Run(ctx context.Context) error {
 	return nil
 }
 
+// Usage implements UsageReporter. It is the usage of the steps of the
+// sub workflow.
+func (e *subWorkflow) Usage() ResourceUsage {
+	return e.usage
+}
+
+func (e *subWorkflow) setUsage(result *digraph.Status) {
+	e.usage = ResourceUsage{
+		PeakRSS:    result.PeakRSS,
+		UserTime:   result.UserTime,
+		SystemTime: result.SystemTime,
+	}
+}
+
 func (e *subWorkflow) SetStdout(out io.Writer) {
 	e.cmd.Stdout = out
 	e.writer = out
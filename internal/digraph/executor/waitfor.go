@@ -0,0 +1,145 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+)
+
+var _ Executor = (*waitFor)(nil)
+
+// ErrWaitForTimeout is returned when the DAG to wait for has not succeeded
+// within the timeout.
+var ErrWaitForTimeout = errors.New("timed out waiting for the DAG")
+
+// waitFor is an executor that waits until a run of another DAG on the date
+// has succeeded by polling the execution history.
+type waitFor struct {
+	config digraph.WaitFor
+	dag    *digraph.DAG
+	date   time.Time
+	stdout io.Writer
+	lock   sync.Mutex
+	cancel context.CancelFunc
+}
+
+func newWaitFor(ctx context.Context, step digraph.Step) (Executor, error) {
+	if step.WaitFor == nil {
+		return nil, fmt.Errorf("waitFor is not configured for the step %q", step.Name)
+	}
+
+	stepContext := digraph.GetStepContext(ctx)
+	name, err := stepContext.EvalString(step.WaitFor.DAG)
+	if err != nil {
+		return nil, fmt.Errorf("failed to substitute the DAG name: %w", err)
+	}
+
+	dag, err := stepContext.GetDAGByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the DAG %q to wait for: %w", name, err)
+	}
+
+	return &waitFor{
+		config: *step.WaitFor,
+		dag:    dag,
+		// The date is of the run, e.g. the scheduled time, so that it
+		// does not change with the start delays or while waiting over
+		// midnight.
+		date:   stepContext.LogicalTime().Add(step.WaitFor.Offset),
+		stdout: os.Stdout,
+	}, nil
+}
+
+func (e *waitFor) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if e.config.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, e.config.Timeout)
+		defer cancel()
+	}
+
+	e.lock.Lock()
+	e.cancel = cancel
+	e.lock.Unlock()
+
+	stepContext := digraph.GetStepContext(ctx)
+	date := e.date.Format("2006-01-02")
+
+	ticker := time.NewTicker(e.config.PokeInterval)
+	defer ticker.Stop()
+
+	for {
+		results, err := stepContext.GetResultsOn(e.dag.Location, e.date)
+		if err != nil {
+			return fmt.Errorf("failed to read the status of the DAG %q: %w", e.dag.Name, err)
+		}
+		for _, result := range results {
+			if result.Success {
+				return e.writeResult(stepContext, result)
+			}
+		}
+
+		latest := "no run"
+		if len(results) > 0 {
+			latest = results[0].StatusText
+		}
+		_, _ = fmt.Fprintf(e.stdout, "waiting for the DAG %s on %s to succeed (latest: %s)\n", e.dag.Name, date, latest)
+
+		select {
+		case <-ctx.Done():
+			if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ctx.Err()
+			}
+			err := fmt.Errorf("%w: %s on %s after %s", ErrWaitForTimeout, e.dag.Name, date, e.config.Timeout)
+			if e.config.SoftFail {
+				return fmt.Errorf("%w: %w", ErrSkipped, err)
+			}
+			return err
+		case <-ticker.C:
+		}
+	}
+}
+
+// writeResult writes the result of the run including the outputs so that
+// they can be used by the following steps.
+func (e *waitFor) writeResult(stepContext digraph.StepContext, result *digraph.Status) error {
+	if full, err := stepContext.GetResult(e.dag.Location, result.RequestID); err == nil {
+		full.RequestID, full.StatusText, full.StartedAt, full.Success = result.RequestID, result.StatusText, result.StartedAt, result.Success
+		result = full
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal the result: %w", err)
+	}
+	if _, err := e.stdout.Write(jsonData); err != nil {
+		return fmt.Errorf("failed to write the result: %w", err)
+	}
+	return nil
+}
+
+func (e *waitFor) SetStdout(out io.Writer) {
+	e.stdout = out
+}
+
+func (e *waitFor) SetStderr(_ io.Writer) {}
+
+func (e *waitFor) Kill(_ os.Signal) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.cancel != nil {
+		e.cancel()
+	}
+	return nil
+}
+
+func init() {
+	Register(digraph.ExecutorTypeWaitFor, newWaitFor)
+}
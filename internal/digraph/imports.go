@@ -0,0 +1,532 @@
+package digraph
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// Keys of the spec to reuse step templates. They are resolved before the
+// spec is decoded, so they are not part of the definition.
+const (
+	importsKey   = "imports"
+	includeKey   = "include" // alias of imports
+	templatesKey = "templates"
+	usesKey      = "uses"
+	withKey      = "with"
+)
+
+// TemplatesDir is the name of the directories holding the files that only
+// define templates to be imported. They are not DAGs, so the directories
+// are skipped when the DAGs are listed or scheduled.
+const TemplatesDir = "templates"
+
+// errors on resolving templates.
+var (
+	ErrImportCycle                = errors.New("import cycle")
+	ErrImportsMustBeStringOrArray = errors.New("imports must be a string or an array of strings")
+	ErrTemplatesMustBeMap         = errors.New("templates must be a map")
+	ErrTemplateMustBeMap          = errors.New("template must be a map")
+	ErrDuplicateTemplate          = errors.New("duplicate template")
+	ErrTemplateNotFound           = errors.New("template not found")
+	ErrUsesMustBeString           = errors.New("uses must be a string")
+	ErrWithMustBeMap              = errors.New("with must be a map")
+	ErrUnknownTemplateArg         = errors.New("unknown template argument")
+	ErrMissingTemplateArg         = errors.New("missing template argument")
+	ErrInvalidImportKey           = errors.New("imported file has invalid key")
+	ErrTemplateLibrary            = errors.New("file only defines templates and has no steps to run")
+)
+
+// SpecError is an error at a position of a spec file.
+type SpecError struct {
+	File   string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *SpecError) Error() string {
+	switch {
+	case e.File != "" && e.Line > 0:
+		return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+	case e.File != "":
+		return fmt.Sprintf("%s: %v", e.File, e.Err)
+	case e.Line > 0:
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	default:
+		return e.Err.Error()
+	}
+}
+
+func (e *SpecError) Unwrap() error {
+	return e.Err
+}
+
+// specSource is the YAML data of a spec and where it comes from.
+type specSource struct {
+	file string // path of the file, empty for data given directly
+	dir  string // directory to resolve relative imports
+	data []byte
+	root *yamlv3.Node
+}
+
+// errorAt returns an error at the position of the value at the path.
+// The path consists of map keys (string) and array indices (int).
+func (s *specSource) errorAt(err error, path ...any) error {
+	line, column := s.position(path...)
+	return &SpecError{File: s.file, Line: line, Column: column, Err: err}
+}
+
+// position returns the line and the column of the value at the path or the
+// closest parent found. It returns zeros if the data cannot be parsed.
+func (s *specSource) position(path ...any) (int, int) {
+	if s.root == nil {
+		var doc yamlv3.Node
+		if err := yamlv3.Unmarshal(s.data, &doc); err != nil || len(doc.Content) == 0 {
+			return 0, 0
+		}
+		s.root = doc.Content[0]
+	}
+
+	node, line, column := s.root, 0, 0
+	for _, key := range path {
+		var next *yamlv3.Node
+		switch key := key.(type) {
+		case string:
+			if node.Kind != yamlv3.MappingNode {
+				return line, column
+			}
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					line, column = node.Content[i].Line, node.Content[i].Column
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case int:
+			if node.Kind != yamlv3.SequenceNode || key >= len(node.Content) {
+				return line, column
+			}
+			next = node.Content[key]
+			line, column = next.Line, next.Column
+		}
+		if next == nil {
+			return line, column
+		}
+		node = next
+	}
+	return line, column
+}
+
+// stepTemplate is a reusable step definition.
+type stepTemplate struct {
+	name string
+	spec map[string]any
+	src  *specSource
+}
+
+// templateLibrary holds the templates collected from a spec and the files
+// it imports.
+type templateLibrary struct {
+	templates map[string]*stepTemplate
+	imported  map[string]bool
+}
+
+// resolveTemplates expands the steps using templates in the spec and
+// removes the keys to define templates from it. The templates are defined
+// in the spec itself or in the files it imports.
+func resolveTemplates(raw map[string]any, src *specSource) error {
+	if raw == nil {
+		return nil
+	}
+
+	lib := &templateLibrary{
+		templates: make(map[string]*stepTemplate),
+		imported:  make(map[string]bool),
+	}
+	var stack []string
+	if src.file != "" {
+		stack = append(stack, src.file)
+	}
+	if err := lib.collect(raw, src, stack); err != nil {
+		return err
+	}
+	templates := lib.templates
+	removeTemplateKeys(raw)
+
+	if steps, ok := raw["steps"]; ok {
+		expanded, err := expandSteps(steps, src, templates)
+		if err != nil {
+			return err
+		}
+		raw["steps"] = expanded
+	}
+
+	if handlerOn, ok := toStringMap(raw["handlerOn"]); ok {
+		for event, step := range handlerOn {
+			stepMap, ok := toStringMap(step)
+			if !ok {
+				continue
+			}
+			expanded, err := expandStep(stepMap, src, templates, "handlerOn", event)
+			if err != nil {
+				return err
+			}
+			handlerOn[event] = expanded
+		}
+		raw["handlerOn"] = handlerOn
+	}
+
+	return nil
+}
+
+// checkNotLibrary returns ErrTemplateLibrary if the spec defines or imports
+// templates but has no steps, i.e. it is a template library to be imported
+// rather than a DAG.
+func checkNotLibrary(raw map[string]any, src *specSource) error {
+	if _, ok := raw["steps"]; ok {
+		return nil
+	}
+	for _, key := range []string{templatesKey, importsKey, includeKey} {
+		if _, ok := raw[key]; ok {
+			return src.errorAt(ErrTemplateLibrary, key)
+		}
+	}
+	return nil
+}
+
+// removeTemplateKeys removes the keys used only to resolve templates.
+func removeTemplateKeys(raw map[string]any) {
+	delete(raw, importsKey)
+	delete(raw, includeKey)
+	delete(raw, templatesKey)
+}
+
+// collect collects the templates defined in the spec and the
+// files it imports recursively. The stack holds the files being imported
+// to detect cycles.
+func (lib *templateLibrary) collect(raw map[string]any, src *specSource, stack []string) error {
+	for _, key := range []string{importsKey, includeKey} {
+		value, ok := raw[key]
+		if !ok {
+			continue
+		}
+		files, err := importPaths(value)
+		if err != nil {
+			return src.errorAt(err, key)
+		}
+		for i, file := range files {
+			if err := lib.importFile(file, src, stack, key, i); err != nil {
+				return err
+			}
+		}
+	}
+
+	value, ok := raw[templatesKey]
+	if !ok || value == nil {
+		return nil
+	}
+	defs, ok := toStringMap(value)
+	if !ok {
+		return src.errorAt(ErrTemplatesMustBeMap, templatesKey)
+	}
+
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		spec, ok := toStringMap(defs[name])
+		if !ok {
+			return src.errorAt(fmt.Errorf("%w: %s", ErrTemplateMustBeMap, name), templatesKey, name)
+		}
+		if found, exists := lib.templates[name]; exists {
+			return src.errorAt(fmt.Errorf("%w: %s (also defined in %s)",
+				ErrDuplicateTemplate, name, found.src.displayName()), templatesKey, name)
+		}
+		lib.templates[name] = &stepTemplate{name: name, spec: spec, src: src}
+	}
+
+	return nil
+}
+
+// importFile collects the templates of an imported file. A file imported
+// more than once through different paths is collected only once.
+func (lib *templateLibrary) importFile(file string, src *specSource, stack []string, path ...any) error {
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(src.dir, file)
+	}
+	file = filepath.Clean(file)
+
+	for i, imported := range stack {
+		if imported == file {
+			cycle := append(append([]string{}, stack[i:]...), file)
+			return src.errorAt(fmt.Errorf("%w: %s", ErrImportCycle, strings.Join(cycle, " -> ")), path...)
+		}
+	}
+
+	if lib.imported[file] {
+		return nil
+	}
+	lib.imported[file] = true
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return src.errorAt(fmt.Errorf("failed to import %q: %w", file, err), path...)
+	}
+	raw, err := unmarshalData(data)
+	if err != nil {
+		return &SpecError{File: file, Err: err}
+	}
+
+	imported := &specSource{file: file, dir: filepath.Dir(file), data: data}
+	for key := range raw {
+		switch key {
+		case importsKey, includeKey, templatesKey:
+		default:
+			return imported.errorAt(fmt.Errorf("%w: %s", ErrInvalidImportKey, key), key)
+		}
+	}
+
+	return lib.collect(raw, imported, append(stack, file))
+}
+
+// importPaths returns the paths of the files to import.
+func importPaths(value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		var ret []string
+		for _, item := range v {
+			file, ok := item.(string)
+			if !ok {
+				return nil, ErrImportsMustBeStringOrArray
+			}
+			ret = append(ret, file)
+		}
+		return ret, nil
+	default:
+		return nil, ErrImportsMustBeStringOrArray
+	}
+}
+
+// expandSteps expands the steps in the array or map form.
+func expandSteps(steps any, src *specSource, templates map[string]*stepTemplate) (any, error) {
+	switch v := steps.(type) {
+	case []any:
+		ret := make([]any, len(v))
+		for i, step := range v {
+			stepMap, ok := toStringMap(step)
+			if !ok {
+				ret[i] = step
+				continue
+			}
+			expanded, err := expandStep(stepMap, src, templates, "steps", i)
+			if err != nil {
+				return nil, err
+			}
+			ret[i] = expanded
+		}
+		return ret, nil
+
+	case map[any]any:
+		ret := make(map[any]any, len(v))
+		for name, step := range v {
+			stepMap, ok := toStringMap(step)
+			if !ok {
+				ret[name] = step
+				continue
+			}
+			expanded, err := expandStep(stepMap, src, templates, "steps", fmt.Sprint(name))
+			if err != nil {
+				return nil, err
+			}
+			ret[name] = expanded
+		}
+		return ret, nil
+
+	default:
+		return steps, nil
+	}
+}
+
+// expandStep returns the step merged into the template it uses. The
+// fields of the step override the ones of the template.
+func expandStep(step map[string]any, src *specSource, templates map[string]*stepTemplate, path ...any) (map[string]any, error) {
+	uses, ok := step[usesKey]
+	if !ok {
+		return step, nil
+	}
+	usesPath := append(append([]any{}, path...), usesKey)
+	name, ok := uses.(string)
+	if !ok {
+		return nil, src.errorAt(ErrUsesMustBeString, usesPath...)
+	}
+	tmpl, ok := templates[name]
+	if !ok {
+		return nil, src.errorAt(fmt.Errorf("%w: %s", ErrTemplateNotFound, name), usesPath...)
+	}
+
+	args, err := templateArgs(step, tmpl, src, path)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]any, len(tmpl.spec)+len(step))
+	for key, value := range tmpl.spec {
+		if key == withKey {
+			continue
+		}
+		expanded, err := substituteArgs(value, args)
+		if err != nil {
+			return nil, tmpl.src.errorAt(err, templatesKey, tmpl.name, key)
+		}
+		ret[key] = expanded
+	}
+	for key, value := range step {
+		if key == usesKey || key == withKey {
+			continue
+		}
+		ret[key] = value
+	}
+	return ret, nil
+}
+
+// templateArgs returns the arguments given to the template merged into
+// its defaults. An argument without default value is required.
+func templateArgs(step map[string]any, tmpl *stepTemplate, src *specSource, path []any) (map[string]string, error) {
+	withPath := append(append([]any{}, path...), withKey)
+
+	var given map[string]any
+	if value, ok := step[withKey]; ok && value != nil {
+		if given, ok = toStringMap(value); !ok {
+			return nil, src.errorAt(ErrWithMustBeMap, withPath...)
+		}
+	}
+	var defaults map[string]any
+	if value, ok := tmpl.spec[withKey]; ok && value != nil {
+		if defaults, ok = toStringMap(value); !ok {
+			return nil, tmpl.src.errorAt(ErrWithMustBeMap, templatesKey, tmpl.name, withKey)
+		}
+	}
+
+	args := make(map[string]string, len(defaults))
+	for key, value := range given {
+		if _, ok := defaults[key]; !ok {
+			return nil, src.errorAt(fmt.Errorf("%w: %s (template %s)", ErrUnknownTemplateArg, key, tmpl.name),
+				append(withPath, key)...)
+		}
+		args[key] = fmt.Sprint(value)
+	}
+
+	var missing []string
+	for key, value := range defaults {
+		if _, ok := args[key]; ok {
+			continue
+		}
+		if value == nil {
+			missing = append(missing, key)
+			continue
+		}
+		args[key] = fmt.Sprint(value)
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, src.errorAt(fmt.Errorf("%w: %s (template %s)", ErrMissingTemplateArg, strings.Join(missing, ", "), tmpl.name),
+			append(path, usesKey)...)
+	}
+
+	return args, nil
+}
+
+// argRegex matches a reference to a template argument, e.g. ${{ with.name }}.
+var argRegex = regexp.MustCompile(`\$\{\{\s*with\.([A-Za-z0-9_-]+)\s*\}\}`)
+
+// substituteArgs returns a copy of the value with the references to the
+// template arguments replaced.
+func substituteArgs(value any, args map[string]string) (any, error) {
+	switch v := value.(type) {
+	case string:
+		var err error
+		ret := argRegex.ReplaceAllStringFunc(v, func(ref string) string {
+			name := argRegex.FindStringSubmatch(ref)[1]
+			arg, ok := args[name]
+			if !ok {
+				err = fmt.Errorf("%w: %s", ErrUnknownTemplateArg, name)
+				return ref
+			}
+			return arg
+		})
+		return ret, err
+
+	case []any:
+		ret := make([]any, len(v))
+		for i, item := range v {
+			expanded, err := substituteArgs(item, args)
+			if err != nil {
+				return nil, err
+			}
+			ret[i] = expanded
+		}
+		return ret, nil
+
+	case map[any]any:
+		ret := make(map[any]any, len(v))
+		for key, item := range v {
+			expanded, err := substituteArgs(item, args)
+			if err != nil {
+				return nil, err
+			}
+			ret[key] = expanded
+		}
+		return ret, nil
+
+	case map[string]any:
+		ret := make(map[string]any, len(v))
+		for key, item := range v {
+			expanded, err := substituteArgs(item, args)
+			if err != nil {
+				return nil, err
+			}
+			ret[key] = expanded
+		}
+		return ret, nil
+
+	default:
+		return value, nil
+	}
+}
+
+// toStringMap converts a map decoded from YAML to a map with string keys.
+func toStringMap(value any) (map[string]any, bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		return v, true
+	case map[any]any:
+		ret := make(map[string]any, len(v))
+		for key, item := range v {
+			ret[fmt.Sprint(key)] = item
+		}
+		return ret, true
+	default:
+		return nil, false
+	}
+}
+
+func (s *specSource) displayName() string {
+	if s.file == "" {
+		return "the spec"
+	}
+	return s.file
+}
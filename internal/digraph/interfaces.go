@@ -3,12 +3,19 @@
 
 package digraph
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // DBClient gets a result of a DAG execution.
 type DBClient interface {
 	GetDAG(ctx context.Context, name string) (*DAG, error)
 	GetStatus(ctx context.Context, name string, requestID string) (*Status, error)
+	// GetStatusesOn returns the statuses of the runs of the DAG scheduled,
+	// or started if not scheduled, on the date of the time in its location,
+	// newest first.
+	GetStatusesOn(ctx context.Context, name string, date time.Time) ([]*Status, error)
 }
 
 // Status is the result of a DAG execution.
@@ -19,4 +26,17 @@ type Status struct {
 	Params string `json:"params,omitempty"`
 	// Outputs is the outputs of the DAG execution.
 	Outputs map[string]string `json:"outputs,omitempty"`
+	// RequestID is the request ID of the DAG execution.
+	RequestID string `json:"requestId,omitempty"`
+	// StatusText is the status of the DAG execution, e.g. "finished".
+	StatusText string `json:"statusText,omitempty"`
+	// StartedAt is the time the DAG execution started.
+	StartedAt string `json:"startedAt,omitempty"`
+	// Success is true if the DAG execution finished successfully.
+	Success bool `json:"success,omitempty"`
+	// PeakRSS is the largest peak resident set size of the steps in bytes.
+	PeakRSS int64 `json:"-"`
+	// UserTime and SystemTime are the total CPU time of the steps.
+	UserTime   time.Duration `json:"-"`
+	SystemTime time.Duration `json:"-"`
 }
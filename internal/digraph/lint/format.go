@@ -0,0 +1,163 @@
+package lint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+)
+
+// Formats to write diagnostics in.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// ErrUnknownFormat is returned when the format to write diagnostics in is
+// not known.
+var ErrUnknownFormat = errors.New("unknown format")
+
+// Write writes the diagnostics in the format.
+func Write(w io.Writer, diagnostics []Diagnostic, format string) error {
+	switch format {
+	case FormatText:
+		return writeText(w, diagnostics)
+	case FormatJSON:
+		return writeJSON(w, diagnostics)
+	case FormatSARIF:
+		return writeSARIF(w, diagnostics)
+	default:
+		return fmt.Errorf("%w: %q (must be one of %s, %s, %s)", ErrUnknownFormat, format, FormatText, FormatJSON, FormatSARIF)
+	}
+}
+
+// writeText writes the diagnostics in the format of compilers, e.g.
+// "dag.yaml:3:5: error: unknown key "foo" in steps[0] (schema)".
+func writeText(w io.Writer, diagnostics []Diagnostic) error {
+	for _, d := range diagnostics {
+		position := d.File
+		if d.Line > 0 {
+			position = fmt.Sprintf("%s:%d:%d", d.File, d.Line, d.Column)
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s: %s (%s)\n", position, d.Severity, d.Message, d.Rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, diagnostics []Diagnostic) error {
+	if diagnostics == nil {
+		diagnostics = []Diagnostic{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(diagnostics)
+}
+
+// sarifLog is the subset of SARIF 2.1.0 to report the diagnostics to code
+// scanning tools.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+}
+
+func writeSARIF(w io.Writer, diagnostics []Diagnostic) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "dagu",
+			InformationURI: "https://github.com/dagu-org/dagu",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	rules := map[string]bool{}
+	for _, d := range diagnostics {
+		rules[d.Rule] = true
+
+		location := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(d.File)},
+		}
+		if d.Line > 0 {
+			location.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Column}
+		}
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    d.Rule,
+			Level:     string(d.Severity),
+			Message:   sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	var ids []string
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -0,0 +1,617 @@
+// Package lint checks DAG files for mistakes, including the ones which load
+// fine, and reports all of them with the positions in the file.
+package lint
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/executor"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/dagu-org/dagu/internal/secrets"
+	"github.com/dagu-org/dagu/schemas"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is the severity of a diagnostic.
+type Severity string
+
+// Severities of diagnostics.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	// SeverityNote is for the information which is not an issue, e.g. the
+	// file is a template library rather than a DAG.
+	SeverityNote Severity = "note"
+)
+
+// Rules of the checks.
+const (
+	RuleYAML              = "yaml"
+	RuleSchema            = "schema"
+	RuleLoad              = "load"
+	RuleTemplate          = "template"
+	RuleDepends           = "depends"
+	RuleExecutorConfig    = "executor-config"
+	RuleUnusedParam       = "unused-param"
+	RuleUndefinedVariable = "undefined-variable"
+)
+
+// Diagnostic is an issue found in a DAG file.
+type Diagnostic struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+	Severity Severity `json:"severity"`
+	Rule     string   `json:"rule"`
+	Message  string   `json:"message"`
+}
+
+// Options are the options to lint DAG files.
+type Options struct {
+	// BaseConfig is the path of the base DAG configuration file.
+	BaseConfig string
+	// DAGsDir is the directory of the DAG files.
+	DAGsDir string
+}
+
+// builtinVariables are the environment variables set for all steps.
+var builtinVariables = []string{
+	digraph.EnvKeyLogPath,
+	digraph.EnvKeySchedulerLogPath,
+	digraph.EnvKeyRequestID,
+	digraph.EnvKeyDAGName,
+	digraph.EnvKeyDAGStepName,
+	digraph.EnvKeyDAGStepLogPath,
+}
+
+var (
+	// variableRegexp matches the references to variables in the form of
+	// ${NAME}, including the ones to the fields of JSON values like
+	// ${NAME.field}.
+	variableRegexp = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)`)
+
+	// referenceRegexp matches the references to variables in the form of
+	// both $NAME and ${NAME}.
+	referenceRegexp = regexp.MustCompile(`\$\{?([A-Za-z_][A-Za-z0-9_]*)`)
+
+	identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// File lints the DAG file. It returns an error only if the file cannot be
+// read; the issues in the file are returned as the diagnostics.
+func File(ctx context.Context, file string, opts Options) ([]Diagnostic, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	l := &linter{file: file}
+	l.lint(ctx, data, opts)
+
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		a, b := l.diagnostics[i], l.diagnostics[j]
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+
+	return l.diagnostics, nil
+}
+
+type linter struct {
+	file        string
+	diagnostics []Diagnostic
+}
+
+func (l *linter) report(node *yaml.Node, severity Severity, rule, format string, args ...any) {
+	d := Diagnostic{
+		File:     l.file,
+		Severity: severity,
+		Rule:     rule,
+		Message:  message(fmt.Sprintf(format, args...)),
+	}
+	if node != nil {
+		d.Line, d.Column = node.Line, node.Column
+	}
+	l.diagnostics = append(l.diagnostics, d)
+}
+
+func (l *linter) lint(ctx context.Context, data []byte, opts Options) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&doc); err != nil && !errors.Is(err, io.EOF) {
+		l.reportYAMLError(err)
+		return
+	}
+	var root *yaml.Node
+	if len(doc.Content) > 0 {
+		root = resolveAlias(doc.Content[0])
+	}
+
+	// The top-level keys with the errors found by the schema.
+	schemaErrors := map[string]bool{}
+	unknownKeys := map[*yaml.Node]bool{}
+	if root != nil {
+		validator, err := newSchemaValidator(schemas.DAG)
+		if err != nil {
+			l.report(nil, SeverityError, RuleSchema, "%v", err)
+		} else {
+			for _, err := range validator.validate(root) {
+				l.report(err.node, SeverityError, RuleSchema, "%s", err.message)
+				key, _, _ := strings.Cut(err.path, ".")
+				key, _, _ = strings.Cut(key, "[")
+				schemaErrors[key] = true
+				if err.unknownKey {
+					unknownKeys[err.node] = true
+				}
+			}
+		}
+	}
+
+	// The variables defined in the environment of the linter are not
+	// reported as undefined. Loading the DAG sets the parameters in the
+	// environment, so it is taken before loading.
+	environ := map[string]bool{}
+	for _, env := range os.Environ() {
+		name, _, _ := strings.Cut(env, "=")
+		environ[name] = true
+	}
+
+	dag, err := digraph.Load(ctx, l.file,
+		digraph.WithoutEval(),
+		digraph.WithBaseConfig(opts.BaseConfig),
+		digraph.WithDAGsDir(opts.DAGsDir),
+	)
+	if err != nil && len(unknownKeys) > 0 {
+		// The unknown keys fail decoding the file. The DAG is loaded without
+		// them to report the other errors and to run the other checks.
+		dag, err = loadWithoutKeys(ctx, l.file, root, unknownKeys, opts)
+	}
+	if err != nil {
+		l.reportLoadError(root, err, schemaErrors)
+	}
+
+	if root == nil || root.Kind != yaml.MappingNode {
+		return
+	}
+
+	steps := rawSteps(root)
+	l.checkDepends(root, steps, dag)
+	if dag == nil {
+		return
+	}
+	l.checkExecutorConfigs(root, steps, dag)
+	l.checkParams(root, dag)
+	l.checkVariables(root, steps, dag, environ)
+}
+
+// message returns the message in a line, as the errors of decoding span
+// multiple lines.
+func message(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+var yamlLineRegexp = regexp.MustCompile(`line (\d+)`)
+
+func (l *linter) reportYAMLError(err error) {
+	d := Diagnostic{
+		File:     l.file,
+		Severity: SeverityError,
+		Rule:     RuleYAML,
+		Message:  message(strings.TrimPrefix(err.Error(), "yaml: ")),
+	}
+	if m := yamlLineRegexp.FindStringSubmatch(err.Error()); m != nil {
+		d.Line, _ = strconv.Atoi(m[1])
+		d.Column = 1
+	}
+	l.diagnostics = append(l.diagnostics, d)
+}
+
+// reportLoadError reports the errors on loading the DAG at the top-level key
+// of the field. The errors on the keys with the errors found by the schema
+// are not reported as they are the same issues.
+func (l *linter) reportLoadError(root *yaml.Node, err error, schemaErrors map[string]bool) {
+	var specErr *digraph.SpecError
+	if errors.Is(err, digraph.ErrTemplateLibrary) && errors.As(err, &specErr) {
+		// A template library is imported by DAGs and is not run by itself.
+		l.reportAt(specErr, SeverityNote, RuleTemplate, "the file is a template library and is not run as a DAG")
+		return
+	}
+
+	var errs *digraph.ErrorList
+	if !errors.As(err, &errs) {
+		// The file failed to be decoded.
+		switch {
+		case errors.As(err, &specErr):
+			l.reportAt(specErr, SeverityError, RuleLoad, "%v", specErr.Err)
+		case len(schemaErrors) == 0:
+			l.report(nil, SeverityError, RuleLoad, "%v", err)
+		}
+		return
+	}
+	for _, err := range *errs {
+		if errors.As(err, &specErr) {
+			l.reportAt(specErr, SeverityError, RuleLoad, "%v", specErr.Err)
+			continue
+		}
+		key := loadErrorKey(root, err)
+		if key != nil && schemaErrors[key.Value] {
+			continue
+		}
+		l.report(key, SeverityError, RuleLoad, "%v", err)
+	}
+}
+
+// reportAt reports the diagnostic at the position of the error in the spec.
+func (l *linter) reportAt(err *digraph.SpecError, severity Severity, rule, format string, args ...any) {
+	d := Diagnostic{
+		File:     l.file,
+		Line:     err.Line,
+		Column:   err.Column,
+		Severity: severity,
+		Rule:     rule,
+		Message:  message(fmt.Sprintf(format, args...)),
+	}
+	if err.File != "" && !sameFile(err.File, l.file) {
+		d.File = err.File
+	}
+	l.diagnostics = append(l.diagnostics, d)
+}
+
+func sameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return absA == absB
+}
+
+// loadErrorKey returns the top-level key of the innermost field of the error
+// which is found in the file.
+func loadErrorKey(root *yaml.Node, err error) *yaml.Node {
+	var found *yaml.Node
+	for {
+		var loadErr *digraph.LoadError
+		if !errors.As(err, &loadErr) {
+			return found
+		}
+		if key := mappingKey(root, loadErr.Field); key != nil {
+			found = key
+		}
+		err = loadErr.Err
+	}
+}
+
+// loadWithoutKeys loads the DAG from the file with the keys removed.
+func loadWithoutKeys(ctx context.Context, file string, root *yaml.Node, keys map[*yaml.Node]bool, opts Options) (*digraph.DAG, error) {
+	data, err := yaml.Marshal(withoutKeys(root, keys))
+	if err != nil {
+		return nil, err
+	}
+	return digraph.LoadYAML(ctx, data,
+		digraph.WithoutEval(),
+		digraph.WithBaseConfig(opts.BaseConfig),
+		digraph.WithImportDir(filepath.Dir(file)),
+	)
+}
+
+// withoutKeys returns a copy of the node with the keys removed.
+func withoutKeys(node *yaml.Node, keys map[*yaml.Node]bool) *yaml.Node {
+	ret := *node
+	ret.Content = nil
+	for i := 0; i < len(node.Content); i++ {
+		if node.Kind == yaml.MappingNode && i%2 == 0 && keys[node.Content[i]] {
+			i++
+			continue
+		}
+		ret.Content = append(ret.Content, withoutKeys(node.Content[i], keys))
+	}
+	return &ret
+}
+
+// rawStep is a step in the file.
+type rawStep struct {
+	name string
+	node *yaml.Node
+}
+
+// rawSteps returns the steps in the file in both the list and the map form.
+func rawSteps(root *yaml.Node) []rawStep {
+	var steps []rawStep
+
+	node := mappingValue(root, "steps")
+	if node == nil {
+		return nil
+	}
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, step := range node.Content {
+			step = resolveAlias(step)
+			if step.Kind != yaml.MappingNode {
+				continue
+			}
+			s := rawStep{node: step}
+			if name := mappingValue(step, "name"); name != nil {
+				s.name = name.Value
+			}
+			steps = append(steps, s)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			step := resolveAlias(node.Content[i+1])
+			if step.Kind != yaml.MappingNode {
+				continue
+			}
+			steps = append(steps, rawStep{name: node.Content[i].Value, node: step})
+		}
+	}
+
+	return steps
+}
+
+// checkDepends checks that the steps depend on the steps which exist and
+// that the dependencies have no cycle.
+func (l *linter) checkDepends(root *yaml.Node, steps []rawStep, dag *digraph.DAG) {
+	names := map[string]bool{}
+	for _, step := range steps {
+		names[step.name] = true
+	}
+	if dag != nil {
+		// The steps may be defined by templates.
+		for _, step := range dag.Steps {
+			names[step.Name] = true
+		}
+	}
+
+	unknown := false
+	for _, step := range steps {
+		depends := mappingValue(step.node, "depends")
+		if depends == nil {
+			continue
+		}
+		values := []*yaml.Node{depends}
+		if depends.Kind == yaml.SequenceNode {
+			values = depends.Content
+		}
+		for _, value := range values {
+			value = resolveAlias(value)
+			if value.Kind != yaml.ScalarNode || isNull(value) {
+				continue
+			}
+			if !names[value.Value] {
+				l.report(value, SeverityError, RuleDepends, "step %q depends on the unknown step %q", step.name, value.Value)
+				unknown = true
+			}
+		}
+	}
+
+	if dag != nil && !unknown {
+		if _, err := scheduler.NewExecutionGraph(dag.Steps...); err != nil {
+			l.report(mappingKey(root, "steps"), SeverityError, RuleDepends, "invalid dependencies: %v", err)
+		}
+	}
+}
+
+// checkExecutorConfigs checks the executor configs of the steps for the
+// executors which are not known and the keys which are not used.
+func (l *linter) checkExecutorConfigs(root *yaml.Node, steps []rawStep, dag *digraph.DAG) {
+	nodes := map[string]*yaml.Node{}
+	for _, step := range steps {
+		nodes[step.name] = step.node
+	}
+
+	check := func(step digraph.Step, node *yaml.Node) {
+		if err := executor.ValidateConfig(step); err != nil {
+			if key := mappingKey(node, "executor"); key != nil {
+				node = key
+			}
+			l.report(node, SeverityError, RuleExecutorConfig, "step %q has an invalid executor config: %v", step.Name, err)
+		}
+	}
+
+	for _, step := range dag.Steps {
+		node := nodes[step.Name]
+		if node == nil {
+			node = mappingKey(root, "steps")
+		}
+		check(step, node)
+	}
+
+	handlerOn := mappingValue(root, "handlerOn")
+	for _, h := range []struct {
+		key  string
+		step *digraph.Step
+	}{
+		{"success", dag.HandlerOn.Success},
+		{"failure", dag.HandlerOn.Failure},
+		{"cancel", dag.HandlerOn.Cancel},
+		{"exit", dag.HandlerOn.Exit},
+	} {
+		if h.step == nil {
+			continue
+		}
+		var node *yaml.Node
+		if handlerOn != nil {
+			node = mappingValue(handlerOn, h.key)
+		}
+		check(*h.step, node)
+	}
+}
+
+// checkParams checks that the named parameters are referenced in the file.
+func (l *linter) checkParams(root *yaml.Node, dag *digraph.DAG) {
+	params := mappingValue(root, "params")
+	if params == nil {
+		return
+	}
+
+	referenced := map[string]bool{}
+	walkScalars(root, func(_ string, node *yaml.Node) {
+		for _, m := range referenceRegexp.FindAllStringSubmatch(node.Value, -1) {
+			referenced[m[1]] = true
+		}
+	}, params)
+
+	for _, param := range dag.Params {
+		name, _, ok := strings.Cut(param, "=")
+		if !ok || !identifierRegexp.MatchString(name) || referenced[name] {
+			continue
+		}
+		node := findParam(params, name)
+		l.report(node, SeverityWarning, RuleUnusedParam, "param %q is not referenced in the DAG", name)
+	}
+}
+
+// checkVariables checks that the variables referenced as ${NAME} are
+// defined by the environment, the parameters, the outputs of the steps or
+// the environment of the linter.
+func (l *linter) checkVariables(root *yaml.Node, steps []rawStep, dag *digraph.DAG, environ map[string]bool) {
+	defined := map[string]bool{}
+	for name := range environ {
+		defined[name] = true
+	}
+	for _, name := range builtinVariables {
+		defined[name] = true
+	}
+	for _, env := range dag.Env {
+		name, _, _ := strings.Cut(env, "=")
+		defined[name] = true
+	}
+	for _, param := range dag.Params {
+		if name, _, ok := strings.Cut(param, "="); ok {
+			defined[name] = true
+		}
+	}
+	for _, name := range dotenvVariables(l.file, dag.Dotenv) {
+		defined[name] = true
+	}
+	for _, step := range dag.Steps {
+		if step.Output != "" {
+			defined[strings.TrimPrefix(step.Output, "$")] = true
+		}
+	}
+	for _, step := range steps {
+		if output := mappingValue(step.node, "output"); output != nil {
+			defined[strings.TrimPrefix(output.Value, "$")] = true
+		}
+	}
+
+	walkScalars(root, func(key string, node *yaml.Node) {
+		// The scripts are run by the shell, which may define variables.
+		if key == "script" {
+			return
+		}
+		// The references to secrets are resolved at runtime.
+		value := secrets.ReplaceReferences(node.Value, func(string) string { return "" })
+		for _, m := range variableRegexp.FindAllStringSubmatch(value, -1) {
+			if !defined[m[1]] {
+				l.report(node, SeverityWarning, RuleUndefinedVariable, "variable ${%s} is not defined", m[1])
+			}
+		}
+	}, nil)
+}
+
+// dotenvVariables returns the names of the variables in the dotenv files
+// which are found.
+func dotenvVariables(file string, dotenv []string) []string {
+	var names []string
+	resolver := fileutil.NewFileResolver([]string{file})
+	for _, path := range dotenv {
+		resolved, err := resolver.ResolveFilePath(path)
+		if err != nil {
+			continue
+		}
+		vars, err := godotenv.Read(resolved)
+		if err != nil {
+			continue
+		}
+		for name := range vars {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// walkScalars calls the function with the scalar values in the node and the
+// keys of them. The node to skip is not walked.
+func walkScalars(node *yaml.Node, fn func(key string, node *yaml.Node), skip *yaml.Node) {
+	var walk func(key string, node *yaml.Node)
+	walk = func(key string, node *yaml.Node) {
+		if node == skip && skip != nil {
+			return
+		}
+		switch node.Kind {
+		case yaml.ScalarNode:
+			fn(key, node)
+		case yaml.SequenceNode:
+			for _, child := range node.Content {
+				walk(key, child)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				walk(node.Content[i].Value, node.Content[i+1])
+			}
+		}
+	}
+	walk("", node)
+}
+
+// findParam returns the node defining the parameter, or the node of the
+// parameters if it is not found.
+func findParam(params *yaml.Node, name string) *yaml.Node {
+	found := params
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		for _, child := range node.Content {
+			if found != params {
+				return
+			}
+			if child.Kind == yaml.ScalarNode && (child.Value == name || strings.Contains(child.Value, name+"=")) {
+				found = child
+			}
+			walk(child)
+		}
+	}
+	walk(params)
+	return found
+}
+
+// mappingKey returns the key node of the key in the mapping node.
+func mappingKey(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+// mappingValue returns the value node of the key in the mapping node.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveAlias(node.Content[i+1])
+		}
+	}
+	return nil
+}
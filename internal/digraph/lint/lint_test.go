@@ -0,0 +1,161 @@
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/test"
+	"github.com/dagu-org/dagu/schemas"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func testFile(t *testing.T, name string) []Diagnostic {
+	t.Helper()
+
+	diagnostics, err := File(context.Background(), test.TestdataPath(t, filepath.Join("lint", name)), Options{})
+	require.NoError(t, err)
+	return diagnostics
+}
+
+// position is the position and the rule of a diagnostic.
+type position struct {
+	line, column int
+	rule         string
+}
+
+func positions(diagnostics []Diagnostic) []position {
+	var ret []position
+	for _, d := range diagnostics {
+		ret = append(ret, position{line: d.Line, column: d.Column, rule: d.Rule})
+	}
+	return ret
+}
+
+func TestFile(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		require.Empty(t, testFile(t, "valid.yaml"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		diagnostics := testFile(t, "invalid.yaml")
+
+		require.Equal(t, []position{
+			{3, 5, RuleUnusedParam},
+			{4, 1, RuleSchema},
+			{7, 14, RuleUndefinedVariable},
+			{10, 5, RuleExecutorConfig},
+			{18, 9, RuleDepends},
+		}, positions(diagnostics))
+
+		require.Equal(t, SeverityWarning, diagnostics[0].Severity)
+		require.Contains(t, diagnostics[0].Message, `"UNUSED"`)
+		require.Equal(t, SeverityError, diagnostics[1].Severity)
+		require.Contains(t, diagnostics[1].Message, `unknown key "schedul"`)
+		require.Contains(t, diagnostics[2].Message, "${UNDEFINED}")
+		require.Contains(t, diagnostics[3].Message, "timeot")
+		require.Contains(t, diagnostics[4].Message, `unknown step "gret"`)
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		require.Equal(t, []position{{1, 1, RuleDepends}}, positions(testFile(t, "cycle.yaml")))
+	})
+	t.Run("LoadError", func(t *testing.T) {
+		diagnostics := testFile(t, "load_error.yaml")
+		require.Equal(t, []position{{1, 1, RuleLoad}}, positions(diagnostics))
+		require.Contains(t, diagnostics[0].Message, "retryPolicy")
+	})
+	t.Run("TemplateError", func(t *testing.T) {
+		diagnostics := testFile(t, "template_error.yaml")
+		require.Equal(t, []position{{6, 5, RuleLoad}}, positions(diagnostics))
+		require.Equal(t, "template not found: missing", diagnostics[0].Message)
+	})
+	t.Run("TemplateLibrary", func(t *testing.T) {
+		diagnostics := testFile(t, "template_library.yaml")
+		require.Equal(t, []position{{1, 1, RuleTemplate}}, positions(diagnostics))
+		require.Equal(t, SeverityNote, diagnostics[0].Severity)
+	})
+	t.Run("InvalidYAML", func(t *testing.T) {
+		diagnostics := testFile(t, "invalid_yaml.yaml")
+		require.Len(t, diagnostics, 1)
+		require.Equal(t, RuleYAML, diagnostics[0].Rule)
+		require.Positive(t, diagnostics[0].Line)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := File(context.Background(), test.TestdataPath(t, filepath.Join("lint", "not_found.yaml")), Options{})
+		require.Error(t, err)
+	})
+}
+
+func TestWrite(t *testing.T) {
+	diagnostics := []Diagnostic{
+		{File: "dag.yaml", Line: 4, Column: 1, Severity: SeverityError, Rule: RuleSchema, Message: `unknown key "schedul" in the DAG`},
+		{File: "dag.yaml", Severity: SeverityError, Rule: RuleLoad, Message: "import cycle"},
+	}
+
+	t.Run("Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, diagnostics, FormatText))
+		require.Equal(t, `dag.yaml:4:1: error: unknown key "schedul" in the DAG (schema)
+dag.yaml: error: import cycle (load)
+`, buf.String())
+	})
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, diagnostics, FormatJSON))
+
+		var got []Diagnostic
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.Equal(t, diagnostics, got)
+	})
+	t.Run("EmptyJSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, nil, FormatJSON))
+		require.Equal(t, "[]\n", buf.String())
+	})
+	t.Run("SARIF", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, diagnostics, FormatSARIF))
+
+		var got sarifLog
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.Equal(t, "2.1.0", got.Version)
+		require.Len(t, got.Runs, 1)
+		require.Equal(t, []sarifRule{{ID: RuleLoad}, {ID: RuleSchema}}, got.Runs[0].Tool.Driver.Rules)
+
+		results := got.Runs[0].Results
+		require.Len(t, results, 2)
+		require.Equal(t, RuleSchema, results[0].RuleID)
+		require.Equal(t, "error", results[0].Level)
+		require.Equal(t, &sarifRegion{StartLine: 4, StartColumn: 1}, results[0].Locations[0].PhysicalLocation.Region)
+		require.Nil(t, results[1].Locations[0].PhysicalLocation.Region)
+	})
+	t.Run("UnknownFormat", func(t *testing.T) {
+		err := Write(&bytes.Buffer{}, diagnostics, "xml")
+		require.ErrorIs(t, err, ErrUnknownFormat)
+	})
+}
+
+func TestSchemaAcceptsValidDAGs(t *testing.T) {
+	validator, err := newSchemaValidator(schemas.DAG)
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(test.TestdataPath(t, filepath.Join("digraph", "valid_*.yaml")))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			require.NoError(t, err)
+
+			var doc yaml.Node
+			require.NoError(t, yaml.Unmarshal(data, &doc))
+			for _, err := range validator.validate(doc.Content[0]) {
+				t.Errorf("%d:%d: %s", err.node.Line, err.node.Column, err.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schema is the subset of JSON schema used by the schema of the DAG files.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *additional        `json:"additionalProperties"`
+	Items                *schema            `json:"items"`
+	OneOf                []*schema          `json:"oneOf"`
+	Required             []string           `json:"required"`
+	Enum                 []any              `json:"enum"`
+	Pattern              string             `json:"pattern"`
+	Minimum              *float64           `json:"minimum"`
+	Definitions          map[string]*schema `json:"definitions"`
+
+	pattern *regexp.Regexp
+}
+
+// additional is the value of additionalProperties, either a boolean or a
+// schema.
+type additional struct {
+	allowed bool
+	schema  *schema
+}
+
+func (a *additional) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &a.allowed); err == nil {
+		return nil
+	}
+	a.allowed = true
+	return json.Unmarshal(data, &a.schema)
+}
+
+// schemaError is a violation of the schema by a node.
+type schemaError struct {
+	node    *yaml.Node
+	path    string
+	message string
+	// unknownKey is true if the node is a key not allowed by the schema.
+	unknownKey bool
+}
+
+// schemaValidator validates the nodes of a YAML document against a schema.
+type schemaValidator struct {
+	root *schema
+}
+
+func newSchemaValidator(data []byte) (*schemaValidator, error) {
+	root := new(schema)
+	if err := json.Unmarshal(data, root); err != nil {
+		return nil, fmt.Errorf("failed to parse the schema: %w", err)
+	}
+	return &schemaValidator{root: root}, nil
+}
+
+func (v *schemaValidator) validate(node *yaml.Node) []schemaError {
+	return v.validateNode(node, v.root, "")
+}
+
+func (v *schemaValidator) resolve(s *schema) (*schema, error) {
+	for s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/definitions/")
+		if !ok || v.root.Definitions[name] == nil {
+			return nil, fmt.Errorf("unsupported reference %q in the schema", s.Ref)
+		}
+		s = v.root.Definitions[name]
+	}
+	return s, nil
+}
+
+func (v *schemaValidator) validateNode(node *yaml.Node, s *schema, path string) []schemaError {
+	node = resolveAlias(node)
+
+	s, err := v.resolve(s)
+	if err != nil {
+		return []schemaError{{node: node, path: path, message: err.Error()}}
+	}
+
+	// An empty value is accepted for any field, as it is decoded as the
+	// zero value.
+	if isNull(node) {
+		return nil
+	}
+
+	if len(s.OneOf) > 0 {
+		return v.validateOneOf(node, s, path)
+	}
+
+	if s.Type != "" && !matchType(node, s.Type) {
+		return []schemaError{{node: node, path: path, message: fmt.Sprintf("%s must be %s, got %s", displayPath(path), article(s.Type), nodeType(node))}}
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		return v.validateMapping(node, s, path)
+	case yaml.SequenceNode:
+		var errs []schemaError
+		if s.Items != nil {
+			for i, item := range node.Content {
+				errs = append(errs, v.validateNode(item, s.Items, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+		return errs
+	case yaml.ScalarNode:
+		return v.validateScalar(node, s, path)
+	}
+
+	return nil
+}
+
+// validateOneOf validates the node against the schemas of oneOf. When the
+// node matches none of them, the errors of the schema of the same type as
+// the node are reported as they are the most specific.
+func (v *schemaValidator) validateOneOf(node *yaml.Node, s *schema, path string) []schemaError {
+	var (
+		types   []string
+		closest []schemaError
+		found   bool
+	)
+	for _, option := range s.OneOf {
+		option, err := v.resolve(option)
+		if err != nil {
+			return []schemaError{{node: node, path: path, message: err.Error()}}
+		}
+		errs := v.validateNode(node, option, path)
+		if len(errs) == 0 {
+			return nil
+		}
+		if option.Type != "" {
+			types = append(types, option.Type)
+		}
+		if !found && (option.Type == "" || matchType(node, option.Type)) {
+			closest, found = errs, true
+		}
+	}
+	if found {
+		return closest
+	}
+	return []schemaError{{node: node, path: path, message: fmt.Sprintf("%s must be %s, got %s", displayPath(path), strings.Join(uniqueArticles(types), " or "), nodeType(node))}}
+}
+
+func (v *schemaValidator) validateMapping(node *yaml.Node, s *schema, path string) []schemaError {
+	var errs []schemaError
+
+	keys := map[string]bool{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		keys[key.Value] = true
+
+		// The merge key (<<) of YAML is resolved by the decoder.
+		if key.Tag == "!!merge" {
+			continue
+		}
+
+		keyPath := joinPath(path, key.Value)
+		if prop, ok := s.Properties[key.Value]; ok {
+			errs = append(errs, v.validateNode(value, prop, keyPath)...)
+			continue
+		}
+		switch {
+		case s.AdditionalProperties == nil:
+		case s.AdditionalProperties.schema != nil:
+			errs = append(errs, v.validateNode(value, s.AdditionalProperties.schema, keyPath)...)
+		case !s.AdditionalProperties.allowed:
+			errs = append(errs, schemaError{node: key, path: keyPath, message: fmt.Sprintf("unknown key %q in %s", key.Value, displayPath(path)), unknownKey: true})
+		}
+	}
+
+	for _, required := range s.Required {
+		if !keys[required] && !hasMergeKey(node) {
+			errs = append(errs, schemaError{node: node, path: path, message: fmt.Sprintf("%s is required", displayPath(joinPath(path, required)))})
+		}
+	}
+
+	return errs
+}
+
+func (v *schemaValidator) validateScalar(node *yaml.Node, s *schema, path string) []schemaError {
+	if len(s.Enum) > 0 {
+		var values []string
+		for _, e := range s.Enum {
+			values = append(values, fmt.Sprint(e))
+		}
+		found := false
+		for _, value := range values {
+			if value == node.Value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return []schemaError{{node: node, path: path, message: fmt.Sprintf("%s must be one of %s, got %q", displayPath(path), strings.Join(values, ", "), node.Value)}}
+		}
+	}
+
+	if s.Pattern != "" {
+		if s.pattern == nil {
+			re, err := regexp.Compile(s.Pattern)
+			if err != nil {
+				return []schemaError{{node: node, path: path, message: fmt.Sprintf("invalid pattern %q in the schema: %v", s.Pattern, err)}}
+			}
+			s.pattern = re
+		}
+		if !s.pattern.MatchString(node.Value) {
+			return []schemaError{{node: node, path: path, message: fmt.Sprintf("%s does not match the pattern %s: %q", displayPath(path), s.Pattern, node.Value)}}
+		}
+	}
+
+	if s.Minimum != nil {
+		if n, err := strconv.ParseFloat(node.Value, 64); err == nil && n < *s.Minimum {
+			return []schemaError{{node: node, path: path, message: fmt.Sprintf("%s must be at least %v, got %s", displayPath(path), *s.Minimum, node.Value)}}
+		}
+	}
+
+	return nil
+}
+
+func matchType(node *yaml.Node, typ string) bool {
+	switch typ {
+	case "object":
+		return node.Kind == yaml.MappingNode
+	case "array":
+		return node.Kind == yaml.SequenceNode
+	case "string":
+		return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!str"
+	case "integer":
+		return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!int"
+	case "number":
+		return node.Kind == yaml.ScalarNode && (node.ShortTag() == "!!int" || node.ShortTag() == "!!float")
+	case "boolean":
+		return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!bool"
+	case "null":
+		return isNull(node)
+	}
+	return true
+}
+
+func nodeType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "an object"
+	case yaml.SequenceNode:
+		return "an array"
+	}
+	switch node.ShortTag() {
+	case "!!int":
+		return "an integer"
+	case "!!float":
+		return "a number"
+	case "!!bool":
+		return "a boolean"
+	}
+	return "a string"
+}
+
+func article(typ string) string {
+	switch typ {
+	case "object", "array", "integer":
+		return "an " + typ
+	}
+	return "a " + typ
+}
+
+func uniqueArticles(types []string) []string {
+	var ret []string
+	seen := map[string]bool{}
+	for _, typ := range types {
+		if !seen[typ] {
+			seen[typ] = true
+			ret = append(ret, article(typ))
+		}
+	}
+	return ret
+}
+
+func isNull(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null"
+}
+
+func hasMergeKey(node *yaml.Node) bool {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Tag == "!!merge" {
+			return true
+		}
+	}
+	return false
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func displayPath(path string) string {
+	if path == "" {
+		return "the DAG"
+	}
+	return path
+}
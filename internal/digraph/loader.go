@@ -25,6 +25,8 @@ type LoadOptions struct {
 	paramsList   []string // List of parameters to override default parameters in the DAG.
 	noEval       bool     // Flag to disable evaluation of dynamic fields.
 	onlyMetadata bool     // Flag to load only metadata without full DAG details.
+	importDir    string   // Directory to resolve relative imports in YAML data.
+	dagsDir      string   // DAGs directory to determine the namespace of the DAG.
 }
 
 // LoadOption is a function type for setting LoadOptions.
@@ -65,6 +67,22 @@ func OnlyMetadata() LoadOption {
 	}
 }
 
+// WithImportDir sets the directory to resolve relative imports when the DAG
+// is loaded from YAML data instead of a file.
+func WithImportDir(dir string) LoadOption {
+	return func(o *LoadOptions) {
+		o.importDir = dir
+	}
+}
+
+// WithDAGsDir sets the DAGs directory. The directory of the DAG file
+// relative to it is set as the namespace of the DAG.
+func WithDAGsDir(dir string) LoadOption {
+	return func(o *LoadOptions) {
+		o.dagsDir = dir
+	}
+}
+
 // Load loads the DAG from the given file with the specified options.
 func Load(ctx context.Context, dag string, opts ...LoadOption) (*DAG, error) {
 	var options LoadOptions
@@ -79,6 +97,7 @@ func Load(ctx context.Context, dag string, opts ...LoadOption) (*DAG, error) {
 			ParametersList: options.paramsList,
 			OnlyMetadata:   options.onlyMetadata,
 			NoEval:         options.noEval,
+			DAGsDir:        options.dagsDir,
 		},
 	}
 	return loadDAG(buildContext, dag)
@@ -96,17 +115,14 @@ func LoadYAML(ctx context.Context, data []byte, opts ...LoadOption) (*DAG, error
 		ParametersList: options.paramsList,
 		OnlyMetadata:   options.onlyMetadata,
 		NoEval:         options.noEval,
+		ImportDir:      options.importDir,
+		DAGsDir:        options.dagsDir,
 	})
 }
 
 // LoadYAMLWithOpts loads the DAG configuration from YAML data.
 func LoadYAMLWithOpts(ctx context.Context, data []byte, opts BuildOpts) (*DAG, error) {
-	raw, err := unmarshalData(data)
-	if err != nil {
-		return nil, err
-	}
-
-	def, err := decode(raw)
+	def, err := decodeSpec(&specSource{dir: opts.ImportDir, data: data}, opts.OnlyMetadata)
 	if err != nil {
 		return nil, err
 	}
@@ -122,14 +138,14 @@ func LoadBaseConfig(ctx BuildContext, file string) (*DAG, error) {
 		return nil, nil
 	}
 
-	// Load the raw data from the file.
-	raw, err := readFile(file)
+	// Load the spec from the file.
+	src, err := readFile(file)
 	if err != nil {
 		return nil, err
 	}
 
-	// Decode the raw data into a config definition.
-	def, err := decode(raw)
+	// Decode the spec into a config definition.
+	def, err := decodeSpec(src, false)
 	if err != nil {
 		return nil, err
 	}
@@ -152,12 +168,12 @@ func loadDAG(ctx BuildContext, dag string) (*DAG, error) {
 		return nil, err
 	}
 
-	raw, err := readFile(filePath)
+	src, err := readFile(filePath)
 	if err != nil {
 		return nil, err
 	}
 
-	spec, err := decode(raw)
+	spec, err := decodeSpec(src, ctx.opts.OnlyMetadata)
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +194,8 @@ func loadDAG(ctx BuildContext, dag string) (*DAG, error) {
 		dest.Name = defaultName(filePath)
 	}
 
+	dest.Namespace = Namespace(ctx.opts.DAGsDir, filePath)
+
 	// Set defaults
 	dest.setup()
 
@@ -246,14 +264,62 @@ func (*mergeTransformer) Transformer(
 	return nil
 }
 
-// readFile reads the contents of the file into a map.
-func readFile(file string) (cfg map[string]any, err error) {
+// readFile reads the spec from the file.
+func readFile(file string) (*specSource, error) {
 	data, err := os.ReadFile(file)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file %q: %v", file, err)
 	}
 
-	return unmarshalData(data)
+	return &specSource{file: file, dir: filepath.Dir(file), data: data}, nil
+}
+
+// decodeSpec decodes the spec into a definition after expanding the steps
+// using templates. The templates are not resolved for metadata.
+func decodeSpec(src *specSource, onlyMetadata bool) (*definition, error) {
+	raw, err := unmarshalData(src.data)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNotLibrary(raw, src); err != nil {
+		return nil, err
+	}
+
+	if onlyMetadata {
+		removeTemplateKeys(raw)
+	} else if err := resolveTemplates(raw, src); err != nil {
+		return nil, err
+	}
+
+	return decode(raw)
+}
+
+// Expand returns the spec of the DAG file with the steps using templates
+// expanded and the templates removed.
+func Expand(file string) ([]byte, error) {
+	filePath, err := resolveYamlFilePath(file)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := readFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := unmarshalData(src.data)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkNotLibrary(raw, src); err != nil {
+		return nil, err
+	}
+
+	if err := resolveTemplates(raw, src); err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(raw)
 }
 
 // unmarshalData unmarshals the data into a map.
@@ -142,3 +142,128 @@ steps:
 		require.Error(t, err)
 	})
 }
+
+func TestLoadWithTemplates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("UsesTemplates", func(t *testing.T) {
+		t.Parallel()
+
+		testDAG := test.TestdataPath(t, filepath.Join("digraph", "uses_template.yaml"))
+		dag, err := digraph.Load(context.Background(), testDAG)
+		require.NoError(t, err)
+		require.Len(t, dag.Steps, 3)
+
+		// Local template with the default argument
+		assert.Equal(t, "echo hello world", dag.Steps[0].CmdWithArgs)
+
+		// Imported template with the given and default arguments
+		notify := dag.Steps[1]
+		assert.Equal(t, `echo "[general] done"`, notify.CmdWithArgs)
+		assert.Equal(t, 2, notify.RetryPolicy.Limit)
+		assert.Equal(t, []string{"greet"}, notify.Depends)
+
+		// Template imported by the imported file, overridden by the step
+		assert.Equal(t, "rm -rf /var/tmp/cache", dag.Steps[2].CmdWithArgs)
+
+		require.NotNil(t, dag.HandlerOn.Failure)
+		assert.Equal(t, `echo "[alerts] failed"`, dag.HandlerOn.Failure.CmdWithArgs)
+	})
+	t.Run("Expand", func(t *testing.T) {
+		t.Parallel()
+
+		testDAG := test.TestdataPath(t, filepath.Join("digraph", "uses_template.yaml"))
+		spec, err := digraph.Expand(testDAG)
+		require.NoError(t, err)
+		require.Contains(t, string(spec), `command: echo "[general] done"`)
+		require.NotContains(t, string(spec), "uses:")
+		require.NotContains(t, string(spec), "imports:")
+
+		// The expanded spec loads the same DAG.
+		dag, err := digraph.LoadYAML(context.Background(), spec)
+		require.NoError(t, err)
+		require.Len(t, dag.Steps, 3)
+	})
+	t.Run("ImportCycle", func(t *testing.T) {
+		t.Parallel()
+
+		testDAG := test.TestdataPath(t, filepath.Join("digraph", "import_cycle.yaml"))
+		_, err := digraph.Load(context.Background(), testDAG)
+		require.ErrorIs(t, err, digraph.ErrImportCycle)
+		require.Contains(t, err.Error(), "cycle_b.yaml:1:")
+		require.Contains(t, err.Error(), "cycle_a.yaml -> ")
+	})
+	t.Run("MetadataOnly", func(t *testing.T) {
+		t.Parallel()
+
+		testDAG := test.TestdataPath(t, filepath.Join("digraph", "import_cycle.yaml"))
+		dag, err := digraph.Load(context.Background(), testDAG, digraph.OnlyMetadata())
+		require.NoError(t, err)
+		require.Equal(t, "import_cycle", dag.Name)
+	})
+	t.Run("TemplateLibrary", func(t *testing.T) {
+		t.Parallel()
+
+		// A file defining only templates is not a DAG.
+		library := test.TestdataPath(t, filepath.Join("digraph", "templates", "common.yaml"))
+		_, err := digraph.Load(context.Background(), library)
+		require.ErrorIs(t, err, digraph.ErrTemplateLibrary)
+		require.Contains(t, err.Error(), "common.yaml:")
+
+		_, err = digraph.Load(context.Background(), library, digraph.OnlyMetadata())
+		require.ErrorIs(t, err, digraph.ErrTemplateLibrary)
+	})
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		const templates = `
+templates:
+  greet:
+    with:
+      name:
+    command: echo hello ${{ with.name }}
+steps:
+`
+		tests := []struct {
+			name  string
+			steps string
+			err   error
+			line  string
+		}{
+			{
+				name:  "TemplateNotFound",
+				steps: "  - name: a\n    uses: unknown\n",
+				err:   digraph.ErrTemplateNotFound,
+				line:  "line 9:",
+			},
+			{
+				name:  "MissingArgument",
+				steps: "  - name: a\n    command: echo a\n  - name: b\n    uses: greet\n",
+				err:   digraph.ErrMissingTemplateArg,
+				line:  "line 11:",
+			},
+			{
+				name:  "UnknownArgument",
+				steps: "  - name: a\n    uses: greet\n    with:\n      name: a\n      age: 1\n",
+				err:   digraph.ErrUnknownTemplateArg,
+				line:  "line 12:",
+			},
+			{
+				name:  "ImportNotFound",
+				steps: "  - name: a\n    command: echo a\nimports: not_found.yaml\n",
+				line:  "line 10:",
+			},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := digraph.LoadYAML(context.Background(), []byte(templates+tc.steps),
+					digraph.WithImportDir(t.TempDir()))
+				require.Error(t, err)
+				if tc.err != nil {
+					require.ErrorIs(t, err, tc.err)
+				}
+				require.Contains(t, err.Error(), tc.line)
+			})
+		}
+	})
+}
@@ -117,7 +117,11 @@ func parseParams(ctx BuildContext, value any, params *[]paramPair, envs *[]strin
 
 	for index, paramPair := range paramPairs {
 		if !ctx.opts.NoEval {
-			paramPair.Value = os.ExpandEnv(paramPair.Value)
+			// The references to secrets are kept to be resolved at runtime.
+			paramPair.Value, err = cmdutil.EvalString(ctx.ctx, paramPair.Value, cmdutil.WithoutSubstitute())
+			if err != nil {
+				return wrapError("params", paramPair.Value, fmt.Errorf("%w: %s", ErrInvalidParamValue, err))
+			}
 		}
 
 		*params = append(*params, paramPair)
@@ -267,7 +271,11 @@ func parseStringParams(ctx BuildContext, input string) ([]paramPair, error) {
 					value,
 					func(match string) string {
 						cmdStr := strings.Trim(match, "`")
-						cmdStr = os.ExpandEnv(cmdStr)
+						cmdStr, err := cmdutil.EvalString(ctx.ctx, cmdStr, cmdutil.WithoutSubstitute())
+						if err != nil {
+							cmdErr = err
+							return match
+						}
 						cmdOut, err := exec.Command("sh", "-c", cmdStr).Output()
 						if err != nil {
 							cmdErr = err
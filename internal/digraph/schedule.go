@@ -2,6 +2,7 @@ package digraph
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/robfig/cron/v3"
 )
@@ -11,12 +12,17 @@ var cronParser = cron.NewParser(
 )
 
 // buildScheduler parses the schedule values and returns a list of schedules.
-// each schedule is parsed as a cron expression.
-func buildScheduler(values []string) ([]Schedule, error) {
+// each schedule is parsed as a cron expression. The expressions without
+// CRON_TZ are evaluated in the timezone if it is not empty.
+func buildScheduler(values []string, timezone string) ([]Schedule, error) {
 	var ret []Schedule
 
 	for _, v := range values {
-		parsed, err := cronParser.Parse(v)
+		expr := v
+		if timezone != "" && !hasTimezonePrefix(v) {
+			expr = "CRON_TZ=" + timezone + " " + v
+		}
+		parsed, err := cronParser.Parse(expr)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", ErrInvalidSchedule, err)
 		}
@@ -26,6 +32,13 @@ func buildScheduler(values []string) ([]Schedule, error) {
 	return ret, nil
 }
 
+// hasTimezonePrefix reports whether the cron expression specifies the
+// timezone with CRON_TZ (or TZ) as supported by the cron parser.
+func hasTimezonePrefix(expr string) bool {
+	expr = strings.TrimSpace(expr)
+	return strings.HasPrefix(expr, "CRON_TZ=") || strings.HasPrefix(expr, "TZ=")
+}
+
 // parseScheduleMap parses the schedule map and populates the starts, stops,
 // and restarts slices. Each key in the map must be either "start", "stop", or
 // "restart". The value can be Case 1 or Case 2.
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/executor"
 	"github.com/dagu-org/dagu/internal/stringutil"
 )
 
@@ -33,6 +34,13 @@ type NodeState struct {
 	DoneCount  int
 	Error      error
 	ExitCode   int
+	// PeakRSS is the peak resident set size of the step in bytes.
+	PeakRSS int64
+	// UserTime and SystemTime are the CPU time of the step.
+	UserTime   time.Duration
+	SystemTime time.Duration
+	// LogLevels is the number of the lines of the JSON log by level.
+	LogLevels map[string]int
 }
 
 type NodeStatus int
@@ -328,6 +336,22 @@ func (n *SafeData) SetExitCode(exitCode int) {
 	n.inner.State.ExitCode = exitCode
 }
 
+func (n *SafeData) SetUsage(usage executor.ResourceUsage) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.inner.State.PeakRSS = usage.PeakRSS
+	n.inner.State.UserTime = usage.UserTime
+	n.inner.State.SystemTime = usage.SystemTime
+}
+
+func (n *SafeData) SetLogLevels(levels map[string]int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.inner.State.LogLevels = levels
+}
+
 func (n *SafeData) ClearState() {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -244,10 +244,7 @@ func (g *ExecutionGraph) findStep(name string) (*Node, error) {
 			return n, nil
 		}
 	}
-	return nil, fmt.Errorf("%w: %s", errStepNotFound, name)
+	return nil, fmt.Errorf("%w: %s", ErrStepNotFound, name)
 }
 
-var (
-	errCycleDetected = errors.New("cycle detected")
-	errStepNotFound  = errors.New("step not found")
-)
+var errCycleDetected = errors.New("cycle detected")
@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/dagu-org/dagu/internal/logstream"
+	"github.com/dagu-org/dagu/internal/stringutil"
+)
+
+// maxErrorLogs is the maximum number of the messages of the error lines
+// kept for an execution of the step.
+const maxErrorLogs = 1000
+
+// maxJSONLineLength is the length of a line after which it is parsed
+// without waiting for the end of the line.
+const maxJSONLineLength = 1024 * 1024
+
+// jsonLog parses the lines written to the stdout by a step of
+// digraph.LogFormatJSON and indexes them by level.
+type jsonLog struct {
+	mu sync.Mutex
+	// partial is the last line written without the line break yet.
+	partial []byte
+	// levels is the number of the lines by level in the log of the step.
+	levels map[string]int
+	// errors are the messages of the error lines of the current execution.
+	errors []string
+}
+
+func newJSONLog() *jsonLog {
+	return &jsonLog{levels: make(map[string]int)}
+}
+
+// Write implements io.Writer. It never fails so that the output of the step
+// is written to the other writers.
+func (l *jsonLog) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.partial = append(l.partial, p...)
+	for {
+		i := bytes.IndexByte(l.partial, '\n')
+		if i < 0 {
+			break
+		}
+		l.add(string(l.partial[:i]))
+		l.partial = l.partial[i+1:]
+	}
+	if len(l.partial) >= maxJSONLineLength {
+		l.add(string(l.partial))
+		l.partial = nil
+	}
+	l.partial = bytes.Clone(l.partial)
+	return len(p), nil
+}
+
+func (l *jsonLog) add(line string) {
+	e, ok := logstream.ParseEntry(line)
+	if !ok {
+		return
+	}
+	if e.Level != "" {
+		l.levels[e.Level]++
+	}
+	if e.IsError() && len(l.errors) < maxErrorLogs {
+		l.errors = append(l.errors, e.Message)
+	}
+}
+
+// reset clears the messages of the error lines for the next execution.
+func (l *jsonLog) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.partial = nil
+	l.errors = nil
+}
+
+// flush parses the last line without the line break.
+func (l *jsonLog) flush() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.partial) > 0 {
+		l.add(string(l.partial))
+		l.partial = nil
+	}
+}
+
+// counts returns the number of the lines by level.
+func (l *jsonLog) counts() map[string]int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return maps.Clone(l.levels)
+}
+
+// errorsMatch reports whether any message of the error lines of the
+// current execution matches the patterns.
+func (l *jsonLog) errorsMatch(ctx context.Context, patterns []string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.errors) == 0 {
+		return false
+	}
+	return stringutil.MatchPattern(ctx, strings.Join(l.errors, "\n"), patterns)
+}
@@ -19,7 +19,10 @@ import (
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/executor"
 	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/dagu-org/dagu/internal/logfile"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/logsink"
+	"github.com/dagu-org/dagu/internal/secrets"
 	"github.com/dagu-org/dagu/internal/stringutil"
 )
 
@@ -35,6 +38,9 @@ type Node struct {
 	done         atomic.Bool
 	retryPolicy  RetryPolicy
 	cmdEvaluated atomic.Bool
+	// stopStatus is the status to finish the node with when it is stopped
+	// by Stop, or NodeStatusNone.
+	stopStatus atomic.Int32
 }
 
 func NewNode(step digraph.Step, state NodeState) *Node {
@@ -131,6 +137,11 @@ func (n *Node) shouldContinue(ctx context.Context) bool {
 		}
 	}
 
+	if len(continueOn.ErrorLog) > 0 && n.outputs.errorLogMatches(ctx, continueOn.ErrorLog) {
+		n.data.setBoolVariable(cacheKey, true)
+		return true
+	}
+
 	n.data.setBoolVariable(cacheKey, false)
 	return false
 }
@@ -159,6 +170,15 @@ func (n *Node) Execute(ctx context.Context) error {
 
 	n.data.SetExitCode(exitCode)
 
+	// Record the resources used if the command implements UsageReporter
+	if cmd, ok := cmd.(executor.UsageReporter); ok {
+		n.data.SetUsage(cmd.Usage())
+	}
+
+	if levels := n.outputs.logLevels(); levels != nil {
+		n.data.SetLogLevels(levels)
+	}
+
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
@@ -173,6 +193,16 @@ func (n *Node) Execute(ctx context.Context) error {
 	return n.data.Error()
 }
 
+// shouldRetry reports whether the failed step meets the conditions of the
+// retry policy other than the limit.
+func (n *Node) shouldRetry(ctx context.Context) bool {
+	patterns := n.data.Step().RetryPolicy.ErrorLog
+	if len(patterns) == 0 {
+		return true
+	}
+	return n.outputs.errorLogMatches(ctx, patterns)
+}
+
 func (n *Node) clearVariable(key string) {
 	_ = os.Unsetenv(key)
 	n.data.ClearVariable(key)
@@ -186,6 +216,11 @@ func (n *Node) setupExecutor(ctx context.Context) (executor.Executor, error) {
 
 	n.cancelFunc = fn
 
+	// Do not start the command of the node stopped before it started.
+	if _, ok := n.stoppedAs(); ok {
+		return nil, ErrStepStopped
+	}
+
 	// Clear the cache
 	n.clearVariable(digraph.SystemVariablePrefix + "CONTINUE_ON." + n.data.Name())
 
@@ -330,6 +365,45 @@ func (n *Node) Signal(ctx context.Context, sig os.Signal, allowOverride bool) {
 	}
 }
 
+// Stop sends the signal to the command of the running node to stop it
+// without canceling the DAG. The node finishes with the status instead of
+// canceled.
+func (n *Node) Stop(ctx context.Context, sig os.Signal, allowOverride bool, status NodeStatus) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.data.Status() != NodeStatusRunning {
+		return fmt.Errorf("%w: %s", ErrStepNotRunning, n.data.Name())
+	}
+	n.stopStatus.Store(int32(status))
+	if n.cmd != nil {
+		if allowOverride && n.data.SignalOnStop() != "" {
+			sig = unix.SignalNum(n.data.SignalOnStop())
+		}
+		logger.Info(ctx, "Stopping step", "signal", sig, "step", n.data.Name())
+		if err := n.cmd.Kill(sig); err != nil {
+			logger.Error(ctx, "Failed to send signal", "err", err, "step", n.data.Name())
+		}
+	}
+	return nil
+}
+
+// stoppedAs returns the status to finish the node with if it is stopped by
+// Stop.
+func (n *Node) stoppedAs() (NodeStatus, bool) {
+	status := NodeStatus(n.stopStatus.Load())
+	return status, status != NodeStatusNone
+}
+
+// reset clears the state of the finished node to run it again.
+func (n *Node) reset() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.stopStatus.Store(int32(NodeStatusNone))
+	n.data.ClearState()
+}
+
 func (n *Node) Cancel(ctx context.Context) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -354,7 +428,7 @@ func (n *Node) SetupContextBeforeExec(ctx context.Context) context.Context {
 	return digraph.WithStepContext(ctx, stepContext)
 }
 
-func (n *Node) Setup(ctx context.Context, logDir string, requestID string) error {
+func (n *Node) Setup(ctx context.Context, logDir string, requestID string, logLimit LogLimit) error {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
@@ -374,7 +448,7 @@ func (n *Node) Setup(ctx context.Context, logDir string, requestID string) error
 	if err := n.data.Setup(ctx, logFile, startedAt); err != nil {
 		return fmt.Errorf("failed to setup node data: %w", err)
 	}
-	if err := n.outputs.setup(ctx, n.data.Data()); err != nil {
+	if err := n.outputs.setup(ctx, n.data.Data(), logLimit); err != nil {
 		return fmt.Errorf("failed to setup outputs: %w", err)
 	}
 	if err := n.setupRetryPolicy(ctx); err != nil {
@@ -390,7 +464,7 @@ func (n *Node) Teardown(ctx context.Context) error {
 	n.done.Store(true)
 
 	var lastErr error
-	if err := n.outputs.closeResources(ctx); err != nil {
+	if err := n.outputs.closeResources(ctx, n.State().Status); err != nil {
 		lastErr = err
 	}
 
@@ -527,6 +601,15 @@ type OutputCoordinator struct {
 	stderrWriter *bufio.Writer
 	outputWriter *os.File
 	outputReader *os.File
+	// jsonLog parses the stdout of the step of digraph.LogFormatJSON.
+	jsonLog *jsonLog
+	// logSink ships the lines of the log to the log sinks of the run.
+	logSink *logsink.LineWriter
+
+	// maskWriters mask the values of the secrets written to the files.
+	logMask    *secrets.MaskWriter
+	stdoutMask *secrets.MaskWriter
+	stderrMask *secrets.MaskWriter
 }
 
 func (oc *OutputCoordinator) LogFile() string {
@@ -544,31 +627,36 @@ func (oc *OutputCoordinator) unlock() {
 	oc.mu.Unlock()
 }
 
-func (oc *OutputCoordinator) setup(ctx context.Context, data NodeData) error {
-	if err := oc.setupLog(ctx, data); err != nil {
+func (oc *OutputCoordinator) setup(ctx context.Context, data NodeData, logLimit LogLimit) error {
+	if err := oc.setupLog(ctx, data, logLimit); err != nil {
 		return err
 	}
+	if data.Step.LogFormat == digraph.LogFormatJSON {
+		oc.jsonLog = newJSONLog()
+	}
 	if err := oc.setupStdout(ctx, data); err != nil {
 		return err
 	}
 	return oc.setupStderr(ctx, data)
 }
 
-func (oc *OutputCoordinator) setupExecutorIO(_ context.Context, cmd executor.Executor, data NodeData) error {
+func (oc *OutputCoordinator) setupExecutorIO(ctx context.Context, cmd executor.Executor, data NodeData) error {
 	oc.mu.Lock()
 	defer oc.mu.Unlock()
 
+	logWriter, stdoutWriter, stderrWriter := oc.maskedWriters(ctx)
+
 	var stdout io.Writer
 
 	// Output to log only
-	if oc.logWriter != nil {
-		stdout = oc.logWriter
+	if logWriter != nil {
+		stdout = logWriter
 		cmd.SetStderr(stdout)
 	}
 
 	// Output to both log and stdout
-	if oc.stdoutWriter != nil {
-		stdout = io.MultiWriter(oc.logWriter, oc.stdoutWriter)
+	if stdoutWriter != nil {
+		stdout = io.MultiWriter(logWriter, stdoutWriter)
 	}
 
 	// Setup output capture
@@ -580,10 +668,16 @@ func (oc *OutputCoordinator) setupExecutorIO(_ context.Context, cmd executor.Exe
 		stdout = io.MultiWriter(stdout, oc.outputWriter)
 	}
 
-	cmd.SetStdout(stdout)
+	// Only the lines written to the stdout are parsed.
+	if oc.jsonLog != nil {
+		oc.jsonLog.reset()
+		cmd.SetStdout(io.MultiWriter(stdout, oc.jsonLog))
+	} else {
+		cmd.SetStdout(stdout)
+	}
 
-	if oc.stderrWriter != nil {
-		cmd.SetStderr(oc.stderrWriter)
+	if stderrWriter != nil {
+		cmd.SetStderr(stderrWriter)
 	} else {
 		// If stderr output is not set, use stdout for stderr as well
 		cmd.SetStderr(stdout)
@@ -592,11 +686,99 @@ func (oc *OutputCoordinator) setupExecutorIO(_ context.Context, cmd executor.Exe
 	return nil
 }
 
-func (oc *OutputCoordinator) closeResources(_ context.Context) error {
+// maskedWriters returns the writers of the files masking the values of the
+// secrets resolved in the context. The writers are created once so that
+// retries of the step share the buffered text.
+func (oc *OutputCoordinator) maskedWriters(ctx context.Context) (log, stdout, stderr io.Writer) {
+	masker := secrets.MaskerFromContext(ctx)
+
+	if oc.logWriter != nil {
+		log = flushWriter{oc.logWriter}
+		if oc.logSink != nil {
+			log = io.MultiWriter(log, oc.logSink)
+		}
+		if masker != nil {
+			if oc.logMask == nil {
+				oc.logMask = secrets.NewMaskWriter(log, masker)
+			}
+			log = oc.logMask
+		}
+	}
+	if oc.stdoutWriter != nil {
+		stdout = oc.stdoutWriter
+		if masker != nil {
+			if oc.stdoutMask == nil {
+				oc.stdoutMask = secrets.NewMaskWriter(oc.stdoutWriter, masker)
+			}
+			stdout = oc.stdoutMask
+		}
+	}
+	if oc.stderrWriter != nil {
+		stderr = oc.stderrWriter
+		if masker != nil {
+			if oc.stderrMask == nil {
+				oc.stderrMask = secrets.NewMaskWriter(oc.stderrWriter, masker)
+			}
+			stderr = oc.stderrMask
+		}
+	}
+	return log, stdout, stderr
+}
+
+// flushWriter flushes the buffered writer after each write so that the log
+// can be followed while the step is running.
+type flushWriter struct {
+	w *bufio.Writer
+}
+
+func (w flushWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, w.w.Flush()
+}
+
+// logLevels returns the number of the lines of the JSON log by level, or
+// nil if the stdout is not parsed.
+func (oc *OutputCoordinator) logLevels() map[string]int {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	if oc.jsonLog == nil {
+		return nil
+	}
+	oc.jsonLog.flush()
+	return oc.jsonLog.counts()
+}
+
+// errorLogMatches reports whether any message of the error lines of the
+// JSON log of the last execution matches the patterns.
+func (oc *OutputCoordinator) errorLogMatches(ctx context.Context, patterns []string) bool {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+
+	if oc.jsonLog == nil {
+		return false
+	}
+	return oc.jsonLog.errorsMatch(ctx, patterns)
+}
+
+func (oc *OutputCoordinator) closeResources(_ context.Context, status NodeStatus) error {
 	oc.mu.Lock()
 	defer oc.mu.Unlock()
 
 	var lastErr error
+	for _, w := range []*secrets.MaskWriter{oc.logMask, oc.stdoutMask, oc.stderrMask} {
+		if w != nil {
+			if err := w.Flush(); err != nil {
+				lastErr = err
+			}
+		}
+	}
+	if oc.logSink != nil {
+		oc.logSink.Finish(status.String())
+	}
 	for _, w := range []*bufio.Writer{oc.logWriter, oc.stdoutWriter, oc.stderrWriter} {
 		if w != nil {
 			if err := w.Flush(); err != nil {
@@ -653,7 +835,7 @@ func (oc *OutputCoordinator) setupStderr(ctx context.Context, data NodeData) err
 	return nil
 }
 
-func (oc *OutputCoordinator) setupLog(_ context.Context, data NodeData) error {
+func (oc *OutputCoordinator) setupLog(ctx context.Context, data NodeData, logLimit LogLimit) error {
 	oc.mu.Lock()
 	defer oc.mu.Unlock()
 
@@ -662,8 +844,17 @@ func (oc *OutputCoordinator) setupLog(_ context.Context, data NodeData) error {
 	if err != nil {
 		return fmt.Errorf("failed to open log file: %w", err)
 	}
-	oc.logWriter = bufio.NewWriter(oc.logFile)
+	var w io.Writer = oc.logFile
+	if logLimit.MaxSize > 0 {
+		if w, err = logfile.NewLimitWriter(oc.logFile, logLimit.MaxSize, logLimit.TruncateHead); err != nil {
+			return fmt.Errorf("failed to limit log file: %w", err)
+		}
+	}
+	oc.logWriter = bufio.NewWriter(w)
 	oc.logFilename = data.State.Log
+	if shipper := logsink.FromContext(ctx); shipper != nil {
+		oc.logSink = shipper.StepWriter(data.Step.Name)
+	}
 
 	return nil
 }
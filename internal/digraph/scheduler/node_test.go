@@ -66,6 +66,18 @@ func TestNode(t *testing.T) {
 		node.Execute(t)
 		node.AssertLogContains(t, "hello")
 	})
+	t.Run("LogMaxSize", func(t *testing.T) {
+		t.Parallel()
+
+		node := setupNode(t, withNodeCommand("sh"), withNodeScript("echo first; echo second; echo third"))
+		node.logLimit = scheduler.LogLimit{MaxSize: 8}
+		node.Execute(t)
+		node.AssertLogContains(t, "first\nse\n[dagu] the rest of the log is truncated")
+
+		dat, err := os.ReadFile(node.LogFile())
+		require.NoError(t, err)
+		require.NotContains(t, string(dat), "third")
+	})
 	t.Run("Stdout", func(t *testing.T) {
 		t.Parallel()
 
@@ -185,6 +197,7 @@ func TestNode(t *testing.T) {
 type nodeHelper struct {
 	*scheduler.Node
 	test.Helper
+	logLimit scheduler.LogLimit
 }
 
 type nodeOption func(*scheduler.NodeData)
@@ -251,7 +264,7 @@ func (n nodeHelper) Execute(t *testing.T) {
 	t.Helper()
 
 	reqID := reqID()
-	err := n.Node.Setup(n.Context, n.Config.Paths.LogDir, reqID)
+	err := n.Node.Setup(n.Context, n.Config.Paths.LogDir, reqID, n.logLimit)
 	require.NoError(t, err, "failed to setup node")
 
 	err = n.Node.Execute(n.execContext(reqID))
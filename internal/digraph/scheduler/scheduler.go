@@ -2,13 +2,16 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"runtime/debug"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/executor"
 	"github.com/dagu-org/dagu/internal/logger"
 )
 
@@ -20,6 +23,9 @@ const (
 	StatusError
 	StatusCancel
 	StatusSuccess
+	// StatusSkipped is the status of a scheduled run that is not started,
+	// e.g. because of the calendar of the DAG.
+	StatusSkipped
 )
 
 func (s Status) String() string {
@@ -32,6 +38,8 @@ func (s Status) String() string {
 		return "canceled"
 	case StatusSuccess:
 		return "finished"
+	case StatusSkipped:
+		return "skipped"
 	case StatusNone:
 		fallthrough
 	default:
@@ -42,6 +50,11 @@ func (s Status) String() string {
 var (
 	ErrUpstreamFailed  = fmt.Errorf("upstream failed")
 	ErrUpstreamSkipped = fmt.Errorf("upstream skipped")
+	ErrStepStopped     = fmt.Errorf("step stopped")
+	ErrStepNotFound    = fmt.Errorf("step not found")
+	ErrStepNotRunning  = fmt.Errorf("step not running")
+	ErrStepNotFailed   = fmt.Errorf("step not failed")
+	ErrDAGNotRunning   = fmt.Errorf("DAG not running")
 )
 
 // Scheduler is a scheduler that runs a graph of steps.
@@ -56,8 +69,10 @@ type Scheduler struct {
 	onFailure     *digraph.Step
 	onCancel      *digraph.Step
 	requestID     string
+	logLimit      LogLimit
 
 	canceled  int32
+	finished  bool
 	mu        sync.RWMutex
 	pause     time.Duration
 	lastError error
@@ -76,6 +91,7 @@ func New(cfg *Config) *Scheduler {
 		onFailure:     cfg.OnFailure,
 		onCancel:      cfg.OnCancel,
 		requestID:     cfg.ReqID,
+		logLimit:      cfg.LogLimit,
 		pause:         time.Millisecond * 100,
 	}
 }
@@ -91,6 +107,17 @@ type Config struct {
 	OnFailure     *digraph.Step
 	OnCancel      *digraph.Step
 	ReqID         string
+	LogLimit      LogLimit
+}
+
+// LogLimit is the limit of the size of the logs of the steps.
+type LogLimit struct {
+	// MaxSize is the maximum size in bytes. The logs are not limited if it
+	// is zero.
+	MaxSize int64
+	// TruncateHead drops the beginning of the log exceeding the size
+	// instead of the rest.
+	TruncateHead bool
 }
 
 // Schedule runs the graph of steps.
@@ -178,6 +205,13 @@ func (sc *Scheduler) Schedule(ctx context.Context, graph *ExecutionGraph, done c
 			ExecRepeat: // repeat execution
 				for setupSucceed && !sc.isCanceled() {
 					execErr := sc.execNode(ctx, node)
+					if status, ok := node.stoppedAs(); ok {
+						sc.finishStoppedNode(ctx, node, status)
+						if done != nil {
+							done <- node
+						}
+						return
+					}
 					if execErr != nil {
 						status := node.State().Status
 						switch {
@@ -192,7 +226,11 @@ func (sc *Scheduler) Schedule(ctx context.Context, graph *ExecutionGraph, done c
 						case sc.isCanceled():
 							sc.setLastError(execErr)
 
-						case node.retryPolicy.Limit > node.data.GetRetryCount():
+						case errors.Is(execErr, executor.ErrSkipped):
+							logger.Info(ctx, "Step skipped", "step", node.data.Name(), "reason", execErr)
+							node.data.SetStatus(NodeStatusSkipped)
+
+						case node.retryPolicy.Limit > node.data.GetRetryCount() && node.shouldRetry(ctx):
 							// retry
 							node.data.IncRetryCount()
 							logger.Info(ctx, "Step execution failed. Retrying...", "step", node.data.Name(), "error", execErr, "retry", node.data.GetRetryCount())
@@ -306,7 +344,7 @@ func (sc *Scheduler) setLastError(err error) {
 
 func (sc *Scheduler) setupNode(ctx context.Context, node *Node) error {
 	if !sc.dry {
-		return node.Setup(ctx, sc.logDir, sc.requestID)
+		return node.Setup(ctx, sc.logDir, sc.requestID, sc.logLimit)
 	}
 	return nil
 }
@@ -402,6 +440,81 @@ func (sc *Scheduler) Signal(
 	}
 }
 
+// StopNode stops the running step without canceling the DAG. The step
+// finishes with the status, either NodeStatusError or NodeStatusSkipped,
+// and the steps depending on it run or not as if it failed or was skipped.
+func (sc *Scheduler) StopNode(
+	ctx context.Context, graph *ExecutionGraph, name string, sig os.Signal, allowOverride bool, status NodeStatus,
+) error {
+	if status != NodeStatusError && status != NodeStatusSkipped {
+		return fmt.Errorf("invalid status to stop the step with: %s", status)
+	}
+	node, err := graph.findStep(name)
+	if err != nil {
+		return err
+	}
+	return node.Stop(ctx, sig, allowOverride, status)
+}
+
+// finishStoppedNode finishes the step stopped by StopNode with the status.
+func (sc *Scheduler) finishStoppedNode(ctx context.Context, node *Node, status NodeStatus) {
+	logger.Info(ctx, "Step stopped", "step", node.data.Name(), "status", status)
+	if status == NodeStatusSkipped {
+		node.data.SetStatus(NodeStatusSkipped)
+		node.data.SetError(ErrStepStopped)
+		return
+	}
+	node.data.MarkError(ErrStepStopped)
+	sc.setLastError(ErrStepStopped)
+}
+
+// RetryNode runs the failed step again while the DAG is running. The steps
+// canceled or skipped because of the failure are scheduled again as well.
+func (sc *Scheduler) RetryNode(ctx context.Context, graph *ExecutionGraph, name string) error {
+	node, err := graph.findStep(name)
+	if err != nil {
+		return err
+	}
+
+	// Hold the lock so that the DAG does not finish while the state of the
+	// steps is reset.
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if sc.finished || sc.canceled == 1 {
+		return ErrDAGNotRunning
+	}
+	// The step is finished when its goroutine has torn it down.
+	state := node.State()
+	if state.Status != NodeStatusError || state.FinishedAt.Before(state.StartedAt) {
+		return fmt.Errorf("%w: %s", ErrStepNotFailed, name)
+	}
+
+	logger.Info(ctx, "Step retry requested", "step", name)
+	node.reset()
+	node.data.SetRetriedAt(time.Now())
+
+	queue := slices.Clone(graph.from[node.id])
+	for len(queue) > 0 {
+		next := graph.node(queue[0])
+		queue = queue[1:]
+		state := next.State()
+		if (state.Status == NodeStatusCancel || state.Status == NodeStatusSkipped) && state.StartedAt.IsZero() {
+			next.reset()
+			queue = append(queue, graph.from[next.id]...)
+		}
+	}
+
+	// The DAG fails with the errors of the other failed steps only.
+	sc.lastError = nil
+	for _, n := range graph.Nodes() {
+		if state := n.State(); state.Status == NodeStatusError {
+			sc.lastError = state.Error
+		}
+	}
+	return nil
+}
+
 // Cancel sends -1 signal to all nodes.
 func (sc *Scheduler) Cancel(ctx context.Context, g *ExecutionGraph) {
 	sc.setCanceled()
@@ -495,7 +608,7 @@ func (sc *Scheduler) runHandlerNode(ctx context.Context, graph *ExecutionGraph,
 	node.data.SetStatus(NodeStatusRunning)
 
 	if !sc.dry {
-		if err := node.Setup(ctx, sc.logDir, sc.requestID); err != nil {
+		if err := node.Setup(ctx, sc.logDir, sc.requestID, sc.logLimit); err != nil {
 			node.data.SetStatus(NodeStatusError)
 			return nil
 		}
@@ -561,13 +674,18 @@ func (*Scheduler) runningCount(g *ExecutionGraph) int {
 	return count
 }
 
-func (*Scheduler) isFinished(g *ExecutionGraph) bool {
+// isFinished reports whether all the steps are finished. The failed steps
+// cannot be retried by RetryNode once it is true.
+func (sc *Scheduler) isFinished(g *ExecutionGraph) bool {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
 	for _, node := range g.Nodes() {
 		if node.State().Status == NodeStatusRunning ||
 			node.State().Status == NodeStatusNone {
 			return false
 		}
 	}
+	sc.finished = true
 	return true
 }
 
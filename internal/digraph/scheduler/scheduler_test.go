@@ -5,6 +5,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"runtime"
 	"syscall"
 	"testing"
 	"time"
@@ -172,6 +173,27 @@ func TestScheduler(t *testing.T) {
 		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
 		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
 	})
+	t.Run("ContinueOnErrorLog", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 (error line: not found) -> 2
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand(`echo '{"level":"info","msg":"start"}'; echo '{"level":"error","msg":"not found: x"}'; false`),
+				withLogFormat(digraph.LogFormatJSON),
+				withContinueOn(digraph.ContinueOn{
+					ErrorLog: []string{"re:^not found"},
+				}),
+			),
+			successStep("2", "1"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+		require.Equal(t, map[string]int{"info": 1, "error": 1}, result.Node(t, "1").State().LogLevels)
+	})
 	t.Run("ContinueOnOutputStdout", func(t *testing.T) {
 		sc := setup(t)
 
@@ -317,6 +339,32 @@ func TestScheduler(t *testing.T) {
 		node := result.Node(t, "1")
 		require.Equal(t, 2, node.State().RetryCount) // 2 retry
 	})
+	t.Run("RetryPolicyErrorLog", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 is retried as the error line matches, 2 is not.
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand(`echo '{"level":"error","msg":"connection refused"}'; false`),
+				withLogFormat(digraph.LogFormatJSON),
+				withRetryPolicy(2, 0),
+				withRetryErrorLog("connection refused"),
+			),
+			newStep("2",
+				withCommand(`echo '{"level":"error","msg":"permission denied"}'; false`),
+				withLogFormat(digraph.LogFormatJSON),
+				withRetryPolicy(2, 0),
+				withRetryErrorLog("connection refused"),
+			),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		require.Equal(t, 2, result.Node(t, "1").State().RetryCount)
+		require.Equal(t, 0, result.Node(t, "2").State().RetryCount)
+		// The levels are counted in the log of the last retry.
+		require.Equal(t, map[string]int{"error": 1}, result.Node(t, "1").State().LogLevels)
+	})
 	t.Run("RetryWithScript", func(t *testing.T) {
 		sc := setup(t)
 
@@ -607,6 +655,115 @@ func TestScheduler(t *testing.T) {
 
 		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
 	})
+	t.Run("StopNodeAsFailed", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 -> 2
+		// 3
+		graph := sc.newGraph(t,
+			newStep("1", withCommand("sleep 10")),
+			successStep("2", "1"),
+			newStep("3", withCommand("sleep 1")),
+		)
+
+		go func() {
+			time.Sleep(time.Millisecond * 100) // wait for step 1 to start
+			err := graph.StopNode(t, "1", scheduler.NodeStatusError)
+			assert.NoError(t, err)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusError)
+
+		// Only the step and the steps depending on it are stopped.
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusError)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusCancel)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSuccess)
+		require.ErrorIs(t, result.Node(t, "1").State().Error, scheduler.ErrStepStopped)
+	})
+	t.Run("StopNodeAsSkipped", func(t *testing.T) {
+		sc := setup(t)
+
+		// 1 (continue on skipped) -> 2
+		graph := sc.newGraph(t,
+			newStep("1",
+				withCommand("sleep 10"),
+				withContinueOn(digraph.ContinueOn{Skipped: true}),
+			),
+			successStep("2", "1"),
+		)
+
+		go func() {
+			time.Sleep(time.Millisecond * 100) // wait for step 1 to start
+			err := graph.StopNode(t, "1", scheduler.NodeStatusSkipped)
+			assert.NoError(t, err)
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSkipped)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+	})
+	t.Run("StopNodeNotRunning", func(t *testing.T) {
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			successStep("1"),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		err := result.StopNode(t, "1", scheduler.NodeStatusError)
+		require.ErrorIs(t, err, scheduler.ErrStepNotRunning)
+		err = result.StopNode(t, "2", scheduler.NodeStatusError)
+		require.ErrorIs(t, err, scheduler.ErrStepNotFound)
+	})
+	t.Run("RetryNode", func(t *testing.T) {
+		file := filepath.Join(
+			os.TempDir(), fmt.Sprintf("flag_test_retry_node_%s", uuid.Must(uuid.NewRandom()).String()),
+		)
+		t.Cleanup(func() {
+			_ = os.Remove(file)
+		})
+
+		sc := setup(t)
+
+		// 1 -> 2
+		// 3
+		graph := sc.newGraph(t,
+			newStep("1", withCommand(fmt.Sprintf("%s %s", testScript, file))),
+			successStep("2", "1"),
+			newStep("3", withCommand("sleep 2")),
+		)
+
+		go func() {
+			// The step cannot be retried until it fails.
+			err := graph.RetryNode(t, "1")
+			assert.ErrorIs(t, err, scheduler.ErrStepNotFailed)
+
+			assert.Eventually(t, func() bool {
+				state := graph.Node(t, "1").State()
+				return state.Status == scheduler.NodeStatusError && !state.FinishedAt.IsZero()
+			}, time.Second, time.Millisecond*50)
+
+			// Create the file for the retry to succeed.
+			f, err := os.Create(file)
+			assert.NoError(t, err)
+			_ = f.Close()
+
+			assert.NoError(t, graph.RetryNode(t, "1"))
+		}()
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		result.AssertNodeStatus(t, "1", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "2", scheduler.NodeStatusSuccess)
+		result.AssertNodeStatus(t, "3", scheduler.NodeStatusSuccess)
+		assert.NotEmpty(t, result.Node(t, "1").State().RetriedAt)
+
+		// The step cannot be retried after the DAG finished.
+		err := result.RetryNode(t, "1")
+		require.ErrorIs(t, err, scheduler.ErrDAGNotRunning)
+	})
 	t.Run("NodeSetupFailure", func(t *testing.T) {
 		sc := setup(t)
 
@@ -644,6 +801,39 @@ func TestScheduler(t *testing.T) {
 		require.True(t, ok, "output variable not found")
 		require.Equal(t, "RESULT=hello", output, "expected output %q, got %q", "hello", output)
 	})
+	t.Run("Resources", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("resource limits are only enforced on Linux")
+		}
+		sc := setup(t)
+
+		// The rlimits are set right after the process starts.
+		graph := sc.newGraph(t,
+			newStep("1", withScript("sleep 0.2\nulimit -n"), withOutput("NOFILE"), withResources(digraph.Resources{Nofile: 64})),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		node := result.Node(t, "1")
+		output, _ := node.Data().Step.OutputVariables.Load("NOFILE")
+		require.Equal(t, "NOFILE=64", output)
+		require.Positive(t, node.State().PeakRSS)
+	})
+	t.Run("RunAs", func(t *testing.T) {
+		if os.Geteuid() != 0 {
+			t.Skip("switching the user requires root")
+		}
+		sc := setup(t)
+
+		graph := sc.newGraph(t,
+			newStep("1", withScript("id -un"), withOutput("USER_NAME"), withRunAs("nobody")),
+		)
+
+		result := graph.Schedule(t, scheduler.StatusSuccess)
+
+		output, _ := result.Node(t, "1").Data().Step.OutputVariables.Load("USER_NAME")
+		require.Equal(t, "USER_NAME=nobody", output)
+	})
 	t.Run("OutputInheritance", func(t *testing.T) {
 		sc := setup(t)
 
@@ -819,6 +1009,18 @@ func withRetryPolicy(limit int, interval time.Duration) stepOption {
 	}
 }
 
+func withRetryErrorLog(patterns ...string) stepOption {
+	return func(step *digraph.Step) {
+		step.RetryPolicy.ErrorLog = patterns
+	}
+}
+
+func withLogFormat(format string) stepOption {
+	return func(step *digraph.Step) {
+		step.LogFormat = format
+	}
+}
+
 func withRepeatPolicy(repeat bool, interval time.Duration) stepOption {
 	return func(step *digraph.Step) {
 		step.RepeatPolicy.Repeat = repeat
@@ -838,6 +1040,18 @@ func withScript(script string) stepOption {
 	}
 }
 
+func withResources(resources digraph.Resources) stepOption {
+	return func(step *digraph.Step) {
+		step.Resources = &resources
+	}
+}
+
+func withRunAs(user string) stepOption {
+	return func(step *digraph.Step) {
+		step.RunAs = &digraph.RunAs{User: user}
+	}
+}
+
 func withWorkingDir(dir string) stepOption {
 	return func(step *digraph.Step) {
 		step.Dir = dir
@@ -1008,6 +1222,18 @@ func (gh graphHelper) Signal(sig syscall.Signal) {
 	gh.Scheduler.Signal(gh.Context, gh.ExecutionGraph, sig, nil, false)
 }
 
+func (gh graphHelper) StopNode(t *testing.T, name string, status scheduler.NodeStatus) error {
+	t.Helper()
+
+	return gh.Scheduler.StopNode(gh.Context, gh.ExecutionGraph, name, syscall.SIGTERM, false, status)
+}
+
+func (gh graphHelper) RetryNode(t *testing.T, name string) error {
+	t.Helper()
+
+	return gh.Scheduler.RetryNode(gh.Context, gh.ExecutionGraph, name)
+}
+
 func (gh graphHelper) Cancel(t *testing.T) {
 	t.Helper()
 
@@ -1058,27 +1284,27 @@ func (sr scheduleResult) AssertNodeStatus(t *testing.T, stepName string, expecte
 	require.Equal(t, expected.String(), target.State().Status.String(), "expected status %q, got %q", expected.String(), target.State().Status.String())
 }
 
-func (sr scheduleResult) Node(t *testing.T, stepName string) *scheduler.Node {
+func (gh graphHelper) Node(t *testing.T, stepName string) *scheduler.Node {
 	t.Helper()
 
-	nodes := sr.ExecutionGraph.Nodes()
+	nodes := gh.ExecutionGraph.Nodes()
 	for _, node := range nodes {
 		if node.Data().Step.Name == stepName {
 			return node
 		}
 	}
 
-	if sr.Config.OnExit != nil && sr.Config.OnExit.Name == stepName {
-		return sr.Scheduler.HandlerNode(digraph.HandlerOnExit)
+	if gh.Config.OnExit != nil && gh.Config.OnExit.Name == stepName {
+		return gh.Scheduler.HandlerNode(digraph.HandlerOnExit)
 	}
-	if sr.Config.OnSuccess != nil && sr.Config.OnSuccess.Name == stepName {
-		return sr.Scheduler.HandlerNode(digraph.HandlerOnSuccess)
+	if gh.Config.OnSuccess != nil && gh.Config.OnSuccess.Name == stepName {
+		return gh.Scheduler.HandlerNode(digraph.HandlerOnSuccess)
 	}
-	if sr.Config.OnFailure != nil && sr.Config.OnFailure.Name == stepName {
-		return sr.Scheduler.HandlerNode(digraph.HandlerOnFailure)
+	if gh.Config.OnFailure != nil && gh.Config.OnFailure.Name == stepName {
+		return gh.Scheduler.HandlerNode(digraph.HandlerOnFailure)
 	}
-	if sr.Config.OnCancel != nil && sr.Config.OnCancel.Name == stepName {
-		return sr.Scheduler.HandlerNode(digraph.HandlerOnCancel)
+	if gh.Config.OnCancel != nil && gh.Config.OnCancel.Name == stepName {
+		return gh.Scheduler.HandlerNode(digraph.HandlerOnCancel)
 	}
 
 	t.Fatalf("step %s not found", stepName)
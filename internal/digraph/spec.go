@@ -14,11 +14,32 @@ type definition struct {
 	Dotenv any
 	// Schedule is the cron schedule to run the DAG.
 	Schedule any
+	// Timezone is the timezone to evaluate the schedule in, e.g. "Asia/Tokyo".
+	Timezone string
+	// ScheduleJitter is the maximum delay to start the DAG after the
+	// scheduled time, e.g. "5m" (string or seconds as int).
+	ScheduleJitter any
+	// Calendar is the calendar files of the dates to skip the schedule on
+	// (string or []string).
+	Calendar any
+	// ExcludeDates is the dates to skip the schedule on, e.g. "2024-12-25".
+	ExcludeDates []string
+	// BlackoutWindows is the periods of the day to skip the schedule in.
+	BlackoutWindows []blackoutWindowDef
+	// RunOn is the rule of the days to run on schedule, e.g. "businessDays"
+	// or "lastBusinessDayOfMonth".
+	RunOn string
 	// SkipIfSuccessful is the flag to skip the DAG on schedule when it is
 	// executed manually before the schedule.
 	SkipIfSuccessful bool
 	// LogFile is the file to write the log.
 	LogDir string
+	// LogMaxSize is the maximum size of the log of a step in bytes or with
+	// a unit, e.g. 100Mi.
+	LogMaxSize any
+	// LogTruncate is the part of the log to drop when it exceeds the
+	// maximum size: "tail" (default) or "head".
+	LogTruncate string
 	// Env is the environment variables setting.
 	Env any
 	// HandlerOn is the handler configuration.
@@ -59,6 +80,18 @@ type definition struct {
 	Tags any
 }
 
+// blackoutWindowDef defines a period of the day to skip the schedule in.
+type blackoutWindowDef struct {
+	// Start is the start time of the window, e.g. "23:00".
+	Start string
+	// End is the end time of the window. The window ends on the next day
+	// if it is not after the start time.
+	End string
+	// Days is the days of the week the window starts on (string or
+	// []string). The window applies to every day if it is empty.
+	Days any
+}
+
 // handlerOnDef defines the steps to be executed on different events.
 type handlerOnDef struct {
 	Failure *stepDef // Step to execute on failure
@@ -89,6 +122,9 @@ type stepDef struct {
 	Stderr string
 	// Output is the variable name to store the output.
 	Output string
+	// LogFormat is the format of the lines written to the stdout: "text"
+	// (default) or "json".
+	LogFormat string
 	// Depends is the list of steps to depend on.
 	Depends any // string or []string
 	// ContinueOn is the condition to continue on.
@@ -113,6 +149,29 @@ type stepDef struct {
 	Run string
 	// Params is the parameters for the sub workflow
 	Params string
+	// WaitFor is another DAG to wait for to succeed.
+	WaitFor *waitForDef
+	// Resources is the limits of the resources the step can use.
+	Resources *resourcesDef
+	// RunAs is the user to run the step as, e.g. "user" or "user:group".
+	RunAs string
+}
+
+// resourcesDef defines the limits of the resources for a step.
+type resourcesDef struct {
+	Memory any // Memory in bytes or with a unit, e.g. 512M or 1Gi
+	CPU    any // Number of CPUs, e.g. 0.5 or 500m
+	Pids   int // Maximum number of processes
+	Nofile int // Maximum number of open files
+}
+
+// waitForDef defines a step that waits for a run of another DAG.
+type waitForDef struct {
+	DAG          string // Name of the DAG to wait for
+	Offset       any    // Offset of the date of the run from now
+	Timeout      any    // Time to give up waiting, or zero to wait indefinitely
+	PokeInterval any    // Interval to check the status of the DAG
+	SoftFail     bool   // Skip the step instead of failing on the timeout
 }
 
 // funcDef defines a function in the DAG.
@@ -134,6 +193,7 @@ type continueOnDef struct {
 	Skipped     bool // Continue on skipped
 	ExitCode    any  // Continue on specific exit codes
 	Output      any  // Continue on specific output (string or []string)
+	ErrorLog    any  // Continue on specific messages of the error lines of the JSON log (string or []string)
 	MarkSuccess bool // Mark the step as success when the condition is met
 }
 
@@ -147,6 +207,7 @@ type repeatPolicyDef struct {
 type retryPolicyDef struct {
 	Limit       any // Limit on the number of retries
 	IntervalSec any // Interval in seconds between retries
+	ErrorLog    any // Retry only on specific messages of the error lines of the JSON log (string or []string)
 }
 
 // smtpConfigDef defines the SMTP configuration.
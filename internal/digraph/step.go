@@ -41,6 +41,9 @@ type Step struct {
 	Stderr string `json:"Stderr,omitempty"`
 	// Output is the variable name to store the output.
 	Output string `json:"Output,omitempty"`
+	// LogFormat is the format of the lines written to the stdout. The lines
+	// of LogFormatJSON are parsed and indexed by level.
+	LogFormat string `json:"LogFormat,omitempty"`
 	// Depends contains the list of step names to depend on.
 	Depends []string `json:"Depends,omitempty"`
 	// ContinueOn contains the conditions to continue on failure or skipped.
@@ -57,6 +60,12 @@ type Step struct {
 	SignalOnStop string `json:"SignalOnStop,omitempty"`
 	// SubWorkflow contains the information about a sub DAG to be executed.
 	SubWorkflow *SubWorkflow `json:"SubWorkflow,omitempty"`
+	// WaitFor contains the information about a DAG run to wait for.
+	WaitFor *WaitFor `json:"WaitFor,omitempty"`
+	// Resources contains the limits of the resources for the step.
+	Resources *Resources `json:"Resources,omitempty"`
+	// RunAs contains the user and the group to run the step as.
+	RunAs *RunAs `json:"RunAs,omitempty"`
 }
 
 // setup sets the default values for the step.
@@ -98,6 +107,54 @@ type SubWorkflow struct {
 // the `run` field in the DAG file.
 const ExecutorTypeSubWorkflow = "subworkflow"
 
+// The formats of the lines written to the stdout by a step.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// WaitFor contains information about a run of another DAG to wait for.
+type WaitFor struct {
+	// DAG is the name of the DAG to wait for.
+	DAG string `json:"DAG"`
+	// Offset is added to the current time to get the date of the run to
+	// wait for, e.g. -24h for the run of the previous day.
+	Offset time.Duration `json:"Offset,omitempty"`
+	// Timeout is the time to give up waiting. It waits indefinitely if zero.
+	Timeout time.Duration `json:"Timeout,omitempty"`
+	// PokeInterval is the interval to check the status of the DAG.
+	PokeInterval time.Duration `json:"PokeInterval,omitempty"`
+	// SoftFail skips the step instead of failing it on the timeout.
+	SoftFail bool `json:"SoftFail,omitempty"`
+}
+
+// Resources contains the limits of the resources for a step. A zero value
+// means no limit.
+type Resources struct {
+	// Memory is the maximum memory in bytes.
+	Memory int64 `json:"Memory,omitempty"`
+	// CPU is the maximum number of CPUs, e.g. 0.5 for half of a CPU.
+	CPU float64 `json:"CPU,omitempty"`
+	// Pids is the maximum number of processes.
+	Pids int `json:"Pids,omitempty"`
+	// Nofile is the maximum number of open files.
+	Nofile int `json:"Nofile,omitempty"`
+}
+
+// RunAs contains the user and the group to run a step as. They are looked
+// up on the host when the step runs.
+type RunAs struct {
+	// User is the name or the ID of the user.
+	User string `json:"User"`
+	// Group is the name or the ID of the group. The primary group of the
+	// user is used if it is empty.
+	Group string `json:"Group,omitempty"`
+}
+
+// ExecutorTypeWaitFor is defined here in order to parse
+// the `waitFor` field in the DAG file.
+const ExecutorTypeWaitFor = "waitFor"
+
 // ExecutorConfig contains the configuration for the executor.
 type ExecutorConfig struct {
 	// Type represents one of the registered executors.
@@ -121,6 +178,9 @@ type RetryPolicy struct {
 	LimitStr string `json:"LimitStr,omitempty"`
 	// IntervalSecStr is the string representation of the interval.
 	IntervalSecStr string `json:"IntervalSecStr,omitempty"`
+	// ErrorLog is the list of patterns of the messages of the error lines of
+	// the JSON log. If set, the step is retried only if one of them matches.
+	ErrorLog []string `json:"ErrorLog,omitempty"`
 }
 
 // RepeatPolicy contains the repeat policy for a step.
@@ -141,5 +201,6 @@ type ContinueOn struct {
 	Skipped     bool     `json:"Skipped,omitempty"`     // Skipped is the flag to continue to the next step on skipped.
 	ExitCode    []int    `json:"ExitCode,omitempty"`    // ExitCode is the list of exit codes to continue to the next step.
 	Output      []string `json:"Output,omitempty"`      // Output is the list of output (stdout/stderr) to continue to the next step.
+	ErrorLog    []string `json:"ErrorLog,omitempty"`    // ErrorLog is the list of messages of the error lines of the JSON log to continue to the next step.
 	MarkSuccess bool     `json:"MarkSuccess,omitempty"` // MarkSuccess is the flag to mark the step as success when the condition is met.
 }
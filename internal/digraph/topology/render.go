@@ -0,0 +1,261 @@
+package topology
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Formats to render a graph in.
+const (
+	FormatDOT     = "dot"
+	FormatMermaid = "mermaid"
+	FormatASCII   = "ascii"
+)
+
+// ErrUnknownFormat is returned when the format to render a graph in is not known.
+var ErrUnknownFormat = errors.New("unknown format")
+
+// statusColors are the fill colours of the nodes by the status of the step.
+// The steps not started are not coloured.
+var statusColors = map[string]string{
+	"running":  "#90caf9",
+	"failed":   "#ef9a9a",
+	"canceled": "#f8bbd0",
+	"finished": "#a5d6a7",
+	"skipped":  "#e0e0e0",
+}
+
+// Render writes the graph in the format.
+func Render(w io.Writer, g *Graph, format string) error {
+	var b strings.Builder
+	switch format {
+	case FormatDOT:
+		renderDOT(&b, g)
+	case FormatMermaid:
+		renderMermaid(&b, g)
+	case FormatASCII:
+		renderASCII(&b, g)
+	default:
+		return fmt.Errorf("%w: %q (must be one of %s, %s, %s)", ErrUnknownFormat, format, FormatDOT, FormatMermaid, FormatASCII)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// label returns the text shown for the node.
+func (n *Node) label() string {
+	var label string
+	switch {
+	case n.Kind == KindHandler:
+		label = "on " + n.Event + ": " + n.Name
+	case n.SubWorkflow != "":
+		label = n.Name + " (run: " + n.SubWorkflow + ")"
+	case n.WaitFor != "":
+		label = n.Name + " (waitFor: " + n.WaitFor + ")"
+	default:
+		label = n.Name
+	}
+	return label
+}
+
+// roots returns the nodes of the graph without dependencies.
+func (g *Graph) roots() []*Node {
+	var ret []*Node
+	for _, n := range g.Nodes {
+		if len(n.Depends) == 0 {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+func renderDOT(b *strings.Builder, g *Graph) {
+	fmt.Fprintf(b, "digraph %s {\n", dotQuote(g.Name))
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fillcolor=\"white\"];\n")
+	writeDOTGraph(b, g, "", "  ")
+	if len(g.Handlers) > 0 {
+		b.WriteString("  subgraph \"cluster_handlers\" {\n")
+		b.WriteString("    label=\"handlers\";\n")
+		for _, n := range g.Handlers {
+			writeDOTNode(b, "handler:"+n.Event, n, "    ")
+		}
+		b.WriteString("  }\n")
+	}
+	b.WriteString("}\n")
+}
+
+// writeDOTGraph writes the nodes and the edges of the graph with the IDs
+// prefixed so that the steps of the sub workflows do not clash.
+func writeDOTGraph(b *strings.Builder, g *Graph, prefix, indent string) {
+	for _, n := range g.Nodes {
+		writeDOTNode(b, prefix+n.Name, n, indent)
+		if n.Sub == nil {
+			continue
+		}
+		subPrefix := prefix + n.Name + "/"
+		fmt.Fprintf(b, "%ssubgraph %s {\n", indent, dotQuote("cluster_"+subPrefix))
+		fmt.Fprintf(b, "%s  label=%s;\n", indent, dotQuote(n.Sub.Name))
+		writeDOTGraph(b, n.Sub, subPrefix, indent+"  ")
+		fmt.Fprintf(b, "%s}\n", indent)
+		for _, root := range n.Sub.roots() {
+			fmt.Fprintf(b, "%s%s -> %s [style=dashed];\n", indent, dotQuote(prefix+n.Name), dotQuote(subPrefix+root.Name))
+		}
+	}
+	for _, n := range g.Nodes {
+		for _, dep := range n.Depends {
+			fmt.Fprintf(b, "%s%s -> %s;\n", indent, dotQuote(prefix+dep), dotQuote(prefix+n.Name))
+		}
+	}
+}
+
+func writeDOTNode(b *strings.Builder, id string, n *Node, indent string) {
+	attrs := []string{"label=" + dotQuote(n.label())}
+	if n.Kind == KindHandler {
+		attrs = append(attrs, "shape=note")
+	}
+	if n.Status != "" {
+		attrs = append(attrs, "tooltip="+dotQuote(n.Status))
+		if color, ok := statusColors[n.Status]; ok {
+			attrs = append(attrs, "fillcolor="+dotQuote(color))
+		}
+	}
+	fmt.Fprintf(b, "%s%s [%s];\n", indent, dotQuote(id), strings.Join(attrs, ", "))
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+func renderMermaid(b *strings.Builder, g *Graph) {
+	b.WriteString("flowchart LR\n")
+
+	m := &mermaidWriter{b: b, classes: map[string][]string{}}
+	m.writeGraph(g, "  ")
+	if len(g.Handlers) > 0 {
+		b.WriteString("  subgraph handlers[\"handlers\"]\n")
+		for _, n := range g.Handlers {
+			m.writeNode(n, "    ")
+		}
+		b.WriteString("  end\n")
+	}
+
+	var statuses []string
+	for status := range m.classes {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(b, "  classDef %s fill:%s\n", status, statusColors[status])
+		fmt.Fprintf(b, "  class %s %s\n", strings.Join(m.classes[status], ","), status)
+	}
+}
+
+// mermaidWriter assigns the IDs to the nodes, as Mermaid does not allow
+// arbitrary names as IDs.
+type mermaidWriter struct {
+	b       *strings.Builder
+	next    int
+	classes map[string][]string
+}
+
+// writeGraph writes the nodes and the edges of the graph and returns the IDs
+// of its roots. The IDs are not consecutive when the nodes have sub graphs.
+func (m *mermaidWriter) writeGraph(g *Graph, indent string) []string {
+	ids := map[string]string{}
+	var roots []string
+	for _, n := range g.Nodes {
+		ids[n.Name] = m.writeNode(n, indent)
+		if len(n.Depends) == 0 {
+			roots = append(roots, ids[n.Name])
+		}
+		if n.Sub == nil {
+			continue
+		}
+		fmt.Fprintf(m.b, "%ssubgraph %s_sub[%s]\n", indent, ids[n.Name], mermaidQuote(n.Sub.Name))
+		subRoots := m.writeGraph(n.Sub, indent+"  ")
+		fmt.Fprintf(m.b, "%send\n", indent)
+		for _, root := range subRoots {
+			fmt.Fprintf(m.b, "%s%s -.-> %s\n", indent, ids[n.Name], root)
+		}
+	}
+	for _, n := range g.Nodes {
+		for _, dep := range n.Depends {
+			fmt.Fprintf(m.b, "%s%s --> %s\n", indent, ids[dep], ids[n.Name])
+		}
+	}
+	return roots
+}
+
+func (m *mermaidWriter) writeNode(n *Node, indent string) string {
+	id := fmt.Sprintf("n%d", m.next)
+	m.next++
+	if n.Kind == KindHandler {
+		fmt.Fprintf(m.b, "%s%s[/%s/]\n", indent, id, mermaidQuote(n.label()))
+	} else {
+		fmt.Fprintf(m.b, "%s%s[%s]\n", indent, id, mermaidQuote(n.label()))
+	}
+	if _, ok := statusColors[n.Status]; ok {
+		m.classes[n.Status] = append(m.classes[n.Status], id)
+	}
+	return id
+}
+
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
+
+func renderASCII(b *strings.Builder, g *Graph) {
+	b.WriteString(g.Name + "\n")
+	items := asciiItems(g)
+	if len(g.Handlers) > 0 {
+		var handlers []asciiItem
+		for _, n := range g.Handlers {
+			handlers = append(handlers, asciiItem{text: asciiText(n)})
+		}
+		items = append(items, asciiItem{text: "handlers", children: handlers})
+	}
+	writeASCIITree(b, items, "")
+}
+
+type asciiItem struct {
+	text     string
+	children []asciiItem
+}
+
+func asciiItems(g *Graph) []asciiItem {
+	var items []asciiItem
+	for _, n := range g.Nodes {
+		item := asciiItem{text: asciiText(n)}
+		if n.Sub != nil {
+			item.children = asciiItems(n.Sub)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func asciiText(n *Node) string {
+	text := n.label()
+	if n.Status != "" {
+		text += " [" + n.Status + "]"
+	}
+	if len(n.Depends) > 0 {
+		text += " <- " + strings.Join(n.Depends, ", ")
+	}
+	return text
+}
+
+func writeASCIITree(b *strings.Builder, items []asciiItem, prefix string) {
+	for i, item := range items {
+		branch, next := "├── ", "│   "
+		if i == len(items)-1 {
+			branch, next = "└── ", "    "
+		}
+		b.WriteString(prefix + branch + item.text + "\n")
+		writeASCIITree(b, item.children, prefix+next)
+	}
+}
@@ -0,0 +1,143 @@
+// Package topology renders the dependency graph of the steps of a DAG in
+// text formats such as DOT, Mermaid and ASCII.
+package topology
+
+import (
+	"fmt"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+)
+
+// Node kinds.
+const (
+	KindStep    = "step"
+	KindHandler = "handler"
+	KindWaitFor = "waitFor"
+)
+
+// Graph is the dependency graph of the steps of a DAG.
+type Graph struct {
+	// Name is the name of the DAG.
+	Name string
+	// Nodes are the steps in the order of the DAG definition.
+	Nodes []*Node
+	// Handlers are the handler steps run on the events of the DAG.
+	Handlers []*Node
+}
+
+// Node is a step in the graph.
+type Node struct {
+	// Name is the name of the step.
+	Name string
+	// Kind is the kind of the node, e.g. "step" or "handler".
+	Kind string
+	// Event is the event a handler is run on, e.g. "exit".
+	Event string
+	// Depends are the names of the steps the node depends on.
+	Depends []string
+	// Status is the status of the step in the run, if it is given.
+	Status string
+	// SubWorkflow is the name of the DAG run by the step, if any.
+	SubWorkflow string
+	// WaitFor is the name of the DAG the step waits for, if any.
+	WaitFor string
+	// Sub is the graph of the sub workflow when it is expanded.
+	Sub *Graph
+}
+
+// Options are the options to build a graph.
+type Options struct {
+	// Status is the status of a run of the DAG to colour the nodes by.
+	Status *model.Status
+	// LoadDAG loads a sub workflow by the name to expand it. Sub workflows
+	// are not expanded if it is nil.
+	LoadDAG func(name string) (*digraph.DAG, error)
+}
+
+// Build builds the graph of the DAG. It returns an error if the steps have
+// a cycle or the sub workflows cannot be loaded.
+func Build(dag *digraph.DAG, opts Options) (*Graph, error) {
+	return build(dag, opts, map[string]bool{})
+}
+
+func build(dag *digraph.DAG, opts Options, visiting map[string]bool) (*Graph, error) {
+	// Validate the dependencies in the same way as the scheduler does.
+	if _, err := scheduler.NewExecutionGraph(dag.Steps...); err != nil {
+		return nil, fmt.Errorf("invalid dependencies in %s: %w", dag.Name, err)
+	}
+
+	visiting[dag.Name] = true
+	defer delete(visiting, dag.Name)
+
+	steps := map[string]string{}
+	handlers := map[string]string{}
+	if opts.Status != nil {
+		for _, n := range opts.Status.Nodes {
+			steps[n.Step.Name] = n.Status.String()
+		}
+		for event, n := range map[string]*model.Node{
+			"success": opts.Status.OnSuccess,
+			"failure": opts.Status.OnFailure,
+			"cancel":  opts.Status.OnCancel,
+			"exit":    opts.Status.OnExit,
+		} {
+			if n != nil {
+				handlers[event] = n.Status.String()
+			}
+		}
+	}
+
+	g := &Graph{Name: dag.Name}
+	for _, step := range dag.Steps {
+		node := &Node{
+			Name:    step.Name,
+			Kind:    KindStep,
+			Depends: step.Depends,
+		}
+		if step.SubWorkflow != nil {
+			node.SubWorkflow = step.SubWorkflow.Name
+		}
+		if step.WaitFor != nil {
+			node.Kind = KindWaitFor
+			node.WaitFor = step.WaitFor.DAG
+		}
+		node.Status = steps[step.Name]
+		if node.SubWorkflow != "" && opts.LoadDAG != nil {
+			sub, err := opts.LoadDAG(node.SubWorkflow)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load the sub workflow %s: %w", node.SubWorkflow, err)
+			}
+			// A sub workflow running one of its callers is not expanded
+			// again. The status of the run of the sub workflow is not known.
+			if !visiting[sub.Name] {
+				subOpts := opts
+				subOpts.Status = nil
+				if node.Sub, err = build(sub, subOpts, visiting); err != nil {
+					return nil, err
+				}
+			}
+		}
+		g.Nodes = append(g.Nodes, node)
+	}
+
+	for _, h := range []struct {
+		event string
+		step  *digraph.Step
+	}{
+		{"success", dag.HandlerOn.Success},
+		{"failure", dag.HandlerOn.Failure},
+		{"cancel", dag.HandlerOn.Cancel},
+		{"exit", dag.HandlerOn.Exit},
+	} {
+		if h.step == nil {
+			continue
+		}
+		node := &Node{Name: h.step.Name, Kind: KindHandler, Event: h.event}
+		node.Status = handlers[h.event]
+		g.Handlers = append(g.Handlers, node)
+	}
+
+	return g, nil
+}
@@ -0,0 +1,204 @@
+package topology
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func testDAG() *digraph.DAG {
+	return &digraph.DAG{
+		Name: "parent",
+		Steps: []digraph.Step{
+			{Name: "extract", Command: "true"},
+			{Name: "transform", Depends: []string{"extract"}, SubWorkflow: &digraph.SubWorkflow{Name: "child"}},
+			{Name: "load", Depends: []string{"transform"}, WaitFor: &digraph.WaitFor{DAG: "upstream"}},
+		},
+		HandlerOn: digraph.HandlerOn{
+			Exit: &digraph.Step{Name: "onExit", Command: "true"},
+		},
+	}
+}
+
+func testLoadDAG(dags ...*digraph.DAG) func(string) (*digraph.DAG, error) {
+	return func(name string) (*digraph.DAG, error) {
+		for _, dag := range dags {
+			if dag.Name == name {
+				return dag, nil
+			}
+		}
+		return nil, fmt.Errorf("DAG %s not found", name)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	t.Run("Nodes", func(t *testing.T) {
+		g, err := Build(testDAG(), Options{})
+		require.NoError(t, err)
+
+		require.Equal(t, "parent", g.Name)
+		require.Len(t, g.Nodes, 3)
+		require.Equal(t, KindStep, g.Nodes[0].Kind)
+		require.Equal(t, "child", g.Nodes[1].SubWorkflow)
+		require.Nil(t, g.Nodes[1].Sub)
+		require.Equal(t, KindWaitFor, g.Nodes[2].Kind)
+		require.Equal(t, "upstream", g.Nodes[2].WaitFor)
+		require.Equal(t, []string{"transform"}, g.Nodes[2].Depends)
+
+		require.Len(t, g.Handlers, 1)
+		require.Equal(t, KindHandler, g.Handlers[0].Kind)
+		require.Equal(t, "exit", g.Handlers[0].Event)
+	})
+	t.Run("Status", func(t *testing.T) {
+		dag := testDAG()
+		status := &model.Status{
+			Nodes: []*model.Node{
+				{Step: dag.Steps[0], Status: scheduler.NodeStatusSuccess},
+				{Step: dag.Steps[1], Status: scheduler.NodeStatusError},
+				{Step: dag.Steps[2], Status: scheduler.NodeStatusSkipped},
+			},
+			OnExit: &model.Node{Step: *dag.HandlerOn.Exit, Status: scheduler.NodeStatusSuccess},
+		}
+
+		g, err := Build(dag, Options{Status: status})
+		require.NoError(t, err)
+
+		require.Equal(t, "finished", g.Nodes[0].Status)
+		require.Equal(t, "failed", g.Nodes[1].Status)
+		require.Equal(t, "skipped", g.Nodes[2].Status)
+		require.Equal(t, "finished", g.Handlers[0].Status)
+	})
+	t.Run("ExpandSubWorkflows", func(t *testing.T) {
+		child := &digraph.DAG{
+			Name: "child",
+			Steps: []digraph.Step{
+				{Name: "a", Command: "true"},
+				{Name: "b", Depends: []string{"a"}, SubWorkflow: &digraph.SubWorkflow{Name: "parent"}},
+			},
+		}
+
+		g, err := Build(testDAG(), Options{LoadDAG: testLoadDAG(child, testDAG())})
+		require.NoError(t, err)
+
+		sub := g.Nodes[1].Sub
+		require.NotNil(t, sub)
+		require.Equal(t, "child", sub.Name)
+		require.Len(t, sub.Nodes, 2)
+		// The parent is not expanded again in the child.
+		require.Nil(t, sub.Nodes[1].Sub)
+	})
+	t.Run("SubWorkflowNotFound", func(t *testing.T) {
+		_, err := Build(testDAG(), Options{LoadDAG: testLoadDAG()})
+		require.Error(t, err)
+	})
+	t.Run("Cycle", func(t *testing.T) {
+		dag := &digraph.DAG{
+			Name: "cycle",
+			Steps: []digraph.Step{
+				{Name: "a", Command: "true", Depends: []string{"b"}},
+				{Name: "b", Command: "true", Depends: []string{"a"}},
+			},
+		}
+		_, err := Build(dag, Options{})
+		require.Error(t, err)
+	})
+}
+
+func TestRender(t *testing.T) {
+	child := &digraph.DAG{
+		Name:  "child",
+		Steps: []digraph.Step{{Name: "a", Command: "true"}},
+	}
+	dag := testDAG()
+	status := &model.Status{
+		Nodes: []*model.Node{
+			{Step: dag.Steps[0], Status: scheduler.NodeStatusSuccess},
+			{Step: dag.Steps[1], Status: scheduler.NodeStatusRunning},
+			{Step: dag.Steps[2], Status: scheduler.NodeStatusNone},
+		},
+	}
+	g, err := Build(dag, Options{Status: status, LoadDAG: testLoadDAG(child)})
+	require.NoError(t, err)
+
+	t.Run("DOT", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Render(&buf, g, FormatDOT))
+
+		out := buf.String()
+		require.Contains(t, out, `digraph "parent" {`)
+		require.Contains(t, out, `"extract" [label="extract", tooltip="finished", fillcolor="#a5d6a7"];`)
+		require.Contains(t, out, `"extract" -> "transform";`)
+		require.Contains(t, out, `subgraph "cluster_transform/" {`)
+		require.Contains(t, out, `"transform" -> "transform/a" [style=dashed];`)
+		require.Contains(t, out, `label="load (waitFor: upstream)"`)
+		require.Contains(t, out, `"handler:exit" [label="on exit: onExit", shape=note];`)
+	})
+	t.Run("Mermaid", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Render(&buf, g, FormatMermaid))
+
+		out := buf.String()
+		require.Contains(t, out, "flowchart LR\n")
+		require.Contains(t, out, `n0["extract"]`)
+		require.Contains(t, out, `n1["transform (run: child)"]`)
+		require.Contains(t, out, `subgraph n1_sub["child"]`)
+		require.Contains(t, out, "n1 -.-> n2")
+		require.Contains(t, out, "n0 --> n1")
+		require.Contains(t, out, `n4[/"on exit: onExit"/]`)
+		require.Contains(t, out, "class n0 finished")
+		require.Contains(t, out, "class n1 running")
+	})
+	t.Run("MermaidNestedSubWorkflows", func(t *testing.T) {
+		// a runs b, whose first step runs c before its second root.
+		a := &digraph.DAG{
+			Name:  "a",
+			Steps: []digraph.Step{{Name: "runB", SubWorkflow: &digraph.SubWorkflow{Name: "b"}}},
+		}
+		b := &digraph.DAG{
+			Name: "b",
+			Steps: []digraph.Step{
+				{Name: "runC", SubWorkflow: &digraph.SubWorkflow{Name: "c"}},
+				{Name: "other", Command: "true"},
+			},
+		}
+		c := &digraph.DAG{
+			Name:  "c",
+			Steps: []digraph.Step{{Name: "leaf", Command: "true"}},
+		}
+		g, err := Build(a, Options{LoadDAG: testLoadDAG(b, c)})
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, Render(&buf, g, FormatMermaid))
+
+		out := buf.String()
+		require.Contains(t, out, `n2["leaf"]`)
+		require.Contains(t, out, `n3["other"]`)
+		require.Contains(t, out, "n0 -.-> n1")
+		require.Contains(t, out, "n0 -.-> n3")
+		require.NotContains(t, out, "n0 -.-> n2")
+		require.Contains(t, out, "n1 -.-> n2")
+	})
+	t.Run("ASCII", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Render(&buf, g, FormatASCII))
+
+		require.Equal(t, `parent
+├── extract [finished]
+├── transform (run: child) [running] <- extract
+│   └── a
+├── load (waitFor: upstream) [not started] <- transform
+└── handlers
+    └── on exit: onExit
+`, buf.String())
+	})
+	t.Run("UnknownFormat", func(t *testing.T) {
+		err := Render(&bytes.Buffer{}, g, "svg")
+		require.ErrorIs(t, err, ErrUnknownFormat)
+	})
+}
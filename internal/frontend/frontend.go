@@ -5,15 +5,32 @@ import (
 	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/frontend/handlers"
 	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/persistence"
 )
 
-func New(cfg *config.Config, cli client.Client) *server.Server {
+func New(cfg *config.Config, cli client.Client, auditStore persistence.AuditStore, decisionStore persistence.DecisionStore) *server.Server {
 	var apiHandlers []server.Handler
 
-	dagAPIHandler := handlers.NewDAG(cli, cfg.UI.LogEncodingCharset, cfg.RemoteNodes, cfg.APIBasePath)
+	dagAPIHandler := handlers.NewDAG(cli, cfg.UI.LogEncodingCharset, cfg.RemoteNodes, cfg.APIBasePath, auditStore, cfg.Location)
 	apiHandlers = append(apiHandlers, dagAPIHandler)
 
-	systemAPIHandler := handlers.NewSystem()
+	auditAPIHandler := handlers.NewAudit(auditStore)
+	apiHandlers = append(apiHandlers, auditAPIHandler)
+
+	decisionAPIHandler := handlers.NewDecision(decisionStore)
+	apiHandlers = append(apiHandlers, decisionAPIHandler)
+
+	scheduleAPIHandler := handlers.NewSchedule(cli, cfg.Paths.DAGsDir, cfg.Location)
+	apiHandlers = append(apiHandlers, scheduleAPIHandler)
+
+	secretAPIHandler := handlers.NewSecret(cfg.Secrets.LocalFile, cfg.Secrets.KeyFile, auditStore)
+	apiHandlers = append(apiHandlers, secretAPIHandler)
+
+	var leaseDir string
+	if cfg.Scheduler != nil && cfg.Scheduler.HA {
+		leaseDir = cfg.Scheduler.LeaseDir
+	}
+	systemAPIHandler := handlers.NewSystem(leaseDir, cfg.Paths.LogDir, cfg.Paths.AdminLogsDir, cfg.Logs.QuotaBytes)
 	apiHandlers = append(apiHandlers, systemAPIHandler)
 
 	var remoteNodes []string
@@ -34,6 +51,7 @@ func New(cfg *config.Config, cli client.Client) *server.Server {
 		TimeZone:              cfg.TZ,
 		RemoteNodes:           remoteNodes,
 		Headless:              cfg.Headless,
+		TrustedProxies:        cfg.TrustedProxies,
 	}
 
 	if cfg.Auth.Token.Enabled {
@@ -49,5 +67,9 @@ func New(cfg *config.Config, cli client.Client) *server.Server {
 		}
 	}
 
+	if cfg.Auth.OIDC.Enabled {
+		serverParams.OIDC = &cfg.Auth.OIDC
+	}
+
 	return server.New(serverParams)
 }
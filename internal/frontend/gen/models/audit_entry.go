@@ -0,0 +1,154 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// AuditEntry A single record of a mutating operation.
+//
+// swagger:model AuditEntry
+type AuditEntry struct {
+
+	// Action performed.
+	// Required: true
+	Action *string `json:"Action"`
+
+	// User who performed the action.
+	// Required: true
+	Actor *string `json:"Actor"`
+
+	// DAG the action was performed on.
+	// Required: true
+	DAG *string `json:"DAG"`
+
+	// Additional information about the action.
+	Detail string `json:"Detail,omitempty"`
+
+	// Unified diff of the DAG spec for save actions.
+	Diff string `json:"Diff,omitempty"`
+
+	// Request ID of the DAG run the action targeted.
+	RequestID string `json:"RequestId,omitempty"`
+
+	// Where the action originated from (api or cli).
+	// Required: true
+	Source *string `json:"Source"`
+
+	// IP address of the client for API actions.
+	SourceIP string `json:"SourceIP,omitempty"`
+
+	// Step name if the action targeted a specific step.
+	Step string `json:"Step,omitempty"`
+
+	// Time the action was recorded.
+	// Required: true
+	Timestamp *string `json:"Timestamp"`
+}
+
+// Validate validates this audit entry
+func (m *AuditEntry) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAction(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateActor(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateDAG(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSource(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTimestamp(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *AuditEntry) validateAction(formats strfmt.Registry) error {
+
+	if err := validate.Required("Action", "body", m.Action); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *AuditEntry) validateActor(formats strfmt.Registry) error {
+
+	if err := validate.Required("Actor", "body", m.Actor); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *AuditEntry) validateDAG(formats strfmt.Registry) error {
+
+	if err := validate.Required("DAG", "body", m.DAG); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *AuditEntry) validateSource(formats strfmt.Registry) error {
+
+	if err := validate.Required("Source", "body", m.Source); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *AuditEntry) validateTimestamp(formats strfmt.Registry) error {
+
+	if err := validate.Required("Timestamp", "body", m.Timestamp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this audit entry based on context it is used
+func (m *AuditEntry) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *AuditEntry) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *AuditEntry) UnmarshalBinary(b []byte) error {
+	var res AuditEntry
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -83,6 +83,9 @@ type DAGDetails struct {
 	// List of tags for categorizing and filtering DAGs
 	// Required: true
 	Tags []string `json:"Tags"`
+
+	// Timezone of the DAG the schedule is evaluated in unless the expression has CRON_TZ
+	Timezone string `json:"Timezone,omitempty"`
 }
 
 // Validate validates this d a g details
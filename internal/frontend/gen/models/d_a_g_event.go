@@ -0,0 +1,195 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// DAGEvent A change of the status of a running DAG or of one of its steps.
+//
+// swagger:model DAGEvent
+type DAGEvent struct {
+
+	// Step whose status changed, absent if the status of the DAG changed.
+	Node *Node `json:"Node,omitempty"`
+
+	// request Id
+	// Required: true
+	RequestID *string `json:"RequestId"`
+
+	// Sequence number of the event in the run, starting at 1.
+	// Required: true
+	Seq *int64 `json:"Seq"`
+
+	// Status of the DAG at the event.
+	// Required: true
+	Status *int64 `json:"Status"`
+
+	// status text
+	// Required: true
+	StatusText *string `json:"StatusText"`
+
+	// Time of the event.
+	// Required: true
+	Time *string `json:"Time"`
+}
+
+// Validate validates this d a g event
+func (m *DAGEvent) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateNode(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateRequestID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSeq(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateStatusText(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTime(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DAGEvent) validateNode(formats strfmt.Registry) error {
+	if swag.IsZero(m.Node) { // not required
+		return nil
+	}
+
+	if m.Node != nil {
+		if err := m.Node.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Node")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Node")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *DAGEvent) validateRequestID(formats strfmt.Registry) error {
+
+	if err := validate.Required("RequestId", "body", m.RequestID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DAGEvent) validateSeq(formats strfmt.Registry) error {
+
+	if err := validate.Required("Seq", "body", m.Seq); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DAGEvent) validateStatus(formats strfmt.Registry) error {
+
+	if err := validate.Required("Status", "body", m.Status); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DAGEvent) validateStatusText(formats strfmt.Registry) error {
+
+	if err := validate.Required("StatusText", "body", m.StatusText); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DAGEvent) validateTime(formats strfmt.Registry) error {
+
+	if err := validate.Required("Time", "body", m.Time); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this d a g event based on the context it is used
+func (m *DAGEvent) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateNode(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DAGEvent) contextValidateNode(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Node != nil {
+
+		if swag.IsZero(m.Node) { // not required
+			return nil
+		}
+
+		if err := m.Node.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Node")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Node")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DAGEvent) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DAGEvent) UnmarshalBinary(b []byte) error {
+	var res DAGEvent
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
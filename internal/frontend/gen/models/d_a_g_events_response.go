@@ -0,0 +1,158 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// DAGEventsResponse Response object for the events of a running DAG.
+//
+// swagger:model DAGEventsResponse
+type DAGEventsResponse struct {
+
+	// Events after the sequence number, oldest first.
+	// Required: true
+	Events []*DAGEvent `json:"Events"`
+
+	// Whether the run has finished and no events follow.
+	// Required: true
+	Finished *bool `json:"Finished"`
+
+	// Sequence number of the last event of the run, after which the next events are requested.
+	// Required: true
+	Seq *int64 `json:"Seq"`
+}
+
+// Validate validates this d a g events response
+func (m *DAGEventsResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateEvents(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateFinished(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSeq(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DAGEventsResponse) validateEvents(formats strfmt.Registry) error {
+
+	if err := validate.Required("Events", "body", m.Events); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Events); i++ {
+		if swag.IsZero(m.Events[i]) { // not required
+			continue
+		}
+
+		if m.Events[i] != nil {
+			if err := m.Events[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Events" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Events" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+func (m *DAGEventsResponse) validateFinished(formats strfmt.Registry) error {
+
+	if err := validate.Required("Finished", "body", m.Finished); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DAGEventsResponse) validateSeq(formats strfmt.Registry) error {
+
+	if err := validate.Required("Seq", "body", m.Seq); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this d a g events response based on the context it is used
+func (m *DAGEventsResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateEvents(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DAGEventsResponse) contextValidateEvents(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Events); i++ {
+
+		if m.Events[i] != nil {
+
+			if swag.IsZero(m.Events[i]) { // not required
+				return nil
+			}
+
+			if err := m.Events[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Events" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Events" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DAGEventsResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DAGEventsResponse) UnmarshalBinary(b []byte) error {
+	var res DAGEventsResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,122 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// DAGRevision A saved version of a DAG spec.
+//
+// swagger:model DAGRevision
+type DAGRevision struct {
+
+	// User who saved the revision.
+	// Required: true
+	Author *string `json:"Author"`
+
+	// Content hash of the spec.
+	// Required: true
+	Hash *string `json:"Hash"`
+
+	// Description of the change.
+	// Required: true
+	Message *string `json:"Message"`
+
+	// Time the revision was saved.
+	// Required: true
+	Timestamp *string `json:"Timestamp"`
+}
+
+// Validate validates this d a g revision
+func (m *DAGRevision) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateAuthor(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateHash(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateMessage(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTimestamp(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DAGRevision) validateAuthor(formats strfmt.Registry) error {
+
+	if err := validate.Required("Author", "body", m.Author); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DAGRevision) validateHash(formats strfmt.Registry) error {
+
+	if err := validate.Required("Hash", "body", m.Hash); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DAGRevision) validateMessage(formats strfmt.Registry) error {
+
+	if err := validate.Required("Message", "body", m.Message); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *DAGRevision) validateTimestamp(formats strfmt.Registry) error {
+
+	if err := validate.Required("Timestamp", "body", m.Timestamp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this d a g revision based on context it is used
+func (m *DAGRevision) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DAGRevision) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DAGRevision) UnmarshalBinary(b []byte) error {
+	var res DAGRevision
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -43,6 +43,9 @@ type DAGStatus struct {
 	// Required: true
 	RequestID *string `json:"RequestId"`
 
+	// Reason the scheduled run was skipped, e.g. an excluded date
+	SkipReason string `json:"SkipReason,omitempty"`
+
 	// RFC 3339 timestamp when the DAG execution started
 	// Required: true
 	StartedAt *string `json:"StartedAt"`
@@ -64,6 +64,15 @@ type DAGStatusDetails struct {
 	// Required: true
 	RequestID *string `json:"RequestId"`
 
+	// Time the run was scheduled for by the scheduler.
+	ScheduledTime string `json:"ScheduledTime,omitempty"`
+
+	// Reason the scheduled run was skipped, e.g. an excluded date.
+	SkipReason string `json:"SkipReason,omitempty"`
+
+	// Revision hash of the DAG spec used by the run.
+	SpecRevision string `json:"SpecRevision,omitempty"`
+
 	// Timestamp when the DAG started.
 	// Required: true
 	StartedAt *string `json:"StartedAt"`
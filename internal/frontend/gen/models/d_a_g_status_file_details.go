@@ -19,6 +19,9 @@ import (
 // swagger:model DAGStatusFileDetails
 type DAGStatusFileDetails struct {
 
+	// Last git commit that changed the DAG file when git sync is enabled
+	CommitSHA string `json:"CommitSHA,omitempty"`
+
 	// d a g
 	// Required: true
 	DAG *DAGDetails `json:"DAG"`
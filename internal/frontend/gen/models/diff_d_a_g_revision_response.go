@@ -0,0 +1,71 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// DiffDAGRevisionResponse Response object for diffing revisions of a DAG.
+//
+// swagger:model DiffDAGRevisionResponse
+type DiffDAGRevisionResponse struct {
+
+	// Unified diff between the revisions.
+	// Required: true
+	Diff *string `json:"Diff"`
+}
+
+// Validate validates this diff d a g revision response
+func (m *DiffDAGRevisionResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDiff(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *DiffDAGRevisionResponse) validateDiff(formats strfmt.Registry) error {
+
+	if err := validate.Required("Diff", "body", m.Diff); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this diff d a g revision response based on context it is used
+func (m *DiffDAGRevisionResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *DiffDAGRevisionResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *DiffDAGRevisionResponse) UnmarshalBinary(b []byte) error {
+	var res DiffDAGRevisionResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
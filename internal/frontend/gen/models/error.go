@@ -22,7 +22,7 @@ type Error struct {
 
 	// Error code indicating the type of error.
 	// Required: true
-	// Enum: [validation_error not_found internal_error unauthorized bad_gateway]
+	// Enum: [validation_error not_found internal_error unauthorized forbidden bad_gateway]
 	Code *string `json:"code"`
 
 	// Additional error details.
@@ -55,7 +55,7 @@ var errorTypeCodePropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["validation_error","not_found","internal_error","unauthorized","bad_gateway"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["validation_error","not_found","internal_error","unauthorized","forbidden","bad_gateway"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -77,6 +77,9 @@ const (
 	// ErrorCodeUnauthorized captures enum value "unauthorized"
 	ErrorCodeUnauthorized string = "unauthorized"
 
+	// ErrorCodeForbidden captures enum value "forbidden"
+	ErrorCodeForbidden string = "forbidden"
+
 	// ErrorCodeBadGateway captures enum value "bad_gateway"
 	ErrorCodeBadGateway string = "bad_gateway"
 )
@@ -0,0 +1,125 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// GetDAGRevisionResponse Response object for getting a revision of a DAG.
+//
+// swagger:model GetDAGRevisionResponse
+type GetDAGRevisionResponse struct {
+
+	// revision
+	// Required: true
+	Revision *DAGRevision `json:"Revision"`
+
+	// The DAG spec of the revision.
+	// Required: true
+	Spec *string `json:"Spec"`
+}
+
+// Validate validates this get d a g revision response
+func (m *GetDAGRevisionResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateRevision(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateSpec(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GetDAGRevisionResponse) validateRevision(formats strfmt.Registry) error {
+
+	if err := validate.Required("Revision", "body", m.Revision); err != nil {
+		return err
+	}
+
+	if m.Revision != nil {
+		if err := m.Revision.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Revision")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Revision")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *GetDAGRevisionResponse) validateSpec(formats strfmt.Registry) error {
+
+	if err := validate.Required("Spec", "body", m.Spec); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validate this get d a g revision response based on the context it is used
+func (m *GetDAGRevisionResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateRevision(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GetDAGRevisionResponse) contextValidateRevision(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Revision != nil {
+
+		if err := m.Revision.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("Revision")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("Revision")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *GetDAGRevisionResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *GetDAGRevisionResponse) UnmarshalBinary(b []byte) error {
+	var res GetDAGRevisionResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
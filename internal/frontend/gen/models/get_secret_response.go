@@ -0,0 +1,105 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// GetSecretResponse Response object for getting a secret. The value is not returned.
+//
+// swagger:model GetSecretResponse
+type GetSecretResponse struct {
+
+	// Name of the secret.
+	// Required: true
+	Name *string `json:"Name"`
+
+	// Scope of the secret.
+	// Required: true
+	Scope *string `json:"Scope"`
+
+	// Time the secret was last set.
+	// Required: true
+	UpdatedAt *string `json:"UpdatedAt"`
+}
+
+// Validate validates this get secret response
+func (m *GetSecretResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateScope(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateUpdatedAt(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *GetSecretResponse) validateName(formats strfmt.Registry) error {
+
+	if err := validate.Required("Name", "body", m.Name); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *GetSecretResponse) validateScope(formats strfmt.Registry) error {
+
+	if err := validate.Required("Scope", "body", m.Scope); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *GetSecretResponse) validateUpdatedAt(formats strfmt.Registry) error {
+
+	if err := validate.Required("UpdatedAt", "body", m.UpdatedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this get secret response based on context it is used
+func (m *GetSecretResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *GetSecretResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *GetSecretResponse) UnmarshalBinary(b []byte) error {
+	var res GetSecretResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -20,6 +20,12 @@ import (
 // swagger:model HealthResponse
 type HealthResponse struct {
 
+	// logs
+	Logs *LogUsage `json:"logs,omitempty"`
+
+	// scheduler
+	Scheduler *SchedulerLeader `json:"scheduler,omitempty"`
+
 	// Overall health status of the server
 	// Required: true
 	// Enum: [healthy unhealthy]
@@ -42,6 +48,14 @@ type HealthResponse struct {
 func (m *HealthResponse) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateLogs(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateScheduler(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateStatus(formats); err != nil {
 		res = append(res, err)
 	}
@@ -64,6 +78,44 @@ func (m *HealthResponse) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *HealthResponse) validateLogs(formats strfmt.Registry) error {
+	if swag.IsZero(m.Logs) { // not required
+		return nil
+	}
+
+	if m.Logs != nil {
+		if err := m.Logs.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("logs")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("logs")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *HealthResponse) validateScheduler(formats strfmt.Registry) error {
+	if swag.IsZero(m.Scheduler) { // not required
+		return nil
+	}
+
+	if m.Scheduler != nil {
+		if err := m.Scheduler.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("scheduler")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("scheduler")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 var healthResponseTypeStatusPropEnum []interface{}
 
 func init() {
@@ -134,8 +186,63 @@ func (m *HealthResponse) validateVersion(formats strfmt.Registry) error {
 	return nil
 }
 
-// ContextValidate validates this health response based on context it is used
+// ContextValidate validate this health response based on the context it is used
 func (m *HealthResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateLogs(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateScheduler(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *HealthResponse) contextValidateLogs(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Logs != nil {
+
+		if swag.IsZero(m.Logs) { // not required
+			return nil
+		}
+
+		if err := m.Logs.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("logs")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("logs")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *HealthResponse) contextValidateScheduler(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Scheduler != nil {
+
+		if swag.IsZero(m.Scheduler) { // not required
+			return nil
+		}
+
+		if err := m.Scheduler.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("scheduler")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("scheduler")
+			}
+			return err
+		}
+	}
+
 	return nil
 }
 
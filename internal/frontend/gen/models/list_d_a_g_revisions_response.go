@@ -0,0 +1,124 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// ListDAGRevisionsResponse Response object for listing revisions of a DAG.
+//
+// swagger:model ListDAGRevisionsResponse
+type ListDAGRevisionsResponse struct {
+
+	// Revisions of the DAG spec, newest first.
+	// Required: true
+	Revisions []*DAGRevision `json:"Revisions"`
+}
+
+// Validate validates this list d a g revisions response
+func (m *ListDAGRevisionsResponse) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateRevisions(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ListDAGRevisionsResponse) validateRevisions(formats strfmt.Registry) error {
+
+	if err := validate.Required("Revisions", "body", m.Revisions); err != nil {
+		return err
+	}
+
+	for i := 0; i < len(m.Revisions); i++ {
+		if swag.IsZero(m.Revisions[i]) { // not required
+			continue
+		}
+
+		if m.Revisions[i] != nil {
+			if err := m.Revisions[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Revisions" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Revisions" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// ContextValidate validate this list d a g revisions response based on the context it is used
+func (m *ListDAGRevisionsResponse) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateRevisions(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ListDAGRevisionsResponse) contextValidateRevisions(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Revisions); i++ {
+
+		if m.Revisions[i] != nil {
+
+			if swag.IsZero(m.Revisions[i]) { // not required
+				return nil
+			}
+
+			if err := m.Revisions[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Revisions" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Revisions" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ListDAGRevisionsResponse) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ListDAGRevisionsResponse) UnmarshalBinary(b []byte) error {
+	var res ListDAGRevisionsResponse
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
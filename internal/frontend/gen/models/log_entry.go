@@ -0,0 +1,59 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// LogEntry A line of the JSON log of a step
+//
+// swagger:model LogEntry
+type LogEntry struct {
+
+	// Other fields of the line
+	Fields interface{} `json:"fields,omitempty"`
+
+	// Level of the line in lower case, e.g. error
+	Level string `json:"level,omitempty"`
+
+	// Message of the line
+	Message string `json:"message,omitempty"`
+
+	// RFC 3339 timestamp of the line, if any
+	Time string `json:"time,omitempty"`
+}
+
+// Validate validates this log entry
+func (m *LogEntry) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this log entry based on context it is used
+func (m *LogEntry) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *LogEntry) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *LogEntry) UnmarshalBinary(b []byte) error {
+	var res LogEntry
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
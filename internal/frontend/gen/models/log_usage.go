@@ -0,0 +1,91 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// LogUsage Disk usage of the log directory.
+//
+// swagger:model LogUsage
+type LogUsage struct {
+
+	// Quota in bytes of the log directory, 0 if unlimited.
+	// Required: true
+	Quota *int64 `json:"quota"`
+
+	// Time the usage was measured.
+	UpdatedAt string `json:"updatedAt,omitempty"`
+
+	// Total size in bytes of the files in the log directory.
+	// Required: true
+	Usage *int64 `json:"usage"`
+}
+
+// Validate validates this log usage
+func (m *LogUsage) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateQuota(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateUsage(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *LogUsage) validateQuota(formats strfmt.Registry) error {
+
+	if err := validate.Required("quota", "body", m.Quota); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *LogUsage) validateUsage(formats strfmt.Registry) error {
+
+	if err := validate.Required("usage", "body", m.Usage); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this log usage based on context it is used
+func (m *LogUsage) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *LogUsage) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *LogUsage) UnmarshalBinary(b []byte) error {
+	var res LogUsage
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -35,6 +35,12 @@ type Node struct {
 	// Required: true
 	Log *string `json:"Log"`
 
+	// Number of the lines of the JSON log by level, for the steps with logFormat json
+	LogLevels map[string]int64 `json:"LogLevels,omitempty"`
+
+	// Peak resident set size of the step in bytes
+	PeakRSS int64 `json:"PeakRSS,omitempty"`
+
 	// Number of retry attempts made for this step
 	// Required: true
 	RetryCount *int64 `json:"RetryCount"`
@@ -54,6 +60,12 @@ type Node struct {
 	// step
 	// Required: true
 	Step *Step `json:"Step"`
+
+	// CPU time spent in kernel mode by the step in milliseconds
+	SystemTime int64 `json:"SystemTime,omitempty"`
+
+	// CPU time spent in user mode by the step in milliseconds
+	UserTime int64 `json:"UserTime,omitempty"`
 }
 
 // Validate validates this node
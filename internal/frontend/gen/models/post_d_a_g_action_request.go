@@ -22,9 +22,12 @@ type PostDAGActionRequest struct {
 
 	// Action to be performed on the DAG.
 	// Required: true
-	// Enum: [start suspend stop retry mark-success mark-failed save rename]
+	// Enum: [start suspend stop retry mark-success mark-failed stop-step retry-step save rename rollback]
 	Action *string `json:"action"`
 
+	// Description of the change for save and rollback actions.
+	Message string `json:"message,omitempty"`
+
 	// Additional parameters for the action.
 	Params string `json:"params,omitempty"`
 
@@ -34,7 +37,7 @@ type PostDAGActionRequest struct {
 	// Step name if the action targets a specific step.
 	Step string `json:"step,omitempty"`
 
-	// Optional extra value for the action.
+	// Optional extra value for the action, e.g. the status (failed or skipped) to stop the step with.
 	Value string `json:"value,omitempty"`
 }
 
@@ -56,7 +59,7 @@ var postDAGActionRequestTypeActionPropEnum []interface{}
 
 func init() {
 	var res []string
-	if err := json.Unmarshal([]byte(`["start","suspend","stop","retry","mark-success","mark-failed","save","rename"]`), &res); err != nil {
+	if err := json.Unmarshal([]byte(`["start","suspend","stop","retry","mark-success","mark-failed","stop-step","retry-step","save","rename","rollback"]`), &res); err != nil {
 		panic(err)
 	}
 	for _, v := range res {
@@ -84,11 +87,20 @@ const (
 	// PostDAGActionRequestActionMarkDashFailed captures enum value "mark-failed"
 	PostDAGActionRequestActionMarkDashFailed string = "mark-failed"
 
+	// PostDAGActionRequestActionStopDashStep captures enum value "stop-step"
+	PostDAGActionRequestActionStopDashStep string = "stop-step"
+
+	// PostDAGActionRequestActionRetryDashStep captures enum value "retry-step"
+	PostDAGActionRequestActionRetryDashStep string = "retry-step"
+
 	// PostDAGActionRequestActionSave captures enum value "save"
 	PostDAGActionRequestActionSave string = "save"
 
 	// PostDAGActionRequestActionRename captures enum value "rename"
 	PostDAGActionRequestActionRename string = "rename"
+
+	// PostDAGActionRequestActionRollback captures enum value "rollback"
+	PostDAGActionRequestActionRollback string = "rollback"
 )
 
 // prop value enum
@@ -22,6 +22,12 @@ type Schedule struct {
 	// expression
 	// Required: true
 	Expression *string `json:"Expression"`
+
+	// RFC 3339 timestamp of the next run in the effective timezone
+	NextRun string `json:"NextRun,omitempty"`
+
+	// Effective timezone the schedule is evaluated in
+	Timezone string `json:"Timezone,omitempty"`
 }
 
 // Validate validates this schedule
@@ -0,0 +1,173 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// ScheduledRun An upcoming firing of a schedule of a DAG.
+//
+// swagger:model ScheduledRun
+type ScheduledRun struct {
+
+	// ID of the DAG.
+	// Required: true
+	DagID *string `json:"DagID"`
+
+	// Time of the firing in the timezone of the schedule, if the DAG specifies one.
+	DagTime string `json:"DagTime,omitempty"`
+
+	// Name of the DAG.
+	// Required: true
+	Name *string `json:"Name"`
+
+	// Reason the run will be skipped, e.g. an excluded date.
+	SkipReason string `json:"SkipReason,omitempty"`
+
+	// Time the DAG is started, if it is delayed by the schedule jitter (RFC3339).
+	StartTime string `json:"StartTime,omitempty"`
+
+	// Time of the firing in the timezone of the server (RFC3339).
+	// Required: true
+	Time *string `json:"Time"`
+
+	// Timezone of the schedule, if the DAG specifies one.
+	Timezone string `json:"Timezone,omitempty"`
+
+	// Operation performed on the firing.
+	// Required: true
+	// Enum: [start stop restart]
+	Type *string `json:"Type"`
+}
+
+// Validate validates this scheduled run
+func (m *ScheduledRun) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDagID(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateName(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTime(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *ScheduledRun) validateDagID(formats strfmt.Registry) error {
+
+	if err := validate.Required("DagID", "body", m.DagID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ScheduledRun) validateName(formats strfmt.Registry) error {
+
+	if err := validate.Required("Name", "body", m.Name); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *ScheduledRun) validateTime(formats strfmt.Registry) error {
+
+	if err := validate.Required("Time", "body", m.Time); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var scheduledRunTypeTypePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["start","stop","restart"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		scheduledRunTypeTypePropEnum = append(scheduledRunTypeTypePropEnum, v)
+	}
+}
+
+const (
+
+	// ScheduledRunTypeStart captures enum value "start"
+	ScheduledRunTypeStart string = "start"
+
+	// ScheduledRunTypeStop captures enum value "stop"
+	ScheduledRunTypeStop string = "stop"
+
+	// ScheduledRunTypeRestart captures enum value "restart"
+	ScheduledRunTypeRestart string = "restart"
+)
+
+// prop value enum
+func (m *ScheduledRun) validateTypeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, scheduledRunTypeTypePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *ScheduledRun) validateType(formats strfmt.Registry) error {
+
+	if err := validate.Required("Type", "body", m.Type); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateTypeEnum("Type", "body", *m.Type); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this scheduled run based on context it is used
+func (m *ScheduledRun) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *ScheduledRun) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *ScheduledRun) UnmarshalBinary(b []byte) error {
+	var res ScheduledRun
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
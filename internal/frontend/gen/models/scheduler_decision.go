@@ -0,0 +1,276 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
+)
+
+// SchedulerDecision A record of what the scheduler did when a schedule of a DAG fired and why.
+//
+// swagger:model SchedulerDecision
+type SchedulerDecision struct {
+
+	// What the scheduler did.
+	// Required: true
+	// Enum: [started stopped restarted skipped-running skipped-successful skipped-finished skipped-calendar skipped-not-running suspended failed]
+	Decision *string `json:"Decision"`
+
+	// Result of the started run. It is empty while the run is in progress.
+	// Enum: [succeeded precondition-failed failed]
+	Outcome string `json:"Outcome,omitempty"`
+
+	// Why the scheduler did not run the operation or why the started run failed, if any.
+	Reason string `json:"Reason,omitempty"`
+
+	// Time the schedule fired.
+	// Required: true
+	ScheduledTime *string `json:"ScheduledTime"`
+
+	// Time the decision was made.
+	// Required: true
+	Timestamp *string `json:"Timestamp"`
+
+	// Operation of the schedule.
+	// Required: true
+	// Enum: [start stop restart]
+	Type *string `json:"Type"`
+}
+
+// Validate validates this scheduler decision
+func (m *SchedulerDecision) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateDecision(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateOutcome(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateScheduledTime(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateTimestamp(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateType(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+var schedulerDecisionTypeDecisionPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["started","stopped","restarted","skipped-running","skipped-successful","skipped-finished","skipped-calendar","skipped-not-running","suspended","failed"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		schedulerDecisionTypeDecisionPropEnum = append(schedulerDecisionTypeDecisionPropEnum, v)
+	}
+}
+
+const (
+
+	// SchedulerDecisionDecisionStarted captures enum value "started"
+	SchedulerDecisionDecisionStarted string = "started"
+
+	// SchedulerDecisionDecisionStopped captures enum value "stopped"
+	SchedulerDecisionDecisionStopped string = "stopped"
+
+	// SchedulerDecisionDecisionRestarted captures enum value "restarted"
+	SchedulerDecisionDecisionRestarted string = "restarted"
+
+	// SchedulerDecisionDecisionSkippedDashRunning captures enum value "skipped-running"
+	SchedulerDecisionDecisionSkippedDashRunning string = "skipped-running"
+
+	// SchedulerDecisionDecisionSkippedDashSuccessful captures enum value "skipped-successful"
+	SchedulerDecisionDecisionSkippedDashSuccessful string = "skipped-successful"
+
+	// SchedulerDecisionDecisionSkippedDashFinished captures enum value "skipped-finished"
+	SchedulerDecisionDecisionSkippedDashFinished string = "skipped-finished"
+
+	// SchedulerDecisionDecisionSkippedDashCalendar captures enum value "skipped-calendar"
+	SchedulerDecisionDecisionSkippedDashCalendar string = "skipped-calendar"
+
+	// SchedulerDecisionDecisionSkippedDashNotDashRunning captures enum value "skipped-not-running"
+	SchedulerDecisionDecisionSkippedDashNotDashRunning string = "skipped-not-running"
+
+	// SchedulerDecisionDecisionSuspended captures enum value "suspended"
+	SchedulerDecisionDecisionSuspended string = "suspended"
+
+	// SchedulerDecisionDecisionFailed captures enum value "failed"
+	SchedulerDecisionDecisionFailed string = "failed"
+)
+
+// prop value enum
+func (m *SchedulerDecision) validateDecisionEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, schedulerDecisionTypeDecisionPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *SchedulerDecision) validateDecision(formats strfmt.Registry) error {
+
+	if err := validate.Required("Decision", "body", m.Decision); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateDecisionEnum("Decision", "body", *m.Decision); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var schedulerDecisionTypeOutcomePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["succeeded","precondition-failed","failed"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		schedulerDecisionTypeOutcomePropEnum = append(schedulerDecisionTypeOutcomePropEnum, v)
+	}
+}
+
+const (
+
+	// SchedulerDecisionOutcomeSucceeded captures enum value "succeeded"
+	SchedulerDecisionOutcomeSucceeded string = "succeeded"
+
+	// SchedulerDecisionOutcomePreconditionDashFailed captures enum value "precondition-failed"
+	SchedulerDecisionOutcomePreconditionDashFailed string = "precondition-failed"
+
+	// SchedulerDecisionOutcomeFailed captures enum value "failed"
+	SchedulerDecisionOutcomeFailed string = "failed"
+)
+
+// prop value enum
+func (m *SchedulerDecision) validateOutcomeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, schedulerDecisionTypeOutcomePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *SchedulerDecision) validateOutcome(formats strfmt.Registry) error {
+	if swag.IsZero(m.Outcome) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := m.validateOutcomeEnum("Outcome", "body", m.Outcome); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *SchedulerDecision) validateScheduledTime(formats strfmt.Registry) error {
+
+	if err := validate.Required("ScheduledTime", "body", m.ScheduledTime); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *SchedulerDecision) validateTimestamp(formats strfmt.Registry) error {
+
+	if err := validate.Required("Timestamp", "body", m.Timestamp); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var schedulerDecisionTypeTypePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["start","stop","restart"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		schedulerDecisionTypeTypePropEnum = append(schedulerDecisionTypeTypePropEnum, v)
+	}
+}
+
+const (
+
+	// SchedulerDecisionTypeStart captures enum value "start"
+	SchedulerDecisionTypeStart string = "start"
+
+	// SchedulerDecisionTypeStop captures enum value "stop"
+	SchedulerDecisionTypeStop string = "stop"
+
+	// SchedulerDecisionTypeRestart captures enum value "restart"
+	SchedulerDecisionTypeRestart string = "restart"
+)
+
+// prop value enum
+func (m *SchedulerDecision) validateTypeEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, schedulerDecisionTypeTypePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (m *SchedulerDecision) validateType(formats strfmt.Registry) error {
+
+	if err := validate.Required("Type", "body", m.Type); err != nil {
+		return err
+	}
+
+	// value enum
+	if err := m.validateTypeEnum("Type", "body", *m.Type); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ContextValidate validates this scheduler decision based on context it is used
+func (m *SchedulerDecision) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SchedulerDecision) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SchedulerDecision) UnmarshalBinary(b []byte) error {
+	var res SchedulerDecision
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -0,0 +1,59 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package models
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"context"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// SchedulerLeader Leader of the scheduler instances. Only included when the scheduler runs in high availability mode.
+//
+// swagger:model SchedulerLeader
+type SchedulerLeader struct {
+
+	// Time the leader acquired the lease.
+	AcquiredAt string `json:"acquiredAt,omitempty"`
+
+	// Time the lease expires unless renewed.
+	ExpiresAt string `json:"expiresAt,omitempty"`
+
+	// Identity of the scheduler instance holding the lease, empty if none.
+	Leader string `json:"leader,omitempty"`
+
+	// Fencing token of the lease.
+	Token int64 `json:"token,omitempty"`
+}
+
+// Validate validates this scheduler leader
+func (m *SchedulerLeader) Validate(formats strfmt.Registry) error {
+	return nil
+}
+
+// ContextValidate validates this scheduler leader based on context it is used
+func (m *SchedulerLeader) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	return nil
+}
+
+// MarshalBinary interface implementation
+func (m *SchedulerLeader) MarshalBinary() ([]byte, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return swag.WriteJSON(m)
+}
+
+// UnmarshalBinary interface implementation
+func (m *SchedulerLeader) UnmarshalBinary(b []byte) error {
+	var res SchedulerLeader
+	if err := swag.ReadJSON(b, &res); err != nil {
+		return err
+	}
+	*m = res
+	return nil
+}
@@ -7,6 +7,7 @@ package models
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -23,6 +24,9 @@ type StepLog struct {
 	// Required: true
 	Content *string `json:"Content"`
 
+	// Parsed lines of the JSON log, for the steps with logFormat json
+	Entries []*LogEntry `json:"Entries"`
+
 	// log file
 	// Required: true
 	LogFile *string `json:"LogFile"`
@@ -40,6 +44,10 @@ func (m *StepLog) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateEntries(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateLogFile(formats); err != nil {
 		res = append(res, err)
 	}
@@ -63,6 +71,32 @@ func (m *StepLog) validateContent(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *StepLog) validateEntries(formats strfmt.Registry) error {
+	if swag.IsZero(m.Entries) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Entries); i++ {
+		if swag.IsZero(m.Entries[i]) { // not required
+			continue
+		}
+
+		if m.Entries[i] != nil {
+			if err := m.Entries[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Entries" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Entries" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *StepLog) validateLogFile(formats strfmt.Registry) error {
 
 	if err := validate.Required("LogFile", "body", m.LogFile); err != nil {
@@ -96,6 +130,10 @@ func (m *StepLog) validateStep(formats strfmt.Registry) error {
 func (m *StepLog) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.contextValidateEntries(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateStep(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -106,6 +144,31 @@ func (m *StepLog) ContextValidate(ctx context.Context, formats strfmt.Registry)
 	return nil
 }
 
+func (m *StepLog) contextValidateEntries(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Entries); i++ {
+
+		if m.Entries[i] != nil {
+
+			if swag.IsZero(m.Entries[i]) { // not required
+				return nil
+			}
+
+			if err := m.Entries[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("Entries" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("Entries" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 func (m *StepLog) contextValidateStep(ctx context.Context, formats strfmt.Registry) error {
 
 	if m.Step != nil {
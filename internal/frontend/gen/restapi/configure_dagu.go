@@ -40,6 +40,8 @@ func configureAPI(api *operations.DaguAPI) http.Handler {
 
 	api.JSONProducer = runtime.JSONProducer()
 
+	api.TextEventStreamProducer = runtime.TextProducer()
+
 	if api.DagsListDAGsHandler == nil {
 		api.DagsListDAGsHandler = dags.ListDAGsHandlerFunc(
 			func(params dags.ListDAGsParams) middleware.Responder {
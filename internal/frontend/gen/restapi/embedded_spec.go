@@ -44,6 +44,74 @@ func init() {
   "host": "localhost:8080",
   "basePath": "/api/v1",
   "paths": {
+    "/audit": {
+      "get": {
+        "description": "Returns audit log entries of mutating operations, newest first.",
+        "tags": [
+          "audit"
+        ],
+        "summary": "List audit log entries",
+        "operationId": "listAuditLog",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Filter entries by DAG.",
+            "name": "dagId",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Filter entries by action.",
+            "name": "action",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Filter entries by actor.",
+            "name": "actor",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Filter entries by request ID.",
+            "name": "requestId",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return entries recorded at or after this time (RFC3339).",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return entries recorded at or before this time (RFC3339).",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Maximum number of entries to return.",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListAuditLogResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/dags": {
       "get": {
         "description": "Returns a list of DAGs with optional pagination and search filters.",
@@ -159,6 +227,22 @@ func init() {
             "description": "Step name within the DAG.",
             "name": "step",
             "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only show in the step log the lines of the JSON log at this level or above, e.g. warn.",
+            "name": "logLevel",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Only show in the step log the lines of the JSON log with the field, given as key=value or key. May be repeated.",
+            "name": "logField",
+            "in": "query"
           }
         ],
         "responses": {
@@ -244,29 +328,102 @@ func init() {
         }
       }
     },
-    "/health": {
+    "/dags/{dagId}/decisions": {
       "get": {
-        "description": "Returns the health status of the server and its dependencies",
+        "description": "Returns the decisions the scheduler made when the schedules of the DAG fired, newest first.",
         "tags": [
-          "system"
+          "dags"
+        ],
+        "summary": "List scheduler decisions of a DAG",
+        "operationId": "listDAGDecisions",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Filter decisions by the kind, e.g. skipped-running.",
+            "name": "decision",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return decisions made at or after this time (RFC3339).",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return decisions made at or before this time (RFC3339).",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Maximum number of decisions to return.",
+            "name": "limit",
+            "in": "query"
+          }
         ],
-        "summary": "Health check endpoint",
-        "operationId": "getHealth",
         "responses": {
           "200": {
-            "description": "Server is healthy",
+            "description": "A successful response.",
             "schema": {
-              "$ref": "#/definitions/HealthResponse"
+              "$ref": "#/definitions/ListDAGDecisionsResponse"
             }
           },
-          "503": {
-            "description": "Server is unhealthy",
+          "default": {
+            "description": "Generic error response.",
             "schema": {
-              "$ref": "#/definitions/HealthResponse"
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/events": {
+      "get": {
+        "description": "Returns the changes of the status of the running DAG and of its steps after the sequence number. If there are none, the request waits for them until the timeout. The next events are requested with the Seq of the response until Finished is true. An empty list is returned with Finished set if the DAG is not running.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Wait for the events of a running DAG",
+        "operationId": "getDAGEvents",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Sequence number of the last event received. Defaults to 0 for all the events kept.",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Seconds to wait for the events, up to 25. Defaults to 20.",
+            "name": "timeout",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/DAGEventsResponse"
             }
           },
           "default": {
-            "description": "Unexpected error",
+            "description": "Generic error response.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -274,28 +431,72 @@ func init() {
         }
       }
     },
-    "/search": {
+    "/dags/{dagId}/logs/stream": {
       "get": {
-        "description": "Searches for DAGs based on a query string.",
+        "description": "Streams the log of a step, or the scheduler log of the run if the step is omitted, as Server-Sent Events. Each line of the log is sent as a message event whose ID is the offset in the log file right after the line. The lines written after the request are pushed as they are written, and an \"end\" event is sent when the run has finished. The stream resumes after the event given by the Last-Event-ID header.\n",
+        "produces": [
+          "text/event-stream"
+        ],
         "tags": [
           "dags"
         ],
-        "summary": "Search DAGs",
-        "operationId": "searchDAGs",
+        "summary": "Stream a log of a DAG run",
+        "operationId": "streamDAGLog",
         "parameters": [
           {
             "type": "string",
-            "description": "A search query string.",
-            "name": "q",
-            "in": "query",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "Step name. The scheduler log is streamed if omitted.",
+            "name": "step",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Request ID of the run. Defaults to the latest run.",
+            "name": "requestId",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Offset in the log file to start from. Defaults to the beginning.",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "ID of the last event received, taking precedence over the offset.",
+            "name": "Last-Event-ID",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Only stream the lines of the JSON log at this level or above, e.g. warn.",
+            "name": "logLevel",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Only stream the lines of the JSON log with the field, given as key=value or key. May be repeated.",
+            "name": "logField",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "A successful response.",
+            "description": "A stream of the lines of the log.",
             "schema": {
-              "$ref": "#/definitions/SearchDAGsResponse"
+              "type": "string"
             }
           },
           "default": {
@@ -307,19 +508,28 @@ func init() {
         }
       }
     },
-    "/tags": {
+    "/dags/{dagId}/revisions": {
       "get": {
-        "description": "Returns a list of tags used in DAGs.",
+        "description": "Returns the saved revisions of the DAG spec, newest first.",
         "tags": [
           "dags"
         ],
-        "summary": "List all tags",
-        "operationId": "listTags",
+        "summary": "List revisions of a DAG",
+        "operationId": "listDAGRevisions",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          }
+        ],
         "responses": {
           "200": {
             "description": "A successful response.",
             "schema": {
-              "$ref": "#/definitions/ListTagResponse"
+              "$ref": "#/definitions/ListDAGRevisionsResponse"
             }
           },
           "default": {
@@ -330,1015 +540,2485 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "CreateDAGRequest": {
-      "description": "Request body for creating a DAG.",
-      "type": "object",
-      "required": [
-        "action",
-        "value"
-      ],
-      "properties": {
-        "action": {
-          "description": "Action to perform upon creation (if any).",
-          "type": "string"
-        },
-        "value": {
-          "description": "Associated value for the action.",
-          "type": "string"
-        }
-      }
     },
-    "CreateDAGResponse": {
-      "type": "object",
-      "required": [
-        "DagID"
-      ],
-      "properties": {
-        "DagID": {
-          "type": "string"
+    "/dags/{dagId}/revisions/{revision}": {
+      "get": {
+        "description": "Returns a revision of the DAG spec.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Get a revision of a DAG",
+        "operationId": "getDAGRevision",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The revision hash. It may be abbreviated.",
+            "name": "revision",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/GetDAGRevisionResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "DAG": {
-      "description": "Core DAG configuration containing workflow definition and metadata",
-      "type": "object",
-      "required": [
-        "Group",
-        "Name",
-        "Schedule",
-        "Description",
-        "Params",
-        "DefaultParams",
-        "Tags"
-      ],
-      "properties": {
-        "DefaultParams": {
-          "description": "Default parameter values in JSON format if not specified at runtime",
-          "type": "string"
-        },
-        "Description": {
-          "description": "Human-readable description of the DAG's purpose and behavior",
-          "type": "string"
-        },
-        "Group": {
-          "description": "Logical grouping of related DAGs for organizational purposes",
-          "type": "string"
-        },
-        "Name": {
-          "description": "Unique identifier for the DAG within its group",
-          "type": "string"
-        },
-        "Params": {
-          "description": "List of parameter names that can be passed to the DAG at runtime",
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "Schedule": {
-          "description": "List of scheduling expressions defining when the DAG should run",
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/Schedule"
+    "/dags/{dagId}/revisions/{revision}/diff": {
+      "get": {
+        "description": "Returns the unified diff from the revision to another revision or the current spec.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Diff a revision of a DAG",
+        "operationId": "diffDAGRevision",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The revision hash to diff from. It may be abbreviated.",
+            "name": "revision",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The revision hash to diff to. Defaults to the current spec.",
+            "name": "to",
+            "in": "query"
           }
-        },
-        "Tags": {
-          "description": "List of tags for categorizing and filtering DAGs",
-          "type": "array",
-          "items": {
-            "type": "string"
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/DiffDAGRevisionResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
           }
         }
       }
     },
-    "DAGDetails": {
-      "type": "object",
-      "required": [
-        "Location",
-        "Group",
-        "Name",
-        "Schedule",
-        "Description",
-        "Env",
-        "LogDir",
-        "HandlerOn",
-        "Steps",
-        "Delay",
-        "HistRetentionDays",
-        "Preconditions",
-        "MaxActiveRuns",
-        "Params",
-        "DefaultParams",
-        "Tags"
-      ],
-      "properties": {
-        "DefaultParams": {
-          "description": "Default parameter values in JSON format if not specified at runtime",
-          "type": "string"
-        },
-        "Delay": {
-          "description": "Time in seconds to wait before starting the DAG",
-          "type": "integer"
-        },
-        "Description": {
-          "description": "Human-readable description of the DAG's purpose and behavior",
-          "type": "string"
-        },
-        "Env": {
-          "description": "List of environment variables to set before executing",
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "Group": {
-          "description": "Logical grouping of related DAGs for organizational purposes",
-          "type": "string"
-        },
-        "HandlerOn": {
-          "$ref": "#/definitions/HandlerOn"
-        },
-        "HistRetentionDays": {
-          "description": "Number of days to retain historical logs",
-          "type": "integer"
-        },
-        "Location": {
-          "description": "Path to the DAG file",
-          "type": "string"
-        },
-        "LogDir": {
-          "description": "Directory path for storing log files",
-          "type": "string"
-        },
-        "MaxActiveRuns": {
-          "description": "Maximum number of concurrent steps to run",
-          "type": "integer"
-        },
-        "Name": {
-          "description": "Unique identifier for the DAG within its group",
-          "type": "string"
-        },
-        "Params": {
-          "description": "List of parameter names that can be passed to the DAG at runtime",
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "Preconditions": {
-          "description": "Conditions that must be met before the DAG can start",
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/Precondition"
-          }
-        },
-        "Schedule": {
-          "description": "List of scheduling expressions defining when the DAG should run",
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/Schedule"
-          }
-        },
-        "Steps": {
-          "description": "List of steps to execute in the DAG",
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/Step"
-          }
-        },
-        "Tags": {
-          "description": "List of tags for categorizing and filtering DAGs",
-          "type": "array",
-          "items": {
-            "type": "string"
+    "/health": {
+      "get": {
+        "description": "Returns the health status of the server and its dependencies",
+        "tags": [
+          "system"
+        ],
+        "summary": "Health check endpoint",
+        "operationId": "getHealth",
+        "responses": {
+          "200": {
+            "description": "Server is healthy",
+            "schema": {
+              "$ref": "#/definitions/HealthResponse"
+            }
+          },
+          "503": {
+            "description": "Server is unhealthy",
+            "schema": {
+              "$ref": "#/definitions/HealthResponse"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
           }
         }
       }
     },
-    "DAGLogData": {
-      "type": "object",
-      "required": [
-        "GridData",
-        "Logs"
-      ],
-      "properties": {
-        "GridData": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/DAGLogGridItem"
-          }
-        },
-        "Logs": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/DAGLogStatusFile"
+    "/schedule": {
+      "get": {
+        "description": "Returns the upcoming start, stop and restart firings of the schedules of all DAGs in order of time. Suspended DAGs are excluded.",
+        "tags": [
+          "schedule"
+        ],
+        "summary": "List upcoming scheduled runs",
+        "operationId": "listScheduledRuns",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Only return firings at or after this time (RFC3339). Defaults to now.",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return firings at or before this time (RFC3339).",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Maximum number of firings to return (default 100, at most 1000).",
+            "name": "limit",
+            "in": "query"
           }
-        }
-      }
-    },
-    "DAGLogGridItem": {
-      "type": "object",
-      "required": [
-        "Name",
-        "Vals"
-      ],
-      "properties": {
-        "Name": {
-          "type": "string"
-        },
-        "Vals": {
-          "type": "array",
-          "items": {
-            "type": "integer"
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListScheduledRunsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
           }
         }
       }
     },
-    "DAGLogStatusFile": {
-      "type": "object",
-      "required": [
-        "File",
-        "Status"
-      ],
-      "properties": {
-        "File": {
-          "type": "string"
-        },
-        "Status": {
-          "$ref": "#/definitions/DAGStatusDetails"
+    "/search": {
+      "get": {
+        "description": "Searches for DAGs based on a query string.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Search DAGs",
+        "operationId": "searchDAGs",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "A search query string.",
+            "name": "q",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/SearchDAGsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "DAGStatus": {
-      "description": "Current execution status of a DAG instance",
+    "/secrets": {
+      "get": {
+        "description": "Returns the secrets in the local secret store without their values. Requires authentication with the admin role.",
+        "tags": [
+          "secrets"
+        ],
+        "summary": "List secrets",
+        "operationId": "listSecrets",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Only return the secrets of the scope. Defaults to all scopes.",
+            "name": "scope",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListSecretsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/secrets/{name}": {
+      "get": {
+        "description": "Returns a secret in the local secret store without its value. Requires authentication with the admin role.",
+        "tags": [
+          "secrets"
+        ],
+        "summary": "Get a secret",
+        "operationId": "getSecret",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The name of the secret.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Scope of the secret: global, dag:\u003cID\u003e or group:\u003cname\u003e. Defaults to global.",
+            "name": "scope",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/GetSecretResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "Creates or updates a secret in the local secret store. Requires authentication with the admin role.",
+        "tags": [
+          "secrets"
+        ],
+        "summary": "Set a secret",
+        "operationId": "setSecret",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The name of the secret.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Scope of the secret: global, dag:\u003cID\u003e or group:\u003cname\u003e. Defaults to global.",
+            "name": "scope",
+            "in": "query"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SetSecretRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Deletes a secret from the local secret store. Requires authentication with the admin role.",
+        "tags": [
+          "secrets"
+        ],
+        "summary": "Delete a secret",
+        "operationId": "deleteSecret",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The name of the secret.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Scope of the secret: global, dag:\u003cID\u003e or group:\u003cname\u003e. Defaults to global.",
+            "name": "scope",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/tags": {
+      "get": {
+        "description": "Returns a list of tags used in DAGs.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "List all tags",
+        "operationId": "listTags",
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListTagResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "AuditEntry": {
+      "description": "A single record of a mutating operation.",
       "type": "object",
       "required": [
-        "RequestId",
-        "Name",
-        "Status",
-        "StatusText",
-        "Pid",
-        "StartedAt",
-        "FinishedAt",
-        "Log",
-        "Params"
+        "Timestamp",
+        "Source",
+        "Actor",
+        "Action",
+        "DAG"
       ],
       "properties": {
-        "FinishedAt": {
-          "description": "RFC 3339 timestamp when the DAG execution completed",
+        "Action": {
+          "description": "Action performed.",
           "type": "string"
         },
-        "Log": {
-          "description": "Path to the execution log file",
+        "Actor": {
+          "description": "User who performed the action.",
           "type": "string"
         },
-        "Name": {
-          "description": "Name of the DAG being executed",
+        "DAG": {
+          "description": "DAG the action was performed on.",
           "type": "string"
         },
-        "Params": {
-          "description": "Runtime parameters passed to the DAG in JSON format",
+        "Detail": {
+          "description": "Additional information about the action.",
           "type": "string"
         },
-        "Pid": {
-          "description": "Process ID of the DAG execution",
-          "type": "integer"
+        "Diff": {
+          "description": "Unified diff of the DAG spec for save actions.",
+          "type": "string"
         },
         "RequestId": {
-          "description": "Unique identifier for the DAG execution request",
+          "description": "Request ID of the DAG run the action targeted.",
           "type": "string"
         },
-        "StartedAt": {
-          "description": "RFC 3339 timestamp when the DAG execution started",
+        "Source": {
+          "description": "Where the action originated from (api or cli).",
           "type": "string"
         },
-        "Status": {
-          "description": "Numeric status code indicating current execution state",
-          "type": "integer"
+        "SourceIP": {
+          "description": "IP address of the client for API actions.",
+          "type": "string"
         },
-        "StatusText": {
-          "description": "Human-readable status description",
+        "Step": {
+          "description": "Step name if the action targeted a specific step.",
+          "type": "string"
+        },
+        "Timestamp": {
+          "description": "Time the action was recorded.",
           "type": "string"
         }
       }
     },
-    "DAGStatusDetails": {
+    "CreateDAGRequest": {
+      "description": "Request body for creating a DAG.",
       "type": "object",
       "required": [
-        "RequestId",
-        "Name",
-        "Status",
-        "StatusText",
-        "Pid",
-        "Nodes",
-        "OnExit",
-        "OnSuccess",
-        "OnFailure",
-        "OnCancel",
-        "StartedAt",
-        "FinishedAt",
-        "Log",
-        "Params"
+        "action",
+        "value"
       ],
       "properties": {
-        "FinishedAt": {
-          "description": "Timestamp when the DAG finished.",
+        "action": {
+          "description": "Action to perform upon creation (if any).",
           "type": "string"
         },
-        "Log": {
+        "value": {
+          "description": "Associated value for the action.",
           "type": "string"
-        },
-        "Name": {
+        }
+      }
+    },
+    "CreateDAGResponse": {
+      "type": "object",
+      "required": [
+        "DagID"
+      ],
+      "properties": {
+        "DagID": {
           "type": "string"
-        },
-        "Nodes": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/Node"
-          }
-        },
-        "OnCancel": {
-          "$ref": "#/definitions/Node"
-        },
-        "OnExit": {
-          "$ref": "#/definitions/Node"
-        },
-        "OnFailure": {
-          "$ref": "#/definitions/Node"
-        },
-        "OnSuccess": {
-          "$ref": "#/definitions/Node"
-        },
-        "Params": {
+        }
+      }
+    },
+    "DAG": {
+      "description": "Core DAG configuration containing workflow definition and metadata",
+      "type": "object",
+      "required": [
+        "Group",
+        "Name",
+        "Schedule",
+        "Description",
+        "Params",
+        "DefaultParams",
+        "Tags"
+      ],
+      "properties": {
+        "DefaultParams": {
+          "description": "Default parameter values in JSON format if not specified at runtime",
           "type": "string"
         },
-        "Pid": {
-          "type": "integer"
+        "Description": {
+          "description": "Human-readable description of the DAG's purpose and behavior",
+          "type": "string"
         },
-        "RequestId": {
+        "Group": {
+          "description": "Logical grouping of related DAGs for organizational purposes",
           "type": "string"
         },
-        "StartedAt": {
-          "description": "Timestamp when the DAG started.",
+        "Name": {
+          "description": "Unique identifier for the DAG within its group",
           "type": "string"
         },
-        "Status": {
-          "type": "integer"
+        "Params": {
+          "description": "List of parameter names that can be passed to the DAG at runtime",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         },
-        "StatusText": {
-          "type": "string"
+        "Schedule": {
+          "description": "List of scheduling expressions defining when the DAG should run",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Schedule"
+          }
+        },
+        "Tags": {
+          "description": "List of tags for categorizing and filtering DAGs",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
         }
       }
     },
-    "DAGStatusFile": {
+    "DAGDetails": {
       "type": "object",
       "required": [
-        "File",
-        "Dir",
-        "DAG",
-        "Status",
-        "Suspended",
-        "Error"
+        "Location",
+        "Group",
+        "Name",
+        "Schedule",
+        "Description",
+        "Env",
+        "LogDir",
+        "HandlerOn",
+        "Steps",
+        "Delay",
+        "HistRetentionDays",
+        "Preconditions",
+        "MaxActiveRuns",
+        "Params",
+        "DefaultParams",
+        "Tags"
       ],
       "properties": {
-        "DAG": {
-          "$ref": "#/definitions/DAG"
+        "DefaultParams": {
+          "description": "Default parameter values in JSON format if not specified at runtime",
+          "type": "string"
         },
-        "Dir": {
+        "Delay": {
+          "description": "Time in seconds to wait before starting the DAG",
+          "type": "integer"
+        },
+        "Description": {
+          "description": "Human-readable description of the DAG's purpose and behavior",
           "type": "string"
         },
-        "Error": {
+        "Env": {
+          "description": "List of environment variables to set before executing",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Group": {
+          "description": "Logical grouping of related DAGs for organizational purposes",
           "type": "string"
         },
-        "File": {
+        "HandlerOn": {
+          "$ref": "#/definitions/HandlerOn"
+        },
+        "HistRetentionDays": {
+          "description": "Number of days to retain historical logs",
+          "type": "integer"
+        },
+        "Location": {
+          "description": "Path to the DAG file",
           "type": "string"
         },
-        "Status": {
-          "$ref": "#/definitions/DAGStatus"
+        "LogDir": {
+          "description": "Directory path for storing log files",
+          "type": "string"
         },
-        "Suspended": {
-          "type": "boolean"
+        "MaxActiveRuns": {
+          "description": "Maximum number of concurrent steps to run",
+          "type": "integer"
+        },
+        "Name": {
+          "description": "Unique identifier for the DAG within its group",
+          "type": "string"
+        },
+        "Params": {
+          "description": "List of parameter names that can be passed to the DAG at runtime",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Preconditions": {
+          "description": "Conditions that must be met before the DAG can start",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Precondition"
+          }
+        },
+        "Schedule": {
+          "description": "List of scheduling expressions defining when the DAG should run",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Schedule"
+          }
+        },
+        "Steps": {
+          "description": "List of steps to execute in the DAG",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Step"
+          }
+        },
+        "Tags": {
+          "description": "List of tags for categorizing and filtering DAGs",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Timezone": {
+          "description": "Timezone of the DAG the schedule is evaluated in unless the expression has CRON_TZ",
+          "type": "string"
         }
       }
     },
-    "DAGStatusFileDetails": {
-      "description": "Detailed status information for a DAG instance",
+    "DAGEvent": {
+      "description": "A change of the status of a running DAG or of one of its steps.",
       "type": "object",
       "required": [
-        "File",
-        "Dir",
-        "DAG",
+        "Seq",
+        "Time",
+        "RequestId",
         "Status",
-        "Suspended",
-        "Error"
+        "StatusText"
       ],
       "properties": {
-        "DAG": {
-          "$ref": "#/definitions/DAGDetails"
-        },
-        "Dir": {
-          "type": "string"
+        "Node": {
+          "description": "Step whose status changed, absent if the status of the DAG changed.",
+          "$ref": "#/definitions/Node"
         },
-        "Error": {
+        "RequestId": {
           "type": "string"
         },
-        "File": {
-          "type": "string"
+        "Seq": {
+          "description": "Sequence number of the event in the run, starting at 1.",
+          "type": "integer",
+          "format": "int64"
         },
         "Status": {
-          "$ref": "#/definitions/DAGStatusDetails"
+          "description": "Status of the DAG at the event.",
+          "type": "integer"
         },
-        "Suspended": {
-          "type": "boolean"
+        "StatusText": {
+          "type": "string"
+        },
+        "Time": {
+          "description": "Time of the event.",
+          "type": "string"
         }
       }
     },
-    "Error": {
-      "description": "Generic error response object.",
+    "DAGEventsResponse": {
+      "description": "Response object for the events of a running DAG.",
       "type": "object",
       "required": [
-        "code",
-        "message"
+        "Events",
+        "Seq",
+        "Finished"
       ],
       "properties": {
-        "code": {
-          "description": "Error code indicating the type of error.",
-          "type": "string",
-          "enum": [
-            "validation_error",
-            "not_found",
-            "internal_error",
-            "unauthorized",
-            "bad_gateway"
-          ]
+        "Events": {
+          "description": "Events after the sequence number, oldest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DAGEvent"
+          }
         },
-        "details": {
-          "description": "Additional error details.",
-          "type": "object"
+        "Finished": {
+          "description": "Whether the run has finished and no events follow.",
+          "type": "boolean"
         },
-        "message": {
-          "description": "Short error message.",
-          "type": "string"
+        "Seq": {
+          "description": "Sequence number of the last event of the run, after which the next events are requested.",
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
-    "GetDAGDetailsResponse": {
-      "description": "Response object for getting details of a DAG.",
+    "DAGLogData": {
       "type": "object",
       "required": [
-        "Title",
-        "DAG",
-        "Tab",
-        "Graph",
-        "Definition",
-        "LogData",
-        "LogUrl",
-        "StepLog",
-        "ScLog",
-        "Errors"
+        "GridData",
+        "Logs"
       ],
       "properties": {
-        "DAG": {
-          "$ref": "#/definitions/DAGStatusFileDetails"
-        },
-        "Definition": {
-          "description": "DAG definition.",
-          "type": "string"
-        },
-        "Errors": {
-          "description": "List of errors encountered during the request.",
+        "GridData": {
           "type": "array",
           "items": {
-            "type": "string"
+            "$ref": "#/definitions/DAGLogGridItem"
           }
         },
-        "Graph": {
-          "description": "Graph data for visualization.",
-          "type": "string"
-        },
-        "LogData": {
-          "$ref": "#/definitions/DAGLogData"
-        },
-        "LogUrl": {
-          "type": "string"
-        },
-        "ScLog": {
-          "$ref": "#/definitions/SchedulerLog"
-        },
-        "StepLog": {
-          "$ref": "#/definitions/StepLog"
-        },
-        "Tab": {
-          "description": "Tab name for UI navigation.",
-          "type": "string"
-        },
-        "Title": {
-          "description": "Title of the Page.",
-          "type": "string"
-        }
-      }
-    },
-    "HandlerOn": {
-      "description": "Configuration for event handlers in a DAG",
-      "type": "object",
-      "properties": {
-        "Cancel": {
-          "$ref": "#/definitions/Step"
-        },
-        "Exit": {
-          "$ref": "#/definitions/Step"
-        },
-        "Failure": {
-          "$ref": "#/definitions/Step"
-        },
-        "Success": {
-          "$ref": "#/definitions/Step"
+        "Logs": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DAGLogStatusFile"
+          }
         }
       }
     },
-    "HealthResponse": {
-      "description": "Response object for the health check endpoint.",
+    "DAGLogGridItem": {
       "type": "object",
       "required": [
-        "status",
-        "version",
-        "uptime",
-        "timestamp"
+        "Name",
+        "Vals"
       ],
       "properties": {
-        "status": {
-          "description": "Overall health status of the server",
-          "type": "string",
-          "enum": [
-            "healthy",
-            "unhealthy"
-          ]
-        },
-        "timestamp": {
-          "description": "Current server time",
+        "Name": {
           "type": "string"
         },
-        "uptime": {
-          "description": "Server uptime in seconds",
-          "type": "integer"
-        },
-        "version": {
-          "description": "Current version of the server",
-          "type": "string"
+        "Vals": {
+          "type": "array",
+          "items": {
+            "type": "integer"
+          }
         }
       }
     },
-    "ListDAGsResponse": {
-      "description": "Response object for listing all DAGs.",
+    "DAGLogStatusFile": {
       "type": "object",
       "required": [
-        "DAGs",
-        "Errors",
-        "HasError",
-        "PageCount"
+        "File",
+        "Status"
       ],
       "properties": {
-        "DAGs": {
-          "description": "List of DAGs with their status and metadata.",
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/DAGStatusFile"
-          }
-        },
-        "Errors": {
-          "description": "List of errors encountered during the request.",
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "HasError": {
-          "description": "Whether any errors were encountered.",
-          "type": "boolean"
+        "File": {
+          "type": "string"
         },
-        "PageCount": {
-          "description": "Total number of pages available.",
-          "type": "integer"
+        "Status": {
+          "$ref": "#/definitions/DAGStatusDetails"
         }
       }
     },
-    "ListTagResponse": {
-      "description": "Response object for listing all tags",
+    "DAGRevision": {
+      "description": "A saved version of a DAG spec.",
       "type": "object",
       "required": [
-        "Tags",
-        "Errors"
+        "Hash",
+        "Timestamp",
+        "Author",
+        "Message"
       ],
       "properties": {
-        "Errors": {
-          "description": "List of errors encountered during the request",
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
+        "Author": {
+          "description": "User who saved the revision.",
+          "type": "string"
         },
-        "Tags": {
-          "description": "List of unique tags",
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
+        "Hash": {
+          "description": "Content hash of the spec.",
+          "type": "string"
+        },
+        "Message": {
+          "description": "Description of the change.",
+          "type": "string"
+        },
+        "Timestamp": {
+          "description": "Time the revision was saved.",
+          "type": "string"
         }
       }
     },
-    "Node": {
-      "description": "Execution status of an individual step within a DAG",
+    "DAGStatus": {
+      "description": "Current execution status of a DAG instance",
       "type": "object",
       "required": [
-        "Step",
-        "Log",
+        "RequestId",
+        "Name",
+        "Status",
+        "StatusText",
+        "Pid",
         "StartedAt",
         "FinishedAt",
-        "Status",
-        "RetryCount",
-        "DoneCount",
-        "Error",
-        "StatusText"
+        "Log",
+        "Params"
       ],
       "properties": {
-        "DoneCount": {
-          "description": "Number of successful completions for repeating steps",
-          "type": "integer"
+        "FinishedAt": {
+          "description": "RFC 3339 timestamp when the DAG execution completed",
+          "type": "string"
         },
-        "Error": {
-          "description": "Error message if the step failed",
+        "Log": {
+          "description": "Path to the execution log file",
           "type": "string"
         },
-        "FinishedAt": {
-          "description": "RFC 3339 timestamp when the step completed",
+        "Name": {
+          "description": "Name of the DAG being executed",
           "type": "string"
         },
-        "Log": {
-          "description": "Path to step-specific log file",
+        "Params": {
+          "description": "Runtime parameters passed to the DAG in JSON format",
           "type": "string"
         },
-        "RetryCount": {
-          "description": "Number of retry attempts made for this step",
+        "Pid": {
+          "description": "Process ID of the DAG execution",
           "type": "integer"
         },
+        "RequestId": {
+          "description": "Unique identifier for the DAG execution request",
+          "type": "string"
+        },
+        "SkipReason": {
+          "description": "Reason the scheduled run was skipped, e.g. an excluded date",
+          "type": "string"
+        },
         "StartedAt": {
-          "description": "RFC 3339 timestamp when the step started executing",
+          "description": "RFC 3339 timestamp when the DAG execution started",
           "type": "string"
         },
         "Status": {
-          "description": "Numeric status code for the step execution",
+          "description": "Numeric status code indicating current execution state",
           "type": "integer"
         },
         "StatusText": {
           "description": "Human-readable status description",
           "type": "string"
-        },
-        "Step": {
-          "$ref": "#/definitions/Step"
         }
       }
     },
-    "PostDAGActionRequest": {
-      "description": "Request body for posting an action to a DAG.",
+    "DAGStatusDetails": {
       "type": "object",
       "required": [
-        "action"
+        "RequestId",
+        "Name",
+        "Status",
+        "StatusText",
+        "Pid",
+        "Nodes",
+        "OnExit",
+        "OnSuccess",
+        "OnFailure",
+        "OnCancel",
+        "StartedAt",
+        "FinishedAt",
+        "Log",
+        "Params"
       ],
       "properties": {
-        "action": {
-          "description": "Action to be performed on the DAG.",
-          "type": "string",
-          "enum": [
-            "start",
-            "suspend",
-            "stop",
-            "retry",
-            "mark-success",
-            "mark-failed",
-            "save",
-            "rename"
-          ]
+        "FinishedAt": {
+          "description": "Timestamp when the DAG finished.",
+          "type": "string"
         },
-        "params": {
-          "description": "Additional parameters for the action.",
+        "Log": {
           "type": "string"
         },
-        "requestId": {
-          "description": "Unique request ID for the action.",
+        "Name": {
           "type": "string"
         },
-        "step": {
-          "description": "Step name if the action targets a specific step.",
+        "Nodes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Node"
+          }
+        },
+        "OnCancel": {
+          "$ref": "#/definitions/Node"
+        },
+        "OnExit": {
+          "$ref": "#/definitions/Node"
+        },
+        "OnFailure": {
+          "$ref": "#/definitions/Node"
+        },
+        "OnSuccess": {
+          "$ref": "#/definitions/Node"
+        },
+        "Params": {
           "type": "string"
         },
-        "value": {
-          "description": "Optional extra value for the action.",
+        "Pid": {
+          "type": "integer"
+        },
+        "RequestId": {
           "type": "string"
-        }
-      }
-    },
-    "PostDAGActionResponse": {
-      "description": "Response object for posting an action to a DAG.",
-      "type": "object",
-      "properties": {
-        "NewDagID": {
-          "description": "New DAG ID, if the action resulted in a new DAG.",
+        },
+        "ScheduledTime": {
+          "description": "Time the run was scheduled for by the scheduler.",
           "type": "string"
-        }
-      }
-    },
-    "Precondition": {
-      "description": "Precondition that must be satisfied before execution",
-      "type": "object",
-      "properties": {
-        "Condition": {
-          "description": "Expression or check to evaluate",
+        },
+        "SkipReason": {
+          "description": "Reason the scheduled run was skipped, e.g. an excluded date.",
           "type": "string"
         },
-        "Expected": {
-          "description": "Expected result of the condition evaluation",
+        "SpecRevision": {
+          "description": "Revision hash of the DAG spec used by the run.",
           "type": "string"
-        }
-      }
-    },
-    "RepeatPolicy": {
-      "description": "Configuration for step retry behavior",
-      "type": "object",
-      "properties": {
-        "Interval": {
-          "description": "Time in seconds to wait between retry attempts",
+        },
+        "StartedAt": {
+          "description": "Timestamp when the DAG started.",
+          "type": "string"
+        },
+        "Status": {
           "type": "integer"
         },
-        "Repeat": {
-          "description": "Whether the step should be retried on failure",
-          "type": "boolean"
+        "StatusText": {
+          "type": "string"
         }
       }
     },
-    "Schedule": {
+    "DAGStatusFile": {
       "type": "object",
       "required": [
-        "Expression"
+        "File",
+        "Dir",
+        "DAG",
+        "Status",
+        "Suspended",
+        "Error"
       ],
       "properties": {
-        "Expression": {
+        "CommitSHA": {
+          "description": "Last git commit that changed the DAG file when git sync is enabled",
+          "type": "string"
+        },
+        "DAG": {
+          "$ref": "#/definitions/DAG"
+        },
+        "Dir": {
+          "type": "string"
+        },
+        "Error": {
           "type": "string"
+        },
+        "File": {
+          "type": "string"
+        },
+        "Status": {
+          "$ref": "#/definitions/DAGStatus"
+        },
+        "Suspended": {
+          "type": "boolean"
         }
       }
     },
-    "SchedulerLog": {
+    "DAGStatusFileDetails": {
+      "description": "Detailed status information for a DAG instance",
       "type": "object",
       "required": [
-        "LogFile",
-        "Content"
+        "File",
+        "Dir",
+        "DAG",
+        "Status",
+        "Suspended",
+        "Error"
       ],
       "properties": {
-        "Content": {
+        "CommitSHA": {
+          "description": "Last git commit that changed the DAG file when git sync is enabled",
           "type": "string"
         },
-        "LogFile": {
+        "DAG": {
+          "$ref": "#/definitions/DAGDetails"
+        },
+        "Dir": {
           "type": "string"
-        }
-      }
-    },
-    "SearchDAGsMatchItem": {
-      "type": "object",
-      "properties": {
-        "Line": {
+        },
+        "Error": {
           "type": "string"
         },
-        "LineNumber": {
-          "type": "integer"
+        "File": {
+          "type": "string"
         },
-        "StartLine": {
-          "type": "integer"
+        "Status": {
+          "$ref": "#/definitions/DAGStatusDetails"
+        },
+        "Suspended": {
+          "type": "boolean"
         }
       }
     },
-    "SearchDAGsResponse": {
+    "DiffDAGRevisionResponse": {
+      "description": "Response object for diffing revisions of a DAG.",
       "type": "object",
       "required": [
-        "Results",
-        "Errors"
+        "Diff"
       ],
       "properties": {
-        "Errors": {
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "Results": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/SearchDAGsResultItem"
-          }
+        "Diff": {
+          "description": "Unified diff between the revisions.",
+          "type": "string"
         }
       }
     },
-    "SearchDAGsResultItem": {
+    "Error": {
+      "description": "Generic error response object.",
       "type": "object",
+      "required": [
+        "code",
+        "message"
+      ],
       "properties": {
-        "DAG": {
-          "$ref": "#/definitions/DAG"
+        "code": {
+          "description": "Error code indicating the type of error.",
+          "type": "string",
+          "enum": [
+            "validation_error",
+            "not_found",
+            "internal_error",
+            "unauthorized",
+            "forbidden",
+            "bad_gateway"
+          ]
         },
-        "Matches": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/SearchDAGsMatchItem"
-          }
+        "details": {
+          "description": "Additional error details.",
+          "type": "object"
         },
-        "Name": {
+        "message": {
+          "description": "Short error message.",
           "type": "string"
         }
       }
     },
-    "Step": {
-      "description": "Individual task within a DAG that performs a specific operation",
+    "GetDAGDetailsResponse": {
+      "description": "Response object for getting details of a DAG.",
       "type": "object",
       "required": [
-        "Name",
-        "Description",
-        "Dir",
-        "CmdWithArgs",
-        "Command",
-        "Script",
-        "Stdout",
-        "Stderr",
-        "Output",
-        "Args",
-        "Depends",
-        "RepeatPolicy",
-        "MailOnError",
-        "Preconditions"
+        "Title",
+        "DAG",
+        "Tab",
+        "Graph",
+        "Definition",
+        "LogData",
+        "LogUrl",
+        "StepLog",
+        "ScLog",
+        "Errors"
       ],
       "properties": {
-        "Args": {
-          "description": "List of arguments to pass to the command",
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
-        },
-        "CmdWithArgs": {
-          "description": "Complete command string including arguments to execute",
-          "type": "string"
+        "DAG": {
+          "$ref": "#/definitions/DAGStatusFileDetails"
         },
-        "Command": {
-          "description": "Base command to execute without arguments",
+        "Definition": {
+          "description": "DAG definition.",
           "type": "string"
         },
-        "Depends": {
-          "description": "List of step names that must complete before this step can start",
+        "Errors": {
+          "description": "List of errors encountered during the request.",
           "type": "array",
           "items": {
             "type": "string"
           }
         },
-        "Description": {
-          "description": "Human-readable description of what the step does",
+        "Graph": {
+          "description": "Graph data for visualization.",
           "type": "string"
         },
-        "Dir": {
-          "description": "Working directory for executing the step's command",
+        "LogData": {
+          "$ref": "#/definitions/DAGLogData"
+        },
+        "LogUrl": {
           "type": "string"
         },
-        "MailOnError": {
-          "description": "Whether to send email notifications on step failure",
-          "type": "boolean"
+        "ScLog": {
+          "$ref": "#/definitions/SchedulerLog"
         },
-        "Name": {
-          "description": "Unique identifier for the step within the DAG",
-          "type": "string"
+        "StepLog": {
+          "$ref": "#/definitions/StepLog"
         },
-        "Output": {
-          "description": "Variable name to store the step's output",
+        "Tab": {
+          "description": "Tab name for UI navigation.",
           "type": "string"
         },
-        "Params": {
-          "description": "Parameters to pass to the sub DAG",
+        "Title": {
+          "description": "Title of the Page.",
           "type": "string"
+        }
+      }
+    },
+    "GetDAGRevisionResponse": {
+      "description": "Response object for getting a revision of a DAG.",
+      "type": "object",
+      "required": [
+        "Revision",
+        "Spec"
+      ],
+      "properties": {
+        "Revision": {
+          "$ref": "#/definitions/DAGRevision"
         },
-        "Preconditions": {
-          "description": "Conditions that must be met before the step can start",
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/Precondition"
-          }
-        },
-        "RepeatPolicy": {
-          "$ref": "#/definitions/RepeatPolicy"
-        },
-        "Run": {
-          "description": "Sub DAG to run",
+        "Spec": {
+          "description": "The DAG spec of the revision.",
           "type": "string"
-        },
-        "Script": {
-          "description": "Script content if the step executes a script file",
+        }
+      }
+    },
+    "GetSecretResponse": {
+      "description": "Response object for getting a secret. The value is not returned.",
+      "type": "object",
+      "required": [
+        "Scope",
+        "Name",
+        "UpdatedAt"
+      ],
+      "properties": {
+        "Name": {
+          "description": "Name of the secret.",
           "type": "string"
         },
-        "Stderr": {
-          "description": "File path for capturing standard error",
+        "Scope": {
+          "description": "Scope of the secret.",
           "type": "string"
         },
-        "Stdout": {
-          "description": "File path for capturing standard output",
+        "UpdatedAt": {
+          "description": "Time the secret was last set.",
           "type": "string"
         }
       }
     },
-    "StepLog": {
-      "type": "object",
-      "required": [
-        "Step",
-        "LogFile",
-        "Content"
-      ],
-      "properties": {
-        "Content": {
-          "type": "string"
-        },
-        "LogFile": {
-          "type": "string"
-        },
-        "Step": {
-          "$ref": "#/definitions/Node"
+    "HandlerOn": {
+      "description": "Configuration for event handlers in a DAG",
+      "type": "object",
+      "properties": {
+        "Cancel": {
+          "$ref": "#/definitions/Step"
+        },
+        "Exit": {
+          "$ref": "#/definitions/Step"
+        },
+        "Failure": {
+          "$ref": "#/definitions/Step"
+        },
+        "Success": {
+          "$ref": "#/definitions/Step"
+        }
+      }
+    },
+    "HealthResponse": {
+      "description": "Response object for the health check endpoint.",
+      "type": "object",
+      "required": [
+        "status",
+        "version",
+        "uptime",
+        "timestamp"
+      ],
+      "properties": {
+        "logs": {
+          "$ref": "#/definitions/LogUsage"
+        },
+        "scheduler": {
+          "$ref": "#/definitions/SchedulerLeader"
+        },
+        "status": {
+          "description": "Overall health status of the server",
+          "type": "string",
+          "enum": [
+            "healthy",
+            "unhealthy"
+          ]
+        },
+        "timestamp": {
+          "description": "Current server time",
+          "type": "string"
+        },
+        "uptime": {
+          "description": "Server uptime in seconds",
+          "type": "integer"
+        },
+        "version": {
+          "description": "Current version of the server",
+          "type": "string"
+        }
+      }
+    },
+    "ListAuditLogResponse": {
+      "description": "Response object for listing audit log entries.",
+      "type": "object",
+      "required": [
+        "Entries"
+      ],
+      "properties": {
+        "Entries": {
+          "description": "Audit log entries, newest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AuditEntry"
+          }
+        }
+      }
+    },
+    "ListDAGDecisionsResponse": {
+      "description": "Response object for listing scheduler decisions of a DAG.",
+      "type": "object",
+      "required": [
+        "Decisions"
+      ],
+      "properties": {
+        "Decisions": {
+          "description": "Scheduler decisions, newest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SchedulerDecision"
+          }
+        }
+      }
+    },
+    "ListDAGRevisionsResponse": {
+      "description": "Response object for listing revisions of a DAG.",
+      "type": "object",
+      "required": [
+        "Revisions"
+      ],
+      "properties": {
+        "Revisions": {
+          "description": "Revisions of the DAG spec, newest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DAGRevision"
+          }
+        }
+      }
+    },
+    "ListDAGsResponse": {
+      "description": "Response object for listing all DAGs.",
+      "type": "object",
+      "required": [
+        "DAGs",
+        "Errors",
+        "HasError",
+        "PageCount"
+      ],
+      "properties": {
+        "DAGs": {
+          "description": "List of DAGs with their status and metadata.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DAGStatusFile"
+          }
+        },
+        "Errors": {
+          "description": "List of errors encountered during the request.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "HasError": {
+          "description": "Whether any errors were encountered.",
+          "type": "boolean"
+        },
+        "PageCount": {
+          "description": "Total number of pages available.",
+          "type": "integer"
+        }
+      }
+    },
+    "ListScheduledRunsResponse": {
+      "description": "Response object for listing upcoming scheduled runs.",
+      "type": "object",
+      "required": [
+        "Runs"
+      ],
+      "properties": {
+        "Runs": {
+          "description": "Upcoming firings in order of time.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ScheduledRun"
+          }
+        }
+      }
+    },
+    "ListSecretsResponse": {
+      "description": "Response object for listing secrets.",
+      "type": "object",
+      "required": [
+        "Secrets"
+      ],
+      "properties": {
+        "Secrets": {
+          "description": "Secrets ordered by scope and name.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Secret"
+          }
+        }
+      }
+    },
+    "ListTagResponse": {
+      "description": "Response object for listing all tags",
+      "type": "object",
+      "required": [
+        "Tags",
+        "Errors"
+      ],
+      "properties": {
+        "Errors": {
+          "description": "List of errors encountered during the request",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Tags": {
+          "description": "List of unique tags",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "LogEntry": {
+      "description": "A line of the JSON log of a step",
+      "type": "object",
+      "properties": {
+        "fields": {
+          "description": "Other fields of the line",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "level": {
+          "description": "Level of the line in lower case, e.g. error",
+          "type": "string"
+        },
+        "message": {
+          "description": "Message of the line",
+          "type": "string"
+        },
+        "time": {
+          "description": "RFC 3339 timestamp of the line, if any",
+          "type": "string"
+        }
+      }
+    },
+    "LogUsage": {
+      "description": "Disk usage of the log directory.",
+      "type": "object",
+      "required": [
+        "usage",
+        "quota"
+      ],
+      "properties": {
+        "quota": {
+          "description": "Quota in bytes of the log directory, 0 if unlimited.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "updatedAt": {
+          "description": "Time the usage was measured.",
+          "type": "string"
+        },
+        "usage": {
+          "description": "Total size in bytes of the files in the log directory.",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "Node": {
+      "description": "Execution status of an individual step within a DAG",
+      "type": "object",
+      "required": [
+        "Step",
+        "Log",
+        "StartedAt",
+        "FinishedAt",
+        "Status",
+        "RetryCount",
+        "DoneCount",
+        "Error",
+        "StatusText"
+      ],
+      "properties": {
+        "DoneCount": {
+          "description": "Number of successful completions for repeating steps",
+          "type": "integer"
+        },
+        "Error": {
+          "description": "Error message if the step failed",
+          "type": "string"
+        },
+        "FinishedAt": {
+          "description": "RFC 3339 timestamp when the step completed",
+          "type": "string"
+        },
+        "Log": {
+          "description": "Path to step-specific log file",
+          "type": "string"
+        },
+        "LogLevels": {
+          "description": "Number of the lines of the JSON log by level, for the steps with logFormat json",
+          "type": "object",
+          "additionalProperties": {
+            "type": "integer"
+          }
+        },
+        "PeakRSS": {
+          "description": "Peak resident set size of the step in bytes",
+          "type": "integer"
+        },
+        "RetryCount": {
+          "description": "Number of retry attempts made for this step",
+          "type": "integer"
+        },
+        "StartedAt": {
+          "description": "RFC 3339 timestamp when the step started executing",
+          "type": "string"
+        },
+        "Status": {
+          "description": "Numeric status code for the step execution",
+          "type": "integer"
+        },
+        "StatusText": {
+          "description": "Human-readable status description",
+          "type": "string"
+        },
+        "Step": {
+          "$ref": "#/definitions/Step"
+        },
+        "SystemTime": {
+          "description": "CPU time spent in kernel mode by the step in milliseconds",
+          "type": "integer"
+        },
+        "UserTime": {
+          "description": "CPU time spent in user mode by the step in milliseconds",
+          "type": "integer"
+        }
+      }
+    },
+    "PostDAGActionRequest": {
+      "description": "Request body for posting an action to a DAG.",
+      "type": "object",
+      "required": [
+        "action"
+      ],
+      "properties": {
+        "action": {
+          "description": "Action to be performed on the DAG.",
+          "type": "string",
+          "enum": [
+            "start",
+            "suspend",
+            "stop",
+            "retry",
+            "mark-success",
+            "mark-failed",
+            "stop-step",
+            "retry-step",
+            "save",
+            "rename",
+            "rollback"
+          ]
+        },
+        "message": {
+          "description": "Description of the change for save and rollback actions.",
+          "type": "string"
+        },
+        "params": {
+          "description": "Additional parameters for the action.",
+          "type": "string"
+        },
+        "requestId": {
+          "description": "Unique request ID for the action.",
+          "type": "string"
+        },
+        "step": {
+          "description": "Step name if the action targets a specific step.",
+          "type": "string"
+        },
+        "value": {
+          "description": "Optional extra value for the action, e.g. the status (failed or skipped) to stop the step with.",
+          "type": "string"
+        }
+      }
+    },
+    "PostDAGActionResponse": {
+      "description": "Response object for posting an action to a DAG.",
+      "type": "object",
+      "properties": {
+        "NewDagID": {
+          "description": "New DAG ID, if the action resulted in a new DAG.",
+          "type": "string"
+        }
+      }
+    },
+    "Precondition": {
+      "description": "Precondition that must be satisfied before execution",
+      "type": "object",
+      "properties": {
+        "Condition": {
+          "description": "Expression or check to evaluate",
+          "type": "string"
+        },
+        "Expected": {
+          "description": "Expected result of the condition evaluation",
+          "type": "string"
+        }
+      }
+    },
+    "RepeatPolicy": {
+      "description": "Configuration for step retry behavior",
+      "type": "object",
+      "properties": {
+        "Interval": {
+          "description": "Time in seconds to wait between retry attempts",
+          "type": "integer"
+        },
+        "Repeat": {
+          "description": "Whether the step should be retried on failure",
+          "type": "boolean"
+        }
+      }
+    },
+    "Schedule": {
+      "type": "object",
+      "required": [
+        "Expression"
+      ],
+      "properties": {
+        "Expression": {
+          "type": "string"
+        },
+        "NextRun": {
+          "description": "RFC 3339 timestamp of the next run in the effective timezone",
+          "type": "string"
+        },
+        "Timezone": {
+          "description": "Effective timezone the schedule is evaluated in",
+          "type": "string"
+        }
+      }
+    },
+    "ScheduledRun": {
+      "description": "An upcoming firing of a schedule of a DAG.",
+      "type": "object",
+      "required": [
+        "DagID",
+        "Name",
+        "Type",
+        "Time"
+      ],
+      "properties": {
+        "DagID": {
+          "description": "ID of the DAG.",
+          "type": "string"
+        },
+        "DagTime": {
+          "description": "Time of the firing in the timezone of the schedule, if the DAG specifies one.",
+          "type": "string"
+        },
+        "Name": {
+          "description": "Name of the DAG.",
+          "type": "string"
+        },
+        "SkipReason": {
+          "description": "Reason the run will be skipped, e.g. an excluded date.",
+          "type": "string"
+        },
+        "StartTime": {
+          "description": "Time the DAG is started, if it is delayed by the schedule jitter (RFC3339).",
+          "type": "string"
+        },
+        "Time": {
+          "description": "Time of the firing in the timezone of the server (RFC3339).",
+          "type": "string"
+        },
+        "Timezone": {
+          "description": "Timezone of the schedule, if the DAG specifies one.",
+          "type": "string"
+        },
+        "Type": {
+          "description": "Operation performed on the firing.",
+          "type": "string",
+          "enum": [
+            "start",
+            "stop",
+            "restart"
+          ]
+        }
+      }
+    },
+    "SchedulerDecision": {
+      "description": "A record of what the scheduler did when a schedule of a DAG fired and why.",
+      "type": "object",
+      "required": [
+        "Timestamp",
+        "ScheduledTime",
+        "Type",
+        "Decision"
+      ],
+      "properties": {
+        "Decision": {
+          "description": "What the scheduler did.",
+          "type": "string",
+          "enum": [
+            "started",
+            "stopped",
+            "restarted",
+            "skipped-running",
+            "skipped-successful",
+            "skipped-finished",
+            "skipped-calendar",
+            "skipped-not-running",
+            "suspended",
+            "failed"
+          ]
+        },
+        "Outcome": {
+          "description": "Result of the started run. It is empty while the run is in progress.",
+          "type": "string",
+          "enum": [
+            "succeeded",
+            "precondition-failed",
+            "failed"
+          ]
+        },
+        "Reason": {
+          "description": "Why the scheduler did not run the operation or why the started run failed, if any.",
+          "type": "string"
+        },
+        "ScheduledTime": {
+          "description": "Time the schedule fired.",
+          "type": "string"
+        },
+        "Timestamp": {
+          "description": "Time the decision was made.",
+          "type": "string"
+        },
+        "Type": {
+          "description": "Operation of the schedule.",
+          "type": "string",
+          "enum": [
+            "start",
+            "stop",
+            "restart"
+          ]
+        }
+      }
+    },
+    "SchedulerLeader": {
+      "description": "Leader of the scheduler instances. Only included when the scheduler runs in high availability mode.",
+      "type": "object",
+      "properties": {
+        "acquiredAt": {
+          "description": "Time the leader acquired the lease.",
+          "type": "string"
+        },
+        "expiresAt": {
+          "description": "Time the lease expires unless renewed.",
+          "type": "string"
+        },
+        "leader": {
+          "description": "Identity of the scheduler instance holding the lease, empty if none.",
+          "type": "string"
+        },
+        "token": {
+          "description": "Fencing token of the lease.",
+          "type": "integer"
+        }
+      }
+    },
+    "SchedulerLog": {
+      "type": "object",
+      "required": [
+        "LogFile",
+        "Content"
+      ],
+      "properties": {
+        "Content": {
+          "type": "string"
+        },
+        "LogFile": {
+          "type": "string"
+        }
+      }
+    },
+    "SearchDAGsMatchItem": {
+      "type": "object",
+      "properties": {
+        "Line": {
+          "type": "string"
+        },
+        "LineNumber": {
+          "type": "integer"
+        },
+        "StartLine": {
+          "type": "integer"
+        }
+      }
+    },
+    "SearchDAGsResponse": {
+      "type": "object",
+      "required": [
+        "Results",
+        "Errors"
+      ],
+      "properties": {
+        "Errors": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Results": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SearchDAGsResultItem"
+          }
+        }
+      }
+    },
+    "SearchDAGsResultItem": {
+      "type": "object",
+      "properties": {
+        "DAG": {
+          "$ref": "#/definitions/DAG"
+        },
+        "Matches": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SearchDAGsMatchItem"
+          }
+        },
+        "Name": {
+          "type": "string"
+        }
+      }
+    },
+    "Secret": {
+      "description": "A secret in the local secret store without its value.",
+      "type": "object",
+      "required": [
+        "Scope",
+        "Name",
+        "UpdatedAt"
+      ],
+      "properties": {
+        "Name": {
+          "description": "Name of the secret.",
+          "type": "string"
+        },
+        "Scope": {
+          "description": "Scope of the secret: global, dag:\u003cID\u003e or group:\u003cname\u003e.",
+          "type": "string"
+        },
+        "UpdatedAt": {
+          "description": "Time the secret was last set (RFC3339).",
+          "type": "string"
+        }
+      }
+    },
+    "SetSecretRequest": {
+      "description": "Request body for setting a secret.",
+      "type": "object",
+      "required": [
+        "Value"
+      ],
+      "properties": {
+        "Value": {
+          "description": "Value of the secret.",
+          "type": "string"
+        }
+      }
+    },
+    "Step": {
+      "description": "Individual task within a DAG that performs a specific operation",
+      "type": "object",
+      "required": [
+        "Name",
+        "Description",
+        "Dir",
+        "CmdWithArgs",
+        "Command",
+        "Script",
+        "Stdout",
+        "Stderr",
+        "Output",
+        "Args",
+        "Depends",
+        "RepeatPolicy",
+        "MailOnError",
+        "Preconditions"
+      ],
+      "properties": {
+        "Args": {
+          "description": "List of arguments to pass to the command",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "CmdWithArgs": {
+          "description": "Complete command string including arguments to execute",
+          "type": "string"
+        },
+        "Command": {
+          "description": "Base command to execute without arguments",
+          "type": "string"
+        },
+        "Depends": {
+          "description": "List of step names that must complete before this step can start",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Description": {
+          "description": "Human-readable description of what the step does",
+          "type": "string"
+        },
+        "Dir": {
+          "description": "Working directory for executing the step's command",
+          "type": "string"
+        },
+        "MailOnError": {
+          "description": "Whether to send email notifications on step failure",
+          "type": "boolean"
+        },
+        "Name": {
+          "description": "Unique identifier for the step within the DAG",
+          "type": "string"
+        },
+        "Output": {
+          "description": "Variable name to store the step's output",
+          "type": "string"
+        },
+        "Params": {
+          "description": "Parameters to pass to the sub DAG",
+          "type": "string"
+        },
+        "Preconditions": {
+          "description": "Conditions that must be met before the step can start",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Precondition"
+          }
+        },
+        "RepeatPolicy": {
+          "$ref": "#/definitions/RepeatPolicy"
+        },
+        "Run": {
+          "description": "Sub DAG to run",
+          "type": "string"
+        },
+        "Script": {
+          "description": "Script content if the step executes a script file",
+          "type": "string"
+        },
+        "Stderr": {
+          "description": "File path for capturing standard error",
+          "type": "string"
+        },
+        "Stdout": {
+          "description": "File path for capturing standard output",
+          "type": "string"
+        }
+      }
+    },
+    "StepLog": {
+      "type": "object",
+      "required": [
+        "Step",
+        "LogFile",
+        "Content"
+      ],
+      "properties": {
+        "Content": {
+          "type": "string"
+        },
+        "Entries": {
+          "description": "Parsed lines of the JSON log, for the steps with logFormat json",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/LogEntry"
+          }
+        },
+        "LogFile": {
+          "type": "string"
+        },
+        "Step": {
+          "$ref": "#/definitions/Node"
+        }
+      }
+    }
+  },
+  "tags": [
+    {
+      "description": "Operations about DAGs",
+      "name": "dags"
+    },
+    {
+      "description": "System operations",
+      "name": "system"
+    },
+    {
+      "description": "Audit log of mutating operations",
+      "name": "audit"
+    },
+    {
+      "description": "Secrets in the encrypted local secret store (admin only)",
+      "name": "secrets"
+    }
+  ]
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "schemes": [
+    "http"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "description": "REST API for Dagu - a compact, portable workflow engine for orchestrating  command execution across diverse environments including shell scripts,  Python commands, containerized operations, and remote commands.\n",
+    "title": "Dagu",
+    "contact": {
+      "name": "Dagu",
+      "url": "https://github.com/dagu-org/dagu"
+    },
+    "license": {
+      "name": "GPL-3.0",
+      "url": "https://github.com/dagu-org/dagu/blob/main/LICENSE.md"
+    },
+    "version": "0.0.1"
+  },
+  "host": "localhost:8080",
+  "basePath": "/api/v1",
+  "paths": {
+    "/audit": {
+      "get": {
+        "description": "Returns audit log entries of mutating operations, newest first.",
+        "tags": [
+          "audit"
+        ],
+        "summary": "List audit log entries",
+        "operationId": "listAuditLog",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Filter entries by DAG.",
+            "name": "dagId",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Filter entries by action.",
+            "name": "action",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Filter entries by actor.",
+            "name": "actor",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Filter entries by request ID.",
+            "name": "requestId",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return entries recorded at or after this time (RFC3339).",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return entries recorded at or before this time (RFC3339).",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Maximum number of entries to return.",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListAuditLogResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags": {
+      "get": {
+        "description": "Returns a list of DAGs with optional pagination and search filters.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "List all DAGs",
+        "operationId": "listDAGs",
+        "parameters": [
+          {
+            "type": "integer",
+            "description": "Page number (for pagination).",
+            "name": "page",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Number of items to return per page.",
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Filter DAGs by matching name.",
+            "name": "searchName",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Filter DAGs by matching tag.",
+            "name": "searchTag",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListDAGsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Creates a new DAG.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Create a new DAG",
+        "operationId": "createDAG",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CreateDAGRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/CreateDAGResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}": {
+      "get": {
+        "description": "Returns details of a DAG, including files, logs, etc.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Get DAG details",
+        "operationId": "getDAGDetails",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Tab name for UI navigation.",
+            "name": "tab",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Specific file related to the DAG.",
+            "name": "file",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Step name within the DAG.",
+            "name": "step",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only show in the step log the lines of the JSON log at this level or above, e.g. warn.",
+            "name": "logLevel",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Only show in the step log the lines of the JSON log with the field, given as key=value or key. May be repeated.",
+            "name": "logField",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/GetDAGDetailsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Performs a specified action (e.g., start, stop) on the given DAG.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Perform an action on a DAG",
+        "operationId": "postDAGAction",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/PostDAGActionRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/PostDAGActionResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Deletes a DAG by its ID.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Delete a DAG",
+        "operationId": "deleteDAG",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/decisions": {
+      "get": {
+        "description": "Returns the decisions the scheduler made when the schedules of the DAG fired, newest first.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "List scheduler decisions of a DAG",
+        "operationId": "listDAGDecisions",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Filter decisions by the kind, e.g. skipped-running.",
+            "name": "decision",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return decisions made at or after this time (RFC3339).",
+            "name": "from",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return decisions made at or before this time (RFC3339).",
+            "name": "to",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "description": "Maximum number of decisions to return.",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListDAGDecisionsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
-    }
-  },
-  "tags": [
-    {
-      "description": "Operations about DAGs",
-      "name": "dags"
-    },
-    {
-      "description": "System operations",
-      "name": "system"
-    }
-  ]
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json"
-  ],
-  "produces": [
-    "application/json"
-  ],
-  "schemes": [
-    "http"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "description": "REST API for Dagu - a compact, portable workflow engine for orchestrating  command execution across diverse environments including shell scripts,  Python commands, containerized operations, and remote commands.\n",
-    "title": "Dagu",
-    "contact": {
-      "name": "Dagu",
-      "url": "https://github.com/dagu-org/dagu"
-    },
-    "license": {
-      "name": "GPL-3.0",
-      "url": "https://github.com/dagu-org/dagu/blob/main/LICENSE.md"
     },
-    "version": "0.0.1"
-  },
-  "host": "localhost:8080",
-  "basePath": "/api/v1",
-  "paths": {
-    "/dags": {
+    "/dags/{dagId}/events": {
       "get": {
-        "description": "Returns a list of DAGs with optional pagination and search filters.",
+        "description": "Returns the changes of the status of the running DAG and of its steps after the sequence number. If there are none, the request waits for them until the timeout. The next events are requested with the Seq of the response until Finished is true. An empty list is returned with Finished set if the DAG is not running.",
         "tags": [
           "dags"
         ],
-        "summary": "List all DAGs",
-        "operationId": "listDAGs",
+        "summary": "Wait for the events of a running DAG",
+        "operationId": "getDAGEvents",
         "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
           {
             "type": "integer",
-            "description": "Page number (for pagination).",
-            "name": "page",
+            "format": "int64",
+            "description": "Sequence number of the last event received. Defaults to 0 for all the events kept.",
+            "name": "since",
             "in": "query"
           },
           {
             "type": "integer",
-            "description": "Number of items to return per page.",
-            "name": "limit",
+            "description": "Seconds to wait for the events, up to 25. Defaults to 20.",
+            "name": "timeout",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/DAGEventsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/logs/stream": {
+      "get": {
+        "description": "Streams the log of a step, or the scheduler log of the run if the step is omitted, as Server-Sent Events. Each line of the log is sent as a message event whose ID is the offset in the log file right after the line. The lines written after the request are pushed as they are written, and an \"end\" event is sent when the run has finished. The stream resumes after the event given by the Last-Event-ID header.\n",
+        "produces": [
+          "text/event-stream"
+        ],
+        "tags": [
+          "dags"
+        ],
+        "summary": "Stream a log of a DAG run",
+        "operationId": "streamDAGLog",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Step name. The scheduler log is streamed if omitted.",
+            "name": "step",
             "in": "query"
           },
           {
             "type": "string",
-            "description": "Filter DAGs by matching name.",
-            "name": "searchName",
+            "description": "Request ID of the run. Defaults to the latest run.",
+            "name": "requestId",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Offset in the log file to start from. Defaults to the beginning.",
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "ID of the last event received, taking precedence over the offset.",
+            "name": "Last-Event-ID",
+            "in": "header"
+          },
+          {
+            "type": "string",
+            "description": "Only stream the lines of the JSON log at this level or above, e.g. warn.",
+            "name": "logLevel",
             "in": "query"
           },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "multi",
+            "description": "Only stream the lines of the JSON log with the field, given as key=value or key. May be repeated.",
+            "name": "logField",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A stream of the lines of the log.",
+            "schema": {
+              "type": "string"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/revisions": {
+      "get": {
+        "description": "Returns the saved revisions of the DAG spec, newest first.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "List revisions of a DAG",
+        "operationId": "listDAGRevisions",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListDAGRevisionsResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/revisions/{revision}": {
+      "get": {
+        "description": "Returns a revision of the DAG spec.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Get a revision of a DAG",
+        "operationId": "getDAGRevision",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The revision hash. It may be abbreviated.",
+            "name": "revision",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/GetDAGRevisionResponse"
+            }
+          },
+          "default": {
+            "description": "Generic error response.",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/dags/{dagId}/revisions/{revision}/diff": {
+      "get": {
+        "description": "Returns the unified diff from the revision to another revision or the current spec.",
+        "tags": [
+          "dags"
+        ],
+        "summary": "Diff a revision of a DAG",
+        "operationId": "diffDAGRevision",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The ID of the DAG.",
+            "name": "dagId",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The revision hash to diff from. It may be abbreviated.",
+            "name": "revision",
+            "in": "path",
+            "required": true
+          },
           {
             "type": "string",
-            "description": "Filter DAGs by matching tag.",
-            "name": "searchTag",
+            "description": "The revision hash to diff to. Defaults to the current spec.",
+            "name": "to",
             "in": "query"
           }
         ],
@@ -1346,7 +3026,7 @@ func init() {
           "200": {
             "description": "A successful response.",
             "schema": {
-              "$ref": "#/definitions/ListDAGsResponse"
+              "$ref": "#/definitions/DiffDAGRevisionResponse"
             }
           },
           "default": {
@@ -1356,33 +3036,31 @@ func init() {
             }
           }
         }
-      },
-      "post": {
-        "description": "Creates a new DAG.",
+      }
+    },
+    "/health": {
+      "get": {
+        "description": "Returns the health status of the server and its dependencies",
         "tags": [
-          "dags"
-        ],
-        "summary": "Create a new DAG",
-        "operationId": "createDAG",
-        "parameters": [
-          {
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/CreateDAGRequest"
-            }
-          }
+          "system"
         ],
+        "summary": "Health check endpoint",
+        "operationId": "getHealth",
         "responses": {
           "200": {
-            "description": "A successful response.",
+            "description": "Server is healthy",
             "schema": {
-              "$ref": "#/definitions/CreateDAGResponse"
+              "$ref": "#/definitions/HealthResponse"
+            }
+          },
+          "503": {
+            "description": "Server is unhealthy",
+            "schema": {
+              "$ref": "#/definitions/HealthResponse"
             }
           },
           "default": {
-            "description": "Generic error response.",
+            "description": "Unexpected error",
             "schema": {
               "$ref": "#/definitions/Error"
             }
@@ -1390,38 +3068,31 @@ func init() {
         }
       }
     },
-    "/dags/{dagId}": {
+    "/schedule": {
       "get": {
-        "description": "Returns details of a DAG, including files, logs, etc.",
+        "description": "Returns the upcoming start, stop and restart firings of the schedules of all DAGs in order of time. Suspended DAGs are excluded.",
         "tags": [
-          "dags"
+          "schedule"
         ],
-        "summary": "Get DAG details",
-        "operationId": "getDAGDetails",
+        "summary": "List upcoming scheduled runs",
+        "operationId": "listScheduledRuns",
         "parameters": [
           {
             "type": "string",
-            "description": "The ID of the DAG.",
-            "name": "dagId",
-            "in": "path",
-            "required": true
-          },
-          {
-            "type": "string",
-            "description": "Tab name for UI navigation.",
-            "name": "tab",
+            "description": "Only return firings at or after this time (RFC3339). Defaults to now.",
+            "name": "from",
             "in": "query"
           },
           {
             "type": "string",
-            "description": "Specific file related to the DAG.",
-            "name": "file",
+            "description": "Only return firings at or before this time (RFC3339).",
+            "name": "to",
             "in": "query"
           },
           {
-            "type": "string",
-            "description": "Step name within the DAG.",
-            "name": "step",
+            "type": "integer",
+            "description": "Maximum number of firings to return (default 100, at most 1000).",
+            "name": "limit",
             "in": "query"
           }
         ],
@@ -1429,7 +3100,7 @@ func init() {
           "200": {
             "description": "A successful response.",
             "schema": {
-              "$ref": "#/definitions/GetDAGDetailsResponse"
+              "$ref": "#/definitions/ListScheduledRunsResponse"
             }
           },
           "default": {
@@ -1439,36 +3110,30 @@ func init() {
             }
           }
         }
-      },
-      "post": {
-        "description": "Performs a specified action (e.g., start, stop) on the given DAG.",
+      }
+    },
+    "/search": {
+      "get": {
+        "description": "Searches for DAGs based on a query string.",
         "tags": [
           "dags"
         ],
-        "summary": "Perform an action on a DAG",
-        "operationId": "postDAGAction",
+        "summary": "Search DAGs",
+        "operationId": "searchDAGs",
         "parameters": [
           {
             "type": "string",
-            "description": "The ID of the DAG.",
-            "name": "dagId",
-            "in": "path",
+            "description": "A search query string.",
+            "name": "q",
+            "in": "query",
             "required": true
-          },
-          {
-            "name": "body",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/PostDAGActionRequest"
-            }
           }
         ],
         "responses": {
           "200": {
             "description": "A successful response.",
             "schema": {
-              "$ref": "#/definitions/PostDAGActionResponse"
+              "$ref": "#/definitions/SearchDAGsResponse"
             }
           },
           "default": {
@@ -1478,26 +3143,30 @@ func init() {
             }
           }
         }
-      },
-      "delete": {
-        "description": "Deletes a DAG by its ID.",
+      }
+    },
+    "/secrets": {
+      "get": {
+        "description": "Returns the secrets in the local secret store without their values. Requires authentication with the admin role.",
         "tags": [
-          "dags"
+          "secrets"
         ],
-        "summary": "Delete a DAG",
-        "operationId": "deleteDAG",
+        "summary": "List secrets",
+        "operationId": "listSecrets",
         "parameters": [
           {
             "type": "string",
-            "description": "The ID of the DAG.",
-            "name": "dagId",
-            "in": "path",
-            "required": true
+            "description": "Only return the secrets of the scope. Defaults to all scopes.",
+            "name": "scope",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "A successful response."
+            "description": "A successful response.",
+            "schema": {
+              "$ref": "#/definitions/ListSecretsResponse"
+            }
           },
           "default": {
             "description": "Generic error response.",
@@ -1508,59 +3177,111 @@ func init() {
         }
       }
     },
-    "/health": {
+    "/secrets/{name}": {
       "get": {
-        "description": "Returns the health status of the server and its dependencies",
+        "description": "Returns a secret in the local secret store without its value. Requires authentication with the admin role.",
         "tags": [
-          "system"
+          "secrets"
+        ],
+        "summary": "Get a secret",
+        "operationId": "getSecret",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The name of the secret.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Scope of the secret: global, dag:\u003cID\u003e or group:\u003cname\u003e. Defaults to global.",
+            "name": "scope",
+            "in": "query"
+          }
         ],
-        "summary": "Health check endpoint",
-        "operationId": "getHealth",
         "responses": {
           "200": {
-            "description": "Server is healthy",
+            "description": "A successful response.",
             "schema": {
-              "$ref": "#/definitions/HealthResponse"
+              "$ref": "#/definitions/GetSecretResponse"
             }
           },
-          "503": {
-            "description": "Server is unhealthy",
+          "default": {
+            "description": "Generic error response.",
             "schema": {
-              "$ref": "#/definitions/HealthResponse"
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "Creates or updates a secret in the local secret store. Requires authentication with the admin role.",
+        "tags": [
+          "secrets"
+        ],
+        "summary": "Set a secret",
+        "operationId": "setSecret",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The name of the secret.",
+            "name": "name",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "Scope of the secret: global, dag:\u003cID\u003e or group:\u003cname\u003e. Defaults to global.",
+            "name": "scope",
+            "in": "query"
+          },
+          {
+            "name": "body",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SetSecretRequest"
             }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "A successful response."
           },
           "default": {
-            "description": "Unexpected error",
+            "description": "Generic error response.",
             "schema": {
               "$ref": "#/definitions/Error"
             }
           }
         }
-      }
-    },
-    "/search": {
-      "get": {
-        "description": "Searches for DAGs based on a query string.",
+      },
+      "delete": {
+        "description": "Deletes a secret from the local secret store. Requires authentication with the admin role.",
         "tags": [
-          "dags"
+          "secrets"
         ],
-        "summary": "Search DAGs",
-        "operationId": "searchDAGs",
+        "summary": "Delete a secret",
+        "operationId": "deleteSecret",
         "parameters": [
           {
             "type": "string",
-            "description": "A search query string.",
-            "name": "q",
-            "in": "query",
+            "description": "The name of the secret.",
+            "name": "name",
+            "in": "path",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "Scope of the secret: global, dag:\u003cID\u003e or group:\u003cname\u003e. Defaults to global.",
+            "name": "scope",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "A successful response.",
-            "schema": {
-              "$ref": "#/definitions/SearchDAGsResponse"
-            }
+            "description": "A successful response."
           },
           "default": {
             "description": "Generic error response.",
@@ -1597,6 +3318,59 @@ func init() {
     }
   },
   "definitions": {
+    "AuditEntry": {
+      "description": "A single record of a mutating operation.",
+      "type": "object",
+      "required": [
+        "Timestamp",
+        "Source",
+        "Actor",
+        "Action",
+        "DAG"
+      ],
+      "properties": {
+        "Action": {
+          "description": "Action performed.",
+          "type": "string"
+        },
+        "Actor": {
+          "description": "User who performed the action.",
+          "type": "string"
+        },
+        "DAG": {
+          "description": "DAG the action was performed on.",
+          "type": "string"
+        },
+        "Detail": {
+          "description": "Additional information about the action.",
+          "type": "string"
+        },
+        "Diff": {
+          "description": "Unified diff of the DAG spec for save actions.",
+          "type": "string"
+        },
+        "RequestId": {
+          "description": "Request ID of the DAG run the action targeted.",
+          "type": "string"
+        },
+        "Source": {
+          "description": "Where the action originated from (api or cli).",
+          "type": "string"
+        },
+        "SourceIP": {
+          "description": "IP address of the client for API actions.",
+          "type": "string"
+        },
+        "Step": {
+          "description": "Step name if the action targeted a specific step.",
+          "type": "string"
+        },
+        "Timestamp": {
+          "description": "Time the action was recorded.",
+          "type": "string"
+        }
+      }
+    },
     "CreateDAGRequest": {
       "description": "Request body for creating a DAG.",
       "type": "object",
@@ -1770,15 +3544,82 @@ func init() {
           "description": "List of steps to execute in the DAG",
           "type": "array",
           "items": {
-            "$ref": "#/definitions/Step"
+            "$ref": "#/definitions/Step"
+          }
+        },
+        "Tags": {
+          "description": "List of tags for categorizing and filtering DAGs",
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "Timezone": {
+          "description": "Timezone of the DAG the schedule is evaluated in unless the expression has CRON_TZ",
+          "type": "string"
+        }
+      }
+    },
+    "DAGEvent": {
+      "description": "A change of the status of a running DAG or of one of its steps.",
+      "type": "object",
+      "required": [
+        "Seq",
+        "Time",
+        "RequestId",
+        "Status",
+        "StatusText"
+      ],
+      "properties": {
+        "Node": {
+          "description": "Step whose status changed, absent if the status of the DAG changed.",
+          "$ref": "#/definitions/Node"
+        },
+        "RequestId": {
+          "type": "string"
+        },
+        "Seq": {
+          "description": "Sequence number of the event in the run, starting at 1.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "Status": {
+          "description": "Status of the DAG at the event.",
+          "type": "integer"
+        },
+        "StatusText": {
+          "type": "string"
+        },
+        "Time": {
+          "description": "Time of the event.",
+          "type": "string"
+        }
+      }
+    },
+    "DAGEventsResponse": {
+      "description": "Response object for the events of a running DAG.",
+      "type": "object",
+      "required": [
+        "Events",
+        "Seq",
+        "Finished"
+      ],
+      "properties": {
+        "Events": {
+          "description": "Events after the sequence number, oldest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DAGEvent"
           }
         },
-        "Tags": {
-          "description": "List of tags for categorizing and filtering DAGs",
-          "type": "array",
-          "items": {
-            "type": "string"
-          }
+        "Finished": {
+          "description": "Whether the run has finished and no events follow.",
+          "type": "boolean"
+        },
+        "Seq": {
+          "description": "Sequence number of the last event of the run, after which the next events are requested.",
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
@@ -1836,6 +3677,34 @@ func init() {
         }
       }
     },
+    "DAGRevision": {
+      "description": "A saved version of a DAG spec.",
+      "type": "object",
+      "required": [
+        "Hash",
+        "Timestamp",
+        "Author",
+        "Message"
+      ],
+      "properties": {
+        "Author": {
+          "description": "User who saved the revision.",
+          "type": "string"
+        },
+        "Hash": {
+          "description": "Content hash of the spec.",
+          "type": "string"
+        },
+        "Message": {
+          "description": "Description of the change.",
+          "type": "string"
+        },
+        "Timestamp": {
+          "description": "Time the revision was saved.",
+          "type": "string"
+        }
+      }
+    },
     "DAGStatus": {
       "description": "Current execution status of a DAG instance",
       "type": "object",
@@ -1875,6 +3744,10 @@ func init() {
           "description": "Unique identifier for the DAG execution request",
           "type": "string"
         },
+        "SkipReason": {
+          "description": "Reason the scheduled run was skipped, e.g. an excluded date",
+          "type": "string"
+        },
         "StartedAt": {
           "description": "RFC 3339 timestamp when the DAG execution started",
           "type": "string"
@@ -1945,6 +3818,18 @@ func init() {
         "RequestId": {
           "type": "string"
         },
+        "ScheduledTime": {
+          "description": "Time the run was scheduled for by the scheduler.",
+          "type": "string"
+        },
+        "SkipReason": {
+          "description": "Reason the scheduled run was skipped, e.g. an excluded date.",
+          "type": "string"
+        },
+        "SpecRevision": {
+          "description": "Revision hash of the DAG spec used by the run.",
+          "type": "string"
+        },
         "StartedAt": {
           "description": "Timestamp when the DAG started.",
           "type": "string"
@@ -1968,6 +3853,10 @@ func init() {
         "Error"
       ],
       "properties": {
+        "CommitSHA": {
+          "description": "Last git commit that changed the DAG file when git sync is enabled",
+          "type": "string"
+        },
         "DAG": {
           "$ref": "#/definitions/DAG"
         },
@@ -2000,6 +3889,10 @@ func init() {
         "Error"
       ],
       "properties": {
+        "CommitSHA": {
+          "description": "Last git commit that changed the DAG file when git sync is enabled",
+          "type": "string"
+        },
         "DAG": {
           "$ref": "#/definitions/DAGDetails"
         },
@@ -2020,6 +3913,19 @@ func init() {
         }
       }
     },
+    "DiffDAGRevisionResponse": {
+      "description": "Response object for diffing revisions of a DAG.",
+      "type": "object",
+      "required": [
+        "Diff"
+      ],
+      "properties": {
+        "Diff": {
+          "description": "Unified diff between the revisions.",
+          "type": "string"
+        }
+      }
+    },
     "Error": {
       "description": "Generic error response object.",
       "type": "object",
@@ -2036,6 +3942,7 @@ func init() {
             "not_found",
             "internal_error",
             "unauthorized",
+            "forbidden",
             "bad_gateway"
           ]
         },
@@ -2105,6 +4012,46 @@ func init() {
         }
       }
     },
+    "GetDAGRevisionResponse": {
+      "description": "Response object for getting a revision of a DAG.",
+      "type": "object",
+      "required": [
+        "Revision",
+        "Spec"
+      ],
+      "properties": {
+        "Revision": {
+          "$ref": "#/definitions/DAGRevision"
+        },
+        "Spec": {
+          "description": "The DAG spec of the revision.",
+          "type": "string"
+        }
+      }
+    },
+    "GetSecretResponse": {
+      "description": "Response object for getting a secret. The value is not returned.",
+      "type": "object",
+      "required": [
+        "Scope",
+        "Name",
+        "UpdatedAt"
+      ],
+      "properties": {
+        "Name": {
+          "description": "Name of the secret.",
+          "type": "string"
+        },
+        "Scope": {
+          "description": "Scope of the secret.",
+          "type": "string"
+        },
+        "UpdatedAt": {
+          "description": "Time the secret was last set.",
+          "type": "string"
+        }
+      }
+    },
     "HandlerOn": {
       "description": "Configuration for event handlers in a DAG",
       "type": "object",
@@ -2133,6 +4080,12 @@ func init() {
         "timestamp"
       ],
       "properties": {
+        "logs": {
+          "$ref": "#/definitions/LogUsage"
+        },
+        "scheduler": {
+          "$ref": "#/definitions/SchedulerLeader"
+        },
         "status": {
           "description": "Overall health status of the server",
           "type": "string",
@@ -2155,6 +4108,54 @@ func init() {
         }
       }
     },
+    "ListAuditLogResponse": {
+      "description": "Response object for listing audit log entries.",
+      "type": "object",
+      "required": [
+        "Entries"
+      ],
+      "properties": {
+        "Entries": {
+          "description": "Audit log entries, newest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AuditEntry"
+          }
+        }
+      }
+    },
+    "ListDAGDecisionsResponse": {
+      "description": "Response object for listing scheduler decisions of a DAG.",
+      "type": "object",
+      "required": [
+        "Decisions"
+      ],
+      "properties": {
+        "Decisions": {
+          "description": "Scheduler decisions, newest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SchedulerDecision"
+          }
+        }
+      }
+    },
+    "ListDAGRevisionsResponse": {
+      "description": "Response object for listing revisions of a DAG.",
+      "type": "object",
+      "required": [
+        "Revisions"
+      ],
+      "properties": {
+        "Revisions": {
+          "description": "Revisions of the DAG spec, newest first.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/DAGRevision"
+          }
+        }
+      }
+    },
     "ListDAGsResponse": {
       "description": "Response object for listing all DAGs.",
       "type": "object",
@@ -2189,6 +4190,38 @@ func init() {
         }
       }
     },
+    "ListScheduledRunsResponse": {
+      "description": "Response object for listing upcoming scheduled runs.",
+      "type": "object",
+      "required": [
+        "Runs"
+      ],
+      "properties": {
+        "Runs": {
+          "description": "Upcoming firings in order of time.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ScheduledRun"
+          }
+        }
+      }
+    },
+    "ListSecretsResponse": {
+      "description": "Response object for listing secrets.",
+      "type": "object",
+      "required": [
+        "Secrets"
+      ],
+      "properties": {
+        "Secrets": {
+          "description": "Secrets ordered by scope and name.",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Secret"
+          }
+        }
+      }
+    },
     "ListTagResponse": {
       "description": "Response object for listing all tags",
       "type": "object",
@@ -2213,6 +4246,53 @@ func init() {
         }
       }
     },
+    "LogEntry": {
+      "description": "A line of the JSON log of a step",
+      "type": "object",
+      "properties": {
+        "fields": {
+          "description": "Other fields of the line",
+          "type": "object",
+          "additionalProperties": true
+        },
+        "level": {
+          "description": "Level of the line in lower case, e.g. error",
+          "type": "string"
+        },
+        "message": {
+          "description": "Message of the line",
+          "type": "string"
+        },
+        "time": {
+          "description": "RFC 3339 timestamp of the line, if any",
+          "type": "string"
+        }
+      }
+    },
+    "LogUsage": {
+      "description": "Disk usage of the log directory.",
+      "type": "object",
+      "required": [
+        "usage",
+        "quota"
+      ],
+      "properties": {
+        "quota": {
+          "description": "Quota in bytes of the log directory, 0 if unlimited.",
+          "type": "integer",
+          "format": "int64"
+        },
+        "updatedAt": {
+          "description": "Time the usage was measured.",
+          "type": "string"
+        },
+        "usage": {
+          "description": "Total size in bytes of the files in the log directory.",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
     "Node": {
       "description": "Execution status of an individual step within a DAG",
       "type": "object",
@@ -2244,6 +4324,17 @@ func init() {
           "description": "Path to step-specific log file",
           "type": "string"
         },
+        "LogLevels": {
+          "description": "Number of the lines of the JSON log by level, for the steps with logFormat json",
+          "type": "object",
+          "additionalProperties": {
+            "type": "integer"
+          }
+        },
+        "PeakRSS": {
+          "description": "Peak resident set size of the step in bytes",
+          "type": "integer"
+        },
         "RetryCount": {
           "description": "Number of retry attempts made for this step",
           "type": "integer"
@@ -2262,6 +4353,14 @@ func init() {
         },
         "Step": {
           "$ref": "#/definitions/Step"
+        },
+        "SystemTime": {
+          "description": "CPU time spent in kernel mode by the step in milliseconds",
+          "type": "integer"
+        },
+        "UserTime": {
+          "description": "CPU time spent in user mode by the step in milliseconds",
+          "type": "integer"
         }
       }
     },
@@ -2282,10 +4381,17 @@ func init() {
             "retry",
             "mark-success",
             "mark-failed",
+            "stop-step",
+            "retry-step",
             "save",
-            "rename"
+            "rename",
+            "rollback"
           ]
         },
+        "message": {
+          "description": "Description of the change for save and rollback actions.",
+          "type": "string"
+        },
         "params": {
           "description": "Additional parameters for the action.",
           "type": "string"
@@ -2299,7 +4405,7 @@ func init() {
           "type": "string"
         },
         "value": {
-          "description": "Optional extra value for the action.",
+          "description": "Optional extra value for the action, e.g. the status (failed or skipped) to stop the step with.",
           "type": "string"
         }
       }
@@ -2350,6 +4456,143 @@ func init() {
       "properties": {
         "Expression": {
           "type": "string"
+        },
+        "NextRun": {
+          "description": "RFC 3339 timestamp of the next run in the effective timezone",
+          "type": "string"
+        },
+        "Timezone": {
+          "description": "Effective timezone the schedule is evaluated in",
+          "type": "string"
+        }
+      }
+    },
+    "ScheduledRun": {
+      "description": "An upcoming firing of a schedule of a DAG.",
+      "type": "object",
+      "required": [
+        "DagID",
+        "Name",
+        "Type",
+        "Time"
+      ],
+      "properties": {
+        "DagID": {
+          "description": "ID of the DAG.",
+          "type": "string"
+        },
+        "DagTime": {
+          "description": "Time of the firing in the timezone of the schedule, if the DAG specifies one.",
+          "type": "string"
+        },
+        "Name": {
+          "description": "Name of the DAG.",
+          "type": "string"
+        },
+        "SkipReason": {
+          "description": "Reason the run will be skipped, e.g. an excluded date.",
+          "type": "string"
+        },
+        "StartTime": {
+          "description": "Time the DAG is started, if it is delayed by the schedule jitter (RFC3339).",
+          "type": "string"
+        },
+        "Time": {
+          "description": "Time of the firing in the timezone of the server (RFC3339).",
+          "type": "string"
+        },
+        "Timezone": {
+          "description": "Timezone of the schedule, if the DAG specifies one.",
+          "type": "string"
+        },
+        "Type": {
+          "description": "Operation performed on the firing.",
+          "type": "string",
+          "enum": [
+            "start",
+            "stop",
+            "restart"
+          ]
+        }
+      }
+    },
+    "SchedulerDecision": {
+      "description": "A record of what the scheduler did when a schedule of a DAG fired and why.",
+      "type": "object",
+      "required": [
+        "Timestamp",
+        "ScheduledTime",
+        "Type",
+        "Decision"
+      ],
+      "properties": {
+        "Decision": {
+          "description": "What the scheduler did.",
+          "type": "string",
+          "enum": [
+            "started",
+            "stopped",
+            "restarted",
+            "skipped-running",
+            "skipped-successful",
+            "skipped-finished",
+            "skipped-calendar",
+            "skipped-not-running",
+            "suspended",
+            "failed"
+          ]
+        },
+        "Outcome": {
+          "description": "Result of the started run. It is empty while the run is in progress.",
+          "type": "string",
+          "enum": [
+            "succeeded",
+            "precondition-failed",
+            "failed"
+          ]
+        },
+        "Reason": {
+          "description": "Why the scheduler did not run the operation or why the started run failed, if any.",
+          "type": "string"
+        },
+        "ScheduledTime": {
+          "description": "Time the schedule fired.",
+          "type": "string"
+        },
+        "Timestamp": {
+          "description": "Time the decision was made.",
+          "type": "string"
+        },
+        "Type": {
+          "description": "Operation of the schedule.",
+          "type": "string",
+          "enum": [
+            "start",
+            "stop",
+            "restart"
+          ]
+        }
+      }
+    },
+    "SchedulerLeader": {
+      "description": "Leader of the scheduler instances. Only included when the scheduler runs in high availability mode.",
+      "type": "object",
+      "properties": {
+        "acquiredAt": {
+          "description": "Time the leader acquired the lease.",
+          "type": "string"
+        },
+        "expiresAt": {
+          "description": "Time the lease expires unless renewed.",
+          "type": "string"
+        },
+        "leader": {
+          "description": "Identity of the scheduler instance holding the lease, empty if none.",
+          "type": "string"
+        },
+        "token": {
+          "description": "Fencing token of the lease.",
+          "type": "integer"
         }
       }
     },
@@ -2420,6 +4663,42 @@ func init() {
         }
       }
     },
+    "Secret": {
+      "description": "A secret in the local secret store without its value.",
+      "type": "object",
+      "required": [
+        "Scope",
+        "Name",
+        "UpdatedAt"
+      ],
+      "properties": {
+        "Name": {
+          "description": "Name of the secret.",
+          "type": "string"
+        },
+        "Scope": {
+          "description": "Scope of the secret: global, dag:\u003cID\u003e or group:\u003cname\u003e.",
+          "type": "string"
+        },
+        "UpdatedAt": {
+          "description": "Time the secret was last set (RFC3339).",
+          "type": "string"
+        }
+      }
+    },
+    "SetSecretRequest": {
+      "description": "Request body for setting a secret.",
+      "type": "object",
+      "required": [
+        "Value"
+      ],
+      "properties": {
+        "Value": {
+          "description": "Value of the secret.",
+          "type": "string"
+        }
+      }
+    },
     "Step": {
       "description": "Individual task within a DAG that performs a specific operation",
       "type": "object",
@@ -2525,6 +4804,13 @@ func init() {
         "Content": {
           "type": "string"
         },
+        "Entries": {
+          "description": "Parsed lines of the JSON log, for the steps with logFormat json",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/LogEntry"
+          }
+        },
         "LogFile": {
           "type": "string"
         },
@@ -2542,6 +4828,14 @@ func init() {
     {
       "description": "System operations",
       "name": "system"
+    },
+    {
+      "description": "Audit log of mutating operations",
+      "name": "audit"
+    },
+    {
+      "description": "Secrets in the encrypted local secret store (admin only)",
+      "name": "secrets"
     }
   ]
 }`))
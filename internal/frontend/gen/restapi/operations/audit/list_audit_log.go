@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package audit
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ListAuditLogHandlerFunc turns a function with the right signature into a list audit log handler
+type ListAuditLogHandlerFunc func(ListAuditLogParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ListAuditLogHandlerFunc) Handle(params ListAuditLogParams) middleware.Responder {
+	return fn(params)
+}
+
+// ListAuditLogHandler interface for that can handle valid list audit log params
+type ListAuditLogHandler interface {
+	Handle(ListAuditLogParams) middleware.Responder
+}
+
+// NewListAuditLog creates a new http.Handler for the list audit log operation
+func NewListAuditLog(ctx *middleware.Context, handler ListAuditLogHandler) *ListAuditLog {
+	return &ListAuditLog{Context: ctx, Handler: handler}
+}
+
+/*
+	ListAuditLog swagger:route GET /audit audit listAuditLog
+
+# List audit log entries
+
+Returns audit log entries of mutating operations, newest first.
+*/
+type ListAuditLog struct {
+	Context *middleware.Context
+	Handler ListAuditLogHandler
+}
+
+func (o *ListAuditLog) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewListAuditLogParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
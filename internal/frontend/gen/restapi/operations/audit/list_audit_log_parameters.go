@@ -0,0 +1,245 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package audit
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewListAuditLogParams creates a new ListAuditLogParams object
+//
+// There are no default values defined in the spec.
+func NewListAuditLogParams() ListAuditLogParams {
+
+	return ListAuditLogParams{}
+}
+
+// ListAuditLogParams contains all the bound params for the list audit log operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters listAuditLog
+type ListAuditLogParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*Filter entries by action.
+	  In: query
+	*/
+	Action *string
+	/*Filter entries by actor.
+	  In: query
+	*/
+	Actor *string
+	/*Filter entries by DAG.
+	  In: query
+	*/
+	DagID *string
+	/*Only return entries recorded at or after this time (RFC3339).
+	  In: query
+	*/
+	From *string
+	/*Maximum number of entries to return.
+	  In: query
+	*/
+	Limit *int64
+	/*Filter entries by request ID.
+	  In: query
+	*/
+	RequestID *string
+	/*Only return entries recorded at or before this time (RFC3339).
+	  In: query
+	*/
+	To *string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewListAuditLogParams() beforehand.
+func (o *ListAuditLogParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	qAction, qhkAction, _ := qs.GetOK("action")
+	if err := o.bindAction(qAction, qhkAction, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qActor, qhkActor, _ := qs.GetOK("actor")
+	if err := o.bindActor(qActor, qhkActor, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qDagID, qhkDagID, _ := qs.GetOK("dagId")
+	if err := o.bindDagID(qDagID, qhkDagID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qFrom, qhkFrom, _ := qs.GetOK("from")
+	if err := o.bindFrom(qFrom, qhkFrom, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qLimit, qhkLimit, _ := qs.GetOK("limit")
+	if err := o.bindLimit(qLimit, qhkLimit, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qRequestID, qhkRequestID, _ := qs.GetOK("requestId")
+	if err := o.bindRequestID(qRequestID, qhkRequestID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qTo, qhkTo, _ := qs.GetOK("to")
+	if err := o.bindTo(qTo, qhkTo, route.Formats); err != nil {
+		res = append(res, err)
+	}
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindAction binds and validates parameter Action from query.
+func (o *ListAuditLogParams) bindAction(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Action = &raw
+
+	return nil
+}
+
+// bindActor binds and validates parameter Actor from query.
+func (o *ListAuditLogParams) bindActor(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Actor = &raw
+
+	return nil
+}
+
+// bindDagID binds and validates parameter DagID from query.
+func (o *ListAuditLogParams) bindDagID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.DagID = &raw
+
+	return nil
+}
+
+// bindFrom binds and validates parameter From from query.
+func (o *ListAuditLogParams) bindFrom(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.From = &raw
+
+	return nil
+}
+
+// bindLimit binds and validates parameter Limit from query.
+func (o *ListAuditLogParams) bindLimit(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("limit", "query", "int64", raw)
+	}
+	o.Limit = &value
+
+	return nil
+}
+
+// bindRequestID binds and validates parameter RequestID from query.
+func (o *ListAuditLogParams) bindRequestID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.RequestID = &raw
+
+	return nil
+}
+
+// bindTo binds and validates parameter To from query.
+func (o *ListAuditLogParams) bindTo(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.To = &raw
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package audit
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+)
+
+// ListAuditLogOKCode is the HTTP code returned for type ListAuditLogOK
+const ListAuditLogOKCode int = 200
+
+/*
+ListAuditLogOK A successful response.
+
+swagger:response listAuditLogOK
+*/
+type ListAuditLogOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ListAuditLogResponse `json:"body,omitempty"`
+}
+
+// NewListAuditLogOK creates ListAuditLogOK with default headers values
+func NewListAuditLogOK() *ListAuditLogOK {
+
+	return &ListAuditLogOK{}
+}
+
+// WithPayload adds the payload to the list audit log o k response
+func (o *ListAuditLogOK) WithPayload(payload *models.ListAuditLogResponse) *ListAuditLogOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list audit log o k response
+func (o *ListAuditLogOK) SetPayload(payload *models.ListAuditLogResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListAuditLogOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*
+ListAuditLogDefault Generic error response.
+
+swagger:response listAuditLogDefault
+*/
+type ListAuditLogDefault struct {
+	_statusCode int
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewListAuditLogDefault creates ListAuditLogDefault with default headers values
+func NewListAuditLogDefault(code int) *ListAuditLogDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &ListAuditLogDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the list audit log default response
+func (o *ListAuditLogDefault) WithStatusCode(code int) *ListAuditLogDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the list audit log default response
+func (o *ListAuditLogDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the list audit log default response
+func (o *ListAuditLogDefault) WithPayload(payload *models.Error) *ListAuditLogDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list audit log default response
+func (o *ListAuditLogDefault) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListAuditLogDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
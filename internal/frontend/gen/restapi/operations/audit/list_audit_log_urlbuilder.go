@@ -0,0 +1,159 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package audit
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"errors"
+	"net/url"
+	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
+)
+
+// ListAuditLogURL generates an URL for the list audit log operation
+type ListAuditLogURL struct {
+	Action    *string
+	Actor     *string
+	DagID     *string
+	From      *string
+	Limit     *int64
+	RequestID *string
+	To        *string
+
+	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
+}
+
+// WithBasePath sets the base path for this url builder, only required when it's different from the
+// base path specified in the swagger spec.
+// When the value of the base path is an empty string
+func (o *ListAuditLogURL) WithBasePath(bp string) *ListAuditLogURL {
+	o.SetBasePath(bp)
+	return o
+}
+
+// SetBasePath sets the base path for this url builder, only required when it's different from the
+// base path specified in the swagger spec.
+// When the value of the base path is an empty string
+func (o *ListAuditLogURL) SetBasePath(bp string) {
+	o._basePath = bp
+}
+
+// Build a url path and query string
+func (o *ListAuditLogURL) Build() (*url.URL, error) {
+	var _result url.URL
+
+	var _path = "/audit"
+
+	_basePath := o._basePath
+	if _basePath == "" {
+		_basePath = "/api/v1"
+	}
+	_result.Path = golangswaggerpaths.Join(_basePath, _path)
+
+	qs := make(url.Values)
+
+	var actionQ string
+	if o.Action != nil {
+		actionQ = *o.Action
+	}
+	if actionQ != "" {
+		qs.Set("action", actionQ)
+	}
+
+	var actorQ string
+	if o.Actor != nil {
+		actorQ = *o.Actor
+	}
+	if actorQ != "" {
+		qs.Set("actor", actorQ)
+	}
+
+	var dagIDQ string
+	if o.DagID != nil {
+		dagIDQ = *o.DagID
+	}
+	if dagIDQ != "" {
+		qs.Set("dagId", dagIDQ)
+	}
+
+	var fromQ string
+	if o.From != nil {
+		fromQ = *o.From
+	}
+	if fromQ != "" {
+		qs.Set("from", fromQ)
+	}
+
+	var limitQ string
+	if o.Limit != nil {
+		limitQ = swag.FormatInt64(*o.Limit)
+	}
+	if limitQ != "" {
+		qs.Set("limit", limitQ)
+	}
+
+	var requestIDQ string
+	if o.RequestID != nil {
+		requestIDQ = *o.RequestID
+	}
+	if requestIDQ != "" {
+		qs.Set("requestId", requestIDQ)
+	}
+
+	var toQ string
+	if o.To != nil {
+		toQ = *o.To
+	}
+	if toQ != "" {
+		qs.Set("to", toQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
+	return &_result, nil
+}
+
+// Must is a helper function to panic when the url builder returns an error
+func (o *ListAuditLogURL) Must(u *url.URL, err error) *url.URL {
+	if err != nil {
+		panic(err)
+	}
+	if u == nil {
+		panic("url can't be nil")
+	}
+	return u
+}
+
+// String returns the string representation of the path with query string
+func (o *ListAuditLogURL) String() string {
+	return o.Must(o.Build()).String()
+}
+
+// BuildFull builds a full url with scheme, host, path and query string
+func (o *ListAuditLogURL) BuildFull(scheme, host string) (*url.URL, error) {
+	if scheme == "" {
+		return nil, errors.New("scheme is required for a full url on ListAuditLogURL")
+	}
+	if host == "" {
+		return nil, errors.New("host is required for a full url on ListAuditLogURL")
+	}
+
+	base, err := o.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	base.Scheme = scheme
+	base.Host = host
+	return base, nil
+}
+
+// StringFull returns the string representation of a complete url
+func (o *ListAuditLogURL) StringFull(scheme, host string) string {
+	return o.Must(o.BuildFull(scheme, host)).String()
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// DiffDAGRevisionHandlerFunc turns a function with the right signature into a diff d a g revision handler
+type DiffDAGRevisionHandlerFunc func(DiffDAGRevisionParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn DiffDAGRevisionHandlerFunc) Handle(params DiffDAGRevisionParams) middleware.Responder {
+	return fn(params)
+}
+
+// DiffDAGRevisionHandler interface for that can handle valid diff d a g revision params
+type DiffDAGRevisionHandler interface {
+	Handle(DiffDAGRevisionParams) middleware.Responder
+}
+
+// NewDiffDAGRevision creates a new http.Handler for the diff d a g revision operation
+func NewDiffDAGRevision(ctx *middleware.Context, handler DiffDAGRevisionHandler) *DiffDAGRevision {
+	return &DiffDAGRevision{Context: ctx, Handler: handler}
+}
+
+/*
+	DiffDAGRevision swagger:route GET /dags/{dagId}/revisions/{revision}/diff dags diffDAGRevision
+
+# Diff a revision of a DAG
+
+Returns the unified diff from the revision to another revision or the current spec.
+*/
+type DiffDAGRevision struct {
+	Context *middleware.Context
+	Handler DiffDAGRevisionHandler
+}
+
+func (o *DiffDAGRevision) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewDiffDAGRevisionParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
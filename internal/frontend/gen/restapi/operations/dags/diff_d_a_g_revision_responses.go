@@ -0,0 +1,118 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+)
+
+// DiffDAGRevisionOKCode is the HTTP code returned for type DiffDAGRevisionOK
+const DiffDAGRevisionOKCode int = 200
+
+/*
+DiffDAGRevisionOK A successful response.
+
+swagger:response diffDAGRevisionOK
+*/
+type DiffDAGRevisionOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.DiffDAGRevisionResponse `json:"body,omitempty"`
+}
+
+// NewDiffDAGRevisionOK creates DiffDAGRevisionOK with default headers values
+func NewDiffDAGRevisionOK() *DiffDAGRevisionOK {
+
+	return &DiffDAGRevisionOK{}
+}
+
+// WithPayload adds the payload to the diff d a g revision o k response
+func (o *DiffDAGRevisionOK) WithPayload(payload *models.DiffDAGRevisionResponse) *DiffDAGRevisionOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the diff d a g revision o k response
+func (o *DiffDAGRevisionOK) SetPayload(payload *models.DiffDAGRevisionResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DiffDAGRevisionOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*
+DiffDAGRevisionDefault Generic error response.
+
+swagger:response diffDAGRevisionDefault
+*/
+type DiffDAGRevisionDefault struct {
+	_statusCode int
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewDiffDAGRevisionDefault creates DiffDAGRevisionDefault with default headers values
+func NewDiffDAGRevisionDefault(code int) *DiffDAGRevisionDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &DiffDAGRevisionDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the diff d a g revision default response
+func (o *DiffDAGRevisionDefault) WithStatusCode(code int) *DiffDAGRevisionDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the diff d a g revision default response
+func (o *DiffDAGRevisionDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the diff d a g revision default response
+func (o *DiffDAGRevisionDefault) WithPayload(payload *models.Error) *DiffDAGRevisionDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the diff d a g revision default response
+func (o *DiffDAGRevisionDefault) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *DiffDAGRevisionDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -40,6 +40,15 @@ type GetDAGDetailsParams struct {
 	  In: query
 	*/
 	File *string
+	/*Only show in the step log the lines of the JSON log with the field, given as key=value or key. May be repeated.
+	  In: query
+	  Collection Format: multi
+	*/
+	LogField []string
+	/*Only show in the step log the lines of the JSON log at this level or above, e.g. warn.
+	  In: query
+	*/
+	LogLevel *string
 	/*Step name within the DAG.
 	  In: query
 	*/
@@ -71,6 +80,16 @@ func (o *GetDAGDetailsParams) BindRequest(r *http.Request, route *middleware.Mat
 		res = append(res, err)
 	}
 
+	qLogField, qhkLogField, _ := qs.GetOK("logField")
+	if err := o.bindLogField(qLogField, qhkLogField, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qLogLevel, qhkLogLevel, _ := qs.GetOK("logLevel")
+	if err := o.bindLogLevel(qLogLevel, qhkLogLevel, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qStep, qhkStep, _ := qs.GetOK("step")
 	if err := o.bindStep(qStep, qhkStep, route.Formats); err != nil {
 		res = append(res, err)
@@ -118,6 +137,46 @@ func (o *GetDAGDetailsParams) bindFile(rawData []string, hasKey bool, formats st
 	return nil
 }
 
+// bindLogField binds and validates array parameter LogField from query.
+//
+// Arrays are parsed according to CollectionFormat: "multi" (defaults to "csv" when empty).
+func (o *GetDAGDetailsParams) bindLogField(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	// CollectionFormat: multi
+	logFieldIC := rawData
+	if len(logFieldIC) == 0 {
+		return nil
+	}
+
+	var logFieldIR []string
+	for _, logFieldIV := range logFieldIC {
+		logFieldI := logFieldIV
+
+		logFieldIR = append(logFieldIR, logFieldI)
+	}
+
+	o.LogField = logFieldIR
+
+	return nil
+}
+
+// bindLogLevel binds and validates parameter LogLevel from query.
+func (o *GetDAGDetailsParams) bindLogLevel(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.LogLevel = &raw
+
+	return nil
+}
+
 // bindStep binds and validates parameter Step from query.
 func (o *GetDAGDetailsParams) bindStep(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
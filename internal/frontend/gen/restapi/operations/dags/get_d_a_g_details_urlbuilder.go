@@ -10,15 +10,19 @@ import (
 	"net/url"
 	golangswaggerpaths "path"
 	"strings"
+
+	"github.com/go-openapi/swag"
 )
 
 // GetDAGDetailsURL generates an URL for the get d a g details operation
 type GetDAGDetailsURL struct {
 	DagID string
 
-	File *string
-	Step *string
-	Tab  *string
+	File     *string
+	LogField []string
+	LogLevel *string
+	Step     *string
+	Tab      *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -69,6 +73,28 @@ func (o *GetDAGDetailsURL) Build() (*url.URL, error) {
 		qs.Set("file", fileQ)
 	}
 
+	var logFieldIR []string
+	for _, logFieldI := range o.LogField {
+		logFieldIS := logFieldI
+		if logFieldIS != "" {
+			logFieldIR = append(logFieldIR, logFieldIS)
+		}
+	}
+
+	logField := swag.JoinByFormat(logFieldIR, "multi")
+
+	for _, qsv := range logField {
+		qs.Add("logField", qsv)
+	}
+
+	var logLevelQ string
+	if o.LogLevel != nil {
+		logLevelQ = *o.LogLevel
+	}
+	if logLevelQ != "" {
+		qs.Set("logLevel", logLevelQ)
+	}
+
 	var stepQ string
 	if o.Step != nil {
 		stepQ = *o.Step
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetDAGEventsHandlerFunc turns a function with the right signature into a get d a g events handler
+type GetDAGEventsHandlerFunc func(GetDAGEventsParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetDAGEventsHandlerFunc) Handle(params GetDAGEventsParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetDAGEventsHandler interface for that can handle valid get d a g events params
+type GetDAGEventsHandler interface {
+	Handle(GetDAGEventsParams) middleware.Responder
+}
+
+// NewGetDAGEvents creates a new http.Handler for the get d a g events operation
+func NewGetDAGEvents(ctx *middleware.Context, handler GetDAGEventsHandler) *GetDAGEvents {
+	return &GetDAGEvents{Context: ctx, Handler: handler}
+}
+
+/*
+	GetDAGEvents swagger:route GET /dags/{dagId}/events dags getDAGEvents
+
+# Wait for the events of a running DAG
+
+Returns the changes of the status of the running DAG and of its steps after the sequence number. If there are none, the request waits for them until the timeout. The next events are requested with the Seq of the response until Finished is true. An empty list is returned with Finished set if the DAG is not running.
+*/
+type GetDAGEvents struct {
+	Context *middleware.Context
+	Handler GetDAGEventsHandler
+}
+
+func (o *GetDAGEvents) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewGetDAGEventsParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,139 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewGetDAGEventsParams creates a new GetDAGEventsParams object
+//
+// There are no default values defined in the spec.
+func NewGetDAGEventsParams() GetDAGEventsParams {
+
+	return GetDAGEventsParams{}
+}
+
+// GetDAGEventsParams contains all the bound params for the get d a g events operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters getDAGEvents
+type GetDAGEventsParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*The ID of the DAG.
+	  Required: true
+	  In: path
+	*/
+	DagID string
+	/*Sequence number of the last event received. Defaults to 0 for all the events kept.
+	  In: query
+	*/
+	Since *int64
+	/*Seconds to wait for the events, up to 25. Defaults to 20.
+	  In: query
+	*/
+	Timeout *int64
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewGetDAGEventsParams() beforehand.
+func (o *GetDAGEventsParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	rDagID, rhkDagID, _ := route.Params.GetOK("dagId")
+	if err := o.bindDagID(rDagID, rhkDagID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qSince, qhkSince, _ := qs.GetOK("since")
+	if err := o.bindSince(qSince, qhkSince, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qTimeout, qhkTimeout, _ := qs.GetOK("timeout")
+	if err := o.bindTimeout(qTimeout, qhkTimeout, route.Formats); err != nil {
+		res = append(res, err)
+	}
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindDagID binds and validates parameter DagID from path.
+func (o *GetDAGEventsParams) bindDagID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// Parameter is provided by construction from the route
+	o.DagID = raw
+
+	return nil
+}
+
+// bindSince binds and validates parameter Since from query.
+func (o *GetDAGEventsParams) bindSince(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("since", "query", "int64", raw)
+	}
+	o.Since = &value
+
+	return nil
+}
+
+// bindTimeout binds and validates parameter Timeout from query.
+func (o *GetDAGEventsParams) bindTimeout(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("timeout", "query", "int64", raw)
+	}
+	o.Timeout = &value
+
+	return nil
+}
@@ -0,0 +1,118 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+)
+
+// GetDAGEventsOKCode is the HTTP code returned for type GetDAGEventsOK
+const GetDAGEventsOKCode int = 200
+
+/*
+GetDAGEventsOK A successful response.
+
+swagger:response getDAGEventsOK
+*/
+type GetDAGEventsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.DAGEventsResponse `json:"body,omitempty"`
+}
+
+// NewGetDAGEventsOK creates GetDAGEventsOK with default headers values
+func NewGetDAGEventsOK() *GetDAGEventsOK {
+
+	return &GetDAGEventsOK{}
+}
+
+// WithPayload adds the payload to the get d a g events o k response
+func (o *GetDAGEventsOK) WithPayload(payload *models.DAGEventsResponse) *GetDAGEventsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get d a g events o k response
+func (o *GetDAGEventsOK) SetPayload(payload *models.DAGEventsResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetDAGEventsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*
+GetDAGEventsDefault Generic error response.
+
+swagger:response getDAGEventsDefault
+*/
+type GetDAGEventsDefault struct {
+	_statusCode int
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewGetDAGEventsDefault creates GetDAGEventsDefault with default headers values
+func NewGetDAGEventsDefault(code int) *GetDAGEventsDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &GetDAGEventsDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the get d a g events default response
+func (o *GetDAGEventsDefault) WithStatusCode(code int) *GetDAGEventsDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the get d a g events default response
+func (o *GetDAGEventsDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the get d a g events default response
+func (o *GetDAGEventsDefault) WithPayload(payload *models.Error) *GetDAGEventsDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get d a g events default response
+func (o *GetDAGEventsDefault) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetDAGEventsDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// GetDAGRevisionHandlerFunc turns a function with the right signature into a get d a g revision handler
+type GetDAGRevisionHandlerFunc func(GetDAGRevisionParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn GetDAGRevisionHandlerFunc) Handle(params GetDAGRevisionParams) middleware.Responder {
+	return fn(params)
+}
+
+// GetDAGRevisionHandler interface for that can handle valid get d a g revision params
+type GetDAGRevisionHandler interface {
+	Handle(GetDAGRevisionParams) middleware.Responder
+}
+
+// NewGetDAGRevision creates a new http.Handler for the get d a g revision operation
+func NewGetDAGRevision(ctx *middleware.Context, handler GetDAGRevisionHandler) *GetDAGRevision {
+	return &GetDAGRevision{Context: ctx, Handler: handler}
+}
+
+/*
+	GetDAGRevision swagger:route GET /dags/{dagId}/revisions/{revision} dags getDAGRevision
+
+# Get a revision of a DAG
+
+Returns a revision of the DAG spec.
+*/
+type GetDAGRevision struct {
+	Context *middleware.Context
+	Handler GetDAGRevisionHandler
+}
+
+func (o *GetDAGRevision) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewGetDAGRevisionParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
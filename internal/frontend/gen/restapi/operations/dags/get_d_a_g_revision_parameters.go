@@ -0,0 +1,95 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewGetDAGRevisionParams creates a new GetDAGRevisionParams object
+//
+// There are no default values defined in the spec.
+func NewGetDAGRevisionParams() GetDAGRevisionParams {
+
+	return GetDAGRevisionParams{}
+}
+
+// GetDAGRevisionParams contains all the bound params for the get d a g revision operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters getDAGRevision
+type GetDAGRevisionParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*The ID of the DAG.
+	  Required: true
+	  In: path
+	*/
+	DagID string
+	/*The revision hash. It may be abbreviated.
+	  Required: true
+	  In: path
+	*/
+	Revision string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewGetDAGRevisionParams() beforehand.
+func (o *GetDAGRevisionParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	rDagID, rhkDagID, _ := route.Params.GetOK("dagId")
+	if err := o.bindDagID(rDagID, rhkDagID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	rRevision, rhkRevision, _ := route.Params.GetOK("revision")
+	if err := o.bindRevision(rRevision, rhkRevision, route.Formats); err != nil {
+		res = append(res, err)
+	}
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindDagID binds and validates parameter DagID from path.
+func (o *GetDAGRevisionParams) bindDagID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// Parameter is provided by construction from the route
+	o.DagID = raw
+
+	return nil
+}
+
+// bindRevision binds and validates parameter Revision from path.
+func (o *GetDAGRevisionParams) bindRevision(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// Parameter is provided by construction from the route
+	o.Revision = raw
+
+	return nil
+}
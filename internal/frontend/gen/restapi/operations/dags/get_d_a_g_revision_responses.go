@@ -0,0 +1,118 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+)
+
+// GetDAGRevisionOKCode is the HTTP code returned for type GetDAGRevisionOK
+const GetDAGRevisionOKCode int = 200
+
+/*
+GetDAGRevisionOK A successful response.
+
+swagger:response getDAGRevisionOK
+*/
+type GetDAGRevisionOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.GetDAGRevisionResponse `json:"body,omitempty"`
+}
+
+// NewGetDAGRevisionOK creates GetDAGRevisionOK with default headers values
+func NewGetDAGRevisionOK() *GetDAGRevisionOK {
+
+	return &GetDAGRevisionOK{}
+}
+
+// WithPayload adds the payload to the get d a g revision o k response
+func (o *GetDAGRevisionOK) WithPayload(payload *models.GetDAGRevisionResponse) *GetDAGRevisionOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get d a g revision o k response
+func (o *GetDAGRevisionOK) SetPayload(payload *models.GetDAGRevisionResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetDAGRevisionOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*
+GetDAGRevisionDefault Generic error response.
+
+swagger:response getDAGRevisionDefault
+*/
+type GetDAGRevisionDefault struct {
+	_statusCode int
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewGetDAGRevisionDefault creates GetDAGRevisionDefault with default headers values
+func NewGetDAGRevisionDefault(code int) *GetDAGRevisionDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &GetDAGRevisionDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the get d a g revision default response
+func (o *GetDAGRevisionDefault) WithStatusCode(code int) *GetDAGRevisionDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the get d a g revision default response
+func (o *GetDAGRevisionDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the get d a g revision default response
+func (o *GetDAGRevisionDefault) WithPayload(payload *models.Error) *GetDAGRevisionDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get d a g revision default response
+func (o *GetDAGRevisionDefault) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetDAGRevisionDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ListDAGDecisionsHandlerFunc turns a function with the right signature into a list d a g decisions handler
+type ListDAGDecisionsHandlerFunc func(ListDAGDecisionsParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ListDAGDecisionsHandlerFunc) Handle(params ListDAGDecisionsParams) middleware.Responder {
+	return fn(params)
+}
+
+// ListDAGDecisionsHandler interface for that can handle valid list d a g decisions params
+type ListDAGDecisionsHandler interface {
+	Handle(ListDAGDecisionsParams) middleware.Responder
+}
+
+// NewListDAGDecisions creates a new http.Handler for the list d a g decisions operation
+func NewListDAGDecisions(ctx *middleware.Context, handler ListDAGDecisionsHandler) *ListDAGDecisions {
+	return &ListDAGDecisions{Context: ctx, Handler: handler}
+}
+
+/*
+	ListDAGDecisions swagger:route GET /dags/{dagId}/decisions dags listDAGDecisions
+
+# List scheduler decisions of a DAG
+
+Returns the decisions the scheduler made when the schedules of the DAG fired, newest first.
+*/
+type ListDAGDecisions struct {
+	Context *middleware.Context
+	Handler ListDAGDecisionsHandler
+}
+
+func (o *ListDAGDecisions) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewListDAGDecisionsParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
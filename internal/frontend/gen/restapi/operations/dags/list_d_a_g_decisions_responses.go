@@ -0,0 +1,118 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+)
+
+// ListDAGDecisionsOKCode is the HTTP code returned for type ListDAGDecisionsOK
+const ListDAGDecisionsOKCode int = 200
+
+/*
+ListDAGDecisionsOK A successful response.
+
+swagger:response listDAGDecisionsOK
+*/
+type ListDAGDecisionsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ListDAGDecisionsResponse `json:"body,omitempty"`
+}
+
+// NewListDAGDecisionsOK creates ListDAGDecisionsOK with default headers values
+func NewListDAGDecisionsOK() *ListDAGDecisionsOK {
+
+	return &ListDAGDecisionsOK{}
+}
+
+// WithPayload adds the payload to the list d a g decisions o k response
+func (o *ListDAGDecisionsOK) WithPayload(payload *models.ListDAGDecisionsResponse) *ListDAGDecisionsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list d a g decisions o k response
+func (o *ListDAGDecisionsOK) SetPayload(payload *models.ListDAGDecisionsResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListDAGDecisionsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*
+ListDAGDecisionsDefault Generic error response.
+
+swagger:response listDAGDecisionsDefault
+*/
+type ListDAGDecisionsDefault struct {
+	_statusCode int
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewListDAGDecisionsDefault creates ListDAGDecisionsDefault with default headers values
+func NewListDAGDecisionsDefault(code int) *ListDAGDecisionsDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &ListDAGDecisionsDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the list d a g decisions default response
+func (o *ListDAGDecisionsDefault) WithStatusCode(code int) *ListDAGDecisionsDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the list d a g decisions default response
+func (o *ListDAGDecisionsDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the list d a g decisions default response
+func (o *ListDAGDecisionsDefault) WithPayload(payload *models.Error) *ListDAGDecisionsDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list d a g decisions default response
+func (o *ListDAGDecisionsDefault) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListDAGDecisionsDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ListDAGRevisionsHandlerFunc turns a function with the right signature into a list d a g revisions handler
+type ListDAGRevisionsHandlerFunc func(ListDAGRevisionsParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ListDAGRevisionsHandlerFunc) Handle(params ListDAGRevisionsParams) middleware.Responder {
+	return fn(params)
+}
+
+// ListDAGRevisionsHandler interface for that can handle valid list d a g revisions params
+type ListDAGRevisionsHandler interface {
+	Handle(ListDAGRevisionsParams) middleware.Responder
+}
+
+// NewListDAGRevisions creates a new http.Handler for the list d a g revisions operation
+func NewListDAGRevisions(ctx *middleware.Context, handler ListDAGRevisionsHandler) *ListDAGRevisions {
+	return &ListDAGRevisions{Context: ctx, Handler: handler}
+}
+
+/*
+	ListDAGRevisions swagger:route GET /dags/{dagId}/revisions dags listDAGRevisions
+
+# List revisions of a DAG
+
+Returns the saved revisions of the DAG spec, newest first.
+*/
+type ListDAGRevisions struct {
+	Context *middleware.Context
+	Handler ListDAGRevisionsHandler
+}
+
+func (o *ListDAGRevisions) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewListDAGRevisionsParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
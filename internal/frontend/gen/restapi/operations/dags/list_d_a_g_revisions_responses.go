@@ -0,0 +1,118 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+)
+
+// ListDAGRevisionsOKCode is the HTTP code returned for type ListDAGRevisionsOK
+const ListDAGRevisionsOKCode int = 200
+
+/*
+ListDAGRevisionsOK A successful response.
+
+swagger:response listDAGRevisionsOK
+*/
+type ListDAGRevisionsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ListDAGRevisionsResponse `json:"body,omitempty"`
+}
+
+// NewListDAGRevisionsOK creates ListDAGRevisionsOK with default headers values
+func NewListDAGRevisionsOK() *ListDAGRevisionsOK {
+
+	return &ListDAGRevisionsOK{}
+}
+
+// WithPayload adds the payload to the list d a g revisions o k response
+func (o *ListDAGRevisionsOK) WithPayload(payload *models.ListDAGRevisionsResponse) *ListDAGRevisionsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list d a g revisions o k response
+func (o *ListDAGRevisionsOK) SetPayload(payload *models.ListDAGRevisionsResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListDAGRevisionsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*
+ListDAGRevisionsDefault Generic error response.
+
+swagger:response listDAGRevisionsDefault
+*/
+type ListDAGRevisionsDefault struct {
+	_statusCode int
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewListDAGRevisionsDefault creates ListDAGRevisionsDefault with default headers values
+func NewListDAGRevisionsDefault(code int) *ListDAGRevisionsDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &ListDAGRevisionsDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the list d a g revisions default response
+func (o *ListDAGRevisionsDefault) WithStatusCode(code int) *ListDAGRevisionsDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the list d a g revisions default response
+func (o *ListDAGRevisionsDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the list d a g revisions default response
+func (o *ListDAGRevisionsDefault) WithPayload(payload *models.Error) *ListDAGRevisionsDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list d a g revisions default response
+func (o *ListDAGRevisionsDefault) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListDAGRevisionsDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
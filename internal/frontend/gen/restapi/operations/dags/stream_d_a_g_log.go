@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// StreamDAGLogHandlerFunc turns a function with the right signature into a stream d a g log handler
+type StreamDAGLogHandlerFunc func(StreamDAGLogParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn StreamDAGLogHandlerFunc) Handle(params StreamDAGLogParams) middleware.Responder {
+	return fn(params)
+}
+
+// StreamDAGLogHandler interface for that can handle valid stream d a g log params
+type StreamDAGLogHandler interface {
+	Handle(StreamDAGLogParams) middleware.Responder
+}
+
+// NewStreamDAGLog creates a new http.Handler for the stream d a g log operation
+func NewStreamDAGLog(ctx *middleware.Context, handler StreamDAGLogHandler) *StreamDAGLog {
+	return &StreamDAGLog{Context: ctx, Handler: handler}
+}
+
+/*
+	StreamDAGLog swagger:route GET /dags/{dagId}/logs/stream dags streamDAGLog
+
+# Stream a log of a DAG run
+
+Streams the log of a step, or the scheduler log of the run if the step is omitted, as Server-Sent Events. Each line of the log is sent as a message event whose ID is the offset in the log file right after the line. The lines written after the request are pushed as they are written, and an "end" event is sent when the run has finished. The stream resumes after the event given by the Last-Event-ID header.
+*/
+type StreamDAGLog struct {
+	Context *middleware.Context
+	Handler StreamDAGLogHandler
+}
+
+func (o *StreamDAGLog) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewStreamDAGLogParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
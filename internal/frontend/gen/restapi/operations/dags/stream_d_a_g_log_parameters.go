@@ -0,0 +1,245 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+)
+
+// NewStreamDAGLogParams creates a new StreamDAGLogParams object
+//
+// There are no default values defined in the spec.
+func NewStreamDAGLogParams() StreamDAGLogParams {
+
+	return StreamDAGLogParams{}
+}
+
+// StreamDAGLogParams contains all the bound params for the stream d a g log operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters streamDAGLog
+type StreamDAGLogParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*ID of the last event received, taking precedence over the offset.
+	  In: header
+	*/
+	LastEventID *string
+	/*The ID of the DAG.
+	  Required: true
+	  In: path
+	*/
+	DagID string
+	/*Only stream the lines of the JSON log with the field, given as key=value or key. May be repeated.
+	  In: query
+	  Collection Format: multi
+	*/
+	LogField []string
+	/*Only stream the lines of the JSON log at this level or above, e.g. warn.
+	  In: query
+	*/
+	LogLevel *string
+	/*Offset in the log file to start from. Defaults to the beginning.
+	  In: query
+	*/
+	Offset *int64
+	/*Request ID of the run. Defaults to the latest run.
+	  In: query
+	*/
+	RequestID *string
+	/*Step name. The scheduler log is streamed if omitted.
+	  In: query
+	*/
+	Step *string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewStreamDAGLogParams() beforehand.
+func (o *StreamDAGLogParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	if err := o.bindLastEventID(r.Header[http.CanonicalHeaderKey("Last-Event-ID")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	rDagID, rhkDagID, _ := route.Params.GetOK("dagId")
+	if err := o.bindDagID(rDagID, rhkDagID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qLogField, qhkLogField, _ := qs.GetOK("logField")
+	if err := o.bindLogField(qLogField, qhkLogField, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qLogLevel, qhkLogLevel, _ := qs.GetOK("logLevel")
+	if err := o.bindLogLevel(qLogLevel, qhkLogLevel, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qOffset, qhkOffset, _ := qs.GetOK("offset")
+	if err := o.bindOffset(qOffset, qhkOffset, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qRequestID, qhkRequestID, _ := qs.GetOK("requestId")
+	if err := o.bindRequestID(qRequestID, qhkRequestID, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qStep, qhkStep, _ := qs.GetOK("step")
+	if err := o.bindStep(qStep, qhkStep, route.Formats); err != nil {
+		res = append(res, err)
+	}
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindLastEventID binds and validates parameter LastEventID from header.
+func (o *StreamDAGLogParams) bindLastEventID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.LastEventID = &raw
+
+	return nil
+}
+
+// bindDagID binds and validates parameter DagID from path.
+func (o *StreamDAGLogParams) bindDagID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// Parameter is provided by construction from the route
+	o.DagID = raw
+
+	return nil
+}
+
+// bindLogField binds and validates array parameter LogField from query.
+//
+// Arrays are parsed according to CollectionFormat: "multi" (defaults to "csv" when empty).
+func (o *StreamDAGLogParams) bindLogField(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	// CollectionFormat: multi
+	logFieldIC := rawData
+	if len(logFieldIC) == 0 {
+		return nil
+	}
+
+	var logFieldIR []string
+	for _, logFieldIV := range logFieldIC {
+		logFieldI := logFieldIV
+
+		logFieldIR = append(logFieldIR, logFieldI)
+	}
+
+	o.LogField = logFieldIR
+
+	return nil
+}
+
+// bindLogLevel binds and validates parameter LogLevel from query.
+func (o *StreamDAGLogParams) bindLogLevel(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.LogLevel = &raw
+
+	return nil
+}
+
+// bindOffset binds and validates parameter Offset from query.
+func (o *StreamDAGLogParams) bindOffset(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("offset", "query", "int64", raw)
+	}
+	o.Offset = &value
+
+	return nil
+}
+
+// bindRequestID binds and validates parameter RequestID from query.
+func (o *StreamDAGLogParams) bindRequestID(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.RequestID = &raw
+
+	return nil
+}
+
+// bindStep binds and validates parameter Step from query.
+func (o *StreamDAGLogParams) bindStep(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Step = &raw
+
+	return nil
+}
@@ -0,0 +1,116 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+)
+
+// StreamDAGLogOKCode is the HTTP code returned for type StreamDAGLogOK
+const StreamDAGLogOKCode int = 200
+
+/*
+StreamDAGLogOK A stream of the lines of the log.
+
+swagger:response streamDAGLogOK
+*/
+type StreamDAGLogOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload string `json:"body,omitempty"`
+}
+
+// NewStreamDAGLogOK creates StreamDAGLogOK with default headers values
+func NewStreamDAGLogOK() *StreamDAGLogOK {
+
+	return &StreamDAGLogOK{}
+}
+
+// WithPayload adds the payload to the stream d a g log o k response
+func (o *StreamDAGLogOK) WithPayload(payload string) *StreamDAGLogOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the stream d a g log o k response
+func (o *StreamDAGLogOK) SetPayload(payload string) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *StreamDAGLogOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	payload := o.Payload
+	if err := producer.Produce(rw, payload); err != nil {
+		panic(err) // let the recovery middleware deal with this
+	}
+}
+
+/*
+StreamDAGLogDefault Generic error response.
+
+swagger:response streamDAGLogDefault
+*/
+type StreamDAGLogDefault struct {
+	_statusCode int
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewStreamDAGLogDefault creates StreamDAGLogDefault with default headers values
+func NewStreamDAGLogDefault(code int) *StreamDAGLogDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &StreamDAGLogDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the stream d a g log default response
+func (o *StreamDAGLogDefault) WithStatusCode(code int) *StreamDAGLogDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the stream d a g log default response
+func (o *StreamDAGLogDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the stream d a g log default response
+func (o *StreamDAGLogDefault) WithPayload(payload *models.Error) *StreamDAGLogDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the stream d a g log default response
+func (o *StreamDAGLogDefault) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *StreamDAGLogDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package dags
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"errors"
+	"net/url"
+	golangswaggerpaths "path"
+	"strings"
+
+	"github.com/go-openapi/swag"
+)
+
+// StreamDAGLogURL generates an URL for the stream d a g log operation
+type StreamDAGLogURL struct {
+	DagID string
+
+	LogField  []string
+	LogLevel  *string
+	Offset    *int64
+	RequestID *string
+	Step      *string
+
+	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
+}
+
+// WithBasePath sets the base path for this url builder, only required when it's different from the
+// base path specified in the swagger spec.
+// When the value of the base path is an empty string
+func (o *StreamDAGLogURL) WithBasePath(bp string) *StreamDAGLogURL {
+	o.SetBasePath(bp)
+	return o
+}
+
+// SetBasePath sets the base path for this url builder, only required when it's different from the
+// base path specified in the swagger spec.
+// When the value of the base path is an empty string
+func (o *StreamDAGLogURL) SetBasePath(bp string) {
+	o._basePath = bp
+}
+
+// Build a url path and query string
+func (o *StreamDAGLogURL) Build() (*url.URL, error) {
+	var _result url.URL
+
+	var _path = "/dags/{dagId}/logs/stream"
+
+	dagID := o.DagID
+	if dagID != "" {
+		_path = strings.Replace(_path, "{dagId}", dagID, -1)
+	} else {
+		return nil, errors.New("dagId is required on StreamDAGLogURL")
+	}
+
+	_basePath := o._basePath
+	if _basePath == "" {
+		_basePath = "/api/v1"
+	}
+	_result.Path = golangswaggerpaths.Join(_basePath, _path)
+
+	qs := make(url.Values)
+
+	var logFieldIR []string
+	for _, logFieldI := range o.LogField {
+		logFieldIS := logFieldI
+		if logFieldIS != "" {
+			logFieldIR = append(logFieldIR, logFieldIS)
+		}
+	}
+
+	logField := swag.JoinByFormat(logFieldIR, "multi")
+
+	for _, qsv := range logField {
+		qs.Add("logField", qsv)
+	}
+
+	var logLevelQ string
+	if o.LogLevel != nil {
+		logLevelQ = *o.LogLevel
+	}
+	if logLevelQ != "" {
+		qs.Set("logLevel", logLevelQ)
+	}
+
+	var offsetQ string
+	if o.Offset != nil {
+		offsetQ = swag.FormatInt64(*o.Offset)
+	}
+	if offsetQ != "" {
+		qs.Set("offset", offsetQ)
+	}
+
+	var requestIDQ string
+	if o.RequestID != nil {
+		requestIDQ = *o.RequestID
+	}
+	if requestIDQ != "" {
+		qs.Set("requestId", requestIDQ)
+	}
+
+	var stepQ string
+	if o.Step != nil {
+		stepQ = *o.Step
+	}
+	if stepQ != "" {
+		qs.Set("step", stepQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
+	return &_result, nil
+}
+
+// Must is a helper function to panic when the url builder returns an error
+func (o *StreamDAGLogURL) Must(u *url.URL, err error) *url.URL {
+	if err != nil {
+		panic(err)
+	}
+	if u == nil {
+		panic("url can't be nil")
+	}
+	return u
+}
+
+// String returns the string representation of the path with query string
+func (o *StreamDAGLogURL) String() string {
+	return o.Must(o.Build()).String()
+}
+
+// BuildFull builds a full url with scheme, host, path and query string
+func (o *StreamDAGLogURL) BuildFull(scheme, host string) (*url.URL, error) {
+	if scheme == "" {
+		return nil, errors.New("scheme is required for a full url on StreamDAGLogURL")
+	}
+	if host == "" {
+		return nil, errors.New("host is required for a full url on StreamDAGLogURL")
+	}
+
+	base, err := o.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	base.Scheme = scheme
+	base.Host = host
+	return base, nil
+}
+
+// StringFull returns the string representation of a complete url
+func (o *StreamDAGLogURL) StringFull(scheme, host string) string {
+	return o.Must(o.BuildFull(scheme, host)).String()
+}
@@ -7,6 +7,7 @@ package operations
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
@@ -19,7 +20,10 @@ import (
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/audit"
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/dags"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/schedule"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/secrets"
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/system"
 )
 
@@ -44,6 +48,9 @@ func NewDaguAPI(spec *loads.Document) *DaguAPI {
 		JSONConsumer: runtime.JSONConsumer(),
 
 		JSONProducer: runtime.JSONProducer(),
+		TextEventStreamProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("textEventStream producer has not yet been implemented")
+		}),
 
 		DagsCreateDAGHandler: dags.CreateDAGHandlerFunc(func(params dags.CreateDAGParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.CreateDAG has not yet been implemented")
@@ -51,15 +58,45 @@ func NewDaguAPI(spec *loads.Document) *DaguAPI {
 		DagsDeleteDAGHandler: dags.DeleteDAGHandlerFunc(func(params dags.DeleteDAGParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.DeleteDAG has not yet been implemented")
 		}),
+		SecretsDeleteSecretHandler: secrets.DeleteSecretHandlerFunc(func(params secrets.DeleteSecretParams) middleware.Responder {
+			return middleware.NotImplemented("operation secrets.DeleteSecret has not yet been implemented")
+		}),
+		DagsDiffDAGRevisionHandler: dags.DiffDAGRevisionHandlerFunc(func(params dags.DiffDAGRevisionParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.DiffDAGRevision has not yet been implemented")
+		}),
 		DagsGetDAGDetailsHandler: dags.GetDAGDetailsHandlerFunc(func(params dags.GetDAGDetailsParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.GetDAGDetails has not yet been implemented")
 		}),
+		DagsGetDAGEventsHandler: dags.GetDAGEventsHandlerFunc(func(params dags.GetDAGEventsParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.GetDAGEvents has not yet been implemented")
+		}),
+		DagsGetDAGRevisionHandler: dags.GetDAGRevisionHandlerFunc(func(params dags.GetDAGRevisionParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.GetDAGRevision has not yet been implemented")
+		}),
 		SystemGetHealthHandler: system.GetHealthHandlerFunc(func(params system.GetHealthParams) middleware.Responder {
 			return middleware.NotImplemented("operation system.GetHealth has not yet been implemented")
 		}),
+		SecretsGetSecretHandler: secrets.GetSecretHandlerFunc(func(params secrets.GetSecretParams) middleware.Responder {
+			return middleware.NotImplemented("operation secrets.GetSecret has not yet been implemented")
+		}),
+		AuditListAuditLogHandler: audit.ListAuditLogHandlerFunc(func(params audit.ListAuditLogParams) middleware.Responder {
+			return middleware.NotImplemented("operation audit.ListAuditLog has not yet been implemented")
+		}),
+		DagsListDAGDecisionsHandler: dags.ListDAGDecisionsHandlerFunc(func(params dags.ListDAGDecisionsParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.ListDAGDecisions has not yet been implemented")
+		}),
+		DagsListDAGRevisionsHandler: dags.ListDAGRevisionsHandlerFunc(func(params dags.ListDAGRevisionsParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.ListDAGRevisions has not yet been implemented")
+		}),
 		DagsListDAGsHandler: dags.ListDAGsHandlerFunc(func(params dags.ListDAGsParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.ListDAGs has not yet been implemented")
 		}),
+		ScheduleListScheduledRunsHandler: schedule.ListScheduledRunsHandlerFunc(func(params schedule.ListScheduledRunsParams) middleware.Responder {
+			return middleware.NotImplemented("operation schedule.ListScheduledRuns has not yet been implemented")
+		}),
+		SecretsListSecretsHandler: secrets.ListSecretsHandlerFunc(func(params secrets.ListSecretsParams) middleware.Responder {
+			return middleware.NotImplemented("operation secrets.ListSecrets has not yet been implemented")
+		}),
 		DagsListTagsHandler: dags.ListTagsHandlerFunc(func(params dags.ListTagsParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.ListTags has not yet been implemented")
 		}),
@@ -69,6 +106,12 @@ func NewDaguAPI(spec *loads.Document) *DaguAPI {
 		DagsSearchDAGsHandler: dags.SearchDAGsHandlerFunc(func(params dags.SearchDAGsParams) middleware.Responder {
 			return middleware.NotImplemented("operation dags.SearchDAGs has not yet been implemented")
 		}),
+		SecretsSetSecretHandler: secrets.SetSecretHandlerFunc(func(params secrets.SetSecretParams) middleware.Responder {
+			return middleware.NotImplemented("operation secrets.SetSecret has not yet been implemented")
+		}),
+		DagsStreamDAGLogHandler: dags.StreamDAGLogHandlerFunc(func(params dags.StreamDAGLogParams) middleware.Responder {
+			return middleware.NotImplemented("operation dags.StreamDAGLog has not yet been implemented")
+		}),
 	}
 }
 
@@ -105,23 +148,50 @@ type DaguAPI struct {
 	// JSONProducer registers a producer for the following mime types:
 	//   - application/json
 	JSONProducer runtime.Producer
+	// TextEventStreamProducer registers a producer for the following mime types:
+	//   - text/event-stream
+	TextEventStreamProducer runtime.Producer
 
 	// DagsCreateDAGHandler sets the operation handler for the create d a g operation
 	DagsCreateDAGHandler dags.CreateDAGHandler
 	// DagsDeleteDAGHandler sets the operation handler for the delete d a g operation
 	DagsDeleteDAGHandler dags.DeleteDAGHandler
+	// SecretsDeleteSecretHandler sets the operation handler for the delete secret operation
+	SecretsDeleteSecretHandler secrets.DeleteSecretHandler
+	// DagsDiffDAGRevisionHandler sets the operation handler for the diff d a g revision operation
+	DagsDiffDAGRevisionHandler dags.DiffDAGRevisionHandler
 	// DagsGetDAGDetailsHandler sets the operation handler for the get d a g details operation
 	DagsGetDAGDetailsHandler dags.GetDAGDetailsHandler
+	// DagsGetDAGEventsHandler sets the operation handler for the get d a g events operation
+	DagsGetDAGEventsHandler dags.GetDAGEventsHandler
+	// DagsGetDAGRevisionHandler sets the operation handler for the get d a g revision operation
+	DagsGetDAGRevisionHandler dags.GetDAGRevisionHandler
 	// SystemGetHealthHandler sets the operation handler for the get health operation
 	SystemGetHealthHandler system.GetHealthHandler
+	// SecretsGetSecretHandler sets the operation handler for the get secret operation
+	SecretsGetSecretHandler secrets.GetSecretHandler
+	// AuditListAuditLogHandler sets the operation handler for the list audit log operation
+	AuditListAuditLogHandler audit.ListAuditLogHandler
+	// DagsListDAGDecisionsHandler sets the operation handler for the list d a g decisions operation
+	DagsListDAGDecisionsHandler dags.ListDAGDecisionsHandler
+	// DagsListDAGRevisionsHandler sets the operation handler for the list d a g revisions operation
+	DagsListDAGRevisionsHandler dags.ListDAGRevisionsHandler
 	// DagsListDAGsHandler sets the operation handler for the list d a gs operation
 	DagsListDAGsHandler dags.ListDAGsHandler
+	// ScheduleListScheduledRunsHandler sets the operation handler for the list scheduled runs operation
+	ScheduleListScheduledRunsHandler schedule.ListScheduledRunsHandler
+	// SecretsListSecretsHandler sets the operation handler for the list secrets operation
+	SecretsListSecretsHandler secrets.ListSecretsHandler
 	// DagsListTagsHandler sets the operation handler for the list tags operation
 	DagsListTagsHandler dags.ListTagsHandler
 	// DagsPostDAGActionHandler sets the operation handler for the post d a g action operation
 	DagsPostDAGActionHandler dags.PostDAGActionHandler
 	// DagsSearchDAGsHandler sets the operation handler for the search d a gs operation
 	DagsSearchDAGsHandler dags.SearchDAGsHandler
+	// SecretsSetSecretHandler sets the operation handler for the set secret operation
+	SecretsSetSecretHandler secrets.SetSecretHandler
+	// DagsStreamDAGLogHandler sets the operation handler for the stream d a g log operation
+	DagsStreamDAGLogHandler dags.StreamDAGLogHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -198,6 +268,9 @@ func (o *DaguAPI) Validate() error {
 	if o.JSONProducer == nil {
 		unregistered = append(unregistered, "JSONProducer")
 	}
+	if o.TextEventStreamProducer == nil {
+		unregistered = append(unregistered, "TextEventStreamProducer")
+	}
 
 	if o.DagsCreateDAGHandler == nil {
 		unregistered = append(unregistered, "dags.CreateDAGHandler")
@@ -205,15 +278,45 @@ func (o *DaguAPI) Validate() error {
 	if o.DagsDeleteDAGHandler == nil {
 		unregistered = append(unregistered, "dags.DeleteDAGHandler")
 	}
+	if o.SecretsDeleteSecretHandler == nil {
+		unregistered = append(unregistered, "secrets.DeleteSecretHandler")
+	}
+	if o.DagsDiffDAGRevisionHandler == nil {
+		unregistered = append(unregistered, "dags.DiffDAGRevisionHandler")
+	}
 	if o.DagsGetDAGDetailsHandler == nil {
 		unregistered = append(unregistered, "dags.GetDAGDetailsHandler")
 	}
+	if o.DagsGetDAGEventsHandler == nil {
+		unregistered = append(unregistered, "dags.GetDAGEventsHandler")
+	}
+	if o.DagsGetDAGRevisionHandler == nil {
+		unregistered = append(unregistered, "dags.GetDAGRevisionHandler")
+	}
 	if o.SystemGetHealthHandler == nil {
 		unregistered = append(unregistered, "system.GetHealthHandler")
 	}
+	if o.SecretsGetSecretHandler == nil {
+		unregistered = append(unregistered, "secrets.GetSecretHandler")
+	}
+	if o.AuditListAuditLogHandler == nil {
+		unregistered = append(unregistered, "audit.ListAuditLogHandler")
+	}
+	if o.DagsListDAGDecisionsHandler == nil {
+		unregistered = append(unregistered, "dags.ListDAGDecisionsHandler")
+	}
+	if o.DagsListDAGRevisionsHandler == nil {
+		unregistered = append(unregistered, "dags.ListDAGRevisionsHandler")
+	}
 	if o.DagsListDAGsHandler == nil {
 		unregistered = append(unregistered, "dags.ListDAGsHandler")
 	}
+	if o.ScheduleListScheduledRunsHandler == nil {
+		unregistered = append(unregistered, "schedule.ListScheduledRunsHandler")
+	}
+	if o.SecretsListSecretsHandler == nil {
+		unregistered = append(unregistered, "secrets.ListSecretsHandler")
+	}
 	if o.DagsListTagsHandler == nil {
 		unregistered = append(unregistered, "dags.ListTagsHandler")
 	}
@@ -223,6 +326,12 @@ func (o *DaguAPI) Validate() error {
 	if o.DagsSearchDAGsHandler == nil {
 		unregistered = append(unregistered, "dags.SearchDAGsHandler")
 	}
+	if o.SecretsSetSecretHandler == nil {
+		unregistered = append(unregistered, "secrets.SetSecretHandler")
+	}
+	if o.DagsStreamDAGLogHandler == nil {
+		unregistered = append(unregistered, "dags.StreamDAGLogHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -271,6 +380,8 @@ func (o *DaguAPI) ProducersFor(mediaTypes []string) map[string]runtime.Producer
 		switch mt {
 		case "application/json":
 			result["application/json"] = o.JSONProducer
+		case "text/event-stream":
+			result["text/event-stream"] = o.TextEventStreamProducer
 		}
 
 		if p, ok := o.customProducers[mt]; ok {
@@ -319,6 +430,14 @@ func (o *DaguAPI) initHandlerCache() {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
 	o.handlers["DELETE"]["/dags/{dagId}"] = dags.NewDeleteDAG(o.context, o.DagsDeleteDAGHandler)
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/secrets/{name}"] = secrets.NewDeleteSecret(o.context, o.SecretsDeleteSecretHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/revisions/{revision}/diff"] = dags.NewDiffDAGRevision(o.context, o.DagsDiffDAGRevisionHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -326,14 +445,46 @@ func (o *DaguAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/dags/{dagId}/events"] = dags.NewGetDAGEvents(o.context, o.DagsGetDAGEventsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/revisions/{revision}"] = dags.NewGetDAGRevision(o.context, o.DagsGetDAGRevisionHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/health"] = system.NewGetHealth(o.context, o.SystemGetHealthHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/secrets/{name}"] = secrets.NewGetSecret(o.context, o.SecretsGetSecretHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/audit"] = audit.NewListAuditLog(o.context, o.AuditListAuditLogHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/decisions"] = dags.NewListDAGDecisions(o.context, o.DagsListDAGDecisionsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/revisions"] = dags.NewListDAGRevisions(o.context, o.DagsListDAGRevisionsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/dags"] = dags.NewListDAGs(o.context, o.DagsListDAGsHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/schedule"] = schedule.NewListScheduledRuns(o.context, o.ScheduleListScheduledRunsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/secrets"] = secrets.NewListSecrets(o.context, o.SecretsListSecretsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/tags"] = dags.NewListTags(o.context, o.DagsListTagsHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
@@ -343,6 +494,14 @@ func (o *DaguAPI) initHandlerCache() {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/search"] = dags.NewSearchDAGs(o.context, o.DagsSearchDAGsHandler)
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/secrets/{name}"] = secrets.NewSetSecret(o.context, o.SecretsSetSecretHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/dags/{dagId}/logs/stream"] = dags.NewStreamDAGLog(o.context, o.DagsStreamDAGLogHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
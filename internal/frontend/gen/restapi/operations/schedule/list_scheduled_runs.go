@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package schedule
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// ListScheduledRunsHandlerFunc turns a function with the right signature into a list scheduled runs handler
+type ListScheduledRunsHandlerFunc func(ListScheduledRunsParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn ListScheduledRunsHandlerFunc) Handle(params ListScheduledRunsParams) middleware.Responder {
+	return fn(params)
+}
+
+// ListScheduledRunsHandler interface for that can handle valid list scheduled runs params
+type ListScheduledRunsHandler interface {
+	Handle(ListScheduledRunsParams) middleware.Responder
+}
+
+// NewListScheduledRuns creates a new http.Handler for the list scheduled runs operation
+func NewListScheduledRuns(ctx *middleware.Context, handler ListScheduledRunsHandler) *ListScheduledRuns {
+	return &ListScheduledRuns{Context: ctx, Handler: handler}
+}
+
+/*
+	ListScheduledRuns swagger:route GET /schedule schedule listScheduledRuns
+
+# List upcoming scheduled runs
+
+Returns the upcoming start, stop and restart firings of the schedules of all DAGs in order of time. Suspended DAGs are excluded.
+*/
+type ListScheduledRuns struct {
+	Context *middleware.Context
+	Handler ListScheduledRunsHandler
+}
+
+func (o *ListScheduledRuns) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewListScheduledRunsParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
@@ -0,0 +1,118 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package schedule
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+)
+
+// ListScheduledRunsOKCode is the HTTP code returned for type ListScheduledRunsOK
+const ListScheduledRunsOKCode int = 200
+
+/*
+ListScheduledRunsOK A successful response.
+
+swagger:response listScheduledRunsOK
+*/
+type ListScheduledRunsOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.ListScheduledRunsResponse `json:"body,omitempty"`
+}
+
+// NewListScheduledRunsOK creates ListScheduledRunsOK with default headers values
+func NewListScheduledRunsOK() *ListScheduledRunsOK {
+
+	return &ListScheduledRunsOK{}
+}
+
+// WithPayload adds the payload to the list scheduled runs o k response
+func (o *ListScheduledRunsOK) WithPayload(payload *models.ListScheduledRunsResponse) *ListScheduledRunsOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list scheduled runs o k response
+func (o *ListScheduledRunsOK) SetPayload(payload *models.ListScheduledRunsResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListScheduledRunsOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*
+ListScheduledRunsDefault Generic error response.
+
+swagger:response listScheduledRunsDefault
+*/
+type ListScheduledRunsDefault struct {
+	_statusCode int
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewListScheduledRunsDefault creates ListScheduledRunsDefault with default headers values
+func NewListScheduledRunsDefault(code int) *ListScheduledRunsDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &ListScheduledRunsDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the list scheduled runs default response
+func (o *ListScheduledRunsDefault) WithStatusCode(code int) *ListScheduledRunsDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the list scheduled runs default response
+func (o *ListScheduledRunsDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the list scheduled runs default response
+func (o *ListScheduledRunsDefault) WithPayload(payload *models.Error) *ListScheduledRunsDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the list scheduled runs default response
+func (o *ListScheduledRunsDefault) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *ListScheduledRunsDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package secrets
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+)
+
+// GetSecretOKCode is the HTTP code returned for type GetSecretOK
+const GetSecretOKCode int = 200
+
+/*
+GetSecretOK A successful response.
+
+swagger:response getSecretOK
+*/
+type GetSecretOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.GetSecretResponse `json:"body,omitempty"`
+}
+
+// NewGetSecretOK creates GetSecretOK with default headers values
+func NewGetSecretOK() *GetSecretOK {
+
+	return &GetSecretOK{}
+}
+
+// WithPayload adds the payload to the get secret o k response
+func (o *GetSecretOK) WithPayload(payload *models.GetSecretResponse) *GetSecretOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get secret o k response
+func (o *GetSecretOK) SetPayload(payload *models.GetSecretResponse) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetSecretOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
+/*
+GetSecretDefault Generic error response.
+
+swagger:response getSecretDefault
+*/
+type GetSecretDefault struct {
+	_statusCode int
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewGetSecretDefault creates GetSecretDefault with default headers values
+func NewGetSecretDefault(code int) *GetSecretDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &GetSecretDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the get secret default response
+func (o *GetSecretDefault) WithStatusCode(code int) *GetSecretDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the get secret default response
+func (o *GetSecretDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the get secret default response
+func (o *GetSecretDefault) WithPayload(payload *models.Error) *GetSecretDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the get secret default response
+func (o *GetSecretDefault) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *GetSecretDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package secrets
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+)
+
+// NewListSecretsParams creates a new ListSecretsParams object
+//
+// There are no default values defined in the spec.
+func NewListSecretsParams() ListSecretsParams {
+
+	return ListSecretsParams{}
+}
+
+// ListSecretsParams contains all the bound params for the list secrets operation
+// typically these are obtained from a http.Request
+//
+// swagger:parameters listSecrets
+type ListSecretsParams struct {
+
+	// HTTP Request Object
+	HTTPRequest *http.Request `json:"-"`
+
+	/*Only return the secrets of the scope. Defaults to all scopes.
+	  In: query
+	*/
+	Scope *string
+}
+
+// BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
+// for simple values it will use straight method calls.
+//
+// To ensure default values, the struct must have been initialized with NewListSecretsParams() beforehand.
+func (o *ListSecretsParams) BindRequest(r *http.Request, route *middleware.MatchedRoute) error {
+	var res []error
+
+	o.HTTPRequest = r
+
+	qs := runtime.Values(r.URL.Query())
+
+	qScope, qhkScope, _ := qs.GetOK("scope")
+	if err := o.bindScope(qScope, qhkScope, route.Formats); err != nil {
+		res = append(res, err)
+	}
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+// bindScope binds and validates parameter Scope from query.
+func (o *ListSecretsParams) bindScope(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.Scope = &raw
+
+	return nil
+}
@@ -0,0 +1,58 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package secrets
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// SetSecretHandlerFunc turns a function with the right signature into a set secret handler
+type SetSecretHandlerFunc func(SetSecretParams) middleware.Responder
+
+// Handle executing the request and returning a response
+func (fn SetSecretHandlerFunc) Handle(params SetSecretParams) middleware.Responder {
+	return fn(params)
+}
+
+// SetSecretHandler interface for that can handle valid set secret params
+type SetSecretHandler interface {
+	Handle(SetSecretParams) middleware.Responder
+}
+
+// NewSetSecret creates a new http.Handler for the set secret operation
+func NewSetSecret(ctx *middleware.Context, handler SetSecretHandler) *SetSecret {
+	return &SetSecret{Context: ctx, Handler: handler}
+}
+
+/*
+	SetSecret swagger:route PUT /secrets/{name} secrets setSecret
+
+# Set a secret
+
+Creates or updates a secret in the local secret store. Requires authentication with the admin role.
+*/
+type SetSecret struct {
+	Context *middleware.Context
+	Handler SetSecretHandler
+}
+
+func (o *SetSecret) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	route, rCtx, _ := o.Context.RouteInfo(r)
+	if rCtx != nil {
+		*r = *rCtx
+	}
+	var Params = NewSetSecretParams()
+	if err := o.Context.BindValidRequest(r, route, &Params); err != nil { // bind params
+		o.Context.Respond(rw, r, route.Produces, route, err)
+		return
+	}
+
+	res := o.Handler.Handle(Params) // actually handle the request
+	o.Context.Respond(rw, r, route.Produces, route, res)
+
+}
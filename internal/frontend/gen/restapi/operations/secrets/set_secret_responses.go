@@ -0,0 +1,98 @@
+// Code generated by go-swagger; DO NOT EDIT.
+
+package secrets
+
+// This file was generated by the swagger tool.
+// Editing this file might prove futile when you re-run the swagger generate command
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+)
+
+// SetSecretOKCode is the HTTP code returned for type SetSecretOK
+const SetSecretOKCode int = 200
+
+/*
+SetSecretOK A successful response.
+
+swagger:response setSecretOK
+*/
+type SetSecretOK struct {
+}
+
+// NewSetSecretOK creates SetSecretOK with default headers values
+func NewSetSecretOK() *SetSecretOK {
+
+	return &SetSecretOK{}
+}
+
+// WriteResponse to the client
+func (o *SetSecretOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(200)
+}
+
+/*
+SetSecretDefault Generic error response.
+
+swagger:response setSecretDefault
+*/
+type SetSecretDefault struct {
+	_statusCode int
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewSetSecretDefault creates SetSecretDefault with default headers values
+func NewSetSecretDefault(code int) *SetSecretDefault {
+	if code <= 0 {
+		code = 500
+	}
+
+	return &SetSecretDefault{
+		_statusCode: code,
+	}
+}
+
+// WithStatusCode adds the status to the set secret default response
+func (o *SetSecretDefault) WithStatusCode(code int) *SetSecretDefault {
+	o._statusCode = code
+	return o
+}
+
+// SetStatusCode sets the status to the set secret default response
+func (o *SetSecretDefault) SetStatusCode(code int) {
+	o._statusCode = code
+}
+
+// WithPayload adds the payload to the set secret default response
+func (o *SetSecretDefault) WithPayload(payload *models.Error) *SetSecretDefault {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the set secret default response
+func (o *SetSecretDefault) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *SetSecretDefault) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.WriteHeader(o._statusCode)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
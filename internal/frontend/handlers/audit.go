@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/audit"
+	pkgmiddleware "github.com/dagu-org/dagu/internal/frontend/middleware"
+	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+)
+
+// anonymousActor is recorded when the request is not authenticated.
+const anonymousActor = "anonymous"
+
+var _ server.Handler = (*Audit)(nil)
+
+// Audit is a handler for querying the audit log.
+type Audit struct {
+	store persistence.AuditStore
+}
+
+func NewAudit(store persistence.AuditStore) server.Handler {
+	return &Audit{store: store}
+}
+
+// Configure implements server.Handler.
+func (a *Audit) Configure(api *operations.DaguAPI) {
+	api.AuditListAuditLogHandler = audit.ListAuditLogHandlerFunc(
+		func(params audit.ListAuditLogParams) middleware.Responder {
+			ctx := params.HTTPRequest.Context()
+			resp, err := a.list(ctx, params)
+			if err != nil {
+				return audit.NewListAuditLogDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return audit.NewListAuditLogOK().WithPayload(resp)
+		})
+}
+
+func (a *Audit) list(ctx context.Context, params audit.ListAuditLogParams) (*models.ListAuditLogResponse, *codedError) {
+	filter := persistence.AuditFilter{
+		DAG:       fromPtr(params.DagID),
+		Action:    fromPtr(params.Action),
+		Actor:     fromPtr(params.Actor),
+		RequestID: fromPtr(params.RequestID),
+		Limit:     int(fromPtr(params.Limit)),
+	}
+
+	if params.From != nil {
+		from, err := time.Parse(time.RFC3339, *params.From)
+		if err != nil {
+			return nil, newBadRequestError(fmt.Errorf("invalid from: %w", err))
+		}
+		filter.From = from
+	}
+
+	if params.To != nil {
+		to, err := time.Parse(time.RFC3339, *params.To)
+		if err != nil {
+			return nil, newBadRequestError(fmt.Errorf("invalid to: %w", err))
+		}
+		filter.To = to
+	}
+
+	entries, err := a.store.Query(ctx, filter)
+	if err != nil {
+		return nil, newInternalError(err)
+	}
+
+	resp := &models.ListAuditLogResponse{
+		Entries: make([]*models.AuditEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, convertToAuditEntry(entry))
+	}
+	return resp, nil
+}
+
+func convertToAuditEntry(entry model.AuditEntry) *models.AuditEntry {
+	return &models.AuditEntry{
+		Timestamp: swag.String(stringutil.FormatTime(entry.Timestamp)),
+		Source:    swag.String(entry.Source),
+		Actor:     swag.String(entry.Actor),
+		SourceIP:  entry.SourceIP,
+		Action:    swag.String(entry.Action),
+		DAG:       swag.String(entry.DAG),
+		RequestID: entry.RequestID,
+		Step:      entry.Step,
+		Detail:    entry.Detail,
+		Diff:      entry.Diff,
+	}
+}
+
+// recordAudit appends an entry for an action performed through the API.
+// Failing to record the entry does not fail the action itself.
+func recordAudit(ctx context.Context, store persistence.AuditStore, r *http.Request, entry model.AuditEntry) {
+	if store == nil {
+		return
+	}
+
+	entry.Timestamp = time.Now()
+	entry.Source = model.AuditSourceAPI
+	entry.Actor = actor(r)
+	if r != nil {
+		entry.SourceIP = pkgmiddleware.ClientIP(r)
+	}
+
+	if err := store.Append(ctx, entry); err != nil {
+		logger.Error(ctx, "Failed to record audit log", "action", entry.Action, "dag", entry.DAG, "err", err)
+	}
+}
+
+// actor returns the name of the user who sent the request.
+func actor(r *http.Request) string {
+	if r != nil {
+		if user := pkgmiddleware.AuthenticatedUser(r.Context()); user != "" {
+			return user
+		}
+	}
+	return anonymousActor
+}
@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+	"github.com/dagu-org/dagu/internal/logstream"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/go-openapi/swag"
 )
 
@@ -37,6 +41,10 @@ func convertToStatusDetails(s model.Status) *models.DAGStatusDetails {
 		FinishedAt: swag.String(s.FinishedAt),
 		Status:     swag.Int64(int64(s.Status)),
 		StatusText: swag.String(s.StatusText),
+
+		SpecRevision:  s.SpecRevision,
+		SkipReason:    s.SkipReason,
+		ScheduledTime: s.ScheduledTime,
 	}
 	for _, n := range s.Nodes {
 		status.Nodes = append(status.Nodes, convertToNode(n))
@@ -62,14 +70,65 @@ func convertToNode(node *model.Node) *models.Node {
 		Error:      swag.String(node.Error),
 		FinishedAt: swag.String(node.FinishedAt),
 		Log:        swag.String(node.Log),
+		LogLevels:  convertToLogLevels(node.LogLevels),
+		PeakRSS:    node.PeakRSS,
 		RetryCount: swag.Int64(int64(node.RetryCount)),
 		StartedAt:  swag.String(node.StartedAt),
 		Status:     swag.Int64(int64(node.Status)),
 		StatusText: swag.String(node.StatusText),
 		Step:       convertToStepObject(node.Step),
+		SystemTime: node.SystemTime,
+		UserTime:   node.UserTime,
+	}
+}
+
+func convertToLogLevels(levels map[string]int) map[string]int64 {
+	if len(levels) == 0 {
+		return nil
+	}
+	ret := make(map[string]int64, len(levels))
+	for level, n := range levels {
+		ret[level] = int64(n)
+	}
+	return ret
+}
+
+func convertToEvents(list *model.EventList) *models.DAGEventsResponse {
+	events := make([]*models.DAGEvent, 0, len(list.Events))
+	for _, e := range list.Events {
+		event := &models.DAGEvent{
+			RequestID:  swag.String(e.RequestID),
+			Seq:        swag.Int64(e.Seq),
+			Status:     swag.Int64(int64(e.Status)),
+			StatusText: swag.String(e.StatusText),
+			Time:       swag.String(e.Time),
+		}
+		if e.Node != nil {
+			event.Node = convertToNode(e.Node)
+		}
+		events = append(events, event)
+	}
+	return &models.DAGEventsResponse{
+		Events:   events,
+		Finished: swag.Bool(list.Finished),
+		Seq:      swag.Int64(list.Seq),
 	}
 }
 
+func convertToLogEntry(e logstream.Entry) *models.LogEntry {
+	entry := &models.LogEntry{
+		Level:   e.Level,
+		Message: e.Message,
+	}
+	if !e.Time.IsZero() {
+		entry.Time = e.Time.Format(time.RFC3339Nano)
+	}
+	if len(e.Fields) > 0 {
+		entry.Fields = e.Fields
+	}
+	return entry
+}
+
 func convertToStepObject(step digraph.Step) *models.Step {
 	var conditions []*models.Precondition
 	for _, cond := range step.Preconditions {
@@ -104,3 +163,12 @@ func convertToStepObject(step digraph.Step) *models.Step {
 	}
 	return so
 }
+
+func convertToRevision(r model.Revision) *models.DAGRevision {
+	return &models.DAGRevision{
+		Hash:      swag.String(r.Hash),
+		Timestamp: swag.String(stringutil.FormatTime(r.Timestamp)),
+		Author:    swag.String(r.Author),
+		Message:   swag.String(r.Message),
+	}
+}
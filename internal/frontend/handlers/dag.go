@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -20,14 +21,19 @@ import (
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations"
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/dags"
 	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/logfile"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/logstream"
+	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/jsondb"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/sock"
+	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
 	"github.com/samber/lo"
 	"golang.org/x/text/encoding"
-	"golang.org/x/text/encoding/japanese"
 	"golang.org/x/text/transform"
 )
 
@@ -39,6 +45,13 @@ const (
 	dagTabTypeSchedulerLog = "scheduler-log"
 )
 
+// The timeouts of the requests waiting for the events of a running DAG. The
+// maximum is below the timeout of the requests proxied to the remote nodes.
+const (
+	defaultEventsTimeout = 20
+	maxEventsTimeout     = 25
+)
+
 var _ server.Handler = (*DAG)(nil)
 
 // DAG is a handler for the DAG API.
@@ -47,6 +60,8 @@ type DAG struct {
 	logEncodingCharset string
 	remoteNodes        map[string]config.RemoteNode
 	apiBasePath        string
+	auditStore         persistence.AuditStore
+	location           *time.Location
 }
 
 func NewDAG(
@@ -54,16 +69,23 @@ func NewDAG(
 	logEncodingCharset string,
 	remoteNodeConfigs []config.RemoteNode,
 	apiBasePath string,
+	auditStore persistence.AuditStore,
+	location *time.Location,
 ) server.Handler {
 	remoteNodes := make(map[string]config.RemoteNode)
 	for _, node := range remoteNodeConfigs {
 		remoteNodes[node.Name] = node
 	}
+	if location == nil {
+		location = time.Local
+	}
 	return &DAG{
 		client:             cli,
 		logEncodingCharset: logEncodingCharset,
 		remoteNodes:        remoteNodes,
 		apiBasePath:        apiBasePath,
+		auditStore:         auditStore,
+		location:           location,
 	}
 }
 
@@ -96,6 +118,31 @@ func (h *DAG) Configure(api *operations.DaguAPI) {
 			return dags.NewGetDAGDetailsOK().WithPayload(resp)
 		})
 
+	api.DagsStreamDAGLogHandler = dags.StreamDAGLogHandlerFunc(
+		func(params dags.StreamDAGLogParams) middleware.Responder {
+			ctx := params.HTTPRequest.Context()
+			resp, err := h.streamLog(ctx, params)
+			if err != nil {
+				return dags.NewStreamDAGLogDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return resp
+		})
+
+	api.DagsGetDAGEventsHandler = dags.GetDAGEventsHandlerFunc(
+		func(params dags.GetDAGEventsParams) middleware.Responder {
+			if resp := h.handleRemoteNodeProxy(nil, params.HTTPRequest); resp != nil {
+				return resp
+			}
+			ctx := params.HTTPRequest.Context()
+			resp, err := h.getEvents(ctx, params)
+			if err != nil {
+				return dags.NewGetDAGEventsDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return dags.NewGetDAGEventsOK().WithPayload(resp)
+		})
+
 	api.DagsPostDAGActionHandler = dags.PostDAGActionHandlerFunc(
 		func(params dags.PostDAGActionParams) middleware.Responder {
 			if resp := h.handleRemoteNodeProxy(params.Body, params.HTTPRequest); resp != nil {
@@ -165,6 +212,48 @@ func (h *DAG) Configure(api *operations.DaguAPI) {
 			}
 			return dags.NewListTagsOK().WithPayload(tags)
 		})
+
+	api.DagsListDAGRevisionsHandler = dags.ListDAGRevisionsHandlerFunc(
+		func(params dags.ListDAGRevisionsParams) middleware.Responder {
+			if resp := h.handleRemoteNodeProxy(nil, params.HTTPRequest); resp != nil {
+				return resp
+			}
+			ctx := params.HTTPRequest.Context()
+			resp, err := h.listRevisions(ctx, params)
+			if err != nil {
+				return dags.NewListDAGRevisionsDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return dags.NewListDAGRevisionsOK().WithPayload(resp)
+		})
+
+	api.DagsGetDAGRevisionHandler = dags.GetDAGRevisionHandlerFunc(
+		func(params dags.GetDAGRevisionParams) middleware.Responder {
+			if resp := h.handleRemoteNodeProxy(nil, params.HTTPRequest); resp != nil {
+				return resp
+			}
+			ctx := params.HTTPRequest.Context()
+			resp, err := h.getRevision(ctx, params)
+			if err != nil {
+				return dags.NewGetDAGRevisionDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return dags.NewGetDAGRevisionOK().WithPayload(resp)
+		})
+
+	api.DagsDiffDAGRevisionHandler = dags.DiffDAGRevisionHandlerFunc(
+		func(params dags.DiffDAGRevisionParams) middleware.Responder {
+			if resp := h.handleRemoteNodeProxy(nil, params.HTTPRequest); resp != nil {
+				return resp
+			}
+			ctx := params.HTTPRequest.Context()
+			resp, err := h.diffRevision(ctx, params)
+			if err != nil {
+				return dags.NewDiffDAGRevisionDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return dags.NewDiffDAGRevisionOK().WithPayload(resp)
+		})
 }
 
 // handleRemoteNodeProxy checks if 'remoteNode' is present in the query parameters.
@@ -335,6 +424,10 @@ func (h *DAG) createDAG(ctx context.Context, params dags.CreateDAGParams) (
 		if err != nil {
 			return nil, newInternalError(err)
 		}
+		h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+			Action: model.AuditActionCreate,
+			DAG:    id,
+		})
 		return &models.CreateDAGResponse{DagID: swag.String(id)}, nil
 	default:
 		return nil, newBadRequestError(fmt.Errorf("invalid action: %s", *params.Body.Action))
@@ -349,6 +442,10 @@ func (h *DAG) deleteDAG(ctx context.Context, params dags.DeleteDAGParams) *coded
 	if err := h.client.DeleteDAG(ctx, params.DagID, dagStatus.DAG.Location); err != nil {
 		return newInternalError(err)
 	}
+	h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+		Action: model.AuditActionDelete,
+		DAG:    params.DagID,
+	})
 	return nil
 }
 
@@ -388,9 +485,11 @@ func (h *DAG) getList(ctx context.Context, params dags.ListDAGsParams) (*models.
 			FinishedAt: swag.String(s.FinishedAt),
 			Status:     swag.Int64(int64(s.Status)),
 			StatusText: swag.String(s.StatusText),
+			SkipReason: s.SkipReason,
 		}
 
 		item := &models.DAGStatusFile{
+			CommitSHA: dagStatus.CommitSHA,
 			Dir:       swag.String(dagStatus.Dir),
 			Error:     dagStatus.ErrorT,
 			File:      swag.String(dagStatus.File),
@@ -443,10 +542,9 @@ func (h *DAG) getDetail(
 	}
 
 	var schedules []*models.Schedule
+	now := time.Now()
 	for _, s := range dagStatus.DAG.Schedule {
-		schedules = append(schedules, &models.Schedule{
-			Expression: swag.String(s.Expression),
-		})
+		schedules = append(schedules, h.convertToSchedule(s, now))
 	}
 
 	var preconditions []*models.Precondition
@@ -475,9 +573,11 @@ func (h *DAG) getDetail(
 		Schedule:          schedules,
 		Steps:             steps,
 		Tags:              dag.Tags,
+		Timezone:          dag.Timezone,
 	}
 
 	statusWithDetails := &models.DAGStatusFileDetails{
+		CommitSHA: dagStatus.CommitSHA,
 		DAG:       dagDetail,
 		Dir:       swag.String(dagStatus.Dir),
 		Error:     dagStatus.ErrorT,
@@ -590,39 +690,17 @@ func (h *DAG) processStepLogRequest(
 	}
 
 	// Find the step in the status to get the log file.
-	var node *model.Node
-
-	for _, n := range status.Nodes {
-		if n.Step.Name == *params.Step {
-			node = n
-		}
-	}
-
-	if node == nil {
-		if status.OnSuccess != nil && status.OnSuccess.Step.Name == *params.Step {
-			node = status.OnSuccess
-		}
-		if status.OnFailure != nil && status.OnFailure.Step.Name == *params.Step {
-			node = status.OnFailure
-		}
-		if status.OnCancel != nil && status.OnCancel.Step.Name == *params.Step {
-			node = status.OnCancel
-		}
-		if status.OnExit != nil && status.OnExit.Step.Name == *params.Step {
-			node = status.OnExit
-		}
-	}
-
+	node := status.NodeByName(*params.Step)
 	if node == nil {
 		return nil, newNotFoundError(fmt.Errorf("step not found: %s", *params.Step))
 	}
 
-	var decoder *encoding.Decoder
-	if strings.ToLower(h.logEncodingCharset) == "euc-jp" {
-		decoder = japanese.EUCJP.NewDecoder()
+	filter, err := logstream.NewEntryFilter(fromPtr(params.LogLevel), params.LogField)
+	if err != nil {
+		return nil, newBadRequestError(err)
 	}
 
-	logContent, err := readFileContent(node.Log, decoder)
+	logContent, err := readFileContent(node.Log, h.logDecoder())
 	if err != nil {
 		return nil, newInternalError(err)
 	}
@@ -630,9 +708,36 @@ func (h *DAG) processStepLogRequest(
 	stepLog := &models.StepLog{
 		LogFile: swag.String(node.Log),
 		Step:    convertToNode(node),
-		Content: swag.String(string(logContent)),
 	}
 
+	// The lines of the JSON log are parsed for the table view, and only the
+	// lines passing the filter are shown if it is given.
+	if filter != nil || node.Step.LogFormat == digraph.LogFormatJSON {
+		var b strings.Builder
+		entries := []*models.LogEntry{}
+		for _, line := range strings.SplitAfter(string(logContent), "\n") {
+			var (
+				entry logstream.Entry
+				ok    bool
+			)
+			if filter != nil {
+				entry, ok = filter.Match(line)
+				if !ok {
+					continue
+				}
+				b.WriteString(line)
+			} else if entry, ok = logstream.ParseEntry(line); !ok {
+				continue
+			}
+			entries = append(entries, convertToLogEntry(entry))
+		}
+		stepLog.Entries = entries
+		if filter != nil {
+			logContent = []byte(b.String())
+		}
+	}
+	stepLog.Content = swag.String(string(logContent))
+
 	resp.StepLog = stepLog
 	return resp, nil
 }
@@ -751,6 +856,34 @@ func addNodeStatus(
 	data[nodeName][logIdx] = status
 }
 
+func (h *DAG) getEvents(
+	ctx context.Context,
+	params dags.GetDAGEventsParams,
+) (*models.DAGEventsResponse, *codedError) {
+	dagStatus, err := h.client.GetStatus(ctx, params.DagID)
+	if err != nil {
+		return nil, newNotFoundError(err)
+	}
+
+	var since int64
+	if params.Since != nil {
+		since = *params.Since
+	}
+	timeout := int64(defaultEventsTimeout)
+	if params.Timeout != nil && *params.Timeout >= 0 {
+		timeout = min(*params.Timeout, maxEventsTimeout)
+	}
+
+	list, err := h.client.GetEvents(ctx, dagStatus.DAG, since, time.Duration(timeout)*time.Second)
+	if errors.Is(err, client.ErrDAGNotRunning) {
+		// The run has finished, or has not started, so no events follow.
+		list = &model.EventList{Finished: true}
+	} else if err != nil {
+		return nil, newInternalError(err)
+	}
+	return convertToEvents(list), nil
+}
+
 func (h *DAG) postAction(
 	ctx context.Context,
 	params dags.PostDAGActionParams,
@@ -761,7 +894,7 @@ func (h *DAG) postAction(
 
 	var dagStatus client.DAGStatus
 
-	if *params.Body.Action != "save" {
+	if *params.Body.Action != "save" && *params.Body.Action != "rollback" {
 		s, err := h.client.GetStatus(ctx, params.DagID)
 		if err != nil {
 			return nil, newBadRequestError(err)
@@ -777,10 +910,28 @@ func (h *DAG) postAction(
 		h.client.StartAsync(ctx, dagStatus.DAG, client.StartOptions{
 			Params: params.Body.Params,
 		})
+		h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+			Action: model.AuditActionStart,
+			DAG:    params.DagID,
+			Detail: params.Body.Params,
+		})
 		return &models.PostDAGActionResponse{}, nil
 
 	case "suspend":
-		_ = h.client.ToggleSuspend(ctx, params.DagID, params.Body.Value == "true")
+		suspend := params.Body.Value == "true"
+		if err := h.client.ToggleSuspend(ctx, params.DagID, suspend); err != nil {
+			return nil, newInternalError(
+				fmt.Errorf("error trying to toggle the suspension of the DAG: %w", err),
+			)
+		}
+		action := model.AuditActionResume
+		if suspend {
+			action = model.AuditActionSuspend
+		}
+		h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+			Action: action,
+			DAG:    params.DagID,
+		})
 		return &models.PostDAGActionResponse{}, nil
 
 	case "stop":
@@ -794,6 +945,11 @@ func (h *DAG) postAction(
 				fmt.Errorf("error trying to stop the DAG: %w", err),
 			)
 		}
+		h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+			Action:    model.AuditActionStop,
+			DAG:       params.DagID,
+			RequestID: dagStatus.Status.RequestID,
+		})
 		return &models.PostDAGActionResponse{}, nil
 
 	case "retry":
@@ -807,6 +963,34 @@ func (h *DAG) postAction(
 				fmt.Errorf("error trying to retry the DAG: %w", err),
 			)
 		}
+		h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+			Action:    model.AuditActionRetry,
+			DAG:       params.DagID,
+			RequestID: params.Body.RequestID,
+		})
+		return &models.PostDAGActionResponse{}, nil
+
+	case "stop-step":
+		return h.processStopStep(ctx, params, dagStatus)
+
+	case "retry-step":
+		if params.Body.Step == "" {
+			return nil, newBadRequestError(fmt.Errorf("step name is required"))
+		}
+		if dagStatus.Status.Status != scheduler.StatusRunning {
+			return nil, newBadRequestError(
+				fmt.Errorf("the DAG %q is not running", params.DagID),
+			)
+		}
+		if err := h.client.RetryStep(ctx, dagStatus.DAG, params.Body.Step); err != nil {
+			return nil, stepActionError("retry", err)
+		}
+		h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+			Action:    model.AuditActionRetryStep,
+			DAG:       params.DagID,
+			RequestID: dagStatus.Status.RequestID,
+			Step:      params.Body.Step,
+		})
 		return &models.PostDAGActionResponse{}, nil
 
 	case "mark-success":
@@ -816,9 +1000,24 @@ func (h *DAG) postAction(
 		return h.processUpdateStatus(ctx, params, dagStatus, scheduler.NodeStatusError)
 
 	case "save":
-		if err := h.client.UpdateDAG(ctx, params.DagID, params.Body.Value); err != nil {
+		// The previous spec is only needed for the audit log, so a failure
+		// to read it should not prevent the update.
+		oldSpec, _ := h.client.GetDAGSpec(ctx, params.DagID)
+		if err := h.client.UpdateDAG(ctx, params.DagID, params.Body.Value, persistence.RevisionInfo{
+			Author:  actor(params.HTTPRequest),
+			Message: params.Body.Message,
+		}); err != nil {
 			return nil, newInternalError(err)
 		}
+		diff, err := stringutil.UnifiedDiff(oldSpec, params.Body.Value, "before", "after")
+		if err != nil {
+			logger.Warn(ctx, "Failed to compute spec diff", "dag", params.DagID, "err", err)
+		}
+		h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+			Action: model.AuditActionSave,
+			DAG:    params.DagID,
+			Diff:   diff,
+		})
 		return &models.PostDAGActionResponse{}, nil
 
 	case "rename":
@@ -831,8 +1030,35 @@ func (h *DAG) postAction(
 		if err := h.client.Rename(ctx, params.DagID, newName); err != nil {
 			return nil, newInternalError(err)
 		}
+		h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+			Action: model.AuditActionRename,
+			DAG:    params.DagID,
+			Detail: fmt.Sprintf("renamed to %s", newName),
+		})
 		return &models.PostDAGActionResponse{NewDagID: params.Body.Value}, nil
 
+	case "rollback":
+		if params.Body.Value == "" {
+			return nil, newBadRequestError(
+				fmt.Errorf("the value (revision) is required"),
+			)
+		}
+		if err := h.client.RollbackDAG(ctx, params.DagID, params.Body.Value, persistence.RevisionInfo{
+			Author:  actor(params.HTTPRequest),
+			Message: params.Body.Message,
+		}); err != nil {
+			if errors.Is(err, persistence.ErrRevisionNotFound) {
+				return nil, newNotFoundError(err)
+			}
+			return nil, newInternalError(err)
+		}
+		h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+			Action: model.AuditActionRollback,
+			DAG:    params.DagID,
+			Detail: fmt.Sprintf("rolled back to %s", params.Body.Value),
+		})
+		return &models.PostDAGActionResponse{}, nil
+
 	default:
 		return nil, newBadRequestError(
 			fmt.Errorf("invalid action: %s", *params.Body.Action),
@@ -840,6 +1066,64 @@ func (h *DAG) postAction(
 	}
 }
 
+func (h *DAG) processStopStep(
+	ctx context.Context,
+	params dags.PostDAGActionParams,
+	dagStatus client.DAGStatus,
+) (*models.PostDAGActionResponse, *codedError) {
+	if params.Body.Step == "" {
+		return nil, newBadRequestError(fmt.Errorf("step name is required"))
+	}
+
+	// The step is marked failed unless the value asks to skip it.
+	var to scheduler.NodeStatus
+	switch params.Body.Value {
+	case "", "failed":
+		to = scheduler.NodeStatusError
+	case "skipped":
+		to = scheduler.NodeStatusSkipped
+	default:
+		return nil, newBadRequestError(
+			fmt.Errorf("invalid value: %q (expected failed or skipped)", params.Body.Value),
+		)
+	}
+
+	if dagStatus.Status.Status != scheduler.StatusRunning {
+		return nil, newBadRequestError(
+			fmt.Errorf("the DAG %q is not running", params.DagID),
+		)
+	}
+	if err := h.client.StopStep(ctx, dagStatus.DAG, params.Body.Step, to); err != nil {
+		return nil, stepActionError("stop", err)
+	}
+
+	h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+		Action:    model.AuditActionStopStep,
+		DAG:       params.DagID,
+		RequestID: dagStatus.Status.RequestID,
+		Step:      params.Body.Step,
+		Detail:    fmt.Sprintf("marked %s", to),
+	})
+
+	return &models.PostDAGActionResponse{}, nil
+}
+
+// stepActionError returns the error of the request to the agent of the
+// running DAG to act on a step.
+func stepActionError(action string, err error) *codedError {
+	var respErr *sock.ResponseError
+	if errors.As(err, &respErr) {
+		if respErr.StatusCode == http.StatusNotFound {
+			return newNotFoundError(errors.New(respErr.Message))
+		}
+		return newBadRequestError(errors.New(respErr.Message))
+	}
+	if errors.Is(err, client.ErrDAGNotRunning) {
+		return newBadRequestError(err)
+	}
+	return newInternalError(fmt.Errorf("error trying to %s the step: %w", action, err))
+}
+
 func (h *DAG) processUpdateStatus(
 	ctx context.Context,
 	params dags.PostDAGActionParams,
@@ -887,9 +1171,21 @@ func (h *DAG) processUpdateStatus(
 		return nil, newInternalError(err)
 	}
 
+	h.audit(ctx, params.HTTPRequest, model.AuditEntry{
+		Action:    *params.Body.Action,
+		DAG:       params.DagID,
+		RequestID: params.Body.RequestID,
+		Step:      params.Body.Step,
+	})
+
 	return &models.PostDAGActionResponse{}, nil
 }
 
+// audit records a mutating action performed through the API.
+func (h *DAG) audit(ctx context.Context, r *http.Request, entry model.AuditEntry) {
+	recordAudit(ctx, h.auditStore, r, entry)
+}
+
 func (h *DAG) searchDAGs(ctx context.Context, params dags.SearchDAGsParams) (
 	*models.SearchDAGsResponse, *codedError,
 ) {
@@ -927,12 +1223,18 @@ func (h *DAG) searchDAGs(ctx context.Context, params dags.SearchDAGsParams) (
 	}, nil
 }
 
+// logDecoder returns the decoder of the logs in the charset configured, or
+// nil if the logs are in UTF-8.
+func (h *DAG) logDecoder() *encoding.Decoder {
+	return logstream.NewDecoder(h.logEncodingCharset)
+}
+
 func readFileContent(f string, decoder *encoding.Decoder) ([]byte, error) {
 	if decoder == nil {
-		return os.ReadFile(f)
+		return logfile.ReadFile(f)
 	}
 
-	r, err := os.Open(f)
+	r, err := logfile.Open(f)
 	if err != nil {
 		return nil, fmt.Errorf("error reading %s: %w", f, err)
 	}
@@ -955,6 +1257,48 @@ func (h *DAG) getTagList(ctx context.Context, _ dags.ListTagsParams) (*models.Li
 	}, nil
 }
 
+func (h *DAG) listRevisions(ctx context.Context, params dags.ListDAGRevisionsParams) (*models.ListDAGRevisionsResponse, *codedError) {
+	revisions, err := h.client.ListDAGRevisions(ctx, params.DagID)
+	if err != nil {
+		return nil, newRevisionError(err)
+	}
+	resp := &models.ListDAGRevisionsResponse{
+		Revisions: make([]*models.DAGRevision, 0, len(revisions)),
+	}
+	for _, revision := range revisions {
+		resp.Revisions = append(resp.Revisions, convertToRevision(revision))
+	}
+	return resp, nil
+}
+
+func (h *DAG) getRevision(ctx context.Context, params dags.GetDAGRevisionParams) (*models.GetDAGRevisionResponse, *codedError) {
+	revision, spec, err := h.client.GetDAGRevision(ctx, params.DagID, params.Revision)
+	if err != nil {
+		return nil, newRevisionError(err)
+	}
+	return &models.GetDAGRevisionResponse{
+		Revision: convertToRevision(*revision),
+		Spec:     swag.String(spec),
+	}, nil
+}
+
+func (h *DAG) diffRevision(ctx context.Context, params dags.DiffDAGRevisionParams) (*models.DiffDAGRevisionResponse, *codedError) {
+	diff, err := h.client.DiffDAGRevisions(ctx, params.DagID, params.Revision, fromPtr(params.To))
+	if err != nil {
+		return nil, newRevisionError(err)
+	}
+	return &models.DiffDAGRevisionResponse{
+		Diff: swag.String(diff),
+	}, nil
+}
+
+func newRevisionError(err error) *codedError {
+	if errors.Is(err, persistence.ErrRevisionNotFound) || errors.Is(err, os.ErrNotExist) {
+		return newNotFoundError(err)
+	}
+	return newInternalError(err)
+}
+
 func fromPtr[T any](v *T) T {
 	if v == nil {
 		var zero T
@@ -962,3 +1306,27 @@ func fromPtr[T any](v *T) T {
 	}
 	return *v
 }
+
+// convertToSchedule converts the schedule with the effective timezone, which
+// is the timezone of the schedule or of the server, and the next run after now.
+func (h *DAG) convertToSchedule(s digraph.Schedule, now time.Time) *models.Schedule {
+	location := s.Location()
+	if location == nil {
+		location = h.location
+	}
+	timezone := location.String()
+	if location == time.Local {
+		// Show the abbreviation such as "JST" instead of "Local".
+		timezone, _ = now.In(location).Zone()
+	}
+	schedule := &models.Schedule{
+		Expression: swag.String(s.Expression),
+		Timezone:   timezone,
+	}
+	if s.Parsed != nil {
+		if next := s.Parsed.Next(now.In(h.location)); !next.IsZero() {
+			schedule.NextRun = stringutil.FormatTime(next.In(location))
+		}
+	}
+	return schedule
+}
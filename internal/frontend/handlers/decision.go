@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/dags"
+	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+)
+
+// defaultDecisionsLimit is the number of decisions returned by default.
+const defaultDecisionsLimit = 100
+
+var _ server.Handler = (*Decision)(nil)
+
+// Decision is a handler for querying the decisions the scheduler made.
+type Decision struct {
+	store persistence.DecisionStore
+}
+
+func NewDecision(store persistence.DecisionStore) server.Handler {
+	return &Decision{store: store}
+}
+
+// Configure implements server.Handler.
+func (d *Decision) Configure(api *operations.DaguAPI) {
+	api.DagsListDAGDecisionsHandler = dags.ListDAGDecisionsHandlerFunc(
+		func(params dags.ListDAGDecisionsParams) middleware.Responder {
+			ctx := params.HTTPRequest.Context()
+			resp, err := d.list(ctx, params)
+			if err != nil {
+				return dags.NewListDAGDecisionsDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return dags.NewListDAGDecisionsOK().WithPayload(resp)
+		})
+}
+
+func (d *Decision) list(ctx context.Context, params dags.ListDAGDecisionsParams) (*models.ListDAGDecisionsResponse, *codedError) {
+	filter := persistence.DecisionFilter{
+		DAG:      params.DagID,
+		Decision: fromPtr(params.Decision),
+		Limit:    int(fromPtr(params.Limit)),
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDecisionsLimit
+	}
+
+	if params.From != nil {
+		from, err := time.Parse(time.RFC3339, *params.From)
+		if err != nil {
+			return nil, newBadRequestError(fmt.Errorf("invalid from: %w", err))
+		}
+		filter.From = from
+	}
+
+	if params.To != nil {
+		to, err := time.Parse(time.RFC3339, *params.To)
+		if err != nil {
+			return nil, newBadRequestError(fmt.Errorf("invalid to: %w", err))
+		}
+		filter.To = to
+	}
+
+	decisions, err := d.store.Query(ctx, filter)
+	if err != nil {
+		return nil, newInternalError(err)
+	}
+
+	resp := &models.ListDAGDecisionsResponse{
+		Decisions: make([]*models.SchedulerDecision, 0, len(decisions)),
+	}
+	for _, decision := range decisions {
+		resp.Decisions = append(resp.Decisions, convertToSchedulerDecision(decision))
+	}
+	return resp, nil
+}
+
+func convertToSchedulerDecision(decision model.SchedulerDecision) *models.SchedulerDecision {
+	return &models.SchedulerDecision{
+		Timestamp:     swag.String(stringutil.FormatTime(decision.Timestamp)),
+		ScheduledTime: swag.String(stringutil.FormatTime(decision.ScheduledTime)),
+		Type:          swag.String(decision.Type),
+		Decision:      swag.String(decision.Decision),
+		Reason:        decision.Reason,
+		Outcome:       decision.Outcome,
+	}
+}
@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/dags"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/logstream"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+)
+
+// logStreamPollInterval is the interval to check the log file for new
+// lines and the run for its status.
+const logStreamPollInterval = 500 * time.Millisecond
+
+// streamLog returns the responder streaming the log of the step, or the
+// scheduler log of the run, as Server-Sent Events.
+func (h *DAG) streamLog(ctx context.Context, params dags.StreamDAGLogParams) (middleware.Responder, *codedError) {
+	dagStatus, err := h.client.GetStatus(ctx, params.DagID)
+	if err != nil || dagStatus.DAG == nil {
+		return nil, newNotFoundError(fmt.Errorf("DAG not found: %s", params.DagID))
+	}
+	dag := dagStatus.DAG
+
+	requestID := fromPtr(params.RequestID)
+	if requestID == "" {
+		latest, err := h.client.GetLatestStatus(ctx, dag)
+		if err != nil {
+			return nil, newInternalError(err)
+		}
+		if latest.RequestID == "" {
+			return nil, newNotFoundError(fmt.Errorf("no runs of the DAG: %s", params.DagID))
+		}
+		requestID = latest.RequestID
+	}
+	status, err := h.client.GetStatusByRequestID(ctx, dag, requestID)
+	if err != nil {
+		return nil, newNotFoundError(err)
+	}
+
+	step := fromPtr(params.Step)
+	if step != "" && status.NodeByName(step) == nil {
+		return nil, newNotFoundError(fmt.Errorf("step not found: %s", step))
+	}
+
+	filter, err := logstream.NewEntryFilter(fromPtr(params.LogLevel), params.LogField)
+	if err != nil {
+		return nil, newBadRequestError(err)
+	}
+
+	offset := fromPtr(params.Offset)
+	if params.LastEventID != nil && *params.LastEventID != "" {
+		if offset, err = strconv.ParseInt(*params.LastEventID, 10, 64); err != nil {
+			return nil, newBadRequestError(fmt.Errorf("invalid Last-Event-ID: %s", *params.LastEventID))
+		}
+	}
+	if offset < 0 {
+		return nil, newBadRequestError(fmt.Errorf("invalid offset: %d", offset))
+	}
+
+	// latestStatus returns the status of the run now and whether it has
+	// finished.
+	latestStatus := func() (*model.Status, bool) {
+		s, err := h.client.GetStatusByRequestID(ctx, dag, requestID)
+		if err != nil {
+			return status, true
+		}
+		return s, s.Status != scheduler.StatusRunning
+	}
+	// logFile returns the log file of the run. The log file of the step is
+	// not known until the step starts.
+	logFile := func(s *model.Status) string {
+		if step == "" {
+			return s.Log
+		}
+		if node := s.NodeByName(step); node != nil {
+			return node.Log
+		}
+		return ""
+	}
+
+	return middleware.ResponderFunc(func(w http.ResponseWriter, _ runtime.Producer) {
+		stream := &eventStream{w: w, rc: http.NewResponseController(w)}
+		// The stream lasts longer than the write timeout of the server.
+		_ = stream.rc.SetWriteDeadline(time.Time{})
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		_ = stream.rc.Flush()
+
+		current := status
+		decoder := h.logDecoder()
+		err := logstream.FollowSource(ctx, func() (string, bool) {
+			var done bool
+			current, done = latestStatus()
+			return logFile(current), done
+		}, logstream.Options{
+			Offset:       offset,
+			PollInterval: logStreamPollInterval,
+		}, func(line logstream.Line) error {
+			text := line.Text
+			if decoder != nil {
+				if decoded, err := decoder.String(text); err == nil {
+					text = decoded
+				}
+			}
+			if filter != nil {
+				if _, ok := filter.Match(text); !ok {
+					return nil
+				}
+			}
+			return stream.send(strconv.FormatInt(line.Offset, 10), "", text)
+		})
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(ctx, "Failed to stream the log", "dag", dag.Name, "step", step, "err", err)
+				_ = stream.send("", "error", err.Error())
+			}
+			return
+		}
+		_ = stream.send("", "end", current.Status.String())
+	}), nil
+}
+
+// eventStream writes Server-Sent Events.
+type eventStream struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// send writes the event and flushes it to the client. The event type is
+// the default "message" if it is empty. The data of multiple lines is sent
+// in multiple data fields, which the client joins with line feeds.
+func (s *eventStream) send(id, event, data string) error {
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	lines := strings.FieldsFunc(data, func(r rune) bool { return r == '\r' || r == '\n' })
+	if len(lines) == 0 {
+		lines = []string{""}
+	}
+	for _, line := range lines {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	if _, err := s.w.Write([]byte(b.String())); err != nil {
+		return err
+	}
+	return s.rc.Flush()
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/schedule"
+	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/scheduler"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+)
+
+const (
+	defaultScheduledRunsLimit = 100
+	maxScheduledRunsLimit     = 1000
+)
+
+var _ server.Handler = (*Schedule)(nil)
+
+// Schedule is a handler for previewing the schedules of the DAGs.
+type Schedule struct {
+	client   client.Client
+	dagsDir  string
+	location *time.Location
+}
+
+func NewSchedule(cli client.Client, dagsDir string, location *time.Location) server.Handler {
+	if location == nil {
+		location = time.Local
+	}
+	return &Schedule{client: cli, dagsDir: dagsDir, location: location}
+}
+
+// Configure implements server.Handler.
+func (s *Schedule) Configure(api *operations.DaguAPI) {
+	api.ScheduleListScheduledRunsHandler = schedule.ListScheduledRunsHandlerFunc(
+		func(params schedule.ListScheduledRunsParams) middleware.Responder {
+			ctx := params.HTTPRequest.Context()
+			resp, err := s.list(ctx, params)
+			if err != nil {
+				return schedule.NewListScheduledRunsDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return schedule.NewListScheduledRunsOK().WithPayload(resp)
+		})
+}
+
+func (s *Schedule) list(ctx context.Context, params schedule.ListScheduledRunsParams) (*models.ListScheduledRunsResponse, *codedError) {
+	from := time.Now()
+	if params.From != nil {
+		t, err := time.Parse(time.RFC3339, *params.From)
+		if err != nil {
+			return nil, newBadRequestError(fmt.Errorf("invalid from: %w", err))
+		}
+		from = t
+	}
+
+	var until time.Time
+	if params.To != nil {
+		t, err := time.Parse(time.RFC3339, *params.To)
+		if err != nil {
+			return nil, newBadRequestError(fmt.Errorf("invalid to: %w", err))
+		}
+		until = t
+	}
+
+	limit := int(fromPtr(params.Limit))
+	if limit <= 0 {
+		limit = defaultScheduledRunsLimit
+	}
+	limit = min(limit, maxScheduledRunsLimit)
+
+	manager, err := scheduler.LoadDAGJobManager(ctx, s.dagsDir, s.client)
+	if err != nil {
+		return nil, newInternalError(err)
+	}
+	firings, err := scheduler.Firings(ctx, manager, from.In(s.location), until, limit)
+	if err != nil {
+		return nil, newInternalError(err)
+	}
+
+	resp := &models.ListScheduledRunsResponse{
+		Runs: make([]*models.ScheduledRun, 0, len(firings)),
+	}
+	for _, f := range firings {
+		resp.Runs = append(resp.Runs, convertToScheduledRun(f))
+	}
+	return resp, nil
+}
+
+func convertToScheduledRun(f scheduler.Firing) *models.ScheduledRun {
+	run := &models.ScheduledRun{
+		DagID: swag.String(f.DAG.ID()),
+		Name:  swag.String(f.DAG.Name),
+		Type:  swag.String(strings.ToLower(f.Type.String())),
+		Time:  swag.String(stringutil.FormatTime(f.Time)),
+	}
+	if f.Location != nil {
+		run.DagTime = stringutil.FormatTime(f.Time.In(f.Location))
+		run.Timezone = f.Location.String()
+	}
+	if f.Delay > 0 {
+		run.StartTime = stringutil.FormatTime(f.Time.Add(f.Delay))
+	}
+	run.SkipReason = f.SkipReason
+	return run
+}
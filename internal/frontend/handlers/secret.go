@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/dagu-org/dagu/internal/frontend/gen/models"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations"
+	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/secrets"
+	pkgmiddleware "github.com/dagu-org/dagu/internal/frontend/middleware"
+	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	pkgsecrets "github.com/dagu-org/dagu/internal/secrets"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+)
+
+var _ server.Handler = (*Secret)(nil)
+
+// Secret is a handler for managing the secrets in the encrypted local secret
+// store. Only the users with the admin role are allowed to use it.
+type Secret struct {
+	file       string
+	keyFile    string
+	auditStore persistence.AuditStore
+}
+
+func NewSecret(file, keyFile string, auditStore persistence.AuditStore) server.Handler {
+	return &Secret{file: file, keyFile: keyFile, auditStore: auditStore}
+}
+
+// Configure implements server.Handler.
+func (h *Secret) Configure(api *operations.DaguAPI) {
+	api.SecretsListSecretsHandler = secrets.ListSecretsHandlerFunc(
+		func(params secrets.ListSecretsParams) middleware.Responder {
+			ctx := params.HTTPRequest.Context()
+			resp, err := h.list(ctx, params)
+			if err != nil {
+				return secrets.NewListSecretsDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return secrets.NewListSecretsOK().WithPayload(resp)
+		})
+
+	api.SecretsGetSecretHandler = secrets.GetSecretHandlerFunc(
+		func(params secrets.GetSecretParams) middleware.Responder {
+			ctx := params.HTTPRequest.Context()
+			resp, err := h.get(ctx, params)
+			if err != nil {
+				return secrets.NewGetSecretDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return secrets.NewGetSecretOK().WithPayload(resp)
+		})
+
+	api.SecretsSetSecretHandler = secrets.SetSecretHandlerFunc(
+		func(params secrets.SetSecretParams) middleware.Responder {
+			ctx := params.HTTPRequest.Context()
+			if err := h.set(ctx, params); err != nil {
+				return secrets.NewSetSecretDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return secrets.NewSetSecretOK()
+		})
+
+	api.SecretsDeleteSecretHandler = secrets.DeleteSecretHandlerFunc(
+		func(params secrets.DeleteSecretParams) middleware.Responder {
+			ctx := params.HTTPRequest.Context()
+			if err := h.delete(ctx, params); err != nil {
+				return secrets.NewDeleteSecretDefault(err.HTTPCode).
+					WithPayload(err.APIError)
+			}
+			return secrets.NewDeleteSecretOK()
+		})
+}
+
+func (h *Secret) list(ctx context.Context, params secrets.ListSecretsParams) (*models.ListSecretsResponse, *codedError) {
+	store, cerr := h.store(ctx)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	entries, err := store.List(fromPtr(params.Scope))
+	if err != nil {
+		return nil, newSecretError(err)
+	}
+
+	resp := &models.ListSecretsResponse{
+		Secrets: make([]*models.Secret, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		resp.Secrets = append(resp.Secrets, &models.Secret{
+			Scope:     swag.String(entry.Scope),
+			Name:      swag.String(entry.Name),
+			UpdatedAt: swag.String(stringutil.FormatTime(entry.UpdatedAt)),
+		})
+	}
+	return resp, nil
+}
+
+func (h *Secret) get(ctx context.Context, params secrets.GetSecretParams) (*models.GetSecretResponse, *codedError) {
+	store, cerr := h.store(ctx)
+	if cerr != nil {
+		return nil, cerr
+	}
+
+	// The value is never returned; it is only read by the DAG runs.
+	scope := secretScope(params.Scope)
+	entry, err := store.Entry(scope, params.Name)
+	if err != nil {
+		return nil, newSecretError(err)
+	}
+	return &models.GetSecretResponse{
+		Scope:     swag.String(entry.Scope),
+		Name:      swag.String(entry.Name),
+		UpdatedAt: swag.String(stringutil.FormatTime(entry.UpdatedAt)),
+	}, nil
+}
+
+func (h *Secret) set(ctx context.Context, params secrets.SetSecretParams) *codedError {
+	store, cerr := h.store(ctx)
+	if cerr != nil {
+		return cerr
+	}
+
+	value := fromPtr(params.Body.Value)
+	if value == "" {
+		return newBadRequestError(errors.New("value is required"))
+	}
+
+	scope := secretScope(params.Scope)
+	if err := store.Set(scope, params.Name, value); err != nil {
+		return newSecretError(err)
+	}
+
+	h.audit(ctx, params.HTTPRequest, model.AuditActionSetSecret, scope, params.Name)
+	return nil
+}
+
+func (h *Secret) delete(ctx context.Context, params secrets.DeleteSecretParams) *codedError {
+	store, cerr := h.store(ctx)
+	if cerr != nil {
+		return cerr
+	}
+
+	scope := secretScope(params.Scope)
+	if err := store.Delete(scope, params.Name); err != nil {
+		return newSecretError(err)
+	}
+
+	h.audit(ctx, params.HTTPRequest, model.AuditActionDeleteSecret, scope, params.Name)
+	return nil
+}
+
+// store returns the secret store if the user is authenticated and has the
+// admin role. The secrets cannot be managed without authentication.
+func (h *Secret) store(ctx context.Context) (*pkgsecrets.Store, *codedError) {
+	if !pkgmiddleware.HasAuthenticatedRole(ctx, config.RoleAdmin) {
+		return nil, newError(http.StatusForbidden, models.ErrorCodeForbidden,
+			swag.String("authentication with the admin role is required to manage secrets"))
+	}
+	key, err := pkgsecrets.LoadKey(h.keyFile)
+	if err != nil {
+		return nil, newInternalError(err)
+	}
+	return pkgsecrets.NewStore(h.file, key), nil
+}
+
+// audit records the change of the secret. The value is never recorded.
+func (h *Secret) audit(ctx context.Context, r *http.Request, action, scope, name string) {
+	var dagID string
+	if id, ok := strings.CutPrefix(scope, "dag:"); ok {
+		dagID = id
+	}
+	recordAudit(ctx, h.auditStore, r, model.AuditEntry{
+		Action: action,
+		DAG:    dagID,
+		Detail: fmt.Sprintf("secret %s in the scope %s", name, scope),
+	})
+}
+
+// secretScope returns the scope of the request, the global scope by default.
+func secretScope(scope *string) string {
+	if s := fromPtr(scope); s != "" {
+		return s
+	}
+	return pkgsecrets.ScopeGlobal
+}
+
+func newSecretError(err error) *codedError {
+	switch {
+	case errors.Is(err, pkgsecrets.ErrNotFound):
+		return newNotFoundError(err)
+	case errors.Is(err, pkgsecrets.ErrInvalidScope), errors.Is(err, pkgsecrets.ErrInvalidName):
+		return newBadRequestError(err)
+	}
+	return newInternalError(err)
+}
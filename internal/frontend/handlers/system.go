@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/dagu-org/dagu/internal/build"
@@ -10,6 +11,8 @@ import (
 	"github.com/dagu-org/dagu/internal/frontend/gen/restapi/operations/system"
 	"github.com/dagu-org/dagu/internal/frontend/metrics"
 	"github.com/dagu-org/dagu/internal/frontend/server"
+	"github.com/dagu-org/dagu/internal/logfile"
+	"github.com/dagu-org/dagu/internal/scheduler/lease"
 	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/swag"
@@ -18,7 +21,26 @@ import (
 var _ server.Handler = (*System)(nil)
 
 // System is a handler for system related operations.
-type System struct{}
+type System struct {
+	// leaseDir is the directory of the scheduler lease file. It is empty
+	// unless the scheduler runs in high availability mode.
+	leaseDir string
+	// logDir is the directory of the logs of the DAG runs, and logQuota is
+	// its quota in bytes, 0 if unlimited. adminLogsDir is excluded from the
+	// quota.
+	logDir       string
+	adminLogsDir string
+	logQuota     int64
+
+	// logUsage is the last measured usage of the log directory. It is
+	// measured at most once per logUsageInterval as it walks the directory.
+	mu       sync.Mutex
+	logUsage *models.LogUsage
+}
+
+// logUsageInterval is the interval to measure the usage of the log
+// directory.
+const logUsageInterval = time.Minute
 
 // Configure implements server.Handler.
 func (s *System) Configure(api *operations.DaguAPI) {
@@ -31,15 +53,73 @@ func (s *System) Configure(api *operations.DaguAPI) {
 	})
 }
 
-func NewSystem() server.Handler {
-	return &System{}
+func NewSystem(leaseDir, logDir, adminLogsDir string, logQuota int64) server.Handler {
+	return &System{leaseDir: leaseDir, logDir: logDir, adminLogsDir: adminLogsDir, logQuota: logQuota}
 }
 
 func (s *System) GetHealth(_ system.GetHealthParams) (*models.HealthResponse, error) {
-	return &models.HealthResponse{
+	resp := &models.HealthResponse{
 		Status:    swag.String(models.HealthResponseStatusHealthy),
 		Version:   &build.Version,
 		Uptime:    swag.Int64(metrics.GetUptime()),
 		Timestamp: swag.String(stringutil.FormatTime(time.Now())),
-	}, nil
+	}
+	if s.leaseDir != "" {
+		leader, err := s.schedulerLeader()
+		if err != nil {
+			return nil, err
+		}
+		resp.Scheduler = leader
+	}
+	if s.logDir != "" {
+		usage, err := s.getLogUsage()
+		if err != nil {
+			return nil, err
+		}
+		resp.Logs = usage
+	}
+	return resp, nil
+}
+
+func (s *System) getLogUsage() (*models.LogUsage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.logUsage != nil {
+		updatedAt, err := stringutil.ParseTime(s.logUsage.UpdatedAt)
+		if err == nil && time.Since(updatedAt) < logUsageInterval {
+			return s.logUsage, nil
+		}
+	}
+	usage, err := logfile.Usage(s.logDir, logfile.QuotaOptions{
+		ExcludeDirs: []string{s.adminLogsDir},
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logUsage = &models.LogUsage{
+		Usage:     swag.Int64(usage),
+		Quota:     swag.Int64(s.logQuota),
+		UpdatedAt: stringutil.FormatTime(time.Now()),
+	}
+	return s.logUsage, nil
+}
+
+func (s *System) schedulerLeader() (*models.SchedulerLeader, error) {
+	l, err := lease.Read(s.leaseDir)
+	if err != nil {
+		return nil, err
+	}
+	if l == nil {
+		return &models.SchedulerLeader{}, nil
+	}
+	ret := &models.SchedulerLeader{
+		Token:      l.Token,
+		AcquiredAt: stringutil.FormatTime(l.AcquiredAt),
+		ExpiresAt:  stringutil.FormatTime(l.ExpiresAt),
+	}
+	if !l.Expired(time.Now()) {
+		ret.Leader = l.Holder
+	}
+	return ret, nil
 }
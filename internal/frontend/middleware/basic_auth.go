@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/dagu-org/dagu/internal/config"
 )
 
 const (
@@ -17,7 +19,7 @@ func BasicAuth(realm string, creds map[string]string) func(
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := strings.Split(r.Header.Get(authHeaderKey), " ")
-			if skipBasicAuth(authHeader) {
+			if isAuthenticated(r.Context()) || skipBasicAuth(authHeader) {
 				next.ServeHTTP(w, r)
 				return
 			}
@@ -36,7 +38,7 @@ func BasicAuth(realm string, creds map[string]string) func(
 				return
 			}
 
-			next.ServeHTTP(w, r.WithContext(withAuthenticated(r.Context())))
+			next.ServeHTTP(w, r.WithContext(withAuthenticated(r.Context(), user, config.RoleAdmin)))
 		})
 	}
 }
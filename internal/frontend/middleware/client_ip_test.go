@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.168.1.1"})
+	require.NoError(t, err)
+	trustedProxies = proxies
+	t.Cleanup(func() { trustedProxies = nil })
+
+	testCases := []struct {
+		name       string
+		remoteAddr string
+		forwarded  []string
+		realIP     string
+		expected   string
+	}{
+		{
+			name:       "DirectClient",
+			remoteAddr: "203.0.113.5:1234",
+			expected:   "203.0.113.5",
+		},
+		{
+			name:       "UntrustedPeerForwarded",
+			remoteAddr: "203.0.113.5:1234",
+			forwarded:  []string{"198.51.100.7"},
+			realIP:     "198.51.100.8",
+			expected:   "203.0.113.5",
+		},
+		{
+			name:       "TrustedProxy",
+			remoteAddr: "10.0.0.2:1234",
+			forwarded:  []string{"198.51.100.7"},
+			expected:   "198.51.100.7",
+		},
+		{
+			name:       "ForgedFirstHop",
+			remoteAddr: "10.0.0.2:1234",
+			forwarded:  []string{"1.2.3.4, 198.51.100.7"},
+			expected:   "198.51.100.7",
+		},
+		{
+			name:       "ChainOfTrustedProxies",
+			remoteAddr: "10.0.0.2:1234",
+			forwarded:  []string{"1.2.3.4, 198.51.100.7, 192.168.1.1", "10.0.0.3"},
+			expected:   "198.51.100.7",
+		},
+		{
+			name:       "InvalidHop",
+			remoteAddr: "10.0.0.2:1234",
+			forwarded:  []string{"198.51.100.7, not-an-ip"},
+			expected:   "10.0.0.2",
+		},
+		{
+			name:       "AllHopsTrusted",
+			remoteAddr: "10.0.0.2:1234",
+			forwarded:  []string{"10.0.0.4, 10.0.0.3"},
+			expected:   "10.0.0.4",
+		},
+		{
+			name:       "RealIP",
+			remoteAddr: "192.168.1.1:1234",
+			realIP:     "198.51.100.8",
+			expected:   "198.51.100.8",
+		},
+		{
+			name:       "InvalidRealIP",
+			remoteAddr: "192.168.1.1:1234",
+			realIP:     "forged",
+			expected:   "192.168.1.1",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/dags", nil)
+			r.RemoteAddr = tc.remoteAddr
+			for _, v := range tc.forwarded {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if tc.realIP != "" {
+				r.Header.Set("X-Real-IP", tc.realIP)
+			}
+			require.Equal(t, tc.expected, ClientIP(r))
+		})
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	_, err := ParseTrustedProxies([]string{"10.0.0.0/8", "::1", "127.0.0.1"})
+	require.NoError(t, err)
+
+	_, err = ParseTrustedProxies([]string{"proxy.example.com"})
+	require.Error(t, err)
+}
@@ -0,0 +1,268 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	errMalformedJWT      = errors.New("malformed JWT")
+	errUnsupportedAlg    = errors.New("unsupported signing algorithm")
+	errUnknownKey        = errors.New("signing key not found")
+	errInvalidSignature  = errors.New("invalid signature")
+	errTokenExpired      = errors.New("token is expired")
+	errTokenNotYetValid  = errors.New("token is not valid yet")
+	errInvalidIssuer     = errors.New("invalid issuer")
+	errInvalidAudience   = errors.New("invalid audience")
+	errUnsupportedKeyAlg = errors.New("unsupported key type")
+)
+
+// clockSkew is the leeway allowed when validating time based claims.
+const clockSkew = time.Minute
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// jwtClaims holds the claims of a verified token.
+type jwtClaims map[string]any
+
+func (c jwtClaims) string(key string) string {
+	v, _ := c[key].(string)
+	return v
+}
+
+func (c jwtClaims) time(key string) (time.Time, bool) {
+	v, ok := c[key].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(v), 0), true
+}
+
+// audiences returns the "aud" claim which may be a string or an array.
+func (c jwtClaims) audiences() []string {
+	switch v := c["aud"].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var ret []string
+		for _, a := range v {
+			if s, ok := a.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+		return ret
+	default:
+		return nil
+	}
+}
+
+// lookup resolves a dotted claim path (e.g. "realm_access.roles") and
+// returns its values as strings.
+func (c jwtClaims) lookup(path string) []string {
+	var cur any = map[string]any(c)
+	for _, key := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[key]
+	}
+	switch v := cur.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var ret []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				ret = append(ret, s)
+			}
+		}
+		return ret
+	default:
+		return nil
+	}
+}
+
+// jsonWebKey is a single key of a JWK set.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// publicKey converts the JWK into a crypto public key.
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA exponent: %w", err)
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("%w: EC curve %s", errUnsupportedKeyAlg, k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC x coordinate: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC y coordinate: %w", err)
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedKeyAlg, k.Kty)
+	}
+}
+
+// parseJWT splits a compact serialized JWT and decodes its header and claims
+// without verifying the signature.
+func parseJWT(token string) (jwtHeader, jwtClaims, []byte, []byte, error) {
+	var header jwtHeader
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return header, nil, nil, nil, errMalformedJWT
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return header, nil, nil, nil, fmt.Errorf("%w: %w", errMalformedJWT, err)
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return header, nil, nil, nil, fmt.Errorf("%w: %w", errMalformedJWT, err)
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return header, nil, nil, nil, fmt.Errorf("%w: %w", errMalformedJWT, err)
+	}
+	claims := jwtClaims{}
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return header, nil, nil, nil, fmt.Errorf("%w: %w", errMalformedJWT, err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return header, nil, nil, nil, fmt.Errorf("%w: %w", errMalformedJWT, err)
+	}
+
+	signingInput := []byte(parts[0] + "." + parts[1])
+	return header, claims, signingInput, signature, nil
+}
+
+// verifySignature checks the signature of the signing input with the key.
+func verifySignature(alg string, key crypto.PublicKey, signingInput, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "ES512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("%w: %s", errUnsupportedAlg, alg)
+	}
+
+	hasher := hash.New()
+	_, _ = hasher.Write(signingInput)
+	digest := hasher.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("%w: %s with RSA key", errUnsupportedAlg, alg)
+		}
+		if err := rsa.VerifyPKCS1v15(k, hash, digest, signature); err != nil {
+			return errInvalidSignature
+		}
+		return nil
+
+	case *ecdsa.PublicKey:
+		if !strings.HasPrefix(alg, "ES") {
+			return fmt.Errorf("%w: %s with EC key", errUnsupportedAlg, alg)
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errInvalidSignature
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return errInvalidSignature
+		}
+		return nil
+
+	default:
+		return errUnsupportedKeyAlg
+	}
+}
+
+// validateClaims checks the registered claims of a token.
+func validateClaims(claims jwtClaims, issuer string, audiences []string, now time.Time) error {
+	if claims.string("iss") != issuer {
+		return errInvalidIssuer
+	}
+
+	exp, ok := claims.time("exp")
+	if !ok || now.After(exp.Add(clockSkew)) {
+		return errTokenExpired
+	}
+	if nbf, ok := claims.time("nbf"); ok && now.Add(clockSkew).Before(nbf) {
+		return errTokenNotYetValid
+	}
+
+	for _, aud := range claims.audiences() {
+		for _, expected := range audiences {
+			if aud == expected {
+				return nil
+			}
+		}
+	}
+	return errInvalidAudience
+}
@@ -3,8 +3,10 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"net/netip"
 	"strings"
 
+	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/logger"
 	"github.com/go-chi/chi/v5/middleware"
 )
@@ -29,6 +31,10 @@ func SetupGlobalMiddleware(handler http.Handler) http.Handler {
 			map[string]string{authBasic.Username: authBasic.Password},
 		)(next)
 	}
+
+	if oidcAuth != nil {
+		next = oidcAuth.Middleware(next)
+	}
 	next = prefixChecker(next)
 
 	return next
@@ -38,10 +44,12 @@ type authCtxKey struct{}
 
 type authCtx struct {
 	authenticated bool
+	user          string
+	roles         []string
 }
 
-func withAuthenticated(ctx context.Context) context.Context {
-	return context.WithValue(ctx, authCtxKey{}, &authCtx{authenticated: true})
+func withAuthenticated(ctx context.Context, user string, roles ...string) context.Context {
+	return context.WithValue(ctx, authCtxKey{}, &authCtx{authenticated: true, user: user, roles: roles})
 }
 
 func isAuthenticated(ctx context.Context) bool {
@@ -52,20 +60,78 @@ func isAuthenticated(ctx context.Context) bool {
 	return ok && auth.authenticated
 }
 
+// AuthenticatedUser returns the name of the authenticated user of the request.
+// It returns an empty string if the request is not authenticated.
+func AuthenticatedUser(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	auth, ok := ctx.Value(authCtxKey{}).(*authCtx)
+	if !ok || !auth.authenticated {
+		return ""
+	}
+	return auth.user
+}
+
+// HasRole reports whether the request is granted the role. Users
+// authenticated by basic auth or the API token are granted every role, and
+// so is every request when no authentication is configured.
+func HasRole(ctx context.Context, role string) bool {
+	if authBasic == nil && authToken == nil && oidcAuth == nil {
+		return true
+	}
+	if ctx == nil {
+		return false
+	}
+	auth, ok := ctx.Value(authCtxKey{}).(*authCtx)
+	if !ok || !auth.authenticated {
+		return false
+	}
+	return rolesInclude(auth.roles, role)
+}
+
+// HasAuthenticatedRole reports whether the request is authenticated and
+// granted the role. Unlike HasRole, it is false for every request when no
+// authentication is configured.
+func HasAuthenticatedRole(ctx context.Context, role string) bool {
+	return isAuthenticated(ctx) && HasRole(ctx, role)
+}
+
+// rolesInclude reports whether any of the roles includes the role.
+func rolesInclude(roles []string, role string) bool {
+	rank := map[string]int{
+		config.RoleViewer:   1,
+		config.RoleOperator: 2,
+		config.RoleAdmin:    3,
+	}
+	for _, r := range roles {
+		if rank[r] >= rank[role] && rank[role] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 var (
 	defaultHandler http.Handler
 	authBasic      *AuthBasic
 	authToken      *AuthToken
+	oidcAuth       *oidcAuthenticator
 	appLogger      logger.Logger
 	basePath       string
+	trustedProxies []netip.Prefix
 )
 
 type Options struct {
 	Handler   http.Handler
 	AuthBasic *AuthBasic
 	AuthToken *AuthToken
+	AuthOIDC  *config.AuthOIDC
 	Logger    logger.Logger
 	BasePath  string
+	// TrustedProxies are the addresses of the reverse proxies whose
+	// forwarded headers are trusted to tell the address of the client.
+	TrustedProxies []string
 }
 
 type AuthBasic struct {
@@ -81,8 +147,14 @@ func Setup(opts *Options) {
 	defaultHandler = opts.Handler
 	authBasic = opts.AuthBasic
 	authToken = opts.AuthToken
+	oidcAuth = nil
+	if opts.AuthOIDC != nil {
+		oidcAuth = newOIDCAuthenticator(*opts.AuthOIDC)
+	}
 	appLogger = opts.Logger
 	basePath = opts.BasePath
+	// The addresses are validated when the configuration is loaded.
+	trustedProxies, _ = ParseTrustedProxies(opts.TrustedProxies)
 }
 
 func prefixChecker(next http.Handler) http.Handler {
@@ -97,6 +169,9 @@ func prefixChecker(next http.Handler) http.Handler {
 			http.StripPrefix(basePath, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				if strings.HasPrefix(r.URL.Path, "/api") {
 					next.ServeHTTP(w, r)
+				} else if oidcAuth != nil {
+					// The web UI and the login endpoints require a session.
+					oidcAuth.Middleware(defaultHandler).ServeHTTP(w, r)
 				} else {
 					defaultHandler.ServeHTTP(w, r)
 				}
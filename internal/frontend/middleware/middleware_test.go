@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasAuthenticatedRole(t *testing.T) {
+	t.Run("NoAuthConfigured", func(t *testing.T) {
+		Setup(&Options{})
+		ctx := context.Background()
+		require.True(t, HasRole(ctx, config.RoleAdmin))
+		require.False(t, HasAuthenticatedRole(ctx, config.RoleAdmin))
+	})
+	t.Run("AuthenticatedAdmin", func(t *testing.T) {
+		Setup(&Options{AuthBasic: &AuthBasic{Username: "admin", Password: "secret"}})
+		t.Cleanup(func() { Setup(&Options{}) })
+
+		require.False(t, HasAuthenticatedRole(context.Background(), config.RoleAdmin))
+
+		ctx := withAuthenticated(context.Background(), "viewer", config.RoleViewer)
+		require.False(t, HasAuthenticatedRole(ctx, config.RoleAdmin))
+
+		ctx = withAuthenticated(context.Background(), "admin", config.RoleAdmin)
+		require.True(t, HasAuthenticatedRole(ctx, config.RoleAdmin))
+	})
+}
@@ -0,0 +1,339 @@
+package middleware
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/config"
+	"github.com/stretchr/testify/require"
+)
+
+// testOIDCProvider is a minimal OpenID provider serving the discovery
+// document, the key set and the token endpoint.
+type testOIDCProvider struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+	// claims are returned in the ID token issued by the token endpoint.
+	claims map[string]any
+	// nonce is captured from the authorization request.
+	nonce string
+	// keySetFetches counts the requests for the key set.
+	keySetFetches atomic.Int32
+}
+
+func newTestOIDCProvider(t *testing.T) *testOIDCProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &testOIDCProvider{key: key, clientID: "dagu"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 p.server.URL,
+			"authorization_endpoint": p.server.URL + "/authorize",
+			"token_endpoint":         p.server.URL + "/token",
+			"jwks_uri":               p.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, _ *http.Request) {
+		p.keySetFetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-key",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != p.clientID || clientSecret != "secret" || r.FormValue("code") != "valid-code" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		claims := map[string]any{"nonce": p.nonce}
+		for k, v := range p.claims {
+			claims[k] = v
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"id_token":     p.token(t, claims),
+			"access_token": "opaque",
+		})
+	})
+
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// token issues a signed token with the default claims overridden by claims.
+func (p *testOIDCProvider) token(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	return p.tokenWithKeyID(t, "test-key", claims)
+}
+
+// tokenWithKeyID issues a signed token with the key ID in the header.
+func (p *testOIDCProvider) tokenWithKeyID(t *testing.T, kid string, claims map[string]any) string {
+	t.Helper()
+
+	payload := map[string]any{
+		"iss": p.server.URL,
+		"aud": p.clientID,
+		"sub": "user-1",
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"iat": time.Now().Unix(),
+	}
+	for k, v := range claims {
+		payload[k] = v
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": kid})
+	require.NoError(t, err)
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func setupOIDC(t *testing.T, provider *testOIDCProvider) http.Handler {
+	t.Helper()
+
+	Setup(&Options{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+		AuthOIDC: &config.AuthOIDC{
+			Enabled:       true,
+			Issuer:        provider.server.URL,
+			ClientID:      provider.clientID,
+			ClientSecret:  "secret",
+			ClientURL:     "http://dagu.example.com",
+			SessionSecret: "session-secret",
+			Roles: map[string][]string{
+				config.RoleAdmin:  {"dagu-admins"},
+				config.RoleViewer: {"dagu-viewers"},
+			},
+		},
+	})
+	t.Cleanup(func() {
+		Setup(&Options{})
+	})
+
+	return SetupGlobalMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NotEmpty(t, AuthenticatedUser(r.Context()))
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func TestOIDC(t *testing.T) {
+	provider := newTestOIDCProvider(t)
+	handler := setupOIDC(t, provider)
+
+	serve := func(req *http.Request) *http.Response {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	t.Run("BearerToken", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/dags/test", nil)
+		req.Header.Set("Authorization", "Bearer "+provider.token(t, map[string]any{
+			"groups": []string{"dagu-admins"},
+		}))
+		res := serve(req)
+		require.Equal(t, http.StatusOK, res.StatusCode)
+	})
+	t.Run("ViewerCannotMutate", func(t *testing.T) {
+		token := provider.token(t, map[string]any{"groups": []string{"dagu-viewers"}})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/dags", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		require.Equal(t, http.StatusOK, serve(req).StatusCode)
+
+		req = httptest.NewRequest(http.MethodPost, "/api/v1/dags/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		require.Equal(t, http.StatusForbidden, serve(req).StatusCode)
+	})
+	t.Run("NoRole", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/dags", nil)
+		req.Header.Set("Authorization", "Bearer "+provider.token(t, map[string]any{"groups": []string{"others"}}))
+		require.Equal(t, http.StatusForbidden, serve(req).StatusCode)
+	})
+	t.Run("InvalidTokens", func(t *testing.T) {
+		for name, claims := range map[string]map[string]any{
+			"Expired":       {"exp": time.Now().Add(-time.Hour).Unix(), "groups": []string{"dagu-admins"}},
+			"WrongAudience": {"aud": "other", "groups": []string{"dagu-admins"}},
+			"WrongIssuer":   {"iss": "https://evil.example.com", "groups": []string{"dagu-admins"}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/dags", nil)
+				req.Header.Set("Authorization", "Bearer "+provider.token(t, claims))
+				require.Equal(t, http.StatusUnauthorized, serve(req).StatusCode)
+			})
+		}
+
+		// Tampered signature
+		token := provider.token(t, map[string]any{"groups": []string{"dagu-admins"}})
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/dags", nil)
+		req.Header.Set("Authorization", "Bearer "+token[:len(token)-4]+"AAAA")
+		require.Equal(t, http.StatusUnauthorized, serve(req).StatusCode)
+	})
+	t.Run("Unauthenticated", func(t *testing.T) {
+		res := serve(httptest.NewRequest(http.MethodGet, "/api/v1/dags", nil))
+		require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+		res = serve(httptest.NewRequest(http.MethodGet, "/dags", nil))
+		require.Equal(t, http.StatusFound, res.StatusCode)
+		require.Equal(t, "/oidc/login?redirect=%2Fdags", res.Header.Get("Location"))
+	})
+	t.Run("AuthorizationCodeFlow", func(t *testing.T) {
+		provider.claims = map[string]any{
+			"preferred_username": "alice",
+			"groups":             []string{"dagu-admins"},
+		}
+
+		// Login redirects to the provider
+		res := serve(httptest.NewRequest(http.MethodGet, "/oidc/login?redirect=/dags", nil))
+		require.Equal(t, http.StatusFound, res.StatusCode)
+		location, err := url.Parse(res.Header.Get("Location"))
+		require.NoError(t, err)
+		require.Equal(t, provider.server.URL+"/authorize", location.Scheme+"://"+location.Host+location.Path)
+		require.Equal(t, "dagu", location.Query().Get("client_id"))
+		require.Equal(t, "http://dagu.example.com/oidc/callback", location.Query().Get("redirect_uri"))
+		state := location.Query().Get("state")
+		provider.nonce = location.Query().Get("nonce")
+
+		var stateCookie *http.Cookie
+		for _, c := range res.Cookies() {
+			if c.Name == stateCookieName {
+				stateCookie = c
+			}
+		}
+		require.NotNil(t, stateCookie)
+
+		// State mismatch is rejected
+		req := httptest.NewRequest(http.MethodGet, "/oidc/callback?code=valid-code&state=wrong", nil)
+		req.AddCookie(stateCookie)
+		require.Equal(t, http.StatusBadRequest, serve(req).StatusCode)
+
+		// The provider redirects back with the code
+		req = httptest.NewRequest(http.MethodGet, "/oidc/callback?code=valid-code&state="+state, nil)
+		req.AddCookie(stateCookie)
+		res = serve(req)
+		require.Equal(t, http.StatusFound, res.StatusCode)
+		require.Equal(t, "/dags", res.Header.Get("Location"))
+
+		var sessionCookie *http.Cookie
+		for _, c := range res.Cookies() {
+			if c.Name == sessionCookieName {
+				sessionCookie = c
+			}
+		}
+		require.NotNil(t, sessionCookie)
+
+		// The session cookie authenticates subsequent requests
+		req = httptest.NewRequest(http.MethodPost, "/api/v1/dags/test", nil)
+		req.AddCookie(sessionCookie)
+		require.Equal(t, http.StatusOK, serve(req).StatusCode)
+
+		// A forged session cookie is rejected
+		req = httptest.NewRequest(http.MethodGet, "/api/v1/dags", nil)
+		req.AddCookie(&http.Cookie{Name: sessionCookieName, Value: sessionCookie.Value + "x"})
+		require.Equal(t, http.StatusUnauthorized, serve(req).StatusCode)
+	})
+}
+
+func TestSafeRedirect(t *testing.T) {
+	testCases := []struct {
+		redirect string
+		expected string
+	}{
+		{redirect: "/dags", expected: "/dags"},
+		{redirect: "/dags/test?tab=status", expected: "/dags/test?tab=status"},
+		{redirect: "", expected: ""},
+		{redirect: "dags", expected: ""},
+		{redirect: "//evil.example", expected: ""},
+		{redirect: "https://evil.example", expected: ""},
+		{redirect: `/\evil.example`, expected: ""},
+		{redirect: `/\/evil.example`, expected: ""},
+		{redirect: `\\evil.example`, expected: ""},
+		{redirect: "/%5Cevil.example", expected: ""},
+		{redirect: "/%5c/evil.example", expected: ""},
+		{redirect: "/%2F/evil.example", expected: ""},
+		{redirect: "/\tevil.example", expected: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.redirect, func(t *testing.T) {
+			require.Equal(t, tc.expected, safeRedirect(tc.redirect))
+		})
+	}
+}
+
+func TestOIDC_KeyRefresh(t *testing.T) {
+	provider := newTestOIDCProvider(t)
+	auth := newOIDCAuthenticator(config.AuthOIDC{
+		Enabled:       true,
+		Issuer:        provider.server.URL,
+		ClientID:      provider.clientID,
+		ClientURL:     "http://dagu.example.com",
+		SessionSecret: "session-secret",
+	})
+	audiences := []string{provider.clientID}
+
+	// The key set is fetched for the first token.
+	_, err := auth.verifyToken(context.Background(), provider.token(t, nil), audiences)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), provider.keySetFetches.Load())
+
+	// The tokens with unknown key IDs do not fetch the key set again within
+	// the refresh interval.
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := auth.verifyToken(context.Background(), provider.tokenWithKeyID(t, "unknown", nil), audiences)
+			require.ErrorIs(t, err, errUnknownKey)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), provider.keySetFetches.Load())
+
+	// The key set is fetched again once the interval has passed.
+	auth.refreshMu.Lock()
+	auth.keysFetchedAt = time.Now().Add(-minKeyRefreshInterval)
+	auth.refreshMu.Unlock()
+	_, err = auth.verifyToken(context.Background(), provider.tokenWithKeyID(t, "unknown", nil), audiences)
+	require.ErrorIs(t, err, errUnknownKey)
+	require.Equal(t, int32(2), provider.keySetFetches.Load())
+
+	// The known key is still served from the cache.
+	_, err = auth.verifyToken(context.Background(), provider.token(t, nil), audiences)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), provider.keySetFetches.Load())
+}
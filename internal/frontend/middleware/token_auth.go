@@ -5,8 +5,13 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	"github.com/dagu-org/dagu/internal/config"
 )
 
+// tokenAuthUser is the user name recorded for requests authenticated by the API token.
+const tokenAuthUser = "token"
+
 // TokenAuth implements a similar middleware handler like go-chi's BasicAuth
 // middleware but for bearer tokens
 func TokenAuth(
@@ -36,7 +41,7 @@ func TokenAuth(
 				return
 			}
 
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(w, r.WithContext(withAuthenticated(r.Context(), tokenAuthUser, config.RoleAdmin)))
 		})
 	}
 }
@@ -28,7 +28,9 @@ type Server struct {
 	port        int
 	basicAuth   *BasicAuth
 	authToken   *AuthToken
+	oidc        *config.AuthOIDC
 	tls         *config.TLSConfig
+	proxies     []string
 	server      *restapi.Server
 	handlers    []Handler
 	assets      fs.FS
@@ -40,10 +42,15 @@ type NewServerArgs struct {
 	Port      int
 	BasicAuth *BasicAuth
 	AuthToken *AuthToken
+	OIDC      *config.AuthOIDC
 	TLS       *config.TLSConfig
 	Handlers  []Handler
 	AssetsFS  fs.FS
 
+	// TrustedProxies are the addresses of the reverse proxies in front of
+	// the server.
+	TrustedProxies []string
+
 	Headless              bool
 	NavbarColor           string
 	NavbarTitle           string
@@ -73,7 +80,9 @@ func New(params NewServerArgs) *Server {
 		port:      params.Port,
 		basicAuth: params.BasicAuth,
 		authToken: params.AuthToken,
+		oidc:      params.OIDC,
 		tls:       params.TLS,
+		proxies:   params.TrustedProxies,
 		handlers:  params.Handlers,
 		assets:    params.AssetsFS,
 		headless:  params.Headless, // Assign headless mode flag
@@ -104,9 +113,10 @@ func (svr *Server) Serve(ctx context.Context) (err error) {
 
 	// Setup middleware & routes
 	middlewareOptions := &pkgmiddleware.Options{
-		Handler:  svr.defaultRoutes(ctx, chi.NewRouter()), // API remains active
-		BasePath: svr.funcsConfig.BasePath,
-		Logger:   loggerInstance,
+		Handler:        svr.defaultRoutes(ctx, chi.NewRouter()), // API remains active
+		BasePath:       svr.funcsConfig.BasePath,
+		Logger:         loggerInstance,
+		TrustedProxies: svr.proxies,
 	}
 
 	if svr.authToken != nil {
@@ -120,6 +130,7 @@ func (svr *Server) Serve(ctx context.Context) (err error) {
 			Password: svr.basicAuth.Password,
 		}
 	}
+	middlewareOptions.AuthOIDC = svr.oidc
 	pkgmiddleware.Setup(middlewareOptions)
 
 	// Load API spec (Always required)
@@ -1,11 +1,16 @@
 package integration
 
 import (
+	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/dagu-org/dagu/internal/agent"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/dagu-org/dagu/internal/test"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIntegration(t *testing.T) {
@@ -168,3 +173,58 @@ func TestIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestWaitFor(t *testing.T) {
+	th := test.Setup(t, test.WithDAGsDir(test.TestdataPath(t, "integration")))
+
+	sub := th.DAG(t, filepath.Join("integration", "sub.yaml"))
+	sub.Agent().RunSuccess(t)
+
+	t.Run("Succeeded", func(t *testing.T) {
+		dag := th.DAG(t, filepath.Join("integration", "wait-for.yaml"))
+		dag.Agent().RunSuccess(t)
+
+		dag.AssertLatestStatus(t, scheduler.StatusSuccess)
+		dag.AssertOutputs(t, map[string]any{
+			"OUT2": "xyz",
+		})
+	})
+	t.Run("SoftFail", func(t *testing.T) {
+		dag := th.DAG(t, filepath.Join("integration", "wait-for-soft-fail.yaml"))
+		dag.Agent().RunSuccess(t)
+
+		dag.AssertLatestStatus(t, scheduler.StatusSuccess)
+		status, err := th.Client.GetLatestStatus(th.Context, dag.DAG)
+		require.NoError(t, err)
+		require.Equal(t, scheduler.NodeStatusSkipped, status.Nodes[0].Status)
+		require.Equal(t, scheduler.NodeStatusSkipped, status.Nodes[1].Status)
+	})
+	t.Run("ScheduledTime", func(t *testing.T) {
+		// The offset is from the scheduled time, not from the time the
+		// step starts.
+		dag := th.DAG(t, filepath.Join("integration", "wait-for-scheduled.yaml"))
+		dag.Agent(test.WithAgentOptions(agent.Options{
+			ScheduledTime: time.Now().Add(-2400 * time.Hour),
+		})).RunSuccess(t)
+
+		dag.AssertLatestStatus(t, scheduler.StatusSuccess)
+	})
+	t.Run("ManyRuns", func(t *testing.T) {
+		// The successful run is found behind more runs than read at once.
+		now := time.Now()
+		for i := 0; i < 150; i++ {
+			requestID := fmt.Sprintf("failed-%d", i)
+			timestamp := now.Add(time.Duration(i) * time.Millisecond)
+			require.NoError(t, th.HistoryStore.Open(th.Context, sub.Location, timestamp, requestID))
+			require.NoError(t, th.HistoryStore.Write(th.Context, model.NewStatusFactory(sub.DAG).Create(
+				requestID, scheduler.StatusError, 0, timestamp,
+			)))
+			require.NoError(t, th.HistoryStore.Close(th.Context))
+		}
+
+		dag := th.DAG(t, filepath.Join("integration", "wait-for.yaml"))
+		dag.Agent().RunSuccess(t)
+
+		dag.AssertLatestStatus(t, scheduler.StatusSuccess)
+	})
+}
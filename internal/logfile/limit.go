@@ -0,0 +1,129 @@
+package logfile
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+)
+
+// LimitWriter writes the log to the file up to the maximum size.
+//
+// By default, the rest of the log is dropped beyond the size after a marker
+// line. If the beginning is truncated instead, the file grows up to twice
+// the size, then it is rewritten with a marker line followed by the last
+// lines within the size.
+type LimitWriter struct {
+	f    *os.File
+	max  int64
+	head bool
+
+	size int64
+	// last is the last byte written to the file.
+	last byte
+	// dropped is set once the rest of the log is dropped.
+	dropped bool
+}
+
+var _ io.Writer = (*LimitWriter)(nil)
+
+// NewLimitWriter returns the writer of the log file limiting its size to
+// max bytes. The beginning of the log is truncated if head is set.
+func NewLimitWriter(f *os.File, max int64, head bool) (*LimitWriter, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return &LimitWriter{
+		f:    f,
+		max:  max,
+		head: head,
+		size: info.Size(),
+		last: '\n',
+	}, nil
+}
+
+// Write implements io.Writer. It never fails because of the size, so that
+// the step keeps running when its log is truncated.
+func (w *LimitWriter) Write(p []byte) (int, error) {
+	if w.head {
+		if err := w.write(p); err != nil {
+			return 0, err
+		}
+		if w.size >= 2*w.max {
+			if err := w.compact(); err != nil {
+				return 0, err
+			}
+		}
+		return len(p), nil
+	}
+
+	if w.dropped {
+		return len(p), nil
+	}
+	if w.size+int64(len(p)) <= w.max {
+		if err := w.write(p); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	if err := w.write(p[:max(w.max-w.size, 0)]); err != nil {
+		return 0, err
+	}
+	w.dropped = true
+	marker := fmt.Sprintf("[dagu] the rest of the log is truncated: it exceeds the maximum size of %d bytes\n", w.max)
+	if w.last != '\n' {
+		marker = "\n" + marker
+	}
+	if err := w.write([]byte(marker)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (w *LimitWriter) write(p []byte) error {
+	if len(p) == 0 {
+		return nil
+	}
+	n, err := w.f.Write(p)
+	w.size += int64(n)
+	if n > 0 {
+		w.last = p[n-1]
+	}
+	return err
+}
+
+// compact rewrites the file with the marker line and the last lines of the
+// log within the maximum size.
+func (w *LimitWriter) compact() error {
+	r, err := os.Open(w.f.Name())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	buf := make([]byte, w.max)
+	n, err := r.ReadAt(buf, w.size-w.max)
+	if err != nil && err != io.EOF {
+		return err
+	}
+	buf = buf[:n]
+	// Keep the whole lines only.
+	if i := bytes.IndexByte(buf, '\n'); i >= 0 && i < len(buf)-1 {
+		buf = buf[i+1:]
+	}
+
+	if err := w.f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := w.f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	w.size = 0
+	marker := fmt.Sprintf("[dagu] the beginning of the log is truncated: it exceeds the maximum size of %d bytes\n", w.max)
+	if err := w.write([]byte(marker)); err != nil {
+		return err
+	}
+	return w.write(buf)
+}
@@ -0,0 +1,233 @@
+// Package logfile limits the size of the log files written by the DAG runs,
+// compresses the logs of the finished runs and keeps the log directory
+// within a disk quota.
+package logfile
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CompressedExt is the extension added to the name of the compressed logs.
+const CompressedExt = ".gz"
+
+// Open opens the log file for reading. If the file does not exist, it opens
+// the file compressed by Compress and decompresses it transparently.
+func Open(file string) (io.ReadCloser, error) {
+	f, err := os.Open(file)
+	if err == nil {
+		return f, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	gz, gzErr := os.Open(file + CompressedExt)
+	if gzErr != nil {
+		// Report the log file rather than the compressed one.
+		return nil, err
+	}
+	r, gzErr := gzip.NewReader(gz)
+	if gzErr != nil {
+		_ = gz.Close()
+		return nil, fmt.Errorf("failed to read the compressed log %s: %w", gz.Name(), gzErr)
+	}
+	return &gzipReadCloser{Reader: r, file: gz}, nil
+}
+
+// ReadFile reads the log file, or the file compressed by Compress if it
+// does not exist.
+func ReadFile(file string) ([]byte, error) {
+	r, err := Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+	return io.ReadAll(r)
+}
+
+type gzipReadCloser struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (r *gzipReadCloser) Close() error {
+	err := r.Reader.Close()
+	if cerr := r.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Compress compresses the log file with gzip into the file with the
+// CompressedExt extension and removes it. The compressed file keeps the
+// modification time of the log. It does nothing if the log does not exist
+// or is already compressed.
+func Compress(file string) error {
+	if file == "" || strings.HasSuffix(file, CompressedExt) {
+		return nil
+	}
+	src, err := os.Open(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that the readers never see a
+	// partially written compressed log.
+	tmp, err := os.CreateTemp(filepath.Dir(file), filepath.Base(file)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	w := gzip.NewWriter(tmp)
+	if _, err := io.Copy(w, src); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to compress the log %s: %w", file, err)
+	}
+	if err := w.Close(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to compress the log %s: %w", file, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), info.Mode().Perm()); err != nil {
+		return err
+	}
+	if err := os.Chtimes(tmp.Name(), info.ModTime(), info.ModTime()); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), file+CompressedExt); err != nil {
+		return err
+	}
+	return os.Remove(file)
+}
+
+// QuotaOptions selects the files of the log directory subject to the quota.
+// Only the files in its subdirectories, the log directories of the DAG runs,
+// are subject to it.
+type QuotaOptions struct {
+	// ExcludeDirs are the directories whose files are neither counted nor
+	// deleted, e.g. the logs of the scheduler and the audit log.
+	ExcludeDirs []string
+	// InUse reports whether the file belongs to a running DAG run. Such
+	// files are counted but never deleted.
+	InUse func(path string) bool
+}
+
+// Usage returns the total size of the files subject to the quota under the
+// directory.
+func Usage(dir string, opts QuotaOptions) (int64, error) {
+	files, err := listFiles(dir, opts.ExcludeDirs)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	return total, nil
+}
+
+// EnforceQuota deletes the files subject to the quota under the directory,
+// the oldest first, until their total size is within the quota. It returns
+// the total size after the deletion.
+func EnforceQuota(dir string, quota int64, opts QuotaOptions) (int64, error) {
+	files, err := listFiles(dir, opts.ExcludeDirs)
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, f := range files {
+		total += f.size
+	}
+	if total <= quota {
+		return total, nil
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime < files[j].modTime
+	})
+	for _, f := range files {
+		if total <= quota {
+			break
+		}
+		if opts.InUse != nil && opts.InUse(f.path) {
+			continue
+		}
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return total, err
+		}
+		total -= f.size
+	}
+	return total, nil
+}
+
+type fileEntry struct {
+	path    string
+	size    int64
+	modTime int64
+}
+
+// listFiles returns the regular files in the subdirectories of the
+// directory, except those under the excluded directories. It returns no
+// files if the directory does not exist.
+func listFiles(dir string, excludeDirs []string) ([]fileEntry, error) {
+	root := filepath.Clean(dir)
+	excluded := make(map[string]bool, len(excludeDirs))
+	for _, d := range excludeDirs {
+		if d != "" {
+			excluded[filepath.Clean(d)] = true
+		}
+	}
+
+	var files []fileEntry
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			if excluded[path] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || filepath.Dir(path) == root {
+			return nil
+		}
+		info, err := d.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		files = append(files, fileEntry{path: path, size: info.Size(), modTime: info.ModTime().UnixNano()})
+		return nil
+	})
+	return files, err
+}
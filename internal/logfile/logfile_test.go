@@ -0,0 +1,117 @@
+package logfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logfile"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitWriter(t *testing.T) {
+	t.Run("Tail", func(t *testing.T) {
+		f, err := os.Create(filepath.Join(t.TempDir(), "step.log"))
+		require.NoError(t, err)
+		defer f.Close()
+
+		w, err := logfile.NewLimitWriter(f, 10, false)
+		require.NoError(t, err)
+		for _, s := range []string{"1234\n", "5678\n", "9abc\n", "def\n"} {
+			n, err := w.Write([]byte(s))
+			require.NoError(t, err)
+			require.Equal(t, len(s), n)
+		}
+
+		data, err := os.ReadFile(f.Name())
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		require.Equal(t, []string{"1234", "5678"}, lines[:2])
+		require.Len(t, lines, 3)
+		require.Contains(t, lines[2], "the rest of the log is truncated")
+	})
+	t.Run("Head", func(t *testing.T) {
+		f, err := os.Create(filepath.Join(t.TempDir(), "step.log"))
+		require.NoError(t, err)
+		defer f.Close()
+
+		w, err := logfile.NewLimitWriter(f, 10, true)
+		require.NoError(t, err)
+		for i := range 10 {
+			_, err := w.Write([]byte(strings.Repeat(string(rune('0'+i)), 4) + "\n"))
+			require.NoError(t, err)
+		}
+
+		data, err := os.ReadFile(f.Name())
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+		require.Contains(t, lines[0], "the beginning of the log is truncated")
+		require.Equal(t, "9999", lines[len(lines)-1])
+		require.NotContains(t, string(data), "0000")
+	})
+}
+
+func TestCompress(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "step.log")
+	require.NoError(t, os.WriteFile(file, []byte("first\nsecond\n"), 0600))
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(file, modTime, modTime))
+
+	require.NoError(t, logfile.Compress(file))
+	require.NoFileExists(t, file)
+	info, err := os.Stat(file + logfile.CompressedExt)
+	require.NoError(t, err)
+	require.True(t, info.ModTime().Equal(modTime))
+
+	// The compressed log is read transparently.
+	data, err := logfile.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, "first\nsecond\n", string(data))
+
+	// Compressing again does nothing.
+	require.NoError(t, logfile.Compress(file))
+
+	_, err = logfile.Open(filepath.Join(t.TempDir(), "missing.log"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestEnforceQuota(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	for i, name := range []string{"admin/audit/audit.jsonl", "dag/oldest.log", "dag/running.log", "dag/older.log", "root.log", "other/newest.log"} {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), 0750))
+		require.NoError(t, os.WriteFile(file, []byte(strings.Repeat("x", 100)), 0600))
+		modTime := now.Add(time.Duration(i-6) * time.Hour)
+		require.NoError(t, os.Chtimes(file, modTime, modTime))
+	}
+	opts := logfile.QuotaOptions{
+		ExcludeDirs: []string{filepath.Join(dir, "admin")},
+		InUse: func(path string) bool {
+			return path == filepath.Join(dir, "dag/running.log")
+		},
+	}
+
+	usage, err := logfile.Usage(dir, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(400), usage)
+
+	usage, err = logfile.EnforceQuota(dir, 250, opts)
+	require.NoError(t, err)
+	require.Equal(t, int64(200), usage)
+	require.NoFileExists(t, filepath.Join(dir, "dag/oldest.log"))
+	require.NoFileExists(t, filepath.Join(dir, "dag/older.log"))
+	require.FileExists(t, filepath.Join(dir, "dag/running.log"))
+	require.FileExists(t, filepath.Join(dir, "other/newest.log"))
+
+	// The audit log under the admin logs directory and the files outside
+	// the log directories of the DAG runs are kept.
+	require.FileExists(t, filepath.Join(dir, "admin/audit/audit.jsonl"))
+	require.FileExists(t, filepath.Join(dir, "root.log"))
+
+	usage, err = logfile.Usage(filepath.Join(dir, "missing"), opts)
+	require.NoError(t, err)
+	require.Zero(t, usage)
+}
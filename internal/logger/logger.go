@@ -40,10 +40,11 @@ type appLogger struct {
 }
 
 type Config struct {
-	debug  bool
-	format string
-	writer io.Writer
-	quiet  bool
+	debug    bool
+	format   string
+	writer   io.Writer
+	quiet    bool
+	handlers []slog.Handler
 }
 
 type Option func(*Config)
@@ -76,6 +77,15 @@ func WithQuiet() Option {
 	}
 }
 
+// WithHandler adds the handler receiving the records in addition to stderr
+// and the writer, e.g. to ship them to a log store. The handler filters the
+// records by its own level.
+func WithHandler(h slog.Handler) Option {
+	return func(o *Config) {
+		o.handlers = append(o.handlers, h)
+	}
+}
+
 var defaultLogger = NewLogger(WithFormat("text"))
 
 func NewLogger(opts ...Option) Logger {
@@ -114,6 +124,8 @@ func NewLogger(opts ...Option) Logger {
 		handlers = append(handlers, guardedHandler)
 	}
 
+	handlers = append(handlers, cfg.handlers...)
+
 	return &appLogger{
 		logger:         slog.New(slogmulti.Fanout(handlers...)),
 		guardedHandler: guardedHandler,
@@ -0,0 +1,200 @@
+package logsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// FileConfig is the configuration of the file sink.
+type FileConfig struct {
+	// Path is the path of the file.
+	Path string
+	// MaxSize is the size in bytes beyond which the file is rotated
+	// (default 100MiB).
+	MaxSize int64
+	// MaxBackups is the number of the rotated files kept (default 5). The
+	// rotated files are named with the suffixes .1, .2, ..., the newest
+	// first.
+	MaxBackups int
+}
+
+// File writes the records to a file as JSON lines, rotating the file when
+// it exceeds the maximum size.
+//
+// The runs of the DAGs write to the same file from their processes. The
+// processes hold a lock on the file with the .lock suffix while they check
+// the size, rotate and write, and reopen the file another process rotated.
+type File struct {
+	cfg FileConfig
+	f   *os.File
+}
+
+var _ Sink = (*File)(nil)
+
+// The defaults of the file sink.
+const (
+	defaultFileMaxSize    = 100 << 20
+	defaultFileMaxBackups = 5
+)
+
+// NewFile returns the file sink. It opens the file on the first batch.
+func NewFile(cfg FileConfig) (*File, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("file path is required")
+	}
+	if cfg.MaxSize <= 0 {
+		cfg.MaxSize = defaultFileMaxSize
+	}
+	if cfg.MaxBackups <= 0 {
+		cfg.MaxBackups = defaultFileMaxBackups
+	}
+	return &File{cfg: cfg}, nil
+}
+
+// fileRecord is a line of the file. The labels are the keys of the object
+// next to the time, the level and the message.
+type fileRecord struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level,omitempty"`
+	Message string         `json:"msg"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+}
+
+// Send implements Sink.
+func (s *File) Send(_ context.Context, records []Record) error {
+	var buf bytes.Buffer
+	for _, r := range records {
+		line, err := json.Marshal(fileRecord{
+			Time:    r.Time,
+			Level:   r.Level,
+			Message: r.Message,
+			Attrs:   r.Attrs,
+		})
+		if err != nil {
+			// Drop the attributes which cannot be encoded.
+			if line, err = json.Marshal(fileRecord{Time: r.Time, Level: r.Level, Message: r.Message}); err != nil {
+				continue
+			}
+		}
+		if len(r.Labels) > 0 {
+			labels, err := json.Marshal(r.Labels)
+			if err != nil {
+				continue
+			}
+			// Merge the labels into the object.
+			line = append(append(labels[:len(labels)-1], ','), line[1:]...)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.open(int64(buf.Len())); err != nil {
+		return err
+	}
+	if _, err := s.f.Write(buf.Bytes()); err != nil {
+		_ = s.f.Close()
+		s.f = nil
+		return err
+	}
+	return nil
+}
+
+// lock takes the exclusive lock shared by the processes writing to the file
+// and returns the function to release it.
+func (s *File) lock() (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(s.cfg.Path), 0755); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(s.cfg.Path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to lock %s: %w", f.Name(), err)
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}, nil
+}
+
+// open opens the file to write n bytes to, rotating it if it would exceed
+// the maximum size.
+func (s *File) open(n int64) error {
+	info, err := os.Stat(s.cfg.Path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		// Rotated or removed by another process.
+		info = nil
+	case err != nil:
+		return err
+	}
+	if s.f != nil {
+		current, err := s.f.Stat()
+		if err != nil || info == nil || !os.SameFile(current, info) {
+			_ = s.f.Close()
+			s.f = nil
+		}
+	}
+	if info != nil && info.Size() > 0 && info.Size()+n > s.cfg.MaxSize {
+		if s.f != nil {
+			_ = s.f.Close()
+			s.f = nil
+		}
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	if s.f == nil {
+		f, err := os.OpenFile(s.cfg.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+		if err != nil {
+			return err
+		}
+		s.f = f
+	}
+	return nil
+}
+
+// rotate renames the file to the first backup, shifting the backups and
+// removing the oldest beyond the maximum.
+func (s *File) rotate() error {
+	backup := func(i int) string {
+		return fmt.Sprintf("%s.%d", s.cfg.Path, i)
+	}
+	if err := os.Remove(backup(s.cfg.MaxBackups)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	for i := s.cfg.MaxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(backup(i), backup(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	if err := os.Rename(s.cfg.Path, backup(1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Close implements Sink.
+func (s *File) Close() error {
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
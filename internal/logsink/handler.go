@@ -0,0 +1,217 @@
+package logsink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logstream"
+)
+
+// Handler returns the slog.Handler shipping the records of the scheduler
+// log at the level or above. The "step" and "status" attributes of the
+// records are shipped as the labels.
+func (s *Shipper) Handler(level slog.Leveler) slog.Handler {
+	return &handler{shipper: s, level: level}
+}
+
+type handler struct {
+	shipper *Shipper
+	level   slog.Leveler
+	attrs   []slog.Attr
+	// group is the prefix of the keys of the attributes, e.g. "a.b.".
+	group string
+}
+
+var _ slog.Handler = (*handler)(nil)
+
+// Enabled implements slog.Handler.
+func (h *handler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle implements slog.Handler.
+func (h *handler) Handle(_ context.Context, record slog.Record) error {
+	r := Record{
+		Time:    record.Time,
+		Level:   strings.ToLower(record.Level.String()),
+		Message: record.Message,
+		Labels:  map[string]string{LabelSource: SourceScheduler},
+	}
+	for _, a := range h.attrs {
+		h.addAttr(&r, "", a)
+	}
+	record.Attrs(func(a slog.Attr) bool {
+		h.addAttr(&r, h.group, a)
+		return true
+	})
+	return h.shipper.Ship(r)
+}
+
+func (h *handler) addAttr(r *Record, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			h.addAttr(r, prefix, ga)
+		}
+		return
+	}
+	if prefix == "" && (a.Key == LabelStep || a.Key == LabelStatus) {
+		r.Labels[a.Key] = a.Value.String()
+		return
+	}
+	if r.Attrs == nil {
+		r.Attrs = make(map[string]any)
+	}
+	v := a.Value.Any()
+	// Keep the text of the values the sinks encode in JSON.
+	switch x := v.(type) {
+	case error:
+		v = x.Error()
+	case fmt.Stringer:
+		v = x.String()
+	}
+	r.Attrs[prefix+a.Key] = v
+}
+
+// WithAttrs implements slog.Handler.
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	h2 := *h
+	h2.attrs = slices.Clone(h.attrs)
+	for _, a := range attrs {
+		if h.group != "" {
+			a = slog.Group(strings.TrimSuffix(h.group, "."), a)
+		}
+		h2.attrs = append(h2.attrs, a)
+	}
+	return &h2
+}
+
+// WithGroup implements slog.Handler.
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	h2 := *h
+	h2.group = h.group + name + "."
+	return &h2
+}
+
+// StepWriter returns the writer shipping each line written to the log of
+// the step with the "running" status. The level of the line is read if it is
+// a line of a JSON log. The writer never fails nor blocks.
+func (s *Shipper) StepWriter(step string) *LineWriter {
+	return &LineWriter{shipper: s, step: step}
+}
+
+// maxLineLength is the length of a line after which it is shipped without
+// waiting for the end of the line.
+const maxLineLength = 64 * 1024
+
+// LineWriter ships the lines written to the log of a step.
+type LineWriter struct {
+	shipper *Shipper
+	step    string
+
+	mu      sync.Mutex
+	partial []byte
+}
+
+// Write implements io.Writer.
+func (w *LineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.ship(string(bytes.TrimSuffix(w.partial[:i], []byte("\r"))))
+		w.partial = w.partial[i+1:]
+	}
+	if len(w.partial) >= maxLineLength {
+		w.ship(string(w.partial))
+		w.partial = nil
+	}
+	w.partial = bytes.Clone(w.partial)
+	return len(p), nil
+}
+
+// Finish ships the last line without the line break, followed by the
+// record of the end of the step with its final status, e.g. "failed".
+func (w *LineWriter) Finish(status string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.partial) > 0 {
+		w.ship(string(w.partial))
+		w.partial = nil
+	}
+	level := "info"
+	if status == "failed" {
+		level = "error"
+	}
+	_ = w.shipper.Ship(Record{
+		Time:    time.Now(),
+		Level:   level,
+		Message: "Step execution finished",
+		Labels: map[string]string{
+			LabelSource: SourceStep,
+			LabelStep:   w.step,
+			LabelStatus: status,
+		},
+	})
+}
+
+func (w *LineWriter) ship(line string) {
+	r := Record{
+		Time:    time.Now(),
+		Message: line,
+		Labels: map[string]string{
+			LabelSource: SourceStep,
+			LabelStep:   w.step,
+			LabelStatus: "running",
+		},
+	}
+	if e, ok := logstream.ParseEntry(line); ok {
+		r.Level = e.Level
+	}
+	_ = w.shipper.Ship(r)
+}
+
+// text returns the message of the record followed by its attributes as
+// key=value pairs sorted by the key.
+func (r Record) text() string {
+	if len(r.Attrs) == 0 {
+		return r.Message
+	}
+	var b strings.Builder
+	b.WriteString(r.Message)
+	keys := make([]string, 0, len(r.Attrs))
+	for k := range r.Attrs {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		v := fmt.Sprint(r.Attrs[k])
+		if v == "" || strings.ContainsAny(v, " \t\r\n\"=") {
+			v = strconv.Quote(v)
+		}
+		fmt.Fprintf(&b, " %s=%s", k, v)
+	}
+	return b.String()
+}
@@ -0,0 +1,355 @@
+// Package logsink ships the step logs and the scheduler logs of the DAG runs
+// to central log stores, e.g. syslog or Loki, in addition to the log files.
+//
+// The records are queued in a bounded buffer of each sink and sent in
+// batches by a goroutine, retrying the failed batches. Shipping never blocks
+// the caller: the records are dropped when the buffer is full.
+package logsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// The labels of the records.
+const (
+	LabelDAG       = "dag"
+	LabelRequestID = "request_id"
+	LabelStep      = "step"
+	LabelStatus    = "status"
+	// LabelSource is either SourceStep or SourceScheduler.
+	LabelSource = "source"
+)
+
+// The sources of the records.
+const (
+	// SourceStep is the source of the lines of the step logs.
+	SourceStep = "step"
+	// SourceScheduler is the source of the records of the scheduler log.
+	SourceScheduler = "scheduler"
+)
+
+// Record is a line of a log shipped to the sinks.
+type Record struct {
+	Time time.Time
+	// Level is the level in lower case, e.g. "info". It is empty for the
+	// lines of the step logs without a level.
+	Level   string
+	Message string
+	// Labels identify the log, e.g. the DAG and the step.
+	Labels map[string]string
+	// Attrs are the other attributes of the records of the scheduler log.
+	Attrs map[string]any
+}
+
+// Sink sends the batches of records to a log store.
+type Sink interface {
+	// Send sends the records. It is called by a single goroutine.
+	Send(ctx context.Context, records []Record) error
+	// Close releases the resources of the sink.
+	Close() error
+}
+
+// permanentError is the error of a batch which fails on retries as well,
+// e.g. a request rejected by the server.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Options are the options of the queue of a sink.
+type Options struct {
+	// Name identifies the sink in the errors, e.g. "loki".
+	Name string
+	// Labels are added to the labels of all the records.
+	Labels map[string]string
+	// BufferSize is the maximum number of the records waiting to be sent
+	// (default 10000). The records are dropped beyond it.
+	BufferSize int
+	// BatchSize is the maximum number of the records sent at once
+	// (default 100).
+	BatchSize int
+	// FlushInterval is the interval to send the records waiting even if
+	// the batch is not full (default 1s).
+	FlushInterval time.Duration
+	// MaxRetries is the number of the retries of a failed batch
+	// (default 3). The batch is dropped after them.
+	MaxRetries int
+	// RetryInterval is the interval before the first retry (default 1s).
+	// It doubles on each retry.
+	RetryInterval time.Duration
+}
+
+// The defaults of the options.
+const (
+	defaultBufferSize    = 10000
+	defaultBatchSize     = 100
+	defaultFlushInterval = time.Second
+	defaultMaxRetries    = 3
+	defaultRetryInterval = time.Second
+)
+
+func (o Options) withDefaults() Options {
+	if o.BufferSize <= 0 {
+		o.BufferSize = defaultBufferSize
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = defaultBatchSize
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = defaultFlushInterval
+	}
+	if o.MaxRetries < 0 {
+		o.MaxRetries = 0
+	} else if o.MaxRetries == 0 {
+		o.MaxRetries = defaultMaxRetries
+	}
+	if o.RetryInterval <= 0 {
+		o.RetryInterval = defaultRetryInterval
+	}
+	return o
+}
+
+// ErrClosed is returned when a record is shipped after the shipper is closed.
+var ErrClosed = errors.New("log shipper is closed")
+
+// Shipper ships the records to the sinks.
+type Shipper struct {
+	// labels are added to the labels of all the records.
+	labels map[string]string
+	queues []*queue
+	closed atomic.Bool
+}
+
+// NewShipper returns the shipper adding the labels to all the records, e.g.
+// the DAG and the request ID of the run.
+func NewShipper(labels map[string]string) *Shipper {
+	return &Shipper{labels: labels}
+}
+
+// Add starts shipping the records to the sink.
+func (s *Shipper) Add(sink Sink, opts Options) {
+	opts = opts.withDefaults()
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &queue{
+		sink:    sink,
+		opts:    opts,
+		records: make(chan Record, opts.BufferSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+	s.queues = append(s.queues, q)
+	go q.run()
+}
+
+// Len returns the number of the sinks.
+func (s *Shipper) Len() int {
+	return len(s.queues)
+}
+
+// Ship queues the record to be sent to the sinks. It never blocks: the
+// record is dropped for the sinks whose buffer is full. It returns ErrClosed
+// after the shipper is closed.
+func (s *Shipper) Ship(r Record) error {
+	if s.closed.Load() {
+		return ErrClosed
+	}
+	if len(s.queues) == 0 {
+		return nil
+	}
+	if r.Time.IsZero() {
+		r.Time = time.Now()
+	}
+	labels := maps.Clone(s.labels)
+	if labels == nil {
+		labels = make(map[string]string, len(r.Labels))
+	}
+	maps.Copy(labels, r.Labels)
+	r.Labels = labels
+	for _, q := range s.queues {
+		q.push(r)
+	}
+	return nil
+}
+
+// Dropped returns the number of the records dropped by all the sinks
+// because the buffer was full or the batch failed.
+func (s *Shipper) Dropped() int64 {
+	var n int64
+	for _, q := range s.queues {
+		n += q.dropped.Load()
+	}
+	return n
+}
+
+// Close sends the records waiting and closes the sinks. The records still
+// waiting are dropped when the context is done. It returns the last errors
+// of the sinks which dropped records.
+func (s *Shipper) Close(ctx context.Context) error {
+	if s.closed.Swap(true) {
+		return ErrClosed
+	}
+	for _, q := range s.queues {
+		close(q.stop)
+	}
+	var errs []error
+	for _, q := range s.queues {
+		select {
+		case <-q.done:
+		case <-ctx.Done():
+			// Stop retrying the batch being sent.
+			q.cancel()
+			<-q.done
+		}
+		q.cancel()
+		if err := q.sink.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", q.opts.Name, err))
+		}
+		if dropped := q.dropped.Load(); dropped > 0 {
+			err := fmt.Errorf("%s: %d records dropped", q.opts.Name, dropped)
+			if lastErr := q.lastError(); lastErr != nil {
+				err = fmt.Errorf("%w: %w", err, lastErr)
+			}
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// queue is the bounded buffer of the records of a sink, sent in batches
+// by its goroutine.
+type queue struct {
+	sink    Sink
+	opts    Options
+	records chan Record
+	dropped atomic.Int64
+
+	// stop is closed to send the records waiting and stop the goroutine.
+	stop chan struct{}
+	// done is closed when the goroutine has stopped.
+	done chan struct{}
+	// ctx is canceled to give up sending the batch.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+func (q *queue) push(r Record) {
+	if len(q.opts.Labels) > 0 {
+		labels := maps.Clone(q.opts.Labels)
+		maps.Copy(labels, r.Labels)
+		r.Labels = labels
+	}
+	select {
+	case <-q.stop:
+		q.dropped.Add(1)
+	case q.records <- r:
+	default:
+		q.dropped.Add(1)
+	}
+}
+
+func (q *queue) run() {
+	defer close(q.done)
+
+	ticker := time.NewTicker(q.opts.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Record, 0, q.opts.BatchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			q.send(batch)
+			batch = batch[:0]
+		}
+	}
+	for {
+		select {
+		case r := <-q.records:
+			batch = append(batch, r)
+			if len(batch) >= q.opts.BatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-q.stop:
+			for {
+				select {
+				case r := <-q.records:
+					batch = append(batch, r)
+					if len(batch) >= q.opts.BatchSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// send sends the batch, retrying it with the backoff. The batch is dropped
+// if it fails after the retries.
+func (q *queue) send(batch []Record) {
+	interval := q.opts.RetryInterval
+	for retry := 0; ; retry++ {
+		err := q.sink.Send(q.ctx, batch)
+		if err == nil {
+			return
+		}
+		q.setLastError(err)
+
+		var perr *permanentError
+		if retry >= q.opts.MaxRetries || errors.As(err, &perr) {
+			q.dropped.Add(int64(len(batch)))
+			return
+		}
+		select {
+		case <-time.After(interval):
+		case <-q.ctx.Done():
+			q.dropped.Add(int64(len(batch)))
+			return
+		}
+		interval *= 2
+	}
+}
+
+func (q *queue) setLastError(err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.lastErr = err
+}
+
+func (q *queue) lastError() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.lastErr
+}
+
+type ctxKey struct{}
+
+// WithShipper returns a context with the shipper of the run.
+func WithShipper(ctx context.Context, s *Shipper) context.Context {
+	return context.WithValue(ctx, ctxKey{}, s)
+}
+
+// FromContext returns the shipper of the context, or nil if it has none.
+func FromContext(ctx context.Context) *Shipper {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(ctxKey{}).(*Shipper)
+	return s
+}
@@ -0,0 +1,401 @@
+package logsink_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logsink"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySink keeps the batches sent to it. The first failures sends fail.
+type memorySink struct {
+	mu       sync.Mutex
+	batches  [][]logsink.Record
+	failures int
+	// block blocks the sends until it is closed if it is set.
+	block chan struct{}
+}
+
+func (s *memorySink) Send(ctx context.Context, records []logsink.Record) error {
+	if s.block != nil {
+		select {
+		case <-s.block:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("unavailable")
+	}
+	s.batches = append(s.batches, append([]logsink.Record(nil), records...))
+	return nil
+}
+
+func (s *memorySink) Close() error { return nil }
+
+func (s *memorySink) records() []logsink.Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var records []logsink.Record
+	for _, b := range s.batches {
+		records = append(records, b...)
+	}
+	return records
+}
+
+func TestShipper(t *testing.T) {
+	t.Run("Batch", func(t *testing.T) {
+		sink := &memorySink{}
+		shipper := logsink.NewShipper(map[string]string{logsink.LabelDAG: "dag"})
+		shipper.Add(sink, logsink.Options{
+			Name:          "memory",
+			Labels:        map[string]string{"env": "test"},
+			BatchSize:     2,
+			FlushInterval: time.Hour,
+		})
+		for i := range 5 {
+			shipper.Ship(logsink.Record{Message: strconv.Itoa(i), Labels: map[string]string{logsink.LabelStep: "step"}})
+		}
+		require.NoError(t, shipper.Close(context.Background()))
+
+		records := sink.records()
+		require.Len(t, records, 5)
+		require.Equal(t, "0", records[0].Message)
+		require.Equal(t, map[string]string{"dag": "dag", "env": "test", "step": "step"}, records[0].Labels)
+		require.False(t, records[0].Time.IsZero())
+		for _, b := range sink.batches {
+			require.LessOrEqual(t, len(b), 2)
+		}
+	})
+	t.Run("Retry", func(t *testing.T) {
+		sink := &memorySink{failures: 2}
+		shipper := logsink.NewShipper(nil)
+		shipper.Add(sink, logsink.Options{MaxRetries: 2, RetryInterval: time.Millisecond})
+		shipper.Ship(logsink.Record{Message: "hello"})
+		require.NoError(t, shipper.Close(context.Background()))
+		require.Len(t, sink.records(), 1)
+		require.Zero(t, shipper.Dropped())
+	})
+	t.Run("NeverBlocks", func(t *testing.T) {
+		sink := &memorySink{block: make(chan struct{})}
+		shipper := logsink.NewShipper(nil)
+		shipper.Add(sink, logsink.Options{Name: "slow", BufferSize: 2, BatchSize: 1})
+
+		start := time.Now()
+		for range 100 {
+			shipper.Ship(logsink.Record{Message: "hello"})
+		}
+		require.Less(t, time.Since(start), time.Second)
+		require.Greater(t, shipper.Dropped(), int64(90))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err := shipper.Close(ctx)
+		require.ErrorContains(t, err, "slow")
+		require.ErrorContains(t, err, "records dropped")
+	})
+	t.Run("ShipAfterClose", func(t *testing.T) {
+		sink := &memorySink{}
+		shipper := logsink.NewShipper(nil)
+		shipper.Add(sink, logsink.Options{})
+		require.NoError(t, shipper.Ship(logsink.Record{Message: "hello"}))
+		require.NoError(t, shipper.Close(context.Background()))
+
+		require.ErrorIs(t, shipper.Ship(logsink.Record{Message: "late"}), logsink.ErrClosed)
+		require.Len(t, sink.records(), 1)
+	})
+}
+
+func TestHandler(t *testing.T) {
+	sink := &memorySink{}
+	shipper := logsink.NewShipper(map[string]string{logsink.LabelRequestID: "req"})
+	shipper.Add(sink, logsink.Options{})
+
+	log := slog.New(shipper.Handler(slog.LevelInfo))
+	log.Debug("dropped")
+	log.With("step", "build").Error("Step failed", "err", errors.New("exit status 1"), slog.Group("retry", "count", 2))
+	log.Info("DAG execution finished", "status", "finished")
+	require.NoError(t, shipper.Close(context.Background()))
+
+	records := sink.records()
+	require.Len(t, records, 2)
+	require.Equal(t, "error", records[0].Level)
+	require.Equal(t, "Step failed", records[0].Message)
+	require.Equal(t, map[string]string{"request_id": "req", "source": "scheduler", "step": "build"}, records[0].Labels)
+	require.Equal(t, map[string]any{"err": "exit status 1", "retry.count": int64(2)}, records[0].Attrs)
+	require.Equal(t, "finished", records[1].Labels[logsink.LabelStatus])
+}
+
+func TestStepWriter(t *testing.T) {
+	sink := &memorySink{}
+	shipper := logsink.NewShipper(nil)
+	shipper.Add(sink, logsink.Options{})
+
+	w := shipper.StepWriter("build")
+	_, err := w.Write([]byte("first\n{\"level\":\"error\",\"msg\":\"boom\"}\r\nla"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("st"))
+	require.NoError(t, err)
+	w.Finish("failed")
+	require.NoError(t, shipper.Close(context.Background()))
+
+	records := sink.records()
+	require.Len(t, records, 4)
+	require.Equal(t, "first", records[0].Message)
+	require.Equal(t, "", records[0].Level)
+	require.Equal(t, map[string]string{"source": "step", "step": "build", "status": "running"}, records[0].Labels)
+	require.Equal(t, "error", records[1].Level)
+	require.Equal(t, "last", records[2].Message)
+
+	// The final status of the step is shipped when it finishes.
+	require.Equal(t, "Step execution finished", records[3].Message)
+	require.Equal(t, "error", records[3].Level)
+	require.Equal(t, map[string]string{"source": "step", "step": "build", "status": "failed"}, records[3].Labels)
+}
+
+var testRecord = logsink.Record{
+	Time:    time.Date(2024, 2, 1, 9, 0, 0, 0, time.UTC),
+	Level:   "error",
+	Message: "Step failed",
+	Labels: map[string]string{
+		logsink.LabelDAG:    "etl",
+		logsink.LabelStep:   "load",
+		logsink.LabelSource: logsink.SourceScheduler,
+	},
+	Attrs: map[string]any{"err": "exit status 1"},
+}
+
+func TestSyslog(t *testing.T) {
+	t.Run("UDP", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer conn.Close()
+
+		sink, err := logsink.NewSyslog(logsink.SyslogConfig{Address: conn.LocalAddr().String(), Facility: "local0"})
+		require.NoError(t, err)
+		defer sink.Close()
+		require.NoError(t, sink.Send(context.Background(), []logsink.Record{testRecord}))
+
+		buf := make([]byte, 2048)
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		msg := string(buf[:n])
+		// local0 (16) * 8 + error (3)
+		require.True(t, strings.HasPrefix(msg, "<131>1 2024-02-01T09:00:00.000000Z "), msg)
+		require.Contains(t, msg, " dagu "+strconv.Itoa(os.Getpid())+" scheduler ")
+		require.True(t, strings.HasSuffix(msg, ` [dagu@32473 dag="etl" step="load"] Step failed err="exit status 1"`), msg)
+	})
+	t.Run("TCP", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer ln.Close()
+
+		received := make(chan []string, 1)
+		go func() {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			r := bufio.NewReader(conn)
+			var msgs []string
+			for range 2 {
+				size, err := r.ReadString(' ')
+				if err != nil {
+					break
+				}
+				n, _ := strconv.Atoi(strings.TrimSpace(size))
+				buf := make([]byte, n)
+				if _, err := r.Read(buf); err != nil {
+					break
+				}
+				msgs = append(msgs, string(buf))
+			}
+			received <- msgs
+		}()
+
+		sink, err := logsink.NewSyslog(logsink.SyslogConfig{Network: "tcp", Address: ln.Addr().String()})
+		require.NoError(t, err)
+		defer sink.Close()
+		second := testRecord
+		second.Level = ""
+		second.Message = "done"
+		second.Attrs = nil
+		require.NoError(t, sink.Send(context.Background(), []logsink.Record{testRecord, second}))
+
+		select {
+		case msgs := <-received:
+			require.Len(t, msgs, 2)
+			require.True(t, strings.HasPrefix(msgs[0], "<11>1 "), msgs[0])
+			// user (1) * 8 + informational (6)
+			require.True(t, strings.HasPrefix(msgs[1], "<14>1 "), msgs[1])
+			require.True(t, strings.HasSuffix(msgs[1], "] done"), msgs[1])
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout")
+		}
+	})
+	t.Run("InvalidConfig", func(t *testing.T) {
+		_, err := logsink.NewSyslog(logsink.SyslogConfig{Address: "localhost:514", Network: "unix"})
+		require.Error(t, err)
+		_, err = logsink.NewSyslog(logsink.SyslogConfig{Address: "localhost:514", Facility: "nope"})
+		require.Error(t, err)
+	})
+}
+
+func TestLoki(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+		body     struct {
+			Streams []struct {
+				Stream map[string]string `json:"stream"`
+				Values [][2]string       `json:"values"`
+			} `json:"streams"`
+		}
+		status = http.StatusNoContent
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		require.Equal(t, "tenant", r.Header.Get("X-Scope-OrgID"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	sink, err := logsink.NewLoki(logsink.LokiConfig{URL: srv.URL, Headers: map[string]string{"x-scope-orgid": "tenant"}})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	other := testRecord
+	other.Labels = map[string]string{logsink.LabelDAG: "etl", logsink.LabelStep: "extract"}
+	require.NoError(t, sink.Send(context.Background(), []logsink.Record{testRecord, testRecord, other}))
+	require.Len(t, body.Streams, 2)
+	require.Equal(t, map[string]string{"dag": "etl", "step": "load", "source": "scheduler", "level": "error"}, body.Streams[0].Stream)
+	require.Len(t, body.Streams[0].Values, 2)
+	require.Equal(t, [2]string{"1706778000000000000", `Step failed err="exit status 1"`}, body.Streams[0].Values[0])
+
+	// The rejected batches are not retried.
+	status = http.StatusBadRequest
+	shipper := logsink.NewShipper(nil)
+	shipper.Add(sink, logsink.Options{Name: "loki", RetryInterval: time.Millisecond})
+	shipper.Ship(testRecord)
+	require.ErrorContains(t, shipper.Close(context.Background()), "400 Bad Request")
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 2, requests)
+}
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "dagu.jsonl")
+	sink, err := logsink.NewFile(logsink.FileConfig{Path: path, MaxSize: 300, MaxBackups: 2})
+	require.NoError(t, err)
+	defer sink.Close()
+
+	require.NoError(t, sink.Send(context.Background(), []logsink.Record{testRecord}))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(data, &line))
+	require.Equal(t, map[string]any{
+		"time":   "2024-02-01T09:00:00Z",
+		"level":  "error",
+		"msg":    "Step failed",
+		"dag":    "etl",
+		"step":   "load",
+		"source": "scheduler",
+		"attrs":  map[string]any{"err": "exit status 1"},
+	}, line)
+
+	// The file is rotated beyond the maximum size, keeping two backups.
+	for range 5 {
+		require.NoError(t, sink.Send(context.Background(), []logsink.Record{testRecord, testRecord}))
+	}
+	require.FileExists(t, path+".1")
+	require.FileExists(t, path+".2")
+	require.NoFileExists(t, path+".3")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.LessOrEqual(t, info.Size(), int64(300))
+
+	// The file rotated by another process is reopened.
+	require.NoError(t, os.Rename(path, path+".moved"))
+	require.NoError(t, sink.Send(context.Background(), []logsink.Record{testRecord}))
+	require.FileExists(t, path)
+}
+
+func TestFile_Processes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dagu.jsonl")
+
+	// Measure the size of a line.
+	sink, err := logsink.NewFile(logsink.FileConfig{Path: path + ".size"})
+	require.NoError(t, err)
+	require.NoError(t, sink.Send(context.Background(), []logsink.Record{testRecord}))
+	require.NoError(t, sink.Close())
+	info, err := os.Stat(path + ".size")
+	require.NoError(t, err)
+	lineSize := info.Size()
+
+	// The sinks stand in for the processes writing to the same file. The
+	// size is checked and the file rotated under the lock, so no line is
+	// lost and no file is rotated twice.
+	const sinks, batches = 8, 100
+	maxSize := lineSize * 5
+	var wg sync.WaitGroup
+	for range sinks {
+		sink, err := logsink.NewFile(logsink.FileConfig{Path: path, MaxSize: maxSize, MaxBackups: sinks * batches / 5})
+		require.NoError(t, err)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer sink.Close()
+			for range batches {
+				require.NoError(t, sink.Send(context.Background(), []logsink.Record{testRecord}))
+			}
+		}()
+	}
+	wg.Wait()
+
+	files, err := filepath.Glob(path + "*")
+	require.NoError(t, err)
+	var lines int
+	for _, file := range files {
+		if strings.HasSuffix(file, ".lock") || strings.HasSuffix(file, ".size") {
+			continue
+		}
+		if file != path {
+			info, err := os.Stat(file)
+			require.NoError(t, err)
+			require.Equal(t, maxSize, info.Size(), file)
+		}
+		f, err := os.Open(file)
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var v map[string]any
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &v), file)
+			lines++
+		}
+		_ = f.Close()
+	}
+	require.Equal(t, sinks*batches, lines)
+}
@@ -0,0 +1,143 @@
+package logsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LokiConfig is the configuration of the Loki sink.
+type LokiConfig struct {
+	// URL is the push endpoint, e.g. http://localhost:3100/loki/api/v1/push.
+	URL string
+	// Headers are added to the requests, e.g. X-Scope-OrgID or
+	// Authorization.
+	Headers map[string]string
+	// Timeout is the timeout of a request (default 10s).
+	Timeout time.Duration
+}
+
+// Loki pushes the records to the HTTP API of Loki, or a compatible server.
+// The labels of the records and their level are the labels of the streams.
+type Loki struct {
+	cfg    LokiConfig
+	client *http.Client
+}
+
+var _ Sink = (*Loki)(nil)
+
+// NewLoki returns the Loki sink.
+func NewLoki(cfg LokiConfig) (*Loki, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("loki url is required")
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	return &Loki{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}, nil
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+type lokiPush struct {
+	Streams []*lokiStream `json:"streams"`
+}
+
+// Send implements Sink.
+func (l *Loki) Send(ctx context.Context, records []Record) error {
+	var (
+		push    lokiPush
+		streams = make(map[string]*lokiStream)
+	)
+	for _, r := range records {
+		labels := make(map[string]string, len(r.Labels)+1)
+		for k, v := range r.Labels {
+			labels[lokiLabelName(k)] = v
+		}
+		if r.Level != "" {
+			labels["level"] = r.Level
+		}
+		key := lokiStreamKey(labels)
+		stream, ok := streams[key]
+		if !ok {
+			stream = &lokiStream{Stream: labels}
+			streams[key] = stream
+			push.Streams = append(push.Streams, stream)
+		}
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(r.Time.UnixNano(), 10),
+			r.text(),
+		})
+	}
+
+	body, err := json.Marshal(push)
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range l.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode/100 == 2 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("loki push failed: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	// The server rejects the same request on retries, except when it is
+	// overloaded.
+	if resp.StatusCode/100 == 4 && resp.StatusCode != http.StatusTooManyRequests {
+		return &permanentError{err: err}
+	}
+	return err
+}
+
+// Close implements Sink.
+func (l *Loki) Close() error {
+	l.client.CloseIdleConnections()
+	return nil
+}
+
+// lokiLabelName returns the name valid as a label of Loki, replacing the
+// invalid characters with underscores.
+func lokiLabelName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		valid := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (i > 0 && c >= '0' && c <= '9')
+		if !valid {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+func lokiStreamKey(labels map[string]string) string {
+	var b strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		fmt.Fprintf(&b, "%s=%q,", k, labels[k])
+	}
+	return b.String()
+}
@@ -0,0 +1,234 @@
+package logsink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SyslogConfig is the configuration of the syslog sink.
+type SyslogConfig struct {
+	// Network is either "udp" (default) or "tcp".
+	Network string
+	// Address is the address of the server, e.g. localhost:514.
+	Address string
+	// Facility is the facility of the messages, e.g. "daemon" (default
+	// "user").
+	Facility string
+	// AppName is the APP-NAME of the messages (default "dagu").
+	AppName string
+	// Timeout is the timeout to connect and write (default 5s).
+	Timeout time.Duration
+}
+
+// syslogFacilities are the facilities by name.
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5,
+	"lpr": 6, "news": 7, "uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// syslogSeverities are the severities by level. The other levels are
+// informational.
+var syslogSeverities = map[string]int{
+	"fatal": 2,
+	"error": 3,
+	"warn":  4,
+	"debug": 7,
+	"trace": 7,
+}
+
+const syslogInformational = 6
+
+// syslogSDID is the ID of the structured data holding the labels. 32473 is
+// the private enterprise number reserved for documentation by RFC 5612.
+const syslogSDID = "dagu@32473"
+
+// Syslog sends the records to a syslog server in the RFC 5424 format. The
+// messages are framed by the octet counting of RFC 6587 over TCP.
+type Syslog struct {
+	cfg      SyslogConfig
+	facility int
+	hostname string
+	pid      string
+
+	conn net.Conn
+}
+
+var _ Sink = (*Syslog)(nil)
+
+// NewSyslog returns the syslog sink. It connects to the server on the
+// first batch.
+func NewSyslog(cfg SyslogConfig) (*Syslog, error) {
+	if cfg.Network == "" {
+		cfg.Network = "udp"
+	}
+	if cfg.Network != "udp" && cfg.Network != "tcp" {
+		return nil, fmt.Errorf("invalid syslog network: %s", cfg.Network)
+	}
+	if cfg.Address == "" {
+		return nil, fmt.Errorf("syslog address is required")
+	}
+	if cfg.Facility == "" {
+		cfg.Facility = "user"
+	}
+	facility, ok := syslogFacilities[cfg.Facility]
+	if !ok {
+		return nil, fmt.Errorf("invalid syslog facility: %s", cfg.Facility)
+	}
+	if cfg.AppName == "" {
+		cfg.AppName = "dagu"
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &Syslog{
+		cfg:      cfg,
+		facility: facility,
+		hostname: hostname,
+		pid:      strconv.Itoa(os.Getpid()),
+	}, nil
+}
+
+// Send implements Sink. Each record is sent in a datagram over UDP.
+func (s *Syslog) Send(ctx context.Context, records []Record) error {
+	if s.conn == nil {
+		dialer := net.Dialer{Timeout: s.cfg.Timeout}
+		conn, err := dialer.DialContext(ctx, s.cfg.Network, s.cfg.Address)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(s.cfg.Timeout)); err != nil {
+		return s.reset(err)
+	}
+
+	if s.cfg.Network == "udp" {
+		for _, r := range records {
+			if _, err := s.conn.Write(s.format(r)); err != nil {
+				return s.reset(err)
+			}
+		}
+		return nil
+	}
+
+	var buf bytes.Buffer
+	for _, r := range records {
+		msg := s.format(r)
+		buf.WriteString(strconv.Itoa(len(msg)))
+		buf.WriteByte(' ')
+		buf.Write(msg)
+	}
+	if _, err := s.conn.Write(buf.Bytes()); err != nil {
+		return s.reset(err)
+	}
+	return nil
+}
+
+// reset closes the connection to reconnect on the next batch.
+func (s *Syslog) reset(err error) error {
+	_ = s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// Close implements Sink.
+func (s *Syslog) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// format returns the record in the RFC 5424 format:
+//
+//	<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID [SD-ID LABELS] MSG
+func (s *Syslog) format(r Record) []byte {
+	severity, ok := syslogSeverities[r.Level]
+	if !ok {
+		severity = syslogInformational
+	}
+	msgID := r.Labels[LabelSource]
+	if msgID == "" {
+		msgID = "-"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<%d>1 %s %s %s %s %s ",
+		s.facility*8+severity,
+		r.Time.Format("2006-01-02T15:04:05.000000Z07:00"),
+		syslogHeader(s.hostname, 255),
+		syslogHeader(s.cfg.AppName, 48),
+		s.pid,
+		syslogHeader(msgID, 32),
+	)
+	b.WriteString(syslogStructuredData(r.Labels))
+	b.WriteByte(' ')
+	b.WriteString(r.text())
+	return b.Bytes()
+}
+
+// syslogHeader returns the value of a header field: printable ASCII without
+// spaces up to the length.
+func syslogHeader(s string, n int) string {
+	s = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return '_'
+		}
+		return r
+	}, s)
+	if len(s) > n {
+		s = s[:n]
+	}
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// syslogStructuredData returns the labels as the structured data, without
+// the source given as the MSGID.
+func syslogStructuredData(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		if k != LabelSource {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return "-"
+	}
+	slices.Sort(keys)
+
+	var b strings.Builder
+	b.WriteString("[" + syslogSDID)
+	for _, k := range keys {
+		name := strings.Map(func(r rune) rune {
+			if r <= ' ' || r > '~' || r == '=' || r == ']' || r == '"' {
+				return '_'
+			}
+			return r
+		}, k)
+		if len(name) > 32 {
+			name = name[:32]
+		}
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(labels[k])
+		fmt.Fprintf(&b, ` %s="%s"`, name, value)
+	}
+	b.WriteString("]")
+	return b.String()
+}
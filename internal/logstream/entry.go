@@ -0,0 +1,219 @@
+package logstream
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The levels of the entries of the JSON logs, from the least severe.
+const (
+	LevelTrace = "trace"
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+	LevelFatal = "fatal"
+)
+
+// levelRanks are the severities of the levels. The other levels have no
+// severity.
+var levelRanks = map[string]int{
+	LevelTrace: 1,
+	LevelDebug: 2,
+	LevelInfo:  3,
+	LevelWarn:  4,
+	LevelError: 5,
+	LevelFatal: 6,
+}
+
+// numericLevels are the levels written as numbers, e.g. by pino or bunyan.
+var numericLevels = map[int]string{
+	10: LevelTrace,
+	20: LevelDebug,
+	30: LevelInfo,
+	40: LevelWarn,
+	50: LevelError,
+	60: LevelFatal,
+}
+
+// The keys of the time, the level and the message in the JSON logs, in the
+// order of precedence.
+var (
+	timeKeys    = []string{"time", "ts", "timestamp", "@timestamp"}
+	levelKeys   = []string{"level", "lvl", "severity", "log.level"}
+	messageKeys = []string{"msg", "message", "@message"}
+)
+
+// Entry is a line of the log written in JSON.
+type Entry struct {
+	// Time is the time of the entry, or zero if it has none.
+	Time time.Time `json:"time,omitempty"`
+	// Level is the level of the entry in lower case, e.g. "error".
+	Level string `json:"level,omitempty"`
+	// Message is the message of the entry.
+	Message string `json:"message,omitempty"`
+	// Fields are the other keys and values of the entry.
+	Fields map[string]any `json:"fields,omitempty"`
+}
+
+// IsError reports whether the entry is at the error level or above.
+func (e Entry) IsError() bool {
+	return levelRanks[e.Level] >= levelRanks[LevelError]
+}
+
+// ParseEntry parses the line of the log written in JSON. It returns false
+// if the line is not a JSON object, e.g. a line of plain text.
+func ParseEntry(text string) (Entry, bool) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "{") {
+		return Entry{}, false
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(text), &fields); err != nil {
+		return Entry{}, false
+	}
+
+	var e Entry
+	if key, v, ok := lookup(fields, timeKeys); ok {
+		if t, ok := parseTime(v); ok {
+			e.Time = t
+			delete(fields, key)
+		}
+	}
+	if key, v, ok := lookup(fields, levelKeys); ok {
+		e.Level = normalizeLevel(v)
+		delete(fields, key)
+	}
+	if key, v, ok := lookup(fields, messageKeys); ok {
+		if s, ok := v.(string); ok {
+			e.Message = s
+		} else {
+			e.Message = fmt.Sprint(v)
+		}
+		delete(fields, key)
+	}
+	if len(fields) > 0 {
+		e.Fields = fields
+	}
+	return e, true
+}
+
+func lookup(fields map[string]any, keys []string) (string, any, bool) {
+	for _, key := range keys {
+		if v, ok := fields[key]; ok {
+			return key, v, true
+		}
+	}
+	return "", nil, false
+}
+
+func parseTime(v any) (time.Time, bool) {
+	switch v := v.(type) {
+	case string:
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t, err == nil
+	case float64:
+		// Seconds or milliseconds since the epoch.
+		if v > 1e12 {
+			return time.UnixMilli(int64(v)), true
+		}
+		sec := int64(v)
+		return time.Unix(sec, int64((v-float64(sec))*1e9)), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+// normalizeLevel returns the level in lower case, replacing the aliases
+// written by the common loggers with the level.
+func normalizeLevel(v any) string {
+	switch v := v.(type) {
+	case string:
+		level := strings.ToLower(strings.TrimSpace(v))
+		switch level {
+		case "warning":
+			return LevelWarn
+		case "err":
+			return LevelError
+		case "critical", "crit", "panic":
+			return LevelFatal
+		}
+		return level
+	case float64:
+		if level, ok := numericLevels[int(v)]; ok {
+			return level
+		}
+		return fmt.Sprint(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// EntryFilter selects the entries of the JSON logs by level and fields.
+type EntryFilter struct {
+	// level is the minimum level of the entries.
+	level string
+	// fields are the values of the fields the entries must have. The field
+	// only needs to exist if the value is nil.
+	fields map[string]*string
+}
+
+// NewEntryFilter returns the filter of the entries at the level or above
+// with all the fields. A field is either "key=value" or "key" to only
+// require the key. It returns nil if neither is given.
+func NewEntryFilter(level string, fields []string) (*EntryFilter, error) {
+	if level == "" && len(fields) == 0 {
+		return nil, nil
+	}
+	f := &EntryFilter{fields: make(map[string]*string)}
+	if level != "" {
+		f.level = normalizeLevel(level)
+		if _, ok := levelRanks[f.level]; !ok {
+			return nil, fmt.Errorf("unknown level: %s", level)
+		}
+	}
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, "=")
+		if key == "" {
+			return nil, fmt.Errorf("invalid field: %q", field)
+		}
+		if ok {
+			f.fields[key] = &value
+		} else {
+			f.fields[key] = nil
+		}
+	}
+	return f, nil
+}
+
+// Match parses the line and reports whether it passes the filter. The
+// lines which are not JSON never pass.
+func (f *EntryFilter) Match(text string) (Entry, bool) {
+	e, ok := ParseEntry(text)
+	if !ok {
+		return e, false
+	}
+	if f.level != "" && levelRanks[e.Level] < levelRanks[f.level] {
+		return e, false
+	}
+	for key, want := range f.fields {
+		v, ok := e.Fields[key]
+		if !ok {
+			return e, false
+		}
+		if want == nil {
+			continue
+		}
+		got, ok := v.(string)
+		if !ok {
+			b, _ := json.Marshal(v)
+			got = string(b)
+		}
+		if got != *want {
+			return e, false
+		}
+	}
+	return e, true
+}
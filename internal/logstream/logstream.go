@@ -0,0 +1,216 @@
+// Package logstream follows the log files written by the running DAGs and
+// streams the lines as they are written.
+package logstream
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logfile"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// defaultPollInterval is the interval to check the file for new lines.
+const defaultPollInterval = 500 * time.Millisecond
+
+// maxLineLength is the length of a line after which it is streamed without
+// waiting for the end of the line.
+const maxLineLength = 64 * 1024
+
+// Line is a line of the log file.
+type Line struct {
+	// Offset is the offset in the file right after the line. Following the
+	// file from the offset resumes with the next line.
+	Offset int64
+	// Text is the line without the line break.
+	Text string
+}
+
+// Options is the options to follow the file.
+type Options struct {
+	// Offset is the offset in the file to start from.
+	Offset int64
+	// Done reports whether the file has been written completely, e.g. the
+	// run writing it has finished. Following stops at the end of the file
+	// once it returns true. The file is followed until the context is
+	// canceled if it is nil.
+	Done func() bool
+	// PollInterval is the interval to check the file for new lines.
+	PollInterval time.Duration
+}
+
+// Follow calls fn for each line of the file from the offset, waiting for
+// the lines to be written until the context is canceled or the file has
+// been written completely. The file may not exist yet when it is called.
+func Follow(ctx context.Context, file string, opts Options, fn func(Line) error) error {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	f := &follower{file: file, offset: opts.Offset, fn: fn}
+	defer f.close()
+
+	// The file is read once more after it has been written completely so
+	// that the lines written right before are not lost.
+	var done bool
+	for {
+		if err := f.read(); err != nil {
+			return err
+		}
+		if done {
+			return f.flush()
+		}
+		if opts.Done != nil && opts.Done() {
+			done = true
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Source returns the log file of a run and whether the run has finished.
+// The file is empty while it is not known yet, e.g. the step has not
+// started.
+type Source func() (file string, done bool)
+
+// FollowSource waits for the log file of the source to be known and
+// follows it until the run has finished. The Done option is ignored.
+func FollowSource(ctx context.Context, src Source, opts Options, fn func(Line) error) error {
+	interval := opts.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	file, done := src()
+	for file == "" {
+		if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+		file, done = src()
+	}
+
+	opts.Done = func() bool {
+		_, done := src()
+		return done
+	}
+	return Follow(ctx, file, opts, fn)
+}
+
+// NewDecoder returns the decoder of the logs written in the charset, or nil
+// if the logs are in UTF-8.
+func NewDecoder(charset string) *encoding.Decoder {
+	if strings.ToLower(charset) == "euc-jp" {
+		return japanese.EUCJP.NewDecoder()
+	}
+	return nil
+}
+
+type follower struct {
+	file string
+	fn   func(Line) error
+	rc   io.ReadCloser
+	// f is the file being read, or nil if the compressed log is read.
+	f      *os.File
+	r      *bufio.Reader
+	offset int64
+	// partial is the last line read without the line break yet.
+	partial []byte
+}
+
+// read reads the lines written since the last read.
+func (f *follower) read() error {
+	if f.rc == nil {
+		// The log of the finished run may have been compressed.
+		rc, err := logfile.Open(f.file)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if file, ok := rc.(*os.File); ok {
+			_, err = file.Seek(f.offset, io.SeekStart)
+			f.f = file
+		} else if _, err = io.CopyN(io.Discard, rc, f.offset); errors.Is(err, io.EOF) {
+			err = nil
+		}
+		if err != nil {
+			_ = rc.Close()
+			return err
+		}
+		f.rc = rc
+		f.r = bufio.NewReader(rc)
+	}
+
+	// Start over if the file has been truncated. The compressed log is not
+	// written anymore.
+	if f.f != nil {
+		if info, err := f.f.Stat(); err == nil && info.Size() < f.offset+int64(len(f.partial)) {
+			if _, err := f.f.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			f.r.Reset(f.f)
+			f.offset, f.partial = 0, nil
+		}
+	}
+
+	for {
+		chunk, err := f.r.ReadSlice('\n')
+		f.partial = append(f.partial, chunk...)
+		switch {
+		case err == nil:
+			if err := f.emit(); err != nil {
+				return err
+			}
+		case errors.Is(err, bufio.ErrBufferFull):
+			if len(f.partial) >= maxLineLength {
+				if err := f.emit(); err != nil {
+					return err
+				}
+			}
+		case errors.Is(err, io.EOF):
+			return nil
+		default:
+			return err
+		}
+	}
+}
+
+// flush emits the last line without the line break.
+func (f *follower) flush() error {
+	if len(f.partial) == 0 {
+		return nil
+	}
+	return f.emit()
+}
+
+func (f *follower) emit() error {
+	f.offset += int64(len(f.partial))
+	text := bytes.TrimRight(f.partial, "\r\n")
+	f.partial = f.partial[:0]
+	return f.fn(Line{Offset: f.offset, Text: string(text)})
+}
+
+func (f *follower) close() {
+	if f.rc != nil {
+		_ = f.rc.Close()
+	}
+}
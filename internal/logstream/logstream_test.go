@@ -0,0 +1,159 @@
+package logstream
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logfile"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFollow(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "step.log")
+
+	t.Run("Written", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(file, []byte("first\r\nsecond\nthird"), 0600))
+
+		var lines []Line
+		err := Follow(context.Background(), file, Options{Done: func() bool { return true }}, func(l Line) error {
+			lines = append(lines, l)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []Line{{Offset: 7, Text: "first"}, {Offset: 14, Text: "second"}, {Offset: 19, Text: "third"}}, lines)
+
+		// Resume from the offset of the first line.
+		lines = nil
+		err = Follow(context.Background(), file, Options{Offset: 7, Done: func() bool { return true }}, func(l Line) error {
+			lines = append(lines, l)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []Line{{Offset: 14, Text: "second"}, {Offset: 19, Text: "third"}}, lines)
+	})
+	t.Run("Writing", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "step.log")
+
+		var done atomic.Bool
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			f, err := os.Create(file)
+			if err != nil {
+				return
+			}
+			_, _ = f.WriteString("first\nsec")
+			time.Sleep(50 * time.Millisecond)
+			_, _ = f.WriteString("ond\n")
+			_ = f.Close()
+			done.Store(true)
+		}()
+
+		var texts []string
+		err := Follow(context.Background(), file, Options{Done: done.Load, PollInterval: 10 * time.Millisecond}, func(l Line) error {
+			texts = append(texts, l.Text)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"first", "second"}, texts)
+	})
+	t.Run("Compressed", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "step.log")
+		require.NoError(t, os.WriteFile(file, []byte("first\nsecond\n"), 0600))
+		require.NoError(t, logfile.Compress(file))
+
+		var lines []Line
+		err := Follow(context.Background(), file, Options{Offset: 6, Done: func() bool { return true }}, func(l Line) error {
+			lines = append(lines, l)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []Line{{Offset: 13, Text: "second"}}, lines)
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := Follow(ctx, file, Options{PollInterval: 10 * time.Millisecond}, func(Line) error { return nil })
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestFollowSource(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "step.log")
+	require.NoError(t, os.WriteFile(file, []byte("first\nsecond\n"), 0600))
+
+	t.Run("Started", func(t *testing.T) {
+		// The log file is known after the step has started.
+		var calls int
+		src := func() (string, bool) {
+			calls++
+			if calls < 3 {
+				return "", false
+			}
+			return file, true
+		}
+
+		var texts []string
+		err := FollowSource(context.Background(), src, Options{PollInterval: 10 * time.Millisecond}, func(l Line) error {
+			texts = append(texts, l.Text)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"first", "second"}, texts)
+	})
+	t.Run("NotStarted", func(t *testing.T) {
+		err := FollowSource(context.Background(), func() (string, bool) { return "", true }, Options{}, func(Line) error {
+			t.Fatal("unexpected line")
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestParseEntry(t *testing.T) {
+	e, ok := ParseEntry(`{"time":"2024-02-11T12:00:00Z","level":"ERROR","msg":"failed","user":"alice","code":500}`)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2024, 2, 11, 12, 0, 0, 0, time.UTC), e.Time)
+	require.Equal(t, LevelError, e.Level)
+	require.Equal(t, "failed", e.Message)
+	require.Equal(t, map[string]any{"user": "alice", "code": float64(500)}, e.Fields)
+	require.True(t, e.IsError())
+
+	// The numeric levels and the aliases of the keys.
+	e, ok = ParseEntry(`{"level":40,"message":"slow"}`)
+	require.True(t, ok)
+	require.Equal(t, LevelWarn, e.Level)
+	require.Equal(t, "slow", e.Message)
+	require.False(t, e.IsError())
+
+	_, ok = ParseEntry("plain text")
+	require.False(t, ok)
+}
+
+func TestEntryFilter(t *testing.T) {
+	f, err := NewEntryFilter("warn", []string{"user=alice", "code"})
+	require.NoError(t, err)
+
+	for text, want := range map[string]bool{
+		`{"level":"error","msg":"a","user":"alice","code":500}`: true,
+		`{"level":"warning","user":"alice","code":1}`:           true,
+		`{"level":"info","user":"alice","code":500}`:            false,
+		`{"level":"error","user":"bob","code":500}`:             false,
+		`{"level":"error","user":"alice"}`:                      false,
+		"plain text":                                            false,
+	} {
+		_, ok := f.Match(text)
+		require.Equal(t, want, ok, text)
+	}
+
+	f, err = NewEntryFilter("", nil)
+	require.NoError(t, err)
+	require.Nil(t, f)
+
+	_, err = NewEntryFilter("loud", nil)
+	require.Error(t, err)
+}
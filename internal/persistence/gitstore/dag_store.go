@@ -0,0 +1,359 @@
+// Package gitstore provides a DAG store that treats the DAGs directory as a
+// git working copy. Changes made through the store are committed and
+// optionally pushed, and changes of the remote are pulled periodically.
+package gitstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
+)
+
+var (
+	_ persistence.DAGStore          = (*DAGStore)(nil)
+	_ persistence.VersionedDAGStore = (*DAGStore)(nil)
+)
+
+// ErrConflicts is returned when the working copy has unresolved conflicts.
+var ErrConflicts = errors.New("the DAGs directory has unresolved git conflicts")
+
+const (
+	defaultRemote       = "origin"
+	defaultPullInterval = time.Minute
+	defaultAuthorName   = "dagu"
+	defaultAuthorEmail  = "dagu@localhost"
+
+	// commitCacheTTL is how long the last commits of the DAGs are cached
+	// to avoid running git log for every DAG in a list.
+	commitCacheTTL = 10 * time.Second
+)
+
+type Option func(*Options)
+
+type Options struct {
+	Remote       string
+	Branch       string
+	PullInterval time.Duration
+	Push         bool
+	AuthorName   string
+	AuthorEmail  string
+}
+
+// WithRemote sets the name of the remote to sync with.
+func WithRemote(remote string) Option {
+	return func(o *Options) {
+		o.Remote = remote
+	}
+}
+
+// WithBranch sets the remote branch to sync with.
+func WithBranch(branch string) Option {
+	return func(o *Options) {
+		o.Branch = branch
+	}
+}
+
+// WithPullInterval sets the interval to pull changes from the remote.
+func WithPullInterval(interval time.Duration) Option {
+	return func(o *Options) {
+		o.PullInterval = interval
+	}
+}
+
+// WithPush enables pushing commits to the remote.
+func WithPush(push bool) Option {
+	return func(o *Options) {
+		o.Push = push
+	}
+}
+
+// WithAuthor sets the identity of the committer.
+func WithAuthor(name, email string) Option {
+	return func(o *Options) {
+		o.AuthorName = name
+		o.AuthorEmail = email
+	}
+}
+
+// DAGStore wraps a DAG store to record the changes in git.
+type DAGStore struct {
+	persistence.DAGStore
+
+	baseDir      string
+	repo         *repository
+	push         bool
+	pullInterval time.Duration
+
+	cacheMu      sync.Mutex
+	commits      map[string]string
+	commitsValid time.Time
+}
+
+// New creates a DAG store using dir as a git working copy. The store
+// delegates reading and writing the files to the given store.
+func New(ctx context.Context, dir string, store persistence.DAGStore, opts ...Option) (*DAGStore, error) {
+	options := &Options{
+		Remote:       defaultRemote,
+		PullInterval: defaultPullInterval,
+		AuthorName:   defaultAuthorName,
+		AuthorEmail:  defaultAuthorEmail,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	baseDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := openRepository(ctx, baseDir, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DAGStore{
+		DAGStore:     store,
+		baseDir:      baseDir,
+		repo:         repo,
+		push:         options.Push,
+		pullInterval: options.PullInterval,
+	}, nil
+}
+
+// Start pulls changes from the remote periodically until the context is
+// canceled.
+func (s *DAGStore) Start(ctx context.Context) {
+	if !s.repo.hasRemote(ctx) {
+		logger.Warn(ctx, "Git sync is disabled because the remote is not configured", "remote", s.repo.remote)
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.pullInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.Sync(ctx); err != nil {
+				logger.Error(ctx, "Failed to sync the DAGs directory", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Sync pulls changes from the remote and pushes the local commits if
+// pushing is enabled. It does nothing while the working copy has conflicts.
+func (s *DAGStore) Sync(ctx context.Context) error {
+	unlock, err := s.repo.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.checkConflicts(ctx); err != nil {
+		return err
+	}
+
+	err = s.repo.pull(ctx)
+	s.invalidateCommits()
+	if err != nil {
+		return fmt.Errorf("failed to pull from %s: %w", s.repo.remote, err)
+	}
+
+	if !s.push {
+		return nil
+	}
+	ahead, err := s.repo.ahead(ctx)
+	if err != nil {
+		return err
+	}
+	if ahead > 0 {
+		if err := s.repo.push(ctx); err != nil {
+			return fmt.Errorf("failed to push to %s: %w", s.repo.remote, err)
+		}
+	}
+	return nil
+}
+
+// UpdateSpec updates the spec and commits the change.
+func (s *DAGStore) UpdateSpec(ctx context.Context, name string, spec []byte, info persistence.RevisionInfo) error {
+	unlock, err := s.repo.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.checkConflicts(ctx); err != nil {
+		return err
+	}
+	if err := s.DAGStore.UpdateSpec(ctx, name, spec, info); err != nil {
+		return err
+	}
+
+	message := info.Message
+	if message == "" {
+		message = "Update " + name
+	}
+	return s.commit(ctx, []string{s.filePath(name)}, message, info.Author)
+}
+
+// Create creates a DAG and commits it.
+func (s *DAGStore) Create(ctx context.Context, name string, spec []byte) (string, error) {
+	unlock, err := s.repo.lock()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	if err := s.checkConflicts(ctx); err != nil {
+		return "", err
+	}
+	id, err := s.DAGStore.Create(ctx, name, spec)
+	if err != nil {
+		return "", err
+	}
+	if err := s.commit(ctx, []string{s.filePath(name)}, "Create "+name, ""); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Delete deletes a DAG and commits the removal.
+func (s *DAGStore) Delete(ctx context.Context, name string) error {
+	unlock, err := s.repo.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.checkConflicts(ctx); err != nil {
+		return err
+	}
+	filePath := s.filePath(name)
+	if err := s.DAGStore.Delete(ctx, name); err != nil {
+		return err
+	}
+	return s.commit(ctx, []string{filePath}, "Delete "+name, "")
+}
+
+// Rename renames a DAG and commits the change.
+func (s *DAGStore) Rename(ctx context.Context, oldID, newID string) error {
+	unlock, err := s.repo.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := s.checkConflicts(ctx); err != nil {
+		return err
+	}
+	oldPath := s.filePath(oldID)
+	if err := s.DAGStore.Rename(ctx, oldID, newID); err != nil {
+		return err
+	}
+	return s.commit(ctx, []string{oldPath, s.filePath(newID)}, fmt.Sprintf("Rename %s to %s", oldID, newID), "")
+}
+
+// CommitSHA returns the last commit that changed the DAG. It returns an
+// empty string if the DAG has not been committed.
+func (s *DAGStore) CommitSHA(ctx context.Context, name string) (string, error) {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+
+	if s.commits == nil || time.Since(s.commitsValid) > commitCacheTTL {
+		commits, err := s.repo.lastCommits(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the git history: %w", err)
+		}
+		s.commits = commits
+		s.commitsValid = time.Now()
+	}
+
+	rel, err := filepath.Rel(s.baseDir, s.filePath(name))
+	if err != nil {
+		return "", nil
+	}
+	return s.commits[filepath.ToSlash(rel)], nil
+}
+
+func (s *DAGStore) checkConflicts(ctx context.Context) error {
+	conflicts, err := s.repo.hasConflicts(ctx)
+	if err != nil {
+		return err
+	}
+	if conflicts {
+		return ErrConflicts
+	}
+	return nil
+}
+
+// commit commits the changes of the files and pushes them if enabled.
+// Failing to push is not an error since the commit is pushed on next sync.
+func (s *DAGStore) commit(ctx context.Context, files []string, message, author string) error {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		rel, err := filepath.Rel(s.baseDir, file)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			// The file is outside of the working copy.
+			continue
+		}
+		paths = append(paths, rel)
+	}
+
+	committed, err := s.repo.commit(ctx, paths, message, author)
+	s.invalidateCommits()
+	if err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	if !committed || !s.push {
+		return nil
+	}
+	if err := s.repo.push(ctx); err != nil {
+		logger.Warn(ctx, "Failed to push the commit", "remote", s.repo.remote, "err", err)
+	}
+	return nil
+}
+
+func (s *DAGStore) invalidateCommits() {
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	s.commits = nil
+}
+
+// filePath returns the path of the DAG file whether it exists or not. The
+// name is either a path to the file or the ID of the DAG in the directory,
+// e.g. "team-a/etl".
+func (s *DAGStore) filePath(name string) string {
+	if filepath.IsAbs(name) {
+		return filepath.Clean(name)
+	}
+	if strings.Contains(name, string(filepath.Separator)) && fileutil.FileExists(name) {
+		if filePath, err := filepath.Abs(name); err == nil {
+			return filePath
+		}
+	}
+	base := filepath.Join(s.baseDir, name)
+	for _, ext := range fileutil.ValidYAMLExtensions {
+		if strings.HasSuffix(base, ext) {
+			return base
+		}
+	}
+	for _, ext := range fileutil.ValidYAMLExtensions {
+		if fileutil.FileExists(base + ext) {
+			return base + ext
+		}
+	}
+	return fileutil.EnsureYAMLExtension(base)
+}
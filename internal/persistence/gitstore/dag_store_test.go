@@ -0,0 +1,174 @@
+package gitstore
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/local"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `steps:
+  - name: step1
+    command: echo hello
+`
+
+func TestDAGStore(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+
+	// The remote is a local bare repository with a DAG pushed by other user.
+	remote := filepath.Join(tmpDir, "remote.git")
+	other := filepath.Join(tmpDir, "other")
+	runGit(t, tmpDir, "init", "--quiet", "--bare", "--initial-branch=main", remote)
+	runGit(t, tmpDir, "clone", "--quiet", remote, other)
+	writeFile(t, filepath.Join(other, "existing.yaml"), testSpec)
+	runGit(t, other, "add", ".")
+	runGit(t, other, "commit", "--quiet", "-m", "Add existing")
+	runGit(t, other, "push", "--quiet", "origin", "HEAD:main")
+
+	dagsDir := filepath.Join(tmpDir, "dags")
+	runGit(t, tmpDir, "clone", "--quiet", remote, dagsDir)
+
+	store, err := New(ctx, dagsDir, local.NewDAGStore(dagsDir), WithPush(true), WithAuthor("dagu", "dagu@example.com"))
+	require.NoError(t, err)
+
+	t.Run("CommitSHA", func(t *testing.T) {
+		sha, err := store.CommitSHA(ctx, "existing")
+		require.NoError(t, err)
+		require.Equal(t, runGit(t, dagsDir, "rev-parse", "HEAD"), sha)
+
+		sha, err = store.CommitSHA(ctx, "unknown")
+		require.NoError(t, err)
+		require.Empty(t, sha)
+	})
+	t.Run("Create", func(t *testing.T) {
+		_, err := store.Create(ctx, "created", []byte(testSpec))
+		require.NoError(t, err)
+
+		require.Equal(t, "Create created", runGit(t, dagsDir, "log", "-1", "--format=%s"))
+		require.Equal(t, "dagu", runGit(t, dagsDir, "log", "-1", "--format=%cn"))
+
+		// The commit is pushed to the remote.
+		sha, err := store.CommitSHA(ctx, "created")
+		require.NoError(t, err)
+		require.Equal(t, sha, runGit(t, remote, "rev-parse", "main"))
+	})
+	t.Run("UpdateSpec", func(t *testing.T) {
+		spec := strings.Replace(testSpec, "hello", "updated", 1)
+		require.NoError(t, store.UpdateSpec(ctx, "created", []byte(spec), persistence.RevisionInfo{
+			Author:  "alice",
+			Message: "Change the greeting",
+		}))
+
+		require.Equal(t, "Change the greeting", runGit(t, dagsDir, "log", "-1", "--format=%s"))
+		require.Equal(t, "alice", runGit(t, dagsDir, "log", "-1", "--format=%an"))
+
+		// Saving the same spec makes no commit.
+		head := runGit(t, dagsDir, "rev-parse", "HEAD")
+		require.NoError(t, store.UpdateSpec(ctx, "created", []byte(spec), persistence.RevisionInfo{}))
+		require.Equal(t, head, runGit(t, dagsDir, "rev-parse", "HEAD"))
+	})
+	t.Run("Rename", func(t *testing.T) {
+		require.NoError(t, store.Rename(ctx, "created", "renamed"))
+
+		require.Equal(t, "Rename created to renamed", runGit(t, dagsDir, "log", "-1", "--format=%s"))
+		require.Equal(t, "renamed.yaml", runGit(t, dagsDir, "ls-files", "renamed.yaml"))
+		require.Empty(t, runGit(t, dagsDir, "ls-files", "created.yaml"))
+	})
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(ctx, "renamed"))
+
+		require.Equal(t, "Delete renamed", runGit(t, dagsDir, "log", "-1", "--format=%s"))
+		require.Empty(t, runGit(t, dagsDir, "ls-files", "renamed.yaml"))
+	})
+	t.Run("Sync", func(t *testing.T) {
+		runGit(t, other, "pull", "--quiet", "origin", "main")
+		writeFile(t, filepath.Join(other, "pulled.yaml"), testSpec)
+		runGit(t, other, "add", ".")
+		runGit(t, other, "commit", "--quiet", "-m", "Add pulled")
+		runGit(t, other, "push", "--quiet", "origin", "HEAD:main")
+
+		require.NoError(t, store.Sync(ctx))
+		require.FileExists(t, filepath.Join(dagsDir, "pulled.yaml"))
+
+		sha, err := store.CommitSHA(ctx, "pulled")
+		require.NoError(t, err)
+		require.Equal(t, runGit(t, other, "rev-parse", "HEAD"), sha)
+	})
+	t.Run("LockWorkingCopy", func(t *testing.T) {
+		unpushed, err := New(ctx, dagsDir, local.NewDAGStore(dagsDir))
+		require.NoError(t, err)
+
+		// Another process holds the lock of the working copy.
+		f, err := os.OpenFile(filepath.Join(runGit(t, dagsDir, "rev-parse", "--absolute-git-dir"), lockFileName), os.O_CREATE|os.O_RDWR, 0600)
+		require.NoError(t, err)
+		defer f.Close()
+		require.NoError(t, syscall.Flock(int(f.Fd()), syscall.LOCK_EX))
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := unpushed.Create(ctx, "locked", []byte(testSpec))
+			done <- err
+		}()
+
+		select {
+		case <-done:
+			t.Fatal("the store did not wait for the lock")
+		case <-time.After(200 * time.Millisecond):
+		}
+		require.NoFileExists(t, filepath.Join(dagsDir, "locked.yaml"))
+
+		require.NoError(t, syscall.Flock(int(f.Fd()), syscall.LOCK_UN))
+		require.NoError(t, <-done)
+		require.Equal(t, "Create locked", runGit(t, dagsDir, "log", "-1", "--format=%s"))
+	})
+	t.Run("RefuseEditsOnConflicts", func(t *testing.T) {
+		// Make a local commit that conflicts with the remote.
+		offline, err := New(ctx, dagsDir, local.NewDAGStore(dagsDir), WithRemote("unknown"))
+		require.NoError(t, err)
+		require.NoError(t, offline.UpdateSpec(ctx, "existing", []byte(strings.Replace(testSpec, "hello", "local", 1)), persistence.RevisionInfo{}))
+
+		writeFile(t, filepath.Join(other, "existing.yaml"), strings.Replace(testSpec, "hello", "remote", 1))
+		runGit(t, other, "commit", "--quiet", "-am", "Change existing")
+		runGit(t, other, "push", "--quiet", "origin", "HEAD:main")
+
+		require.Error(t, store.Sync(ctx))
+
+		err = store.UpdateSpec(ctx, "pulled", []byte(testSpec), persistence.RevisionInfo{})
+		require.ErrorIs(t, err, ErrConflicts)
+		_, err = store.Create(ctx, "new", []byte(testSpec))
+		require.ErrorIs(t, err, ErrConflicts)
+		require.ErrorIs(t, store.Delete(ctx, "pulled"), ErrConflicts)
+		require.ErrorIs(t, store.Rename(ctx, "pulled", "moved"), ErrConflicts)
+		require.ErrorIs(t, store.Sync(ctx), ErrConflicts)
+	})
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(name, []byte(content), 0600))
+}
@@ -0,0 +1,217 @@
+package gitstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// lockFileName is the name of the lock file in the git directory that
+// serializes the operations on the working copy.
+const lockFileName = "dagu.lock"
+
+// repository runs git commands in a working copy.
+type repository struct {
+	dir    string
+	remote string
+	branch string
+	env    []string
+	// lockFile serializes the operations on the working copy across the
+	// processes, e.g. the server and the scheduler.
+	lockFile string
+}
+
+func openRepository(ctx context.Context, dir string, opts *Options) (*repository, error) {
+	r := &repository{
+		dir:    dir,
+		remote: opts.Remote,
+		branch: opts.Branch,
+		env: []string{
+			"GIT_TERMINAL_PROMPT=0",
+			"GIT_AUTHOR_NAME=" + opts.AuthorName,
+			"GIT_AUTHOR_EMAIL=" + opts.AuthorEmail,
+			"GIT_COMMITTER_NAME=" + opts.AuthorName,
+			"GIT_COMMITTER_EMAIL=" + opts.AuthorEmail,
+		},
+	}
+
+	gitDir, err := r.git(ctx, "rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a git working copy: %w", dir, err)
+	}
+	r.lockFile = filepath.Join(gitDir, lockFileName)
+
+	if r.branch == "" {
+		branch, err := r.git(ctx, "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the current branch: %w", err)
+		}
+		r.branch = branch
+	}
+
+	return r, nil
+}
+
+// lock takes the exclusive lock of the working copy and returns the function
+// to release it. The lock is held across the processes.
+func (r *repository) lock() (func(), error) {
+	f, err := os.OpenFile(r.lockFile, os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to lock the working copy: %w", err)
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}, nil
+}
+
+// git runs a git command and returns its standard output.
+func (r *repository) git(ctx context.Context, args ...string) (string, error) {
+	return r.gitWithEnv(ctx, nil, args...)
+}
+
+func (r *repository) gitWithEnv(ctx context.Context, env []string, args ...string) (string, error) {
+	// nolint:gosec
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = r.dir
+	cmd.Env = append(append(os.Environ(), r.env...), env...)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// hasConflicts reports whether the working copy has unmerged paths.
+func (r *repository) hasConflicts(ctx context.Context) (bool, error) {
+	out, err := r.git(ctx, "ls-files", "--unmerged")
+	if err != nil {
+		return false, err
+	}
+	return out != "", nil
+}
+
+// commit commits the changes of the paths. It does nothing if the paths
+// have no changes.
+func (r *repository) commit(ctx context.Context, paths []string, message, author string) (bool, error) {
+	paths, err := r.filterPaths(ctx, paths)
+	if err != nil {
+		return false, err
+	}
+	if len(paths) == 0 {
+		return false, nil
+	}
+	if _, err := r.git(ctx, append([]string{"add", "--all", "--"}, paths...)...); err != nil {
+		return false, err
+	}
+
+	// --quiet exits with 1 if there are staged changes.
+	if _, err := r.git(ctx, append([]string{"diff", "--cached", "--quiet", "--"}, paths...)...); err == nil {
+		return false, nil
+	}
+
+	var env []string
+	if author != "" {
+		env = []string{"GIT_AUTHOR_NAME=" + author, "GIT_AUTHOR_EMAIL="}
+		if strings.Contains(author, "@") {
+			env[1] += author
+		}
+	}
+	args := append([]string{"commit", "--quiet", "--message", message, "--"}, paths...)
+	if _, err := r.gitWithEnv(ctx, env, args...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// filterPaths drops the paths neither present in the working copy nor
+// tracked, e.g. a deleted file that was never committed.
+func (r *repository) filterPaths(ctx context.Context, paths []string) ([]string, error) {
+	var ret []string
+	for _, p := range paths {
+		if _, err := os.Stat(filepath.Join(r.dir, p)); err == nil {
+			ret = append(ret, p)
+			continue
+		}
+		out, err := r.git(ctx, "ls-files", "--", p)
+		if err != nil {
+			return nil, err
+		}
+		if out != "" {
+			ret = append(ret, p)
+		}
+	}
+	return ret, nil
+}
+
+// pull merges the changes of the remote branch.
+func (r *repository) pull(ctx context.Context) error {
+	_, err := r.git(ctx, "pull", "--quiet", "--no-rebase", "--no-edit", r.remote, r.branch)
+	return err
+}
+
+// push pushes the local commits to the remote branch.
+func (r *repository) push(ctx context.Context) error {
+	_, err := r.git(ctx, "push", "--quiet", r.remote, "HEAD:"+r.branch)
+	return err
+}
+
+// ahead returns the number of local commits not in the remote branch.
+func (r *repository) ahead(ctx context.Context) (int, error) {
+	out, err := r.git(ctx, "rev-list", "--count", r.remote+"/"+r.branch+"..HEAD")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(out)
+}
+
+// lastCommits returns the last commit changing each file under the working
+// directory keyed by the path relative to it.
+func (r *repository) lastCommits(ctx context.Context) (map[string]string, error) {
+	out, err := r.git(ctx, "log", "--relative", "--name-only", "--format=%x00%H", "--", ".")
+	if err != nil {
+		// A repository without commits has no history.
+		if _, headErr := r.git(ctx, "rev-parse", "--verify", "HEAD"); headErr != nil {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+
+	ret := make(map[string]string)
+	for _, entry := range strings.Split(out, "\x00") {
+		lines := strings.Split(strings.TrimSpace(entry), "\n")
+		if len(lines) < 2 {
+			continue
+		}
+		sha := lines[0]
+		for _, file := range lines[1:] {
+			file = strings.TrimSpace(file)
+			if file == "" {
+				continue
+			}
+			if _, ok := ret[file]; !ok {
+				ret[file] = sha
+			}
+		}
+	}
+	return ret, nil
+}
+
+// hasRemote reports whether the remote is configured.
+func (r *repository) hasRemote(ctx context.Context) bool {
+	_, err := r.git(ctx, "remote", "get-url", r.remote)
+	return err == nil
+}
@@ -14,6 +14,7 @@ var (
 	ErrRequestIDNotFound = fmt.Errorf("request id not found")
 	ErrNoStatusDataToday = fmt.Errorf("no status data today")
 	ErrNoStatusData      = fmt.Errorf("no status data")
+	ErrRevisionNotFound  = fmt.Errorf("revision not found")
 )
 
 type HistoryStore interface {
@@ -39,8 +40,27 @@ type DAGStore interface {
 	Grep(ctx context.Context, pattern string) (ret []*GrepResult, errs []string, err error)
 	Rename(ctx context.Context, oldID, newID string) error
 	GetSpec(ctx context.Context, name string) (string, error)
-	UpdateSpec(ctx context.Context, name string, spec []byte) error
+	UpdateSpec(ctx context.Context, name string, spec []byte, info RevisionInfo) error
 	TagList(ctx context.Context) ([]string, []string, error)
+	// ListRevisions returns the saved revisions of a DAG spec, newest first.
+	ListRevisions(ctx context.Context, name string) ([]model.Revision, error)
+	// GetRevision returns a revision and its spec. The hash may be abbreviated.
+	GetRevision(ctx context.Context, name, hash string) (*model.Revision, []byte, error)
+	// EnsureRevision saves the spec as a revision unless a revision with the
+	// same content exists, e.g. when the file was changed outside the store.
+	EnsureRevision(ctx context.Context, name string, spec []byte) error
+}
+
+// VersionedDAGStore is implemented by DAG stores backed by version control.
+type VersionedDAGStore interface {
+	// CommitSHA returns the last commit that changed the DAG.
+	CommitSHA(ctx context.Context, name string) (string, error)
+}
+
+// RevisionInfo describes a change made to a DAG spec.
+type RevisionInfo struct {
+	Author  string
+	Message string
 }
 
 type DAGListPaginationArgs struct {
@@ -66,3 +86,38 @@ type FlagStore interface {
 	ToggleSuspend(id string, suspend bool) error
 	IsSuspended(id string) bool
 }
+
+// AuditStore is an append-only log of mutating actions.
+type AuditStore interface {
+	Append(ctx context.Context, entry model.AuditEntry) error
+	Query(ctx context.Context, filter AuditFilter) ([]model.AuditEntry, error)
+}
+
+// AuditFilter narrows down the audit entries returned by Query.
+// Zero values are ignored.
+type AuditFilter struct {
+	DAG       string
+	Action    string
+	Actor     string
+	RequestID string
+	From      time.Time
+	To        time.Time
+	Limit     int
+}
+
+// DecisionStore is an append-only log of the decisions the scheduler made
+// when the schedules of the DAGs fired.
+type DecisionStore interface {
+	Append(ctx context.Context, decision model.SchedulerDecision) error
+	Query(ctx context.Context, filter DecisionFilter) ([]model.SchedulerDecision, error)
+}
+
+// DecisionFilter narrows down the decisions returned by Query.
+// Zero values are ignored.
+type DecisionFilter struct {
+	DAG      string
+	Decision string
+	From     time.Time
+	To       time.Time
+	Limit    int
+}
@@ -0,0 +1,183 @@
+package local
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+)
+
+var _ persistence.AuditStore = (*auditStoreImpl)(nil)
+
+const (
+	auditFilePrefix     = "audit."
+	auditFileExtension  = ".log"
+	auditFileDateFormat = "20060102"
+)
+
+// auditStoreImpl stores audit entries as JSON lines in daily files.
+// Files are only ever opened in append mode.
+type auditStoreImpl struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewAuditStore creates a new audit store that writes to the given directory.
+func NewAuditStore(dir string) persistence.AuditStore {
+	return &auditStoreImpl{dir: dir}
+}
+
+// Append writes an entry to the audit log of the day the entry was recorded.
+func (s *auditStoreImpl) Append(_ context.Context, entry model.AuditEntry) error {
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+
+	data, err := entry.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit entry: %w", err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create audit log directory %s: %w", s.dir, err)
+	}
+
+	filePath := filepath.Join(s.dir, auditFileName(entry.Timestamp))
+	// nolint: gosec
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", filePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to write audit log %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// Query returns the entries matching the filter, newest first.
+func (s *auditStoreImpl) Query(ctx context.Context, filter persistence.AuditFilter) ([]model.AuditEntry, error) {
+	files, err := listDailyFiles(s.dir, auditFilePrefix, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	var ret []model.AuditEntry
+	for _, file := range files {
+		entries, err := readAuditFile(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+		// Entries within a file are in chronological order.
+		for i := len(entries) - 1; i >= 0; i-- {
+			if !matchAuditFilter(entries[i], filter) {
+				continue
+			}
+			ret = append(ret, entries[i])
+			if filter.Limit > 0 && len(ret) >= filter.Limit {
+				return ret, nil
+			}
+		}
+	}
+	return ret, nil
+}
+
+// listDailyFiles returns the daily files with the prefix in the directory
+// that may contain entries in the time range, newest first.
+func listDailyFiles(dir, prefix string, from, to time.Time) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, prefix+"*"+auditFileExtension))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, file := range matches {
+		date := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(file), prefix), auditFileExtension)
+		day, err := time.ParseInLocation(auditFileDateFormat, date, time.Local)
+		if err != nil {
+			continue
+		}
+		if !from.IsZero() && day.AddDate(0, 0, 1).Before(from) {
+			continue
+		}
+		if !to.IsZero() && day.After(to) {
+			continue
+		}
+		files = append(files, file)
+	}
+
+	sort.Sort(sort.Reverse(sort.StringSlice(files)))
+	return files, nil
+}
+
+func readAuditFile(ctx context.Context, file string) ([]model.AuditEntry, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log %s: %w", file, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var entries []model.AuditEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		entry, err := model.AuditEntryFromJSON(line)
+		if err != nil {
+			logger.Warn(ctx, "Skipping malformed audit entry", "file", file, "err", err)
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log %s: %w", file, err)
+	}
+	return entries, nil
+}
+
+func matchAuditFilter(entry model.AuditEntry, filter persistence.AuditFilter) bool {
+	if filter.DAG != "" && entry.DAG != filter.DAG {
+		return false
+	}
+	if filter.Action != "" && entry.Action != filter.Action {
+		return false
+	}
+	if filter.Actor != "" && entry.Actor != filter.Actor {
+		return false
+	}
+	if filter.RequestID != "" && entry.RequestID != filter.RequestID {
+		return false
+	}
+	if !filter.From.IsZero() && entry.Timestamp.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && entry.Timestamp.After(filter.To) {
+		return false
+	}
+	return true
+}
+
+func auditFileName(t time.Time) string {
+	return auditFilePrefix + t.Local().Format(auditFileDateFormat) + auditFileExtension
+}
@@ -0,0 +1,93 @@
+package local
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditStore(t *testing.T) {
+	tmpDir := fileutil.MustTempDir("test-audit-store")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	ctx := context.Background()
+	store := NewAuditStore(filepath.Join(tmpDir, "admin"))
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	now := time.Now()
+
+	entries := []model.AuditEntry{
+		{Timestamp: yesterday, Source: model.AuditSourceCLI, Actor: "alice", Action: model.AuditActionStart, DAG: "etl", RequestID: "req-1"},
+		{Timestamp: now, Source: model.AuditSourceAPI, Actor: "bob", Action: model.AuditActionStop, DAG: "etl", RequestID: "req-1"},
+		{Timestamp: now.Add(time.Second), Source: model.AuditSourceAPI, Actor: "bob", Action: model.AuditActionSave, DAG: "report", Diff: "-a\n+b\n"},
+	}
+	for _, entry := range entries {
+		require.NoError(t, store.Append(ctx, entry))
+	}
+
+	t.Run("All", func(t *testing.T) {
+		ret, err := store.Query(ctx, persistence.AuditFilter{})
+		require.NoError(t, err)
+		require.Len(t, ret, 3)
+
+		// Newest first
+		require.Equal(t, "report", ret[0].DAG)
+		require.Equal(t, "-a\n+b\n", ret[0].Diff)
+		require.Equal(t, model.AuditActionStop, ret[1].Action)
+		require.Equal(t, "alice", ret[2].Actor)
+	})
+	t.Run("FilterByDAG", func(t *testing.T) {
+		ret, err := store.Query(ctx, persistence.AuditFilter{DAG: "etl"})
+		require.NoError(t, err)
+		require.Len(t, ret, 2)
+	})
+	t.Run("FilterByActorAndAction", func(t *testing.T) {
+		ret, err := store.Query(ctx, persistence.AuditFilter{Actor: "bob", Action: model.AuditActionSave})
+		require.NoError(t, err)
+		require.Len(t, ret, 1)
+		require.Equal(t, "report", ret[0].DAG)
+	})
+	t.Run("FilterByTime", func(t *testing.T) {
+		ret, err := store.Query(ctx, persistence.AuditFilter{From: now.Add(-time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, ret, 2)
+
+		ret, err = store.Query(ctx, persistence.AuditFilter{To: now.Add(-time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, ret, 1)
+		require.Equal(t, "alice", ret[0].Actor)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		ret, err := store.Query(ctx, persistence.AuditFilter{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, ret, 1)
+		require.Equal(t, "report", ret[0].DAG)
+	})
+	t.Run("KeyStyle", func(t *testing.T) {
+		// The keys are capitalized like those of the status of the runs.
+		data, err := os.ReadFile(filepath.Join(tmpDir, "admin", auditFileName(now)))
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"Actor":"bob","Action":"stop","DAG":"etl","RequestId":"req-1"`)
+
+		// The entries written with the lowercase keys are still read.
+		entry, err := model.AuditEntryFromJSON([]byte(`{"actor":"carol","action":"start","dag":"etl"}`))
+		require.NoError(t, err)
+		require.Equal(t, "carol", entry.Actor)
+		require.Equal(t, "etl", entry.DAG)
+	})
+	t.Run("EmptyDir", func(t *testing.T) {
+		ret, err := NewAuditStore(filepath.Join(tmpDir, "empty")).Query(ctx, persistence.AuditFilter{})
+		require.NoError(t, err)
+		require.Empty(t, ret)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/fileutil"
@@ -16,6 +17,7 @@ import (
 	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/filecache"
 	"github.com/dagu-org/dagu/internal/persistence/grep"
+	"github.com/dagu-org/dagu/internal/persistence/model"
 )
 
 var _ persistence.DAGStore = (*dagStoreImpl)(nil)
@@ -23,7 +25,8 @@ var _ persistence.DAGStore = (*dagStoreImpl)(nil)
 type DAGStoreOption func(*DAGStoreOptions)
 
 type DAGStoreOptions struct {
-	FileCache *filecache.Cache[*digraph.DAG]
+	FileCache    *filecache.Cache[*digraph.DAG]
+	RevisionsDir string
 }
 
 func WithFileCache(cache *filecache.Cache[*digraph.DAG]) DAGStoreOption {
@@ -32,9 +35,17 @@ func WithFileCache(cache *filecache.Cache[*digraph.DAG]) DAGStoreOption {
 	}
 }
 
+// WithRevisionsDir enables keeping a revision of the spec on every change.
+func WithRevisionsDir(dir string) DAGStoreOption {
+	return func(o *DAGStoreOptions) {
+		o.RevisionsDir = dir
+	}
+}
+
 type dagStoreImpl struct {
 	baseDir   string
 	fileCache *filecache.Cache[*digraph.DAG]
+	revisions *revisionStore
 }
 
 func NewDAGStore(dir string, opts ...DAGStoreOption) persistence.DAGStore {
@@ -43,9 +54,15 @@ func NewDAGStore(dir string, opts ...DAGStoreOption) persistence.DAGStore {
 		opt(options)
 	}
 
+	var revisions *revisionStore
+	if options.RevisionsDir != "" {
+		revisions = newRevisionStore(options.RevisionsDir)
+	}
+
 	return &dagStoreImpl{
 		baseDir:   dir,
 		fileCache: options.FileCache,
+		revisions: revisions,
 	}
 }
 
@@ -56,10 +73,10 @@ func (d *dagStoreImpl) GetMetadata(ctx context.Context, name string) (*digraph.D
 		return nil, fmt.Errorf("failed to locate DAG %s: %w", name, err)
 	}
 	if d.fileCache == nil {
-		return digraph.Load(ctx, filePath, digraph.OnlyMetadata(), digraph.WithoutEval())
+		return d.loadMetadata(ctx, filePath)
 	}
 	return d.fileCache.LoadLatest(filePath, func() (*digraph.DAG, error) {
-		return digraph.Load(ctx, filePath, digraph.OnlyMetadata(), digraph.WithoutEval())
+		return d.loadMetadata(ctx, filePath)
 	})
 }
 
@@ -69,13 +86,17 @@ func (d *dagStoreImpl) GetDetails(ctx context.Context, name string) (*digraph.DA
 	if err != nil {
 		return nil, fmt.Errorf("failed to locate DAG %s: %w", name, err)
 	}
-	dat, err := digraph.Load(ctx, filePath, digraph.WithoutEval())
+	dat, err := digraph.Load(ctx, filePath, digraph.WithoutEval(), digraph.WithDAGsDir(d.baseDir))
 	if err != nil {
 		return nil, fmt.Errorf("failed to load DAG %s: %w", name, err)
 	}
 	return dat, nil
 }
 
+func (d *dagStoreImpl) loadMetadata(ctx context.Context, filePath string) (*digraph.DAG, error) {
+	return digraph.Load(ctx, filePath, digraph.OnlyMetadata(), digraph.WithoutEval(), digraph.WithDAGsDir(d.baseDir))
+}
+
 // GetSpec retrieves the specification of a DAG by its name.
 func (d *dagStoreImpl) GetSpec(_ context.Context, name string) (string, error) {
 	filePath, err := d.locateDAG(name)
@@ -93,29 +114,106 @@ func (d *dagStoreImpl) GetSpec(_ context.Context, name string) (string, error) {
 const defaultPerm os.FileMode = 0744
 
 // UpdateSpec updates the specification of a DAG by its name.
-func (d *dagStoreImpl) UpdateSpec(ctx context.Context, name string, spec []byte) error {
-	// Validate the spec before saving it.
-	_, err := digraph.LoadYAML(ctx, spec, digraph.WithoutEval())
-	if err != nil {
-		return err
-	}
+func (d *dagStoreImpl) UpdateSpec(ctx context.Context, name string, spec []byte, info persistence.RevisionInfo) error {
 	filePath, err := d.locateDAG(name)
 	if err != nil {
 		return fmt.Errorf("failed to locate DAG %s: %w", name, err)
 	}
+	// Validate the spec before saving it.
+	if _, err := digraph.LoadYAML(ctx, spec,
+		digraph.WithoutEval(), digraph.WithImportDir(filepath.Dir(filePath)), digraph.WithDAGsDir(d.baseDir),
+	); err != nil {
+		return err
+	}
+	if err := d.saveInitialRevision(filePath); err != nil {
+		return err
+	}
 	if err := os.WriteFile(filePath, spec, defaultPerm); err != nil {
 		return err
 	}
 	if d.fileCache != nil {
 		d.fileCache.Invalidate(filePath)
 	}
+	if d.revisions != nil {
+		if _, err := d.revisions.save(d.revisionKey(filePath), spec, info, time.Now()); err != nil {
+			return fmt.Errorf("failed to save revision of %s: %w", name, err)
+		}
+	}
 	return nil
 }
 
+// saveInitialRevision keeps the current spec of a DAG created before
+// revisions were enabled so that the first change can be rolled back.
+func (d *dagStoreImpl) saveInitialRevision(filePath string) error {
+	key := d.revisionKey(filePath)
+	if d.revisions == nil || d.revisions.hasRevisions(key) {
+		return nil
+	}
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+	current, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	if _, err := d.revisions.save(key, current, persistence.RevisionInfo{
+		Message: "Initial revision",
+	}, info.ModTime()); err != nil {
+		return fmt.Errorf("failed to save initial revision: %w", err)
+	}
+	return nil
+}
+
+// EnsureRevision records the spec as a revision of a DAG unless a revision
+// with the same content is stored already.
+func (d *dagStoreImpl) EnsureRevision(_ context.Context, name string, spec []byte) error {
+	filePath, err := d.locateDAG(name)
+	if err != nil {
+		return fmt.Errorf("failed to locate DAG %s: %w", name, err)
+	}
+	if d.revisions == nil {
+		return nil
+	}
+	if _, err := d.revisions.ensure(d.revisionKey(filePath), spec, persistence.RevisionInfo{
+		Message: "Changed outside Dagu",
+	}, time.Now()); err != nil {
+		return fmt.Errorf("failed to save revision of %s: %w", name, err)
+	}
+	return nil
+}
+
+// ListRevisions lists the revisions of a DAG, newest first.
+func (d *dagStoreImpl) ListRevisions(_ context.Context, name string) ([]model.Revision, error) {
+	filePath, err := d.locateDAG(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate DAG %s: %w", name, err)
+	}
+	if d.revisions == nil {
+		return nil, nil
+	}
+	return d.revisions.list(d.revisionKey(filePath))
+}
+
+// GetRevision retrieves a revision of a DAG and its spec.
+func (d *dagStoreImpl) GetRevision(_ context.Context, name, hash string) (*model.Revision, []byte, error) {
+	filePath, err := d.locateDAG(name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to locate DAG %s: %w", name, err)
+	}
+	if d.revisions == nil {
+		return nil, nil, fmt.Errorf("%w: %s", persistence.ErrRevisionNotFound, hash)
+	}
+	return d.revisions.get(d.revisionKey(filePath), hash)
+}
+
 var errDAGFileAlreadyExists = errors.New("the DAG file already exists")
 
 // Create creates a new DAG with the given name and specification.
 func (d *dagStoreImpl) Create(_ context.Context, name string, spec []byte) (string, error) {
+	if err := validateName(name); err != nil {
+		return "", err
+	}
 	if err := d.ensureDirExist(); err != nil {
 		return "", fmt.Errorf("failed to create DAGs directory %s: %w", d.baseDir, err)
 	}
@@ -123,9 +221,19 @@ func (d *dagStoreImpl) Create(_ context.Context, name string, spec []byte) (stri
 	if fileExists(filePath) {
 		return "", fmt.Errorf("%w: %s", errDAGFileAlreadyExists, filePath)
 	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory for DAG %s: %w", name, err)
+	}
 	if err := os.WriteFile(filePath, spec, defaultPerm); err != nil {
 		return "", fmt.Errorf("failed to write DAG %s: %w", name, err)
 	}
+	if d.revisions != nil {
+		if _, err := d.revisions.save(d.revisionKey(filePath), spec, persistence.RevisionInfo{
+			Message: "Created",
+		}, time.Now()); err != nil {
+			return "", fmt.Errorf("failed to save revision of %s: %w", name, err)
+		}
+	}
 	return name, nil
 }
 
@@ -144,6 +252,11 @@ func (d *dagStoreImpl) Delete(_ context.Context, name string) error {
 	if d.fileCache != nil {
 		d.fileCache.Invalidate(filePath)
 	}
+	if d.revisions != nil {
+		if err := d.revisions.removeAll(d.revisionKey(filePath)); err != nil {
+			return fmt.Errorf("failed to remove revisions of %s: %w", name, err)
+		}
+	}
 	return nil
 }
 
@@ -165,17 +278,7 @@ func (d *dagStoreImpl) ListPagination(ctx context.Context, params persistence.DA
 		count   int
 	)
 
-	if err := filepath.WalkDir(d.baseDir, func(_ string, entry fs.DirEntry, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if entry.IsDir() || !fileutil.IsYAMLFile(entry.Name()) {
-			return nil
-		}
-
-		baseName := path.Base(entry.Name())
-		dagName := strings.TrimSuffix(baseName, path.Ext(baseName))
+	if err := d.walkDAGs(func(filePath, dagName string) error {
 		if params.Name != "" && params.Tag == "" {
 			// If tag is not provided, check before reading the file to avoid
 			// unnecessary file read and parsing.
@@ -186,7 +289,7 @@ func (d *dagStoreImpl) ListPagination(ctx context.Context, params persistence.DA
 		}
 
 		// Read the file and parse the DAG.
-		parsedDAG, err := d.GetMetadata(ctx, dagName)
+		parsedDAG, err := d.GetMetadata(ctx, filePath)
 		if err != nil {
 			errList = append(errList, fmt.Sprintf("reading %s failed: %s", dagName, err))
 			return nil
@@ -227,22 +330,19 @@ func (d *dagStoreImpl) List(ctx context.Context) (ret []*digraph.DAG, errs []str
 		errs = append(errs, err.Error())
 		return
 	}
-	entries, err := os.ReadDir(d.baseDir)
+	err = d.walkDAGs(func(filePath, id string) error {
+		dat, err := d.GetMetadata(ctx, filePath)
+		if err == nil {
+			ret = append(ret, dat)
+		} else {
+			errs = append(errs, fmt.Sprintf(
+				"reading %s failed: %s", id, err),
+			)
+		}
+		return nil
+	})
 	if err != nil {
 		errs = append(errs, err.Error())
-		return
-	}
-	for _, entry := range entries {
-		if fileutil.IsYAMLFile(entry.Name()) {
-			dat, err := d.GetMetadata(ctx, entry.Name())
-			if err == nil {
-				ret = append(ret, dat)
-			} else {
-				errs = append(errs, fmt.Sprintf(
-					"reading %s failed: %s", entry.Name(), err),
-				)
-			}
-		}
 	}
 	return ret, errs, nil
 }
@@ -258,35 +358,30 @@ func (d *dagStoreImpl) Grep(ctx context.Context, pattern string) (
 		return
 	}
 
-	entries, err := os.ReadDir(d.baseDir)
-	if err != nil {
-		logger.Error(ctx, "Failed to read directory", "dir", d.baseDir, "err", err)
-	}
-
-	for _, entry := range entries {
-		if fileutil.IsYAMLFile(entry.Name()) {
-			filePath := filepath.Join(d.baseDir, entry.Name())
-			dat, err := os.ReadFile(filePath)
-			if err != nil {
-				logger.Error(ctx, "Failed to read DAG file", "file", entry.Name(), "err", err)
-				continue
-			}
-			matches, err := grep.Grep(dat, fmt.Sprintf("(?i)%s", pattern), grep.DefaultOptions)
-			if err != nil {
-				errs = append(errs, fmt.Sprintf("grep %s failed: %s", entry.Name(), err))
-				continue
-			}
-			dag, err := digraph.Load(ctx, filePath, digraph.OnlyMetadata(), digraph.WithoutEval())
-			if err != nil {
-				errs = append(errs, fmt.Sprintf("check %s failed: %s", entry.Name(), err))
-				continue
-			}
-			ret = append(ret, &persistence.GrepResult{
-				Name:    strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())),
-				DAG:     dag,
-				Matches: matches,
-			})
+	if err := d.walkDAGs(func(filePath, id string) error {
+		dat, err := os.ReadFile(filePath)
+		if err != nil {
+			logger.Error(ctx, "Failed to read DAG file", "file", id, "err", err)
+			return nil
+		}
+		matches, err := grep.Grep(dat, fmt.Sprintf("(?i)%s", pattern), grep.DefaultOptions)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("grep %s failed: %s", id, err))
+			return nil
 		}
+		dag, err := d.loadMetadata(ctx, filePath)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("check %s failed: %s", id, err))
+			return nil
+		}
+		ret = append(ret, &persistence.GrepResult{
+			Name:    id,
+			DAG:     dag,
+			Matches: matches,
+		})
+		return nil
+	}); err != nil {
+		logger.Error(ctx, "Failed to read directory", "dir", d.baseDir, "err", err)
 	}
 	return ret, errs, nil
 }
@@ -297,20 +392,85 @@ func (d *dagStoreImpl) Rename(_ context.Context, oldID, newID string) error {
 	if err != nil {
 		return fmt.Errorf("failed to locate DAG %s: %w", oldID, err)
 	}
+	if err := validateName(newID); err != nil {
+		return err
+	}
 	newFilePath := d.generateFilePath(newID)
 	if fileExists(newFilePath) {
 		return fmt.Errorf("%w: %s", errDAGFileAlreadyExists, newFilePath)
 	}
-	return os.Rename(oldFilePath, newFilePath)
+	if err := os.MkdirAll(filepath.Dir(newFilePath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for DAG %s: %w", newID, err)
+	}
+	if err := os.Rename(oldFilePath, newFilePath); err != nil {
+		return err
+	}
+	if d.revisions != nil {
+		if err := d.revisions.rename(d.revisionKey(oldFilePath), d.revisionKey(newFilePath)); err != nil {
+			return fmt.Errorf("failed to rename revisions of %s: %w", oldID, err)
+		}
+	}
+	return nil
 }
 
-// generateFilePath generates the file path for a DAG by its name.
-func (d *dagStoreImpl) generateFilePath(name string) string {
-	if strings.Contains(name, string(filepath.Separator)) {
-		filePath, err := filepath.Abs(name)
-		if err == nil {
-			return filePath
+// revisionKey returns the key of the revisions of the DAG file.
+func (d *dagStoreImpl) revisionKey(filePath string) string {
+	return d.dagID(filePath)
+}
+
+// dagID returns the ID of the DAG file, the path relative to the base
+// directory without the extension, e.g. "team-a/etl".
+func (d *dagStoreImpl) dagID(filePath string) string {
+	base := filepath.Base(filePath)
+	name := strings.TrimSuffix(base, filepath.Ext(base))
+	if namespace := digraph.Namespace(d.baseDir, filePath); namespace != "" {
+		return path.Join(namespace, name)
+	}
+	return name
+}
+
+// walkDAGs calls fn for each DAG file in the base directory and its
+// subdirectories. Hidden directories such as .git and the directories of
+// template library files are skipped.
+func (d *dagStoreImpl) walkDAGs(fn func(filePath, id string) error) error {
+	return filepath.WalkDir(d.baseDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if filePath != d.baseDir && (strings.HasPrefix(entry.Name(), ".") || entry.Name() == digraph.TemplatesDir) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !fileutil.IsYAMLFile(entry.Name()) {
+			return nil
 		}
+		return fn(filePath, d.dagID(filePath))
+	})
+}
+
+var errInvalidDAGName = errors.New("invalid DAG name")
+
+// validateName checks the name does not point outside of the base
+// directory. The name may contain a namespace, e.g. "team-a/etl".
+func validateName(name string) error {
+	if filepath.IsAbs(name) {
+		return nil
+	}
+	cleaned := filepath.Clean(name)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%w: %s", errInvalidDAGName, name)
+	}
+	return nil
+}
+
+// generateFilePath generates the file path for a DAG by its name. A name
+// with a namespace is placed in the subdirectory, e.g. "team-a/etl" is
+// placed in "team-a/etl.yaml" under the base directory.
+func (d *dagStoreImpl) generateFilePath(name string) string {
+	if filepath.IsAbs(name) {
+		return filepath.Clean(name)
 	}
 	filePath := fileutil.EnsureYAMLExtension(path.Join(d.baseDir, name))
 	return filepath.Clean(filePath)
@@ -364,20 +524,13 @@ func (d *dagStoreImpl) TagList(ctx context.Context) ([]string, []string, error)
 		tagSet  = make(map[string]struct{})
 	)
 
-	if err := filepath.WalkDir(d.baseDir, func(_ string, entry fs.DirEntry, err error) error {
+	if err := d.walkDAGs(func(filePath, id string) error {
+		parsedDAG, err := d.GetMetadata(ctx, filePath)
 		if err != nil {
-			return err
-		}
-
-		if entry.IsDir() || !fileutil.IsYAMLFile(entry.Name()) {
+			errList = append(errList, fmt.Sprintf("reading %s failed: %s", id, err))
 			return nil
 		}
 
-		parsedDAG, err := d.GetMetadata(ctx, entry.Name())
-		if err != nil {
-			errList = append(errList, fmt.Sprintf("reading %s failed: %s", entry.Name(), err))
-		}
-
 		for _, tag := range parsedDAG.Tags {
 			tagSet[tag] = struct{}{}
 		}
@@ -0,0 +1,187 @@
+package local
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+)
+
+var _ persistence.DecisionStore = (*decisionStoreImpl)(nil)
+
+const (
+	decisionFilePrefix = "decisions."
+
+	// defaultDecisionRetentionDays is the number of days the decisions are
+	// kept by default.
+	defaultDecisionRetentionDays = 30
+)
+
+// decisionStoreImpl stores the scheduler decisions as JSON lines in daily
+// files in the same way as the audit log.
+type decisionStoreImpl struct {
+	dir           string
+	retentionDays int
+	mu            sync.Mutex
+}
+
+// NewDecisionStore creates a new decision store that writes to the given
+// directory. The files older than the retention days are removed when the
+// file of a new day is created; the default is 30 days if it is zero.
+func NewDecisionStore(dir string, retentionDays int) persistence.DecisionStore {
+	if retentionDays <= 0 {
+		retentionDays = defaultDecisionRetentionDays
+	}
+	return &decisionStoreImpl{dir: dir, retentionDays: retentionDays}
+}
+
+// Append writes the decision to the file of the day it was recorded.
+func (s *decisionStoreImpl) Append(_ context.Context, decision model.SchedulerDecision) error {
+	if decision.Timestamp.IsZero() {
+		decision.Timestamp = time.Now()
+	}
+
+	data, err := decision.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal scheduler decision: %w", err)
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create decision log directory %s: %w", s.dir, err)
+	}
+
+	filePath := filepath.Join(s.dir, decisionFilePrefix+decision.Timestamp.Local().Format(auditFileDateFormat)+auditFileExtension)
+	if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		s.removeOld(decision.Timestamp)
+	}
+
+	// nolint: gosec
+	file, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open decision log %s: %w", filePath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("failed to write decision log %s: %w", filePath, err)
+	}
+	return nil
+}
+
+// removeOld removes the files of the days before the retention period.
+func (s *decisionStoreImpl) removeOld(now time.Time) {
+	files, err := listDailyFiles(s.dir, decisionFilePrefix, time.Time{}, now.AddDate(0, 0, -s.retentionDays-1))
+	if err != nil {
+		return
+	}
+	for _, file := range files {
+		_ = os.Remove(file)
+	}
+}
+
+// outcomeKey identifies the firing an outcome entry belongs to.
+type outcomeKey struct {
+	dag           string
+	typ           string
+	scheduledTime int64
+}
+
+func newOutcomeKey(decision model.SchedulerDecision) outcomeKey {
+	return outcomeKey{dag: decision.DAG, typ: decision.Type, scheduledTime: decision.ScheduledTime.UnixNano()}
+}
+
+// Query returns the decisions matching the filter, newest first. The
+// outcomes of the started runs are merged into their decisions.
+func (s *decisionStoreImpl) Query(ctx context.Context, filter persistence.DecisionFilter) ([]model.SchedulerDecision, error) {
+	files, err := listDailyFiles(s.dir, decisionFilePrefix, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list decision logs: %w", err)
+	}
+
+	var ret []model.SchedulerDecision
+	outcomes := make(map[outcomeKey]model.SchedulerDecision)
+	for _, file := range files {
+		decisions, err := readDecisionFile(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+		// Decisions within a file are in chronological order.
+		for i := len(decisions) - 1; i >= 0; i-- {
+			decision := decisions[i]
+			if decision.Outcome != "" {
+				outcomes[newOutcomeKey(decision)] = decision
+				continue
+			}
+			if outcome, ok := outcomes[newOutcomeKey(decision)]; ok {
+				decision.Outcome = outcome.Outcome
+				decision.Reason = outcome.Reason
+			}
+			if !matchDecisionFilter(decision, filter) {
+				continue
+			}
+			ret = append(ret, decision)
+			if filter.Limit > 0 && len(ret) >= filter.Limit {
+				return ret, nil
+			}
+		}
+	}
+	return ret, nil
+}
+
+func readDecisionFile(ctx context.Context, file string) ([]model.SchedulerDecision, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open decision log %s: %w", file, err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	var decisions []model.SchedulerDecision
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		decision, err := model.SchedulerDecisionFromJSON(line)
+		if err != nil {
+			logger.Warn(ctx, "Skipping malformed scheduler decision", "file", file, "err", err)
+			continue
+		}
+		decisions = append(decisions, *decision)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read decision log %s: %w", file, err)
+	}
+	return decisions, nil
+}
+
+func matchDecisionFilter(decision model.SchedulerDecision, filter persistence.DecisionFilter) bool {
+	if filter.DAG != "" && decision.DAG != filter.DAG {
+		return false
+	}
+	if filter.Decision != "" && decision.Decision != filter.Decision {
+		return false
+	}
+	if !filter.From.IsZero() && decision.Timestamp.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && decision.Timestamp.After(filter.To) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package local
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/fileutil"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecisionStore(t *testing.T) {
+	tmpDir := fileutil.MustTempDir("test-decision-store")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	ctx := context.Background()
+	store := NewDecisionStore(filepath.Join(tmpDir, "decisions"), 0)
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	now := time.Now()
+
+	decisions := []model.SchedulerDecision{
+		{Timestamp: yesterday, ScheduledTime: yesterday.Truncate(time.Minute), DAG: "etl", Type: "start", Decision: model.DecisionStarted},
+		{Timestamp: now, ScheduledTime: now.Truncate(time.Minute), DAG: "etl", Type: "start", Decision: model.DecisionSkippedRunning, Reason: "job already running"},
+		{Timestamp: now.Add(time.Second), ScheduledTime: now.Truncate(time.Minute), DAG: "report", Type: "start", Decision: model.DecisionSuspended},
+	}
+	for _, decision := range decisions {
+		require.NoError(t, store.Append(ctx, decision))
+	}
+
+	t.Run("All", func(t *testing.T) {
+		ret, err := store.Query(ctx, persistence.DecisionFilter{})
+		require.NoError(t, err)
+		require.Len(t, ret, 3)
+
+		// Newest first
+		require.Equal(t, "report", ret[0].DAG)
+		require.Equal(t, "job already running", ret[1].Reason)
+		require.Equal(t, model.DecisionStarted, ret[2].Decision)
+	})
+	t.Run("FilterByDAGAndDecision", func(t *testing.T) {
+		ret, err := store.Query(ctx, persistence.DecisionFilter{DAG: "etl"})
+		require.NoError(t, err)
+		require.Len(t, ret, 2)
+
+		ret, err = store.Query(ctx, persistence.DecisionFilter{DAG: "etl", Decision: model.DecisionStarted})
+		require.NoError(t, err)
+		require.Len(t, ret, 1)
+	})
+	t.Run("FilterByTime", func(t *testing.T) {
+		ret, err := store.Query(ctx, persistence.DecisionFilter{From: now.Add(-time.Minute)})
+		require.NoError(t, err)
+		require.Len(t, ret, 2)
+
+		ret, err = store.Query(ctx, persistence.DecisionFilter{To: now.Add(-time.Minute), Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, ret, 1)
+		require.Equal(t, model.DecisionStarted, ret[0].Decision)
+	})
+	t.Run("Outcome", func(t *testing.T) {
+		scheduled := now.Truncate(time.Minute)
+		require.NoError(t, store.Append(ctx, model.SchedulerDecision{Timestamp: now, ScheduledTime: scheduled, DAG: "load", Type: "start", Decision: model.DecisionStarted}))
+
+		// The decision is recorded before the run finishes.
+		ret, err := store.Query(ctx, persistence.DecisionFilter{DAG: "load"})
+		require.NoError(t, err)
+		require.Len(t, ret, 1)
+		require.Equal(t, model.DecisionStarted, ret[0].Decision)
+		require.Empty(t, ret[0].Outcome)
+
+		require.NoError(t, store.Append(ctx, model.SchedulerDecision{Timestamp: now.Add(time.Minute), ScheduledTime: scheduled, DAG: "load", Type: "start", Decision: model.DecisionStarted, Outcome: model.OutcomeFailed, Reason: "exit status 1"}))
+
+		// The outcome is merged into the decision of the firing.
+		ret, err = store.Query(ctx, persistence.DecisionFilter{DAG: "load"})
+		require.NoError(t, err)
+		require.Len(t, ret, 1)
+		require.Equal(t, model.DecisionStarted, ret[0].Decision)
+		require.Equal(t, model.OutcomeFailed, ret[0].Outcome)
+		require.Equal(t, "exit status 1", ret[0].Reason)
+	})
+}
+
+func TestDecisionStoreRetention(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	store := NewDecisionStore(dir, 7)
+
+	now := time.Now()
+	old := now.AddDate(0, 0, -8)
+	recent := now.AddDate(0, 0, -6)
+	for _, ts := range []time.Time{old, recent} {
+		require.NoError(t, store.Append(ctx, model.SchedulerDecision{Timestamp: ts, ScheduledTime: ts, DAG: "etl", Type: "start", Decision: model.DecisionStarted}))
+	}
+	fileOf := func(ts time.Time) string {
+		return filepath.Join(dir, decisionFilePrefix+ts.Format(auditFileDateFormat)+auditFileExtension)
+	}
+	require.FileExists(t, fileOf(old))
+
+	// Creating the file of a new day removes the files out of the retention.
+	require.NoError(t, store.Append(ctx, model.SchedulerDecision{Timestamp: now, ScheduledTime: now, DAG: "etl", Type: "start", Decision: model.DecisionStarted}))
+	require.NoFileExists(t, fileOf(old))
+	require.FileExists(t, fileOf(recent))
+	require.FileExists(t, fileOf(now))
+}
@@ -2,6 +2,7 @@ package local
 
 import (
 	"fmt"
+	"path"
 	"regexp"
 	"strings"
 
@@ -32,8 +33,14 @@ func (f flagStoreImpl) IsSuspended(id string) bool {
 	return f.storage.Exists(fileName(id))
 }
 
+// fileName returns the name of the flag file. The namespace of the ID is
+// kept as subdirectories, so "team-a/etl" and "team-b/etl" do not collide.
 func fileName(id string) string {
-	return fmt.Sprintf("%s.suspend", normalizeFilename(id, "-"))
+	segments := strings.Split(id, "/")
+	for i, segment := range segments {
+		segments[i] = normalizeFilename(segment, "-")
+	}
+	return fmt.Sprintf("%s.suspend", path.Join(segments...))
 }
 
 // https://github.com/sindresorhus/filename-reserved-regex/blob/master/index.js
@@ -25,3 +25,22 @@ func TestFlagStore(t *testing.T) {
 
 	require.True(t, flagStore.IsSuspended("test"))
 }
+
+func TestFlagStoreNamespace(t *testing.T) {
+	tmpDir := fileutil.MustTempDir("test-suspend-namespace")
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	flagStore := NewFlagStore(storage.NewStorage(tmpDir))
+
+	require.NoError(t, flagStore.ToggleSuspend("team-a/etl", true))
+
+	require.True(t, flagStore.IsSuspended("team-a/etl"))
+	require.False(t, flagStore.IsSuspended("team-b/etl"))
+	require.False(t, flagStore.IsSuspended("etl"))
+	require.False(t, flagStore.IsSuspended("team-a-etl"))
+
+	require.NoError(t, flagStore.ToggleSuspend("team-a/etl", false))
+	require.False(t, flagStore.IsSuspended("team-a/etl"))
+}
@@ -0,0 +1,241 @@
+package local
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+)
+
+const (
+	// revisionIndexFile is the append-only list of revisions of a DAG.
+	revisionIndexFile = "revisions.log"
+	// revisionLockFile serializes the changes to the revisions across the
+	// processes, e.g. the server saving a DAG and the agent running it.
+	revisionLockFile = ".lock"
+)
+
+var errAmbiguousRevision = errors.New("ambiguous revision hash")
+
+// revisionStore keeps the revisions of DAG specs in a directory per DAG.
+// The specs are stored by their content hash and listed in an index file.
+type revisionStore struct {
+	dir string
+}
+
+func newRevisionStore(dir string) *revisionStore {
+	return &revisionStore{dir: dir}
+}
+
+// save records a new revision of the spec. Saving the same content as the
+// latest revision does not create a new one.
+func (s *revisionStore) save(name string, spec []byte, info persistence.RevisionInfo, timestamp time.Time) (*model.Revision, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	revisions, err := s.readIndex(name)
+	if err != nil {
+		return nil, err
+	}
+	hash := model.NewRevisionHash(spec)
+	if len(revisions) > 0 && revisions[len(revisions)-1].Hash == hash {
+		return &revisions[len(revisions)-1], nil
+	}
+	return s.append(name, spec, hash, info, timestamp)
+}
+
+// ensure records a new revision of the spec unless any revision has the
+// same content.
+func (s *revisionStore) ensure(name string, spec []byte, info persistence.RevisionInfo, timestamp time.Time) (*model.Revision, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	revisions, err := s.readIndex(name)
+	if err != nil {
+		return nil, err
+	}
+	hash := model.NewRevisionHash(spec)
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if revisions[i].Hash == hash {
+			return &revisions[i], nil
+		}
+	}
+	return s.append(name, spec, hash, info, timestamp)
+}
+
+// append writes the spec and adds the revision to the index.
+func (s *revisionStore) append(name string, spec []byte, hash string, info persistence.RevisionInfo, timestamp time.Time) (*model.Revision, error) {
+	dir := filepath.Join(s.dir, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create revisions directory %s: %w", dir, err)
+	}
+
+	specFile := filepath.Join(dir, hash+".yaml")
+	if !fileExists(specFile) {
+		if err := os.WriteFile(specFile, spec, 0600); err != nil {
+			return nil, fmt.Errorf("failed to write revision %s: %w", hash, err)
+		}
+	}
+
+	revision := model.Revision{
+		Hash:      hash,
+		Timestamp: timestamp,
+		Author:    info.Author,
+		Message:   info.Message,
+	}
+	data, err := revision.ToJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, revisionIndexFile), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open revision index: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return nil, fmt.Errorf("failed to write revision index: %w", err)
+	}
+
+	return &revision, nil
+}
+
+// list returns the revisions of the DAG, newest first.
+func (s *revisionStore) list(name string) ([]model.Revision, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	revisions, err := s.readIndex(name)
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]model.Revision, 0, len(revisions))
+	for i := len(revisions) - 1; i >= 0; i-- {
+		ret = append(ret, revisions[i])
+	}
+	return ret, nil
+}
+
+// get returns the latest revision matching the (possibly abbreviated) hash
+// and its spec.
+func (s *revisionStore) get(name, hash string) (*model.Revision, []byte, error) {
+	revisions, err := s.list(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var found *model.Revision
+	for i := range revisions {
+		if hash == "" || !strings.HasPrefix(revisions[i].Hash, hash) {
+			continue
+		}
+		if found != nil && found.Hash != revisions[i].Hash {
+			return nil, nil, fmt.Errorf("%w: %s", errAmbiguousRevision, hash)
+		}
+		if found == nil {
+			found = &revisions[i]
+		}
+	}
+	if found == nil {
+		return nil, nil, fmt.Errorf("%w: %s", persistence.ErrRevisionNotFound, hash)
+	}
+
+	spec, err := os.ReadFile(filepath.Join(s.dir, name, found.Hash+".yaml"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read revision %s: %w", found.Hash, err)
+	}
+	return found, spec, nil
+}
+
+// hasRevisions reports whether any revision of the DAG is recorded.
+func (s *revisionStore) hasRevisions(name string) bool {
+	return fileExists(filepath.Join(s.dir, name, revisionIndexFile))
+}
+
+func (s *revisionStore) rename(oldName, newName string) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	oldDir := filepath.Join(s.dir, oldName)
+	if !fileExists(oldDir) {
+		return nil
+	}
+	return os.Rename(oldDir, filepath.Join(s.dir, newName))
+}
+
+func (s *revisionStore) removeAll(name string) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return os.RemoveAll(filepath.Join(s.dir, name))
+}
+
+// lock takes the exclusive lock of the revisions and returns the function to
+// release it. The lock is held across the processes.
+func (s *revisionStore) lock() (func(), error) {
+	if err := os.MkdirAll(s.dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create revisions directory %s: %w", s.dir, err)
+	}
+	f, err := os.OpenFile(filepath.Join(s.dir, revisionLockFile), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to lock the revisions: %w", err)
+	}
+	return func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+		_ = f.Close()
+	}, nil
+}
+
+// readIndex reads the revisions of the DAG in the order they were saved.
+func (s *revisionStore) readIndex(name string) ([]model.Revision, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, name, revisionIndexFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read revision index: %w", err)
+	}
+
+	var revisions []model.Revision
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		revision, err := model.RevisionFromJSON(line)
+		if err != nil {
+			// Skip a partially written line.
+			continue
+		}
+		revisions = append(revisions, *revision)
+	}
+	return revisions, scanner.Err()
+}
@@ -0,0 +1,45 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevisionStoreLock(t *testing.T) {
+	dir := t.TempDir()
+	store := newRevisionStore(dir)
+
+	// Another process, e.g. the agent, holds the lock of the revisions.
+	f, err := os.OpenFile(filepath.Join(dir, revisionLockFile), os.O_CREATE|os.O_RDWR, 0600)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, syscall.Flock(int(f.Fd()), syscall.LOCK_EX))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := store.ensure("test", []byte("steps: []"), persistence.RevisionInfo{}, time.Now())
+		done <- err
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("the store did not wait for the lock")
+	case <-time.After(200 * time.Millisecond):
+	}
+	require.False(t, store.hasRevisions("test"))
+
+	require.NoError(t, syscall.Flock(int(f.Fd()), syscall.LOCK_UN))
+	require.NoError(t, <-done)
+
+	revisions, err := store.list("test")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	require.Equal(t, model.NewRevisionHash([]byte("steps: []")), revisions[0].Hash)
+}
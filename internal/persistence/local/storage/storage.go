@@ -26,7 +26,11 @@ func NewStorage(dir string) *Storage {
 
 // Create creates the given file.
 func (s *Storage) Create(file string) error {
-	return os.WriteFile(path.Join(s.Dir, file), []byte{}, defaultPermission)
+	filePath := path.Join(s.Dir, file)
+	if err := os.MkdirAll(path.Dir(filePath), defaultPermission); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, []byte{}, defaultPermission)
 }
 
 // Exists returns true if the given file exists.
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audit actions recorded for mutating operations.
+const (
+	AuditActionCreate       = "create"
+	AuditActionDelete       = "delete"
+	AuditActionStart        = "start"
+	AuditActionStop         = "stop"
+	AuditActionStopStep     = "stop-step"
+	AuditActionRestart      = "restart"
+	AuditActionRetry        = "retry"
+	AuditActionRetryStep    = "retry-step"
+	AuditActionSave         = "save"
+	AuditActionRename       = "rename"
+	AuditActionSuspend      = "suspend"
+	AuditActionResume       = "resume"
+	AuditActionMarkSuccess  = "mark-success"
+	AuditActionMarkFailed   = "mark-failed"
+	AuditActionRollback     = "rollback"
+	AuditActionSetSecret    = "set-secret"
+	AuditActionDeleteSecret = "delete-secret"
+)
+
+// Audit sources describe where an action originated from.
+const (
+	AuditSourceAPI = "api"
+	AuditSourceCLI = "cli"
+)
+
+// AuditEntry is a single record in the audit log.
+type AuditEntry struct {
+	Timestamp time.Time `json:"Timestamp"`
+	Source    string    `json:"Source"`
+	Actor     string    `json:"Actor"`
+	SourceIP  string    `json:"SourceIP,omitempty"`
+	Action    string    `json:"Action"`
+	DAG       string    `json:"DAG"`
+	RequestID string    `json:"RequestId,omitempty"`
+	Step      string    `json:"Step,omitempty"`
+	Detail    string    `json:"Detail,omitempty"`
+	Diff      string    `json:"Diff,omitempty"`
+}
+
+// AuditEntryFromJSON parses a single line of the audit log.
+func AuditEntryFromJSON(data []byte) (*AuditEntry, error) {
+	entry := new(AuditEntry)
+	if err := json.Unmarshal(data, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// ToJSON returns the JSON representation of the entry.
+func (e AuditEntry) ToJSON() ([]byte, error) {
+	return json.Marshal(e)
+}
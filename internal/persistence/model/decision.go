@@ -0,0 +1,56 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Decisions the scheduler makes when a schedule of a DAG fires.
+const (
+	DecisionStarted           = "started"
+	DecisionStopped           = "stopped"
+	DecisionRestarted         = "restarted"
+	DecisionSkippedRunning    = "skipped-running"
+	DecisionSkippedSuccessful = "skipped-successful"
+	DecisionSkippedFinished   = "skipped-finished"
+	DecisionSkippedCalendar   = "skipped-calendar"
+	DecisionSkippedNotRunning = "skipped-not-running"
+	DecisionSuspended         = "suspended"
+	DecisionFailed            = "failed"
+)
+
+// Outcomes of the runs started on the firings.
+const (
+	OutcomeSucceeded          = "succeeded"
+	OutcomePreconditionFailed = "precondition-failed"
+	OutcomeFailed             = "failed"
+)
+
+// SchedulerDecision is a record of what the scheduler did when a schedule
+// of a DAG fired and why.
+type SchedulerDecision struct {
+	Timestamp     time.Time `json:"timestamp"`
+	ScheduledTime time.Time `json:"scheduledTime"`
+	DAG           string    `json:"dag"`
+	Type          string    `json:"type"`
+	Decision      string    `json:"decision"`
+	Reason        string    `json:"reason,omitempty"`
+	// Outcome is the result of the started run. It is recorded in a
+	// separate entry when the run finishes and merged into the decision
+	// of the firing when the decisions are read.
+	Outcome string `json:"outcome,omitempty"`
+}
+
+// SchedulerDecisionFromJSON parses a single line of the decision log.
+func SchedulerDecisionFromJSON(data []byte) (*SchedulerDecision, error) {
+	decision := new(SchedulerDecision)
+	if err := json.Unmarshal(data, decision); err != nil {
+		return nil, err
+	}
+	return decision, nil
+}
+
+// ToJSON returns the JSON representation of the decision.
+func (d SchedulerDecision) ToJSON() ([]byte, error) {
+	return json.Marshal(d)
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+)
+
+// Event is a change of the status of a running DAG or of one of its steps.
+type Event struct {
+	// Seq is the sequence number of the event in the run, starting at 1.
+	Seq        int64            `json:"Seq"`
+	Time       string           `json:"Time"`
+	RequestID  string           `json:"RequestId"`
+	Status     scheduler.Status `json:"Status"`
+	StatusText string           `json:"StatusText"`
+	// Node is the step whose status changed, or nil if the status of the
+	// DAG changed.
+	Node *Node `json:"Node,omitempty"`
+}
+
+// EventList is the list of the events of a run after a sequence number.
+type EventList struct {
+	Events []Event `json:"Events"`
+	// Seq is the sequence number of the last event of the run, after which
+	// the next events are requested.
+	Seq int64 `json:"Seq"`
+	// Finished is true if the run is finished and no events follow.
+	Finished bool `json:"Finished"`
+}
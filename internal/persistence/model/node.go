@@ -3,6 +3,7 @@ package model
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
@@ -37,6 +38,10 @@ func FromNode(node scheduler.NodeData) *Node {
 		RetryCount: node.State.RetryCount,
 		DoneCount:  node.State.DoneCount,
 		Error:      errText(node.State.Error),
+		PeakRSS:    node.State.PeakRSS,
+		UserTime:   node.State.UserTime.Milliseconds(),
+		SystemTime: node.State.SystemTime.Milliseconds(),
+		LogLevels:  node.State.LogLevels,
 	}
 }
 
@@ -51,6 +56,13 @@ type Node struct {
 	DoneCount  int                  `json:"DoneCount,omitempty"`
 	Error      string               `json:"Error,omitempty"`
 	StatusText string               `json:"StatusText"`
+	// PeakRSS is the peak resident set size in bytes.
+	PeakRSS int64 `json:"PeakRSS,omitempty"`
+	// UserTime and SystemTime are the CPU time in milliseconds.
+	UserTime   int64 `json:"UserTime,omitempty"`
+	SystemTime int64 `json:"SystemTime,omitempty"`
+	// LogLevels is the number of the lines of the JSON log by level.
+	LogLevels map[string]int `json:"LogLevels,omitempty"`
 }
 
 func (n *Node) ToNode() *scheduler.Node {
@@ -66,6 +78,10 @@ func (n *Node) ToNode() *scheduler.Node {
 		RetryCount: n.RetryCount,
 		DoneCount:  n.DoneCount,
 		Error:      errFromText(n.Error),
+		PeakRSS:    n.PeakRSS,
+		UserTime:   time.Duration(n.UserTime) * time.Millisecond,
+		SystemTime: time.Duration(n.SystemTime) * time.Millisecond,
+		LogLevels:  n.LogLevels,
 	})
 }
 
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// shortHashLength is the number of characters of the hash shown to users.
+const shortHashLength = 12
+
+// Revision is a saved version of a DAG spec.
+type Revision struct {
+	Hash      string    `json:"Hash"`
+	Timestamp time.Time `json:"Timestamp"`
+	Author    string    `json:"Author,omitempty"`
+	Message   string    `json:"Message,omitempty"`
+}
+
+// ShortHash returns the abbreviated revision hash.
+func (r Revision) ShortHash() string {
+	return ShortRevisionHash(r.Hash)
+}
+
+// ToJSON serializes the revision to JSON.
+func (r Revision) ToJSON() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+// RevisionFromJSON deserializes a revision from JSON.
+func RevisionFromJSON(s string) (*Revision, error) {
+	r := new(Revision)
+	if err := json.Unmarshal([]byte(s), r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewRevisionHash returns the hash identifying the content of a DAG spec.
+func NewRevisionHash(spec []byte) string {
+	sum := sha256.Sum256(spec)
+	return hex.EncodeToString(sum[:])
+}
+
+// ShortRevisionHash abbreviates a revision hash.
+func ShortRevisionHash(hash string) string {
+	if len(hash) > shortHashLength {
+		return hash[:shortHashLength]
+	}
+	return hash
+}
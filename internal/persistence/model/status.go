@@ -89,6 +89,18 @@ func WithLogFilePath(logFilePath string) StatusOption {
 	}
 }
 
+func WithSpecRevision(hash string) StatusOption {
+	return func(s *Status) {
+		s.SpecRevision = hash
+	}
+}
+
+func WithScheduledTime(t time.Time) StatusOption {
+	return func(s *Status) {
+		s.ScheduledTime = FormatTime(t)
+	}
+}
+
 func (f *StatusFactory) Create(
 	requestID string,
 	status scheduler.Status,
@@ -144,6 +156,13 @@ type Status struct {
 	Log        string           `json:"Log"`
 	Params     string           `json:"Params,omitempty"`
 	ParamsList []string         `json:"ParamsList,omitempty"`
+	// SpecRevision is the revision hash of the DAG spec used by the run.
+	SpecRevision string `json:"SpecRevision,omitempty"`
+	// SkipReason is the reason the scheduled run is skipped.
+	SkipReason string `json:"SkipReason,omitempty"`
+	// ScheduledTime is the time the run was scheduled for, as opposed to
+	// StartedAt, which may be delayed by the jitter and the throttling.
+	ScheduledTime string `json:"ScheduledTime,omitempty"`
 }
 
 func (st *Status) CorrectRunningStatus() {
@@ -153,6 +172,26 @@ func (st *Status) CorrectRunningStatus() {
 	}
 }
 
+// NodeByName returns the node of the step or the handler with the name, or
+// nil if there is none.
+func (st *Status) NodeByName(name string) *Node {
+	var found *Node
+	for _, n := range st.Nodes {
+		if n.Step.Name == name {
+			found = n
+		}
+	}
+	if found != nil {
+		return found
+	}
+	for _, n := range []*Node{st.OnSuccess, st.OnFailure, st.OnCancel, st.OnExit} {
+		if n != nil && n.Step.Name == name {
+			found = n
+		}
+	}
+	return found
+}
+
 func FormatTime(val time.Time) string {
 	if val.IsZero() {
 		return ""
@@ -75,3 +75,13 @@ func TestJsonMarshal(t *testing.T) {
 	}
 	t.Log(string(rawJSON))
 }
+
+func TestStatusNodeByName(t *testing.T) {
+	status := Status{
+		Nodes:  []*Node{NewNode(digraph.Step{Name: "first"}), NewNode(digraph.Step{Name: "second"})},
+		OnExit: NewNode(digraph.Step{Name: "onExit"}),
+	}
+	require.Equal(t, "second", status.NodeByName("second").Step.Name)
+	require.Equal(t, "onExit", status.NodeByName("onExit").Step.Name)
+	require.Nil(t, status.NodeByName("third"))
+}
@@ -3,12 +3,14 @@ package scheduler
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/persistence/model"
 	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/robfig/cron/v3"
@@ -21,6 +23,7 @@ var (
 	ErrJobFinished     = errors.New("job already finished")
 	ErrJobSkipped      = errors.New("job skipped")
 	ErrJobSuccess      = errors.New("job already successful")
+	ErrJobSuspended    = errors.New("job suspended")
 )
 
 var _ Job = (*dagJob)(nil)
@@ -32,6 +35,9 @@ type dagJob struct {
 	Next       time.Time
 	Schedule   cron.Schedule
 	Client     client.Client
+	// Decisions records the decision made on each firing. It is nil when
+	// the decisions are not recorded, e.g. in the schedule preview.
+	Decisions persistence.DecisionStore
 }
 
 // GetDAG returns the DAG associated with this job.
@@ -41,23 +47,98 @@ func (job *dagJob) GetDAG(_ context.Context) *digraph.DAG {
 
 // Start attempts to run the job if it is not already running and is ready.
 func (job *dagJob) Start(ctx context.Context) error {
+	if job.Client.IsSuspended(ctx, job.DAG.ID()) {
+		job.record(ctx, ScheduleTypeStart, model.DecisionSuspended, "")
+		return ErrJobSuspended
+	}
+
 	latestStatus, err := job.Client.GetLatestStatus(ctx, job.DAG)
 	if err != nil {
+		job.record(ctx, ScheduleTypeStart, model.DecisionFailed, err.Error())
 		return err
 	}
 
 	// Guard against already running jobs.
 	if latestStatus.Status == scheduler.StatusRunning {
+		job.record(ctx, ScheduleTypeStart, model.DecisionSkippedRunning, ErrJobRunning.Error())
 		return ErrJobRunning
 	}
 
 	// Check if the job is ready to start.
 	if err := job.ready(ctx, latestStatus); err != nil {
+		var skipped *skippedError
+		if errors.As(err, &skipped) {
+			// Record the skipped run so that it is visible in the history.
+			if err := job.Client.RecordSkipped(ctx, job.DAG, job.Next, skipped.reason); err != nil {
+				logger.Error(ctx, "failed to record the skipped run", "err", err)
+			}
+		}
+		job.record(ctx, ScheduleTypeStart, skipDecision(err), err.Error())
 		return err
 	}
 
-	// Job is ready; proceed to start.
-	return job.Client.Start(ctx, job.DAG, client.StartOptions{Quiet: true})
+	// Job is ready; record the firing and start. The call returns when the
+	// run finishes, so the outcome is recorded in a separate entry.
+	job.record(ctx, ScheduleTypeStart, model.DecisionStarted, "")
+	err = job.Client.Start(ctx, job.DAG, client.StartOptions{Quiet: true, ScheduledTime: job.Next})
+	switch {
+	case err == nil:
+		job.recordOutcome(ctx, ScheduleTypeStart, model.OutcomeSucceeded, "")
+	case errors.Is(err, digraph.ErrConditionNotMet):
+		job.recordOutcome(ctx, ScheduleTypeStart, model.OutcomePreconditionFailed, err.Error())
+	default:
+		job.recordOutcome(ctx, ScheduleTypeStart, model.OutcomeFailed, err.Error())
+	}
+	return err
+}
+
+// skipDecision returns the decision for the error returned by ready.
+func skipDecision(err error) string {
+	switch {
+	case errors.Is(err, ErrJobRunning):
+		return model.DecisionSkippedRunning
+	case errors.Is(err, ErrJobSuccess):
+		return model.DecisionSkippedSuccessful
+	case errors.Is(err, ErrJobFinished):
+		return model.DecisionSkippedFinished
+	case errors.Is(err, ErrJobSkipped):
+		return model.DecisionSkippedCalendar
+	default:
+		return model.DecisionFailed
+	}
+}
+
+// record persists the decision made on the firing of the schedule.
+func (job *dagJob) record(ctx context.Context, typ ScheduleType, decision, reason string) {
+	job.append(ctx, model.SchedulerDecision{
+		ScheduledTime: job.Next,
+		DAG:           job.DAG.ID(),
+		Type:          strings.ToLower(typ.String()),
+		Decision:      decision,
+		Reason:        reason,
+	})
+}
+
+// recordOutcome persists the outcome of the run started on the firing.
+func (job *dagJob) recordOutcome(ctx context.Context, typ ScheduleType, outcome, reason string) {
+	job.append(ctx, model.SchedulerDecision{
+		ScheduledTime: job.Next,
+		DAG:           job.DAG.ID(),
+		Type:          strings.ToLower(typ.String()),
+		Decision:      model.DecisionStarted,
+		Reason:        reason,
+		Outcome:       outcome,
+	})
+}
+
+func (job *dagJob) append(ctx context.Context, decision model.SchedulerDecision) {
+	if job.Decisions == nil {
+		return
+	}
+	decision.Timestamp = time.Now()
+	if err := job.Decisions.Append(ctx, decision); err != nil {
+		logger.Error(ctx, "failed to record the scheduler decision", "err", err)
+	}
 }
 
 // ready checks whether the job can be safely started based on the latest status.
@@ -67,11 +148,17 @@ func (job *dagJob) ready(ctx context.Context, latestStatus model.Status) error {
 		return ErrJobRunning
 	}
 
-	latestStartedAt, err := stringutil.ParseTime(latestStatus.StartedAt)
+	// Compare with the scheduled time of the latest run as the start may
+	// have been delayed past the next scheduled time.
+	latestTime := latestStatus.StartedAt
+	if latestStatus.ScheduledTime != "" {
+		latestTime = latestStatus.ScheduledTime
+	}
+	latestStartedAt, err := stringutil.ParseTime(latestTime)
 	if err != nil {
 		// If parsing fails, log and continue (don't skip).
 		logger.Error(ctx, "failed to parse the last successful run time", "err", err)
-		return nil
+		return job.skipByCalendar()
 	}
 
 	// Skip if the last successful run time is on or after the next scheduled time.
@@ -80,10 +167,42 @@ func (job *dagJob) ready(ctx context.Context, latestStatus model.Status) error {
 		return ErrJobFinished
 	}
 
+	// Skip if the scheduled time is excluded by the calendar of the DAG.
+	if err := job.skipByCalendar(); err != nil {
+		return err
+	}
+
 	// Check if we should skip this run due to a prior successful run.
 	return job.skipIfSuccessful(ctx, latestStatus, latestStartedAt)
 }
 
+// skippedError is returned when the scheduled run is skipped by the
+// excluded dates or the blackout windows of the DAG.
+type skippedError struct {
+	reason string
+}
+
+func (e *skippedError) Error() string {
+	return ErrJobSkipped.Error() + ": " + e.reason
+}
+
+func (e *skippedError) Unwrap() error {
+	return ErrJobSkipped
+}
+
+// skipByCalendar checks the scheduled time against the excluded dates and the
+// blackout windows of the DAG in the timezone of the schedule.
+func (job *dagJob) skipByCalendar() error {
+	next := job.Next
+	if location := (digraph.Schedule{Parsed: job.Schedule}).Location(); location != nil {
+		next = next.In(location)
+	}
+	if reason := job.DAG.SkipReason(next); reason != "" {
+		return &skippedError{reason: reason}
+	}
+	return nil
+}
+
 // skipIfSuccessful checks if the DAG has already run successfully in the window since the last scheduled time.
 // If so, the current run is skipped.
 func (job *dagJob) skipIfSuccessful(ctx context.Context, latestStatus model.Status, latestStartedAt time.Time) error {
@@ -111,18 +230,34 @@ func (job *dagJob) prevExecTime(_ context.Context) time.Time {
 
 // Stop halts a running job if it's currently running.
 func (job *dagJob) Stop(ctx context.Context) error {
+	if job.Client.IsSuspended(ctx, job.DAG.ID()) {
+		job.record(ctx, ScheduleTypeStop, model.DecisionSuspended, "")
+		return ErrJobSuspended
+	}
 	latestStatus, err := job.Client.GetLatestStatus(ctx, job.DAG)
 	if err != nil {
+		job.record(ctx, ScheduleTypeStop, model.DecisionFailed, err.Error())
 		return err
 	}
 	if latestStatus.Status != scheduler.StatusRunning {
+		job.record(ctx, ScheduleTypeStop, model.DecisionSkippedNotRunning, ErrJobIsNotRunning.Error())
 		return ErrJobIsNotRunning
 	}
-	return job.Client.Stop(ctx, job.DAG)
+	if err := job.Client.Stop(ctx, job.DAG); err != nil {
+		job.record(ctx, ScheduleTypeStop, model.DecisionFailed, err.Error())
+		return err
+	}
+	job.record(ctx, ScheduleTypeStop, model.DecisionStopped, "")
+	return nil
 }
 
 // Restart restarts the job unconditionally (quiet mode).
 func (job *dagJob) Restart(ctx context.Context) error {
+	if job.Client.IsSuspended(ctx, job.DAG.ID()) {
+		job.record(ctx, ScheduleTypeRestart, model.DecisionSuspended, "")
+		return ErrJobSuspended
+	}
+	job.record(ctx, ScheduleTypeRestart, model.DecisionRestarted, "")
 	return job.Client.Restart(ctx, job.DAG, client.RestartOptions{Quiet: true})
 }
 
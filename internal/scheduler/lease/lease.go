@@ -0,0 +1,204 @@
+// Package lease implements leader election among scheduler instances
+// through a lease file in a shared directory.
+//
+// The lease file records the instance holding the leadership, when the
+// lease expires and a fencing token. The token is incremented whenever the
+// leadership changes hands, so a stale leader, e.g. a process resumed after
+// being paused for longer than the lease, can detect it has been replaced
+// before acting.
+package lease
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// FileName is the name of the lease file in the lease directory.
+const FileName = "scheduler.lease"
+
+// Lease is the record of the leadership stored in the lease file.
+type Lease struct {
+	// Holder is the identity of the instance holding the lease.
+	Holder string `json:"holder"`
+	// Token is the fencing token, incremented on each change of the holder.
+	Token int64 `json:"token"`
+	// AcquiredAt is the time the holder acquired the lease.
+	AcquiredAt time.Time `json:"acquiredAt"`
+	// ExpiresAt is the time the lease expires unless renewed.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Expired reports whether the lease is expired at the time.
+func (l *Lease) Expired(now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+// Read reads the lease in the directory. It returns nil if no instance has
+// ever acquired the lease.
+func Read(dir string) (*Lease, error) {
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var l Lease
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("invalid lease file: %w", err)
+	}
+	return &l, nil
+}
+
+// Elector acquires and renews the lease on behalf of an instance.
+type Elector struct {
+	dir    string
+	id     string
+	period time.Duration
+
+	mu    sync.Mutex
+	lease *Lease
+}
+
+// NewElector creates an elector for the instance identified by id. The lease
+// acquired by the elector expires after the period unless renewed.
+func NewElector(dir, id string, period time.Duration) *Elector {
+	return &Elector{dir: dir, id: id, period: period}
+}
+
+// ID returns the identity of the instance.
+func (e *Elector) ID() string {
+	return e.id
+}
+
+// TryAcquire acquires the lease if it is free or expired, or renews it if
+// the instance holds it. It reports whether the instance is the leader.
+func (e *Elector) TryAcquire() (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var leader bool
+	err := e.withLock(func() error {
+		now := time.Now()
+		current, err := Read(e.dir)
+		if err != nil {
+			return err
+		}
+
+		next := Lease{Holder: e.id, AcquiredAt: now, ExpiresAt: now.Add(e.period)}
+		switch {
+		case current == nil:
+			next.Token = 1
+		case current.Holder == e.id && e.holds(current) && !current.Expired(now):
+			// Renew the lease held by this instance.
+			next.Token = current.Token
+			next.AcquiredAt = current.AcquiredAt
+		case current.Expired(now):
+			next.Token = current.Token + 1
+		default:
+			// Another instance holds the lease.
+			e.lease = nil
+			return nil
+		}
+
+		if err := e.write(&next); err != nil {
+			return err
+		}
+		e.lease = &next
+		leader = true
+		return nil
+	})
+	if err != nil {
+		e.lease = nil
+		return false, err
+	}
+	return leader, nil
+}
+
+// Validate checks the lease file still records the lease held by the
+// instance. It is used as the fencing check before acting as the leader.
+func (e *Elector) Validate() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.lease == nil || e.lease.Expired(time.Now()) {
+		return false
+	}
+	current, err := Read(e.dir)
+	if err != nil || current == nil {
+		return false
+	}
+	return current.Holder == e.id && e.holds(current)
+}
+
+// Release gives up the lease if the instance holds it, so that a standby
+// can take over without waiting for the lease to expire.
+func (e *Elector) Release() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.lease == nil {
+		return nil
+	}
+	defer func() {
+		e.lease = nil
+	}()
+
+	return e.withLock(func() error {
+		current, err := Read(e.dir)
+		if err != nil || current == nil || current.Holder != e.id || !e.holds(current) {
+			return err
+		}
+		released := *current
+		released.ExpiresAt = time.Now()
+		return e.write(&released)
+	})
+}
+
+// holds reports whether the lease is the one acquired by the instance.
+func (e *Elector) holds(l *Lease) bool {
+	return e.lease != nil && e.lease.Token == l.Token
+}
+
+// withLock runs fn holding an exclusive lock on the lock file next to the
+// lease file so that the instances do not update the lease concurrently.
+func (e *Elector) withLock(fn func() error) error {
+	if err := os.MkdirAll(e.dir, 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(e.dir, FileName+".lock"), os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock the lease file: %w", err)
+	}
+	defer func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+	}()
+
+	return fn()
+}
+
+// write replaces the lease file atomically.
+func (e *Elector) write(l *Lease) error {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(e.dir, FileName+".tmp")
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(e.dir, FileName))
+}
@@ -0,0 +1,82 @@
+package lease
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestElector(t *testing.T) {
+	t.Run("SingleLeader", func(t *testing.T) {
+		dir := t.TempDir()
+		a := NewElector(dir, "a", time.Minute)
+		b := NewElector(dir, "b", time.Minute)
+
+		leader, err := a.TryAcquire()
+		require.NoError(t, err)
+		require.True(t, leader)
+
+		leader, err = b.TryAcquire()
+		require.NoError(t, err)
+		require.False(t, leader)
+
+		// The leader renews the lease.
+		leader, err = a.TryAcquire()
+		require.NoError(t, err)
+		require.True(t, leader)
+		require.True(t, a.Validate())
+		require.False(t, b.Validate())
+
+		l, err := Read(dir)
+		require.NoError(t, err)
+		require.Equal(t, "a", l.Holder)
+		require.Equal(t, int64(1), l.Token)
+	})
+	t.Run("FailoverOnExpiry", func(t *testing.T) {
+		dir := t.TempDir()
+		a := NewElector(dir, "a", 100*time.Millisecond)
+		b := NewElector(dir, "b", 100*time.Millisecond)
+
+		leader, err := a.TryAcquire()
+		require.NoError(t, err)
+		require.True(t, leader)
+
+		time.Sleep(150 * time.Millisecond)
+
+		leader, err = b.TryAcquire()
+		require.NoError(t, err)
+		require.True(t, leader)
+
+		// The stale leader is fenced off and cannot renew the lease.
+		require.False(t, a.Validate())
+		leader, err = a.TryAcquire()
+		require.NoError(t, err)
+		require.False(t, leader)
+
+		l, err := Read(dir)
+		require.NoError(t, err)
+		require.Equal(t, "b", l.Holder)
+		require.Equal(t, int64(2), l.Token)
+	})
+	t.Run("Release", func(t *testing.T) {
+		dir := t.TempDir()
+		a := NewElector(dir, "a", time.Minute)
+		b := NewElector(dir, "b", time.Minute)
+
+		leader, err := a.TryAcquire()
+		require.NoError(t, err)
+		require.True(t, leader)
+		require.NoError(t, a.Release())
+		require.False(t, a.Validate())
+
+		leader, err = b.TryAcquire()
+		require.NoError(t, err)
+		require.True(t, leader)
+	})
+	t.Run("NoLease", func(t *testing.T) {
+		l, err := Read(t.TempDir())
+		require.NoError(t, err)
+		require.Nil(t, l)
+	})
+}
@@ -3,6 +3,7 @@ package scheduler
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/dagu-org/dagu/internal/client"
 	"github.com/dagu-org/dagu/internal/fileutil"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/persistence"
 	"github.com/dagu-org/dagu/internal/scheduler/filenotify"
 	"github.com/robfig/cron/v3"
 
@@ -49,10 +51,12 @@ type dagJobManager struct {
 	client     client.Client
 	executable string
 	workDir    string
+	decisions  persistence.DecisionStore
 }
 
 // NewDAGJobManager creates a new DAG manager with the given configuration.
-func NewDAGJobManager(dir string, client client.Client, executable, workDir string) JobManager {
+// The decisions made on the firings are recorded in the store unless it is nil.
+func NewDAGJobManager(dir string, client client.Client, executable, workDir string, decisions persistence.DecisionStore) JobManager {
 	return &dagJobManager{
 		targetDir:  dir,
 		lock:       sync.Mutex{},
@@ -60,6 +64,7 @@ func NewDAGJobManager(dir string, client client.Client, executable, workDir stri
 		client:     client,
 		executable: executable,
 		workDir:    workDir,
+		decisions:  decisions,
 	}
 }
 
@@ -68,23 +73,31 @@ func (m *dagJobManager) Start(ctx context.Context, done chan any) error {
 		return fmt.Errorf("failed to initialize DAGs: %w", err)
 	}
 
-	go m.watchDags(ctx, done)
+	// Watch the directories before returning so that the changes made
+	// after starting are not missed.
+	watcher, err := filenotify.New(time.Minute)
+	if err != nil {
+		logger.Error(ctx, "Watcher creation failed", "err", err)
+		return nil
+	}
+	_ = walkDir(m.targetDir, func(dir string) {
+		_ = watcher.Add(dir)
+	}, nil)
+
+	go m.watchDags(ctx, watcher, done)
 
 	return nil
 }
 
-func (m *dagJobManager) Next(ctx context.Context, now time.Time) ([]*ScheduledJob, error) {
+func (m *dagJobManager) Next(_ context.Context, now time.Time) ([]*ScheduledJob, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
 	var jobs []*ScheduledJob
 
+	// Suspended DAGs are included so that the jobs record the firings as
+	// suspended rather than the firings disappearing silently.
 	for _, dag := range m.registry {
-		dagName := strings.TrimSuffix(filepath.Base(dag.Location), filepath.Ext(dag.Location))
-		if m.client.IsSuspended(ctx, dagName) {
-			continue
-		}
-
 		schedules := []struct {
 			items []digraph.Schedule
 			typ   ScheduleType
@@ -96,6 +109,9 @@ func (m *dagJobManager) Next(ctx context.Context, now time.Time) ([]*ScheduledJo
 
 		for _, s := range schedules {
 			for _, schedule := range s.items {
+				// The schedule is evaluated in the timezone of the DAG if it
+				// specifies one and the next time is returned in the location
+				// of now, i.e., the timezone of the scheduler.
 				next := schedule.Parsed.Next(now)
 				job := NewScheduledJob(next, m.createJob(dag, next, schedule.Parsed), s.typ)
 				jobs = append(jobs, job)
@@ -114,6 +130,7 @@ func (m *dagJobManager) createJob(dag *digraph.DAG, next time.Time, schedule cro
 		Next:       next,
 		Schedule:   schedule,
 		Client:     m.client,
+		Decisions:  m.decisions,
 	}
 }
 
@@ -122,41 +139,59 @@ func (m *dagJobManager) initialize(ctx context.Context) error {
 	defer m.lock.Unlock()
 
 	logger.Info(ctx, "Loading DAGs", "dir", m.targetDir)
-	fis, err := os.ReadDir(m.targetDir)
-	if err != nil {
-		return err
-	}
 
 	var dags []string
-	for _, fi := range fis {
-		if fileutil.IsYAMLFile(fi.Name()) {
-			dag, err := digraph.Load(ctx, filepath.Join(m.targetDir, fi.Name()), digraph.OnlyMetadata(), digraph.WithoutEval())
-			if err != nil {
-				logger.Error(ctx, "DAG load failed", "err", err, "name", fi.Name())
-				continue
-			}
-			m.registry[fi.Name()] = dag
-			dags = append(dags, fi.Name())
+	err := walkDir(m.targetDir, nil, func(filePath string) {
+		if name, ok := m.loadDAG(ctx, filePath); ok {
+			dags = append(dags, name)
 		}
+	})
+	if err != nil {
+		return err
 	}
 
 	logger.Info(ctx, "DAGs loaded", "dags", strings.Join(dags, ","))
 	return nil
 }
 
-func (m *dagJobManager) watchDags(ctx context.Context, done chan any) {
-	watcher, err := filenotify.New(time.Minute)
+// loadDAG loads the DAG file into the registry and returns its name in the
+// registry, which is the path relative to the DAGs directory.
+func (m *dagJobManager) loadDAG(ctx context.Context, filePath string) (string, bool) {
+	name := m.registryName(filePath)
+	dag, err := digraph.Load(ctx, filePath, digraph.OnlyMetadata(), digraph.WithoutEval(), digraph.WithDAGsDir(m.targetDir))
 	if err != nil {
-		logger.Error(ctx, "Watcher creation failed", "err", err)
-		return
+		logger.Error(ctx, "DAG load failed", "err", err, "name", name)
+		return "", false
+	}
+	m.registry[name] = dag
+	return name, true
+}
+
+// removeDAGs removes the DAG of the file, or the DAGs under the directory,
+// from the registry.
+func (m *dagJobManager) removeDAGs(ctx context.Context, filePath string) {
+	name := m.registryName(filePath)
+	for key := range m.registry {
+		if key == name || strings.HasPrefix(key, name+"/") {
+			delete(m.registry, key)
+			logger.Info(ctx, "DAG removed", "name", key)
+		}
+	}
+}
+
+func (m *dagJobManager) registryName(filePath string) string {
+	rel, err := filepath.Rel(m.targetDir, filePath)
+	if err != nil {
+		return filepath.Base(filePath)
 	}
+	return filepath.ToSlash(rel)
+}
 
+func (m *dagJobManager) watchDags(ctx context.Context, watcher filenotify.FileWatcher, done chan any) {
 	defer func() {
 		_ = watcher.Close()
 	}()
 
-	_ = watcher.Add(m.targetDir)
-
 	for {
 		select {
 		case <-done:
@@ -167,25 +202,8 @@ func (m *dagJobManager) watchDags(ctx context.Context, done chan any) {
 				return
 			}
 
-			if !fileutil.IsYAMLFile(event.Name) {
-				continue
-			}
-
 			m.lock.Lock()
-			if event.Op == fsnotify.Create || event.Op == fsnotify.Write {
-				filePath := filepath.Join(m.targetDir, filepath.Base(event.Name))
-				dag, err := digraph.Load(ctx, filePath, digraph.OnlyMetadata(), digraph.WithoutEval())
-				if err != nil {
-					logger.Error(ctx, "DAG load failed", "err", err, "file", event.Name)
-				} else {
-					m.registry[filepath.Base(event.Name)] = dag
-					logger.Info(ctx, "DAG added/updated", "name", filepath.Base(event.Name))
-				}
-			}
-			if event.Op == fsnotify.Rename || event.Op == fsnotify.Remove {
-				delete(m.registry, filepath.Base(event.Name))
-				logger.Info(ctx, "DAG removed", "name", filepath.Base(event.Name))
-			}
+			m.handleEvent(ctx, watcher, event)
 			m.lock.Unlock()
 
 		case err, ok := <-watcher.Errors():
@@ -197,3 +215,72 @@ func (m *dagJobManager) watchDags(ctx context.Context, done chan any) {
 		}
 	}
 }
+
+func (m *dagJobManager) handleEvent(ctx context.Context, watcher filenotify.FileWatcher, event fsnotify.Event) {
+	if event.Op == fsnotify.Rename || event.Op == fsnotify.Remove {
+		m.removeDAGs(ctx, event.Name)
+		return
+	}
+
+	if event.Op == fsnotify.Create {
+		if fi, err := os.Stat(event.Name); err == nil && fi.IsDir() {
+			if skipDir(fi.Name()) {
+				return
+			}
+			// Watch the new directory and load the DAGs created in it
+			// before it was watched.
+			_ = walkDir(event.Name, func(dir string) {
+				if err := watcher.Add(dir); err != nil {
+					logger.Error(ctx, "Failed to watch the directory", "err", err, "dir", dir)
+				}
+			}, func(filePath string) {
+				if name, ok := m.loadDAG(ctx, filePath); ok {
+					logger.Info(ctx, "DAG added/updated", "name", name)
+				}
+			})
+			return
+		}
+	}
+
+	if !fileutil.IsYAMLFile(event.Name) {
+		return
+	}
+	if event.Op == fsnotify.Create || event.Op == fsnotify.Write {
+		if name, ok := m.loadDAG(ctx, event.Name); ok {
+			logger.Info(ctx, "DAG added/updated", "name", name)
+		}
+	}
+}
+
+// walkDir calls onDir for the directory and its subdirectories, and onFile
+// for the YAML files in them. Hidden directories such as .git and the
+// directories of template library files are skipped.
+func walkDir(root string, onDir, onFile func(string)) error {
+	return filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if filePath == root {
+				return err
+			}
+			// Skip the directories that cannot be read.
+			return nil
+		}
+		if entry.IsDir() {
+			if filePath != root && skipDir(entry.Name()) {
+				return filepath.SkipDir
+			}
+			if onDir != nil {
+				onDir(filePath)
+			}
+			return nil
+		}
+		if onFile != nil && fileutil.IsYAMLFile(entry.Name()) {
+			onFile(filePath)
+		}
+		return nil
+	})
+}
+
+// skipDir reports whether the directory is not searched for DAGs.
+func skipDir(name string) bool {
+	return strings.HasPrefix(name, ".") || name == digraph.TemplatesDir
+}
@@ -2,6 +2,8 @@ package scheduler
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -13,7 +15,7 @@ func TestReadEntries(t *testing.T) {
 	now := expectedNext.Add(-time.Second)
 
 	t.Run("InvalidDirectory", func(t *testing.T) {
-		manager := NewDAGJobManager("invalid_directory", nil, "", "")
+		manager := NewDAGJobManager("invalid_directory", nil, "", "", nil)
 		jobs, err := manager.Next(context.Background(), expectedNext)
 		require.NoError(t, err)
 		require.Len(t, jobs, 0)
@@ -57,11 +59,79 @@ func TestReadEntries(t *testing.T) {
 		err = th.client.ToggleSuspend(ctx, dag.Name, true)
 		require.NoError(t, err)
 
-		// check if the job is suspended and not returned
+		// The suspended job is returned so that the firing is recorded,
+		// but it does not start.
 		afterSuspend, err := th.manager.Next(ctx, now)
 		require.NoError(t, err)
-		require.Equal(t, len(afterSuspend), len(beforeSuspend)-1, "suspended job should not be returned")
+		require.Equal(t, len(beforeSuspend), len(afterSuspend))
+		err = findJobByName(t, afterSuspend, "scheduled_job").Job.Start(ctx)
+		require.ErrorIs(t, err, ErrJobSuspended)
 	})
+	t.Run("Namespaces", func(t *testing.T) {
+		th := setupTest(t)
+		ctx := context.Background()
+
+		// Two teams have the DAG with the same name in their subdirectories.
+		dagsDir := t.TempDir()
+		writeDAG(t, filepath.Join(dagsDir, "team-a", "etl.yaml"))
+		writeDAG(t, filepath.Join(dagsDir, "team-b", "etl.yaml"))
+		writeDAG(t, filepath.Join(dagsDir, ".hidden", "etl.yaml"))
+		writeDAG(t, filepath.Join(dagsDir, "team-a", "templates", "etl.yaml"))
+
+		done := make(chan any)
+		defer close(done)
+
+		manager := NewDAGJobManager(dagsDir, th.client, "", "", nil)
+		require.NoError(t, manager.Start(ctx, done))
+
+		jobs, err := manager.Next(ctx, now)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"team-a/etl", "team-b/etl"}, jobIDs(t, jobs))
+
+		// The DAGs are suspended by the ID.
+		require.NoError(t, th.client.ToggleSuspend(ctx, "team-a/etl", true))
+		require.True(t, th.client.IsSuspended(ctx, "team-a/etl"))
+		require.False(t, th.client.IsSuspended(ctx, "team-b/etl"))
+
+		// The DAGs in a new subdirectory are registered.
+		writeDAG(t, filepath.Join(dagsDir, "team-c", "daily", "etl.yaml"))
+		require.Eventually(t, func() bool {
+			jobs, err := manager.Next(ctx, now)
+			require.NoError(t, err)
+			return len(jobs) == 3
+		}, 5*time.Second, 50*time.Millisecond)
+
+		jobs, err = manager.Next(ctx, now)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"team-a/etl", "team-b/etl", "team-c/daily/etl"}, jobIDs(t, jobs))
+
+		// The DAGs are unregistered when the subdirectory is removed.
+		require.NoError(t, os.RemoveAll(filepath.Join(dagsDir, "team-c")))
+		require.Eventually(t, func() bool {
+			jobs, err := manager.Next(ctx, now)
+			require.NoError(t, err)
+			return len(jobs) == 2
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
+
+func writeDAG(t *testing.T, filePath string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+	require.NoError(t, os.WriteFile(filePath, []byte("schedule: \"0 * * * *\"\nsteps:\n  - name: step1\n    command: \"true\"\n"), 0600))
+}
+
+func jobIDs(t *testing.T, jobs []*ScheduledJob) []string {
+	t.Helper()
+
+	var ids []string
+	for _, job := range jobs {
+		dagJob, ok := job.Job.(*dagJob)
+		require.True(t, ok)
+		ids = append(ids, dagJob.DAG.ID())
+	}
+	return ids
 }
 
 func findJobByName(t *testing.T, jobs []*ScheduledJob, name string) *ScheduledJob {
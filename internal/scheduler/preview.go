@@ -0,0 +1,139 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/digraph"
+)
+
+// Firing is an upcoming firing of a schedule of a DAG.
+type Firing struct {
+	DAG  *digraph.DAG
+	Type ScheduleType
+	// Time is the time of the firing in the location of the scheduler.
+	Time time.Time
+	// Location is the timezone of the schedule when the DAG specifies one,
+	// with the timezone key or CRON_TZ. It is nil otherwise.
+	Location *time.Location
+	// Delay is the delay to start the DAG after the time by the schedule
+	// jitter. The time is the scheduled time of the run regardless.
+	Delay time.Duration
+	// SkipReason is the reason the run will be skipped by the excluded
+	// dates or the blackout windows of the DAG, if any.
+	SkipReason string
+}
+
+// LoadDAGJobManager loads the DAGs in the directory into a job manager
+// without watching the changes, e.g. to preview the schedules.
+func LoadDAGJobManager(ctx context.Context, dir string, client client.Client) (JobManager, error) {
+	m := &dagJobManager{
+		targetDir: dir,
+		registry:  map[string]*digraph.DAG{},
+		client:    client,
+	}
+	if err := m.initialize(ctx); err != nil {
+		return nil, fmt.Errorf("failed to load DAGs: %w", err)
+	}
+	return m, nil
+}
+
+// Firings returns the firings of the jobs after the time in the order the
+// scheduler would invoke them. It stops at the limit, or at the end time
+// unless it is zero. Suspended DAGs are excluded as the scheduler does not
+// start them.
+func Firings(ctx context.Context, manager JobManager, from, until time.Time, limit int) ([]Firing, error) {
+	if limit <= 0 && until.IsZero() {
+		return nil, errors.New("either the limit or the end time is required")
+	}
+
+	var ret []Firing
+
+	// The suspension is checked once for each DAG rather than on every
+	// firing, as it reads the flag from the disk.
+	suspended := map[string]bool{}
+	isSuspended := func(job *dagJob) bool {
+		id := job.DAG.ID()
+		if _, ok := suspended[id]; !ok {
+			suspended[id] = job.Client.IsSuspended(ctx, id)
+		}
+		return suspended[id]
+	}
+
+	// The same offset as the scheduler so that a firing at the time is included.
+	t := from.Add(-time.Second)
+	for limit <= 0 || len(ret) < limit {
+		scheduled, err := manager.Next(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+
+		// A schedule that never fires again has the zero time, and the
+		// firings of suspended DAGs do not start.
+		var jobs []*ScheduledJob
+		for _, job := range scheduled {
+			if job.Next.IsZero() {
+				continue
+			}
+			if dj, ok := job.Job.(*dagJob); ok && isSuspended(dj) {
+				continue
+			}
+			jobs = append(jobs, job)
+		}
+		if len(jobs) == 0 {
+			break
+		}
+
+		sort.SliceStable(jobs, func(i, j int) bool {
+			return jobs[i].Next.Before(jobs[j].Next)
+		})
+		next := jobs[0].Next
+		if !until.IsZero() && next.After(until) {
+			break
+		}
+
+		var group []Firing
+		for _, job := range jobs {
+			if !job.Next.Equal(next) {
+				break
+			}
+			dj, ok := job.Job.(*dagJob)
+			if !ok {
+				continue
+			}
+			firing := Firing{
+				DAG:      dj.DAG,
+				Type:     job.Type,
+				Time:     job.Next,
+				Location: digraph.Schedule{Parsed: dj.Schedule}.Location(),
+			}
+			if job.Type == ScheduleTypeStart {
+				firing.Delay = dj.DAG.JitterDelay()
+				var skipped *skippedError
+				if errors.As(dj.skipByCalendar(), &skipped) {
+					firing.SkipReason = skipped.reason
+				}
+			}
+			group = append(group, firing)
+		}
+
+		// Order the firings at the same time by the DAG for a stable output.
+		sort.Slice(group, func(i, j int) bool {
+			if group[i].DAG.ID() != group[j].DAG.ID() {
+				return group[i].DAG.ID() < group[j].DAG.ID()
+			}
+			return group[i].Type < group[j].Type
+		})
+		if limit > 0 && len(ret)+len(group) > limit {
+			group = group[:limit-len(ret)]
+		}
+		ret = append(ret, group...)
+		t = next
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirings(t *testing.T) {
+	th := setupTest(t)
+	ctx := context.Background()
+
+	dagsDir := t.TempDir()
+	writeSpec := func(name, spec string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dagsDir, name), []byte(spec), 0600))
+	}
+	writeSpec("hourly.yaml", `schedule: "0 * * * *"
+steps:
+  - name: step1
+    command: "true"
+`)
+	writeSpec("tokyo.yaml", `schedule:
+  start: "CRON_TZ=Asia/Tokyo 0 9 * * *"
+  stop: "30 0 * * *"
+steps:
+  - name: step1
+    command: "true"
+`)
+	writeSpec("newyork.yaml", `timezone: America/New_York
+schedule: "0 9 * * *"
+excludeDates:
+  - "2020-01-01"
+steps:
+  - name: step1
+    command: "true"
+`)
+
+	manager, err := LoadDAGJobManager(ctx, dagsDir, th.client)
+	require.NoError(t, err)
+
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Limit", func(t *testing.T) {
+		firings, err := Firings(ctx, manager, from, time.Time{}, 3)
+		require.NoError(t, err)
+		require.Len(t, firings, 3)
+
+		// The firing at the start time is included.
+		require.Equal(t, "hourly", firings[0].DAG.Name)
+		require.Equal(t, from, firings[0].Time)
+		require.Equal(t, ScheduleTypeStart, firings[0].Type)
+
+		// 09:00 in Tokyo is 00:00 UTC.
+		require.Equal(t, "tokyo", firings[1].DAG.Name)
+		require.Equal(t, from, firings[1].Time)
+		require.Equal(t, "Asia/Tokyo", firings[1].Location.String())
+
+		require.Equal(t, "tokyo", firings[2].DAG.Name)
+		require.Equal(t, ScheduleTypeStop, firings[2].Type)
+		require.Equal(t, from.Add(30*time.Minute), firings[2].Time)
+		require.Nil(t, firings[2].Location)
+	})
+	t.Run("Between", func(t *testing.T) {
+		firings, err := Firings(ctx, manager, from.Add(time.Minute), from.Add(3*time.Hour), 0)
+		require.NoError(t, err)
+
+		var times []time.Time
+		for _, f := range firings {
+			times = append(times, f.Time)
+		}
+		require.Equal(t, []time.Time{
+			from.Add(30 * time.Minute),
+			from.Add(time.Hour),
+			from.Add(2 * time.Hour),
+			from.Add(3 * time.Hour),
+		}, times)
+	})
+	t.Run("Suspended", func(t *testing.T) {
+		require.NoError(t, th.client.ToggleSuspend(ctx, "hourly", true))
+		t.Cleanup(func() {
+			_ = th.client.ToggleSuspend(ctx, "hourly", false)
+		})
+
+		firings, err := Firings(ctx, manager, from, from.Add(3*time.Hour), 0)
+		require.NoError(t, err)
+		for _, f := range firings {
+			require.Equal(t, "tokyo", f.DAG.Name)
+		}
+	})
+	t.Run("Timezone", func(t *testing.T) {
+		firings, err := Firings(ctx, manager, from.Add(time.Minute), from.Add(24*time.Hour), 0)
+		require.NoError(t, err)
+
+		var found bool
+		for _, f := range firings {
+			if f.DAG.Name != "newyork" {
+				continue
+			}
+			// 09:00 in New York is 14:00 UTC in winter.
+			require.Equal(t, from.Add(14*time.Hour), f.Time)
+			require.Equal(t, "America/New_York", f.Location.String())
+			require.Equal(t, "excluded date 2020-01-01", f.SkipReason)
+			found = true
+		}
+		require.True(t, found)
+	})
+	t.Run("Unbounded", func(t *testing.T) {
+		_, err := Firings(ctx, manager, from, time.Time{}, 0)
+		require.Error(t, err)
+	})
+}
@@ -13,7 +13,9 @@ import (
 	"time"
 
 	"github.com/dagu-org/dagu/internal/config"
+	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/logger"
+	"github.com/dagu-org/dagu/internal/scheduler/lease"
 )
 
 // Job is the interface for the actual DAG.
@@ -32,6 +34,16 @@ type Scheduler struct {
 	stopChan chan struct{}
 	running  atomic.Bool
 	location *time.Location
+
+	// elector elects the leader among the scheduler instances when high
+	// availability is enabled. Only the leader runs the jobs.
+	elector     *lease.Elector
+	leasePeriod time.Duration
+	leader      atomic.Bool
+
+	// throttle limits the number of DAGs started in the same second. It is
+	// nil if the number is unlimited.
+	throttle *startThrottle
 }
 
 func New(cfg *config.Config, manager JobManager) *Scheduler {
@@ -40,12 +52,30 @@ func New(cfg *config.Config, manager JobManager) *Scheduler {
 		timeLoc = time.Local
 	}
 
-	return &Scheduler{
+	s := &Scheduler{
 		logDir:   cfg.Paths.LogDir,
 		stopChan: make(chan struct{}),
 		location: timeLoc,
 		manager:  manager,
 	}
+	if cfg.Scheduler != nil && cfg.Scheduler.HA {
+		s.leasePeriod = cfg.Scheduler.LeasePeriod
+		s.elector = lease.NewElector(cfg.Scheduler.LeaseDir, InstanceID(), s.leasePeriod)
+	}
+	if cfg.Scheduler != nil && cfg.Scheduler.MaxConcurrentStarts > 0 {
+		s.throttle = newStartThrottle(cfg.Scheduler.MaxConcurrentStarts)
+	}
+	return s
+}
+
+// InstanceID returns the identity of the scheduler instance used as the
+// holder of the lease.
+func InstanceID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
 }
 
 // ScheduleType is the type of schedule (start, stop, restart).
@@ -128,12 +158,64 @@ func (s *Scheduler) Start(ctx context.Context) error {
 		}
 	}()
 
-	logger.Info(ctx, "Scheduler started")
+	if s.elector != nil {
+		logger.Info(ctx, "Scheduler started in high availability mode", "id", s.elector.ID(), "leasePeriod", s.leasePeriod)
+		s.elect(ctx)
+		go s.keepElecting(ctx, done)
+	} else {
+		logger.Info(ctx, "Scheduler started")
+	}
 	s.start(ctx)
 
 	return nil
 }
 
+// keepElecting renews the lease while the instance is the leader, or tries
+// to take it over otherwise. The lease is tried four times per period, so a
+// standby takes over within 1.25 times the period after the leader stops:
+// the lease expires one period after the last renewal and the standby
+// notices it at its next try.
+func (s *Scheduler) keepElecting(ctx context.Context, done chan any) {
+	ticker := time.NewTicker(s.leasePeriod / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+
+		case <-ticker.C:
+			if !s.running.Load() {
+				// The lease has been released on stop.
+				continue
+			}
+			s.elect(ctx)
+
+		}
+	}
+}
+
+func (s *Scheduler) elect(ctx context.Context) {
+	leader, err := s.elector.TryAcquire()
+	if err != nil {
+		logger.Error(ctx, "Failed to acquire the scheduler lease", "err", err)
+	}
+	if s.leader.Swap(leader) == leader {
+		return
+	}
+	if leader {
+		logger.Info(ctx, "Became the scheduler leader", "id", s.elector.ID())
+	} else {
+		logger.Info(ctx, "Lost the scheduler leadership; standing by", "id", s.elector.ID())
+	}
+}
+
+// IsLeader reports whether the instance runs the jobs. It is always true
+// when high availability is disabled.
+func (s *Scheduler) IsLeader() bool {
+	return s.elector == nil || s.leader.Load()
+}
+
 func (s *Scheduler) start(ctx context.Context) {
 	t := now().Truncate(time.Minute)
 	timer := time.NewTimer(0)
@@ -158,7 +240,17 @@ func (s *Scheduler) start(ctx context.Context) {
 	}
 }
 
+// isLeading checks the lease file as well as the local state to fence off a
+// stale leader whose lease has been taken over.
+func (s *Scheduler) isLeading() bool {
+	return s.elector == nil || (s.leader.Load() && s.elector.Validate())
+}
+
 func (s *Scheduler) run(ctx context.Context, now time.Time) {
+	if !s.isLeading() {
+		return
+	}
+
 	jobs, err := s.manager.Next(ctx, now.Add(-time.Second).In(s.location))
 	if err != nil {
 		logger.Error(ctx, "failed to get next jobs", "err", err)
@@ -176,6 +268,9 @@ func (s *Scheduler) run(ctx context.Context, now time.Time) {
 		}
 
 		go func(job *ScheduledJob) {
+			if !s.waitToStart(ctx, job, now) {
+				return
+			}
 			if err := job.invoke(ctx); err != nil {
 				if errors.Is(err, ErrJobFinished) {
 					logger.Info(ctx, "job is already finished", "job", job.Job, "err", err)
@@ -183,6 +278,8 @@ func (s *Scheduler) run(ctx context.Context, now time.Time) {
 					logger.Info(ctx, "job is already running", "job", job.Job, "err", err)
 				} else if errors.Is(err, ErrJobSkipped) {
 					logger.Info(ctx, "job is skipped", "job", job.Job, "err", err)
+				} else if errors.Is(err, ErrJobSuspended) {
+					logger.Info(ctx, "job is suspended", "job", job.Job)
 				} else {
 					logger.Error(ctx, "job failed", "job", job.Job, "err", err)
 				}
@@ -191,6 +288,68 @@ func (s *Scheduler) run(ctx context.Context, now time.Time) {
 	}
 }
 
+// waitToStart delays the job by the schedule jitter of the DAG and by the
+// throttle of the starts. The scheduled time of the job is not changed. It
+// returns false if the scheduler is stopped while waiting, or if the
+// instance is no longer the leader after waiting.
+func (s *Scheduler) waitToStart(ctx context.Context, job *ScheduledJob, now time.Time) bool {
+	if job.Type == ScheduleTypeStop {
+		return true
+	}
+	var waited bool
+	if job.Type == ScheduleTypeStart {
+		var dag *digraph.DAG
+		if j, ok := job.Job.(interface {
+			GetDAG(context.Context) *digraph.DAG
+		}); ok {
+			dag = j.GetDAG(ctx)
+		}
+		if dag != nil {
+			if delay := dag.JitterDelay() - now.Sub(job.Next); delay > 0 {
+				logger.Info(ctx, "delaying the start by the schedule jitter", "job", job.Job, "delay", delay)
+				if !s.sleep(ctx, delay) {
+					return false
+				}
+				now = now.Add(delay)
+				waited = true
+			}
+		}
+	}
+	if s.throttle != nil {
+		if delay := s.throttle.reserve(now); delay > 0 {
+			logger.Info(ctx, "delaying the start by the throttle", "job", job.Job, "delay", delay)
+			if !s.sleep(ctx, delay) {
+				return false
+			}
+			waited = true
+		}
+	}
+	// The lease may have been taken over by another instance while waiting.
+	if waited && !s.isLeading() {
+		logger.Info(ctx, "not starting the delayed job as the leadership is lost", "job", job.Job)
+		return false
+	}
+	return true
+}
+
+// sleep waits for the duration. It returns false if the scheduler is stopped.
+func (s *Scheduler) sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+
+	case <-s.stopChan:
+		return false
+
+	case <-ctx.Done():
+		return false
+
+	}
+}
+
 func (*Scheduler) nextTick(now time.Time) time.Time {
 	return now.Add(time.Minute).Truncate(time.Second * 60)
 }
@@ -205,6 +364,13 @@ func (s *Scheduler) Stop(ctx context.Context) {
 	}
 
 	s.running.Store(false)
+
+	if s.elector != nil {
+		if err := s.elector.Release(); err != nil {
+			logger.Error(ctx, "Failed to release the scheduler lease", "err", err)
+		}
+		s.leader.Store(false)
+	}
 	logger.Info(ctx, "Scheduler stopped")
 }
 
@@ -2,12 +2,22 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/dagu-org/dagu/internal/client"
+	"github.com/dagu-org/dagu/internal/config"
 	"github.com/dagu-org/dagu/internal/digraph"
 	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence"
+	"github.com/dagu-org/dagu/internal/persistence/jsondb"
+	"github.com/dagu-org/dagu/internal/persistence/local"
+	"github.com/dagu-org/dagu/internal/persistence/local/storage"
 	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/scheduler/lease"
 	"github.com/dagu-org/dagu/internal/stringutil"
 	"github.com/robfig/cron/v3"
 	"github.com/stretchr/testify/require"
@@ -60,6 +70,94 @@ func TestScheduler(t *testing.T) {
 		time.Sleep(time.Second + time.Millisecond*100)
 		require.Equal(t, int32(1), entryReader.Entries[0].Job.(*mockJob).RestartCount.Load())
 	})
+	t.Run("HighAvailability", func(t *testing.T) {
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		setFixedTime(now)
+
+		th := setupTest(t)
+		th.config.Scheduler = &config.SchedulerConfig{
+			HA:          true,
+			LeaseDir:    t.TempDir(),
+			LeasePeriod: 400 * time.Millisecond,
+		}
+
+		// Two instances share the lease directory.
+		var (
+			managers   []*mockJobManager
+			schedulers []*Scheduler
+		)
+		for i := 0; i < 2; i++ {
+			manager := &mockJobManager{
+				Entries: []*ScheduledJob{{Job: &mockJob{}, Next: now}},
+			}
+			instance := New(th.config, manager)
+			// Give each instance its own identity within the process.
+			instance.elector = lease.NewElector(th.config.Scheduler.LeaseDir, fmt.Sprintf("instance-%d", i), th.config.Scheduler.LeasePeriod)
+			managers = append(managers, manager)
+			schedulers = append(schedulers, instance)
+
+			go func() {
+				_ = instance.Start(context.Background())
+			}()
+			time.Sleep(time.Millisecond * 100)
+		}
+
+		// Only the leader runs the job.
+		require.True(t, schedulers[0].IsLeader())
+		require.False(t, schedulers[1].IsLeader())
+		require.Equal(t, int32(1), managers[0].Entries[0].Job.(*mockJob).RunCount.Load())
+		require.Equal(t, int32(0), managers[1].Entries[0].Job.(*mockJob).RunCount.Load())
+
+		l, err := lease.Read(th.config.Scheduler.LeaseDir)
+		require.NoError(t, err)
+		require.Equal(t, "instance-0", l.Holder)
+
+		// The standby takes over within the lease period after the leader stops.
+		schedulers[0].Stop(context.Background())
+		defer schedulers[1].Stop(context.Background())
+		require.Eventually(t, schedulers[1].IsLeader, th.config.Scheduler.LeasePeriod, 20*time.Millisecond)
+	})
+	t.Run("Jitter", func(t *testing.T) {
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		th := setupTest(t)
+		schedulerInstance := New(th.config, &mockJobManager{})
+
+		dag := &digraph.DAG{Name: "jitter", ScheduleJitter: time.Hour}
+		delay := dag.JitterDelay()
+		require.Greater(t, delay, time.Duration(0))
+		job := &ScheduledJob{Job: &mockJob{DAG: dag}, Next: now, Type: ScheduleTypeStart}
+
+		// The job is started when the delay has passed since the scheduled time.
+		require.True(t, schedulerInstance.waitToStart(context.Background(), job, now.Add(delay)))
+
+		// The wait is canceled when the scheduler is stopped.
+		close(schedulerInstance.stopChan)
+		require.False(t, schedulerInstance.waitToStart(context.Background(), job, now))
+
+		// Stop schedules are not delayed.
+		job.Type = ScheduleTypeStop
+		require.True(t, schedulerInstance.waitToStart(context.Background(), job, now))
+	})
+	t.Run("LeadershipLostWhileDelayed", func(t *testing.T) {
+		now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		th := setupTest(t)
+		schedulerInstance := New(th.config, &mockJobManager{})
+		schedulerInstance.elector = lease.NewElector(t.TempDir(), "instance-0", time.Minute)
+
+		dag := &digraph.DAG{Name: "jitter", ScheduleJitter: time.Hour}
+		job := &ScheduledJob{Job: &mockJob{DAG: dag}, Next: now, Type: ScheduleTypeStart}
+		waitFrom := now.Add(dag.JitterDelay() - 50*time.Millisecond)
+
+		leader, err := schedulerInstance.elector.TryAcquire()
+		require.NoError(t, err)
+		require.True(t, leader)
+		schedulerInstance.leader.Store(true)
+		require.True(t, schedulerInstance.waitToStart(context.Background(), job, waitFrom))
+
+		// The delayed job is not started once the lease is released.
+		require.NoError(t, schedulerInstance.elector.Release())
+		require.False(t, schedulerInstance.waitToStart(context.Background(), job, waitFrom))
+	})
 	t.Run("NextTick", func(t *testing.T) {
 		now := time.Date(2020, 1, 1, 1, 0, 50, 0, time.UTC)
 		setFixedTime(now)
@@ -72,6 +170,21 @@ func TestScheduler(t *testing.T) {
 	})
 }
 
+func TestStartThrottle(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	throttle := newStartThrottle(2)
+
+	var delays []time.Duration
+	for i := 0; i < 5; i++ {
+		delays = append(delays, throttle.reserve(now))
+	}
+	require.Equal(t, []time.Duration{0, 0, time.Second, time.Second, 2 * time.Second}, delays)
+
+	// The reservations are kept after the time passes.
+	require.Equal(t, 1500*time.Millisecond, throttle.reserve(now.Add(500*time.Millisecond)))
+	require.Equal(t, time.Duration(0), throttle.reserve(now.Add(5*time.Second)))
+}
+
 func TestFixedTime(t *testing.T) {
 	fixedTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
 
@@ -91,6 +204,7 @@ func TestJobReady(t *testing.T) {
 		schedule       string
 		now            time.Time
 		lastRunTime    time.Time
+		lastScheduled  time.Time
 		lastStatus     scheduler.Status
 		skipSuccessful bool
 		wantErr        error
@@ -131,6 +245,16 @@ func TestJobReady(t *testing.T) {
 			skipSuccessful: true,
 			wantErr:        ErrJobFinished,
 		},
+		{
+			name:           "last_run_delayed_after_next_schedule",
+			schedule:       "0 * * * *",
+			now:            time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC),
+			lastRunTime:    time.Date(2020, 1, 1, 1, 5, 0, 0, time.UTC),
+			lastScheduled:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			lastStatus:     scheduler.StatusError,
+			skipSuccessful: true,
+			wantErr:        nil,
+		},
 		{
 			name:           "failed_previous_run",
 			schedule:       "0 * * * *",
@@ -158,8 +282,9 @@ func TestJobReady(t *testing.T) {
 			}
 
 			lastRunStatus := model.Status{
-				Status:    tt.lastStatus,
-				StartedAt: stringutil.FormatTime(tt.lastRunTime),
+				Status:        tt.lastStatus,
+				StartedAt:     stringutil.FormatTime(tt.lastRunTime),
+				ScheduledTime: model.FormatTime(tt.lastScheduled),
 			}
 
 			err = job.ready(context.Background(), lastRunStatus)
@@ -168,6 +293,187 @@ func TestJobReady(t *testing.T) {
 	}
 }
 
+func TestJobSkipByCalendar(t *testing.T) {
+	th := setupTest(t)
+	ctx := context.Background()
+
+	file := filepath.Join(t.TempDir(), "calendar.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`timezone: Asia/Tokyo
+schedule: "0 * * * *"
+excludeDates:
+  - "2020-01-01"
+blackoutWindows:
+  - start: "23:00"
+    end: "01:00"
+    days: fri
+steps:
+  - name: step1
+    command: "true"
+`), 0600))
+	dag, err := digraph.Load(ctx, file, digraph.OnlyMetadata(), digraph.WithoutEval())
+	require.NoError(t, err)
+
+	newJob := func(next time.Time) *dagJob {
+		return &dagJob{
+			DAG:      dag,
+			Schedule: dag.Schedule[0].Parsed,
+			Next:     next,
+			Client:   th.client,
+		}
+	}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	t.Run("ExcludedDate", func(t *testing.T) {
+		// 2020-01-01 00:00 in Tokyo is 2019-12-31 15:00 UTC.
+		next := time.Date(2019, 12, 31, 15, 0, 0, 0, time.UTC)
+		err := newJob(next).Start(ctx)
+		require.ErrorIs(t, err, ErrJobSkipped)
+
+		// The skipped run is recorded in the history with the reason.
+		history := th.client.GetRecentHistory(ctx, dag, 1)
+		require.Len(t, history, 1)
+		require.Equal(t, scheduler.StatusSkipped, history[0].Status.Status)
+		require.Equal(t, "excluded date 2020-01-01", history[0].Status.SkipReason)
+		require.Equal(t, stringutil.FormatTime(next), history[0].Status.StartedAt)
+	})
+	t.Run("BlackoutWindow", func(t *testing.T) {
+		// 2020-01-03 is a Friday; the window continues to Saturday 01:00.
+		for _, tc := range []struct {
+			next time.Time
+			skip bool
+		}{
+			{time.Date(2020, 1, 3, 22, 0, 0, 0, tokyo), false},
+			{time.Date(2020, 1, 3, 23, 0, 0, 0, tokyo), true},
+			{time.Date(2020, 1, 4, 0, 0, 0, 0, tokyo), true},
+			{time.Date(2020, 1, 4, 1, 0, 0, 0, tokyo), false},
+			{time.Date(2020, 1, 4, 23, 0, 0, 0, tokyo), false},
+		} {
+			err := newJob(tc.next.UTC()).skipByCalendar()
+			if tc.skip {
+				require.ErrorIs(t, err, ErrJobSkipped, tc.next)
+				require.Contains(t, err.Error(), "blackout window 23:00-01:00 on Fri")
+			} else {
+				require.NoError(t, err, tc.next)
+			}
+		}
+	})
+}
+
+func TestJobDecisions(t *testing.T) {
+	th := setupTest(t)
+	ctx := context.Background()
+
+	file := filepath.Join(t.TempDir(), "decisions.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`schedule: "0 * * * *"
+excludeDates:
+  - "2020-01-01"
+steps:
+  - name: step1
+    command: "true"
+`), 0600))
+	dag, err := digraph.Load(ctx, file, digraph.OnlyMetadata(), digraph.WithoutEval())
+	require.NoError(t, err)
+
+	decisionsDir := t.TempDir()
+	store := local.NewDecisionStore(decisionsDir, 0)
+	newJob := func(next time.Time) *dagJob {
+		return &dagJob{
+			DAG:       dag,
+			Schedule:  dag.Schedule[0].Parsed,
+			Next:      next,
+			Client:    th.client,
+			Decisions: store,
+		}
+	}
+	latest := func(t *testing.T) model.SchedulerDecision {
+		t.Helper()
+		decisions, err := store.Query(ctx, persistence.DecisionFilter{DAG: dag.ID(), Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, decisions, 1)
+		return decisions[0]
+	}
+
+	t.Run("SkippedByCalendar", func(t *testing.T) {
+		next := time.Date(2020, 1, 1, 9, 0, 0, 0, time.Local)
+		require.ErrorIs(t, newJob(next).Start(ctx), ErrJobSkipped)
+
+		decision := latest(t)
+		require.Equal(t, "start", decision.Type)
+		require.Equal(t, model.DecisionSkippedCalendar, decision.Decision)
+		require.Contains(t, decision.Reason, "excluded date 2020-01-01")
+		require.True(t, next.Equal(decision.ScheduledTime))
+	})
+	t.Run("NotRunning", func(t *testing.T) {
+		require.ErrorIs(t, newJob(time.Now()).Stop(ctx), ErrJobIsNotRunning)
+
+		decision := latest(t)
+		require.Equal(t, "stop", decision.Type)
+		require.Equal(t, model.DecisionSkippedNotRunning, decision.Decision)
+	})
+	t.Run("Suspended", func(t *testing.T) {
+		require.NoError(t, th.client.ToggleSuspend(ctx, dag.ID(), true))
+		t.Cleanup(func() {
+			_ = th.client.ToggleSuspend(ctx, dag.ID(), false)
+		})
+		require.ErrorIs(t, newJob(time.Now()).Start(ctx), ErrJobSuspended)
+		require.Equal(t, model.DecisionSuspended, latest(t).Decision)
+	})
+	t.Run("OutcomeOfStartedRun", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			exitCode int
+			outcome  string
+		}{
+			{name: "Succeeded", exitCode: 0, outcome: model.OutcomeSucceeded},
+			{name: "PreconditionFailed", exitCode: digraph.ExitCodeConditionNotMet, outcome: model.OutcomePreconditionFailed},
+			{name: "Failed", exitCode: 1, outcome: model.OutcomeFailed},
+		}
+		for i, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// Stand in for the dagu executable started by the client,
+				// which sees the decisions recorded before the run.
+				executable := filepath.Join(t.TempDir(), "dagu")
+				seen := filepath.Join(t.TempDir(), "seen")
+				script := fmt.Sprintf("#!/bin/sh\ncat %s/* > %s\nexit %d\n", decisionsDir, seen, tc.exitCode)
+				require.NoError(t, os.WriteFile(executable, []byte(script), 0700))
+
+				job := newJob(time.Date(2020, 1, 2, 9+i, 0, 0, 0, time.Local))
+				job.Client = client.New(
+					local.NewDAGStore(th.config.Paths.DAGsDir),
+					jsondb.New(th.config.Paths.DataDir),
+					local.NewFlagStore(storage.NewStorage(th.config.Paths.SuspendFlagsDir)),
+					executable,
+					th.config.WorkDir,
+				)
+				_ = job.Start(ctx)
+
+				decisions, err := store.Query(ctx, persistence.DecisionFilter{DAG: dag.ID()})
+				require.NoError(t, err)
+				var fired []model.SchedulerDecision
+				for _, d := range decisions {
+					if d.ScheduledTime.Equal(job.Next) {
+						fired = append(fired, d)
+					}
+				}
+				require.Len(t, fired, 1)
+				require.Equal(t, model.DecisionStarted, fired[0].Decision)
+				require.Equal(t, tc.outcome, fired[0].Outcome)
+
+				// The firing was recorded as started when the run began.
+				data, err := os.ReadFile(seen)
+				require.NoError(t, err)
+				require.Contains(t, string(data), fmt.Sprintf(`"scheduledTime":%q,"dag":%q,"type":"start","decision":"started"}`, job.Next.Format(time.RFC3339Nano), dag.ID()))
+			})
+		}
+	})
+	t.Run("SkipDecision", func(t *testing.T) {
+		require.Equal(t, model.DecisionSkippedSuccessful, skipDecision(ErrJobSuccess))
+		require.Equal(t, model.DecisionSkippedFinished, skipDecision(ErrJobFinished))
+		require.Equal(t, model.DecisionSkippedRunning, skipDecision(ErrJobRunning))
+	})
+}
+
 func TestPrevExecTime(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -66,7 +66,7 @@ func setupTest(t *testing.T) testHelper {
 	historyStore := jsondb.New(cfg.Paths.DataDir)
 	flagStore := local.NewFlagStore(storage.NewStorage(cfg.Paths.SuspendFlagsDir))
 	cli := client.New(dagStore, historyStore, flagStore, "", cfg.WorkDir)
-	jobManager := NewDAGJobManager(testdataDir, cli, "", "")
+	jobManager := NewDAGJobManager(testdataDir, cli, "", "", nil)
 
 	return testHelper{
 		manager: jobManager,
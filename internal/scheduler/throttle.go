@@ -0,0 +1,37 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// startThrottle limits the number of jobs started in the same second so
+// that the DAGs sharing a schedule do not spawn their processes at once.
+type startThrottle struct {
+	limit int
+
+	mu     sync.Mutex
+	second time.Time // the second of the last reservation
+	count  int       // the number of reservations in the second
+}
+
+func newStartThrottle(limit int) *startThrottle {
+	return &startThrottle{limit: limit}
+}
+
+// reserve reserves a start in the first second that has room for it and
+// returns the time to wait until the second.
+func (t *startThrottle) reserve(now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if second := now.Truncate(time.Second); t.second.Before(second) {
+		t.second, t.count = second, 0
+	}
+	if t.count >= t.limit {
+		t.second, t.count = t.second.Add(time.Second), 0
+	}
+	t.count++
+
+	return max(t.second.Sub(now), 0)
+}
@@ -0,0 +1,26 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+var _ Provider = (*EnvProvider)(nil)
+
+// EnvProvider reads secrets from the environment variables of the process,
+// e.g. ${secret:env/DB_PASSWORD}.
+type EnvProvider struct{}
+
+// NewEnvProvider creates a new provider reading the environment variables.
+func NewEnvProvider() *EnvProvider {
+	return &EnvProvider{}
+}
+
+func (p *EnvProvider) Get(_ context.Context, path string) (string, error) {
+	value, ok := os.LookupEnv(path)
+	if !ok {
+		return "", fmt.Errorf("%w: environment variable %s is not set", ErrNotFound, path)
+	}
+	return value, nil
+}
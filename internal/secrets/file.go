@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var _ Provider = (*FileProvider)(nil)
+
+// FileProvider reads secrets from files, e.g. ${secret:file/db/password}
+// reads the file db/password in the base directory. This works with the
+// secrets mounted as files by Docker or Kubernetes.
+type FileProvider struct {
+	baseDir string
+}
+
+// NewFileProvider creates a new provider reading the files in the base
+// directory. The paths are absolute if the base directory is empty.
+func NewFileProvider(baseDir string) *FileProvider {
+	return &FileProvider{baseDir: baseDir}
+}
+
+func (p *FileProvider) Get(_ context.Context, path string) (string, error) {
+	file := path
+	if p.baseDir != "" {
+		if !filepath.IsLocal(filepath.FromSlash(path)) {
+			return "", fmt.Errorf("invalid path %q: must be relative to %s", path, p.baseDir)
+		}
+		file = filepath.Join(p.baseDir, filepath.FromSlash(path))
+	} else if !filepath.IsAbs(file) {
+		// The path of ${secret:file//run/secrets/db} is "/run/secrets/db",
+		// while that of ${secret:file/run/secrets/db} is "run/secrets/db".
+		file = string(filepath.Separator) + filepath.FromSlash(path)
+	}
+
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: file %s does not exist", ErrNotFound, file)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the secret file: %w", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
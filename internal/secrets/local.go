@@ -0,0 +1,512 @@
+package secrets
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// EnvKeyLocalKey is the environment variable holding the key of the
+// encrypted local secret store. It takes precedence over the key file.
+const EnvKeyLocalKey = "DAGU_SECRETS_KEY"
+
+// EnvKeyLocalKeyFile is the environment variable holding the path of the
+// key file of the local secret store.
+const EnvKeyLocalKeyFile = "DAGU_SECRETS_KEY_FILE"
+
+// keyEnvs are the environment variables taken by TakeKeyEnv.
+var keyEnvs = []string{EnvKeyLocalKey, EnvKeyLocalKeyFile}
+
+// ErrNoKey is returned when the key of the local secret store is not set.
+var ErrNoKey = errors.New("the key of the secret store is not set: set " + EnvKeyLocalKey + " or the key file")
+
+var (
+	takenMu   sync.Mutex
+	takenEnvs = map[string]string{}
+)
+
+// TakeKeyEnv reads the environment variables of the key of the local secret
+// store and unsets them, so that the processes started afterwards, e.g. the
+// steps of a DAG, do not inherit the key. LoadKey returns the key taken.
+func TakeKeyEnv() {
+	takenMu.Lock()
+	defer takenMu.Unlock()
+
+	for _, name := range keyEnvs {
+		if value, ok := os.LookupEnv(name); ok {
+			takenEnvs[name] = value
+			_ = os.Unsetenv(name)
+		}
+	}
+}
+
+// KeyEnv returns the environment variables of the key taken by TakeKeyEnv
+// to pass to a dagu process, e.g. of a sub workflow.
+func KeyEnv() []string {
+	takenMu.Lock()
+	defer takenMu.Unlock()
+
+	var envs []string
+	for _, name := range keyEnvs {
+		if value, ok := takenEnvs[name]; ok {
+			envs = append(envs, name+"="+value)
+		}
+	}
+	return envs
+}
+
+// WithoutKeyEnv returns the environment variables without the ones of the
+// key of the local secret store.
+func WithoutKeyEnv(envs []string) []string {
+	return slices.DeleteFunc(slices.Clone(envs), func(env string) bool {
+		name, _, _ := strings.Cut(env, "=")
+		return slices.Contains(keyEnvs, name)
+	})
+}
+
+// LoadKey returns the key of the local secret store from the environment
+// variable or the key file.
+func LoadKey(keyFile string) ([]byte, error) {
+	if key := os.Getenv(EnvKeyLocalKey); key != "" {
+		return []byte(key), nil
+	}
+	takenMu.Lock()
+	taken := takenEnvs[EnvKeyLocalKey]
+	takenMu.Unlock()
+	if taken != "" {
+		return []byte(taken), nil
+	}
+	if keyFile == "" {
+		return nil, ErrNoKey
+	}
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the key file: %w", err)
+	}
+	key := strings.TrimSpace(string(data))
+	if key == "" {
+		return nil, fmt.Errorf("%w: the key file %s is empty", ErrNoKey, keyFile)
+	}
+	return []byte(key), nil
+}
+
+// Scopes of the secrets in the store. A DAG can read the secrets of its own
+// scope, of the scope of its group and of the global scope.
+const (
+	ScopeGlobal      = "global"
+	scopePrefixDAG   = "dag:"
+	scopePrefixGroup = "group:"
+)
+
+// DAGScope returns the scope of the secrets of the DAG.
+func DAGScope(id string) string {
+	return scopePrefixDAG + id
+}
+
+// GroupScope returns the scope of the secrets of the DAGs of the group.
+func GroupScope(group string) string {
+	return scopePrefixGroup + group
+}
+
+// ErrInvalidScope is returned when the scope is not valid.
+var ErrInvalidScope = errors.New("invalid scope")
+
+// ValidateScope returns an error if the scope is not valid.
+func ValidateScope(scope string) error {
+	if scope == ScopeGlobal {
+		return nil
+	}
+	for _, prefix := range []string{scopePrefixDAG, scopePrefixGroup} {
+		if name, ok := strings.CutPrefix(scope, prefix); ok && name != "" {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q (must be %s, %s<dag> or %s<group>)", ErrInvalidScope, scope, ScopeGlobal, scopePrefixDAG, scopePrefixGroup)
+}
+
+// ErrInvalidName is returned when the name of a secret is not valid.
+var ErrInvalidName = errors.New("invalid secret name")
+
+var reName = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`)
+
+// ValidateName returns an error if the name of the secret is not valid.
+func ValidateName(name string) error {
+	if !reName.MatchString(name) {
+		return fmt.Errorf("%w: %q (must consist of letters, digits, '_', '.' and '-')", ErrInvalidName, name)
+	}
+	return nil
+}
+
+// Entry is a secret in the store without its value.
+type Entry struct {
+	Scope     string
+	Name      string
+	UpdatedAt time.Time
+}
+
+// Store is a file holding secrets encrypted with NaCl secretbox
+// (XSalsa20-Poly1305). The encryption key is derived from the key with
+// scrypt and a random salt stored in the file. The changes are serialized
+// across the processes by the lock file next to the file.
+type Store struct {
+	file string
+	key  []byte
+	mu   sync.Mutex
+}
+
+// storeVersion is the version of the format of the store file.
+const storeVersion = 1
+
+// storeFile is the content of the store file.
+type storeFile struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Nonce   []byte `json:"nonce"`
+	Data    []byte `json:"data"`
+}
+
+// storeData is the decrypted content of the store file, the secrets by
+// scope and name.
+type storeData map[string]map[string]storeValue
+
+type storeValue struct {
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// NewStore creates a new store of the file encrypted with the key.
+func NewStore(file string, key []byte) *Store {
+	return &Store{file: file, key: key}
+}
+
+// Get returns the value of the secret in the scope.
+func (s *Store) Get(scope, name string) (string, error) {
+	if err := ValidateScope(scope); err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	value, ok := data[scope][name]
+	if !ok {
+		return "", fmt.Errorf("%w: %s in the scope %s", ErrNotFound, name, scope)
+	}
+	return value.Value, nil
+}
+
+// Entry returns the secret in the scope without its value.
+func (s *Store) Entry(scope, name string) (Entry, error) {
+	if err := ValidateScope(scope); err != nil {
+		return Entry{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.load()
+	if err != nil {
+		return Entry{}, err
+	}
+	value, ok := data[scope][name]
+	if !ok {
+		return Entry{}, fmt.Errorf("%w: %s in the scope %s", ErrNotFound, name, scope)
+	}
+	return Entry{Scope: scope, Name: name, UpdatedAt: value.UpdatedAt}, nil
+}
+
+// Set sets the value of the secret in the scope.
+func (s *Store) Set(scope, name, value string) error {
+	if err := ValidateScope(scope); err != nil {
+		return err
+	}
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	return s.update(func(data storeData) error {
+		if data[scope] == nil {
+			data[scope] = map[string]storeValue{}
+		}
+		data[scope][name] = storeValue{Value: value, UpdatedAt: time.Now()}
+		return nil
+	})
+}
+
+// Delete deletes the secret in the scope.
+func (s *Store) Delete(scope, name string) error {
+	if err := ValidateScope(scope); err != nil {
+		return err
+	}
+
+	return s.update(func(data storeData) error {
+		if _, ok := data[scope][name]; !ok {
+			return fmt.Errorf("%w: %s in the scope %s", ErrNotFound, name, scope)
+		}
+		delete(data[scope], name)
+		if len(data[scope]) == 0 {
+			delete(data, scope)
+		}
+		return nil
+	})
+}
+
+// update loads the secrets, applies fn to them and saves them holding the
+// lock, so that the changes made by other processes at the same time are
+// not lost.
+func (s *Store) update(fn func(storeData) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.withLock(func() error {
+		data, err := s.load()
+		if err != nil {
+			return err
+		}
+		if err := fn(data); err != nil {
+			return err
+		}
+		return s.save(data)
+	})
+}
+
+// withLock runs fn holding an exclusive lock on the lock file next to the
+// store file.
+func (s *Store) withLock(fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(s.file), 0700); err != nil {
+		return fmt.Errorf("failed to create the directory of the secret store: %w", err)
+	}
+	f, err := os.OpenFile(s.file+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open the lock file of the secret store: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX); err != nil {
+		return fmt.Errorf("failed to lock the secret store: %w", err)
+	}
+	defer func() {
+		_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+	}()
+
+	return fn()
+}
+
+// List returns the secrets in the scope, or in all scopes if the scope is
+// empty, ordered by scope and name.
+func (s *Store) List(scope string) ([]Entry, error) {
+	if scope != "" {
+		if err := ValidateScope(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	for sc, values := range data {
+		if scope != "" && sc != scope {
+			continue
+		}
+		for name, value := range values {
+			entries = append(entries, Entry{Scope: sc, Name: name, UpdatedAt: value.UpdatedAt})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Scope != entries[j].Scope {
+			return entries[i].Scope < entries[j].Scope
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// lookup returns the value of the secret in the first of the scopes having it.
+func (s *Store) lookup(scopes []string, name string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	for _, scope := range scopes {
+		if value, ok := data[scope][name]; ok {
+			return value.Value, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s is not in the secret store", ErrNotFound, name)
+}
+
+// load decrypts and returns the secrets in the store. It returns no
+// secrets if the file does not exist.
+func (s *Store) load() (storeData, error) {
+	data, err := os.ReadFile(s.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return storeData{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the secret store: %w", err)
+	}
+
+	var f storeFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse the secret store %s: %w", s.file, err)
+	}
+	if f.Version != storeVersion || len(f.Nonce) != 24 {
+		return nil, fmt.Errorf("unsupported format of the secret store %s", s.file)
+	}
+
+	key, err := deriveKey(s.key, f.Salt)
+	if err != nil {
+		return nil, err
+	}
+	var nonce [24]byte
+	copy(nonce[:], f.Nonce)
+	plain, ok := secretbox.Open(nil, f.Data, &nonce, key)
+	if !ok {
+		return nil, fmt.Errorf("failed to decrypt the secret store %s: wrong key or corrupted file", s.file)
+	}
+
+	values := storeData{}
+	if err := json.Unmarshal(plain, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse the secrets: %w", err)
+	}
+	return values, nil
+}
+
+// save encrypts and writes the secrets to the store. The file is replaced
+// atomically and is readable only by the owner.
+func (s *Store) save(values storeData) error {
+	plain, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	f := storeFile{Version: storeVersion, Salt: make([]byte, 16), Nonce: make([]byte, 24)}
+	if _, err := rand.Read(f.Salt); err != nil {
+		return err
+	}
+	if _, err := rand.Read(f.Nonce); err != nil {
+		return err
+	}
+	key, err := deriveKey(s.key, f.Salt)
+	if err != nil {
+		return err
+	}
+	var nonce [24]byte
+	copy(nonce[:], f.Nonce)
+	f.Data = secretbox.Seal(nil, plain, &nonce, key)
+
+	data, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.file), 0700); err != nil {
+		return fmt.Errorf("failed to create the directory of the secret store: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.file), ".secrets-*")
+	if err != nil {
+		return fmt.Errorf("failed to write the secret store: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write the secret store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the secret store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.file); err != nil {
+		return fmt.Errorf("failed to write the secret store: %w", err)
+	}
+	return nil
+}
+
+// maxDerivedKeys is the number of the derived keys cached. A new salt is
+// used on every save, so the cache is cleared when it is full.
+const maxDerivedKeys = 16
+
+var (
+	derivedMu   sync.Mutex
+	derivedKeys = map[[sha256.Size]byte]*[32]byte{}
+)
+
+// deriveKey derives the encryption key from the key and the salt. The keys
+// are cached by the key and the salt as scrypt is slow on purpose and the
+// store is read on every lookup of a secret.
+func deriveKey(key, salt []byte) (*[32]byte, error) {
+	keyHash := sha256.Sum256(key)
+	cacheKey := sha256.Sum256(append(keyHash[:], salt...))
+
+	derivedMu.Lock()
+	defer derivedMu.Unlock()
+
+	if ret, ok := derivedKeys[cacheKey]; ok {
+		return ret, nil
+	}
+	derived, err := scrypt.Key(key, salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive the key: %w", err)
+	}
+	var ret [32]byte
+	copy(ret[:], derived)
+
+	if len(derivedKeys) >= maxDerivedKeys {
+		clear(derivedKeys)
+	}
+	derivedKeys[cacheKey] = &ret
+	return &ret, nil
+}
+
+var _ Provider = (*LocalProvider)(nil)
+
+// LocalProvider reads secrets from the encrypted local store, e.g.
+// ${secret:local/db_password}. The secret is looked up in the scopes in
+// order and then in the global scope.
+type LocalProvider struct {
+	file    string
+	keyFile string
+	scopes  []string
+}
+
+// NewLocalProvider creates a new provider reading the store file. The key is
+// read from the environment variable or the key file when a secret is read.
+func NewLocalProvider(file, keyFile string, scopes ...string) *LocalProvider {
+	return &LocalProvider{file: file, keyFile: keyFile, scopes: scopes}
+}
+
+func (p *LocalProvider) Get(_ context.Context, path string) (string, error) {
+	key, err := LoadKey(p.keyFile)
+	if err != nil {
+		return "", err
+	}
+	return NewStore(p.file, key).lookup(append(slices.Clone(p.scopes), ScopeGlobal), path)
+}
@@ -0,0 +1,211 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mask is the text replacing the values of the secrets.
+const Mask = "*****"
+
+// minMaskLength is the minimum length of the values to mask anywhere in the
+// text. Shorter values would mask unrelated text everywhere, so they are
+// masked only as whole words.
+const minMaskLength = 4
+
+// maxLineLength is the length of a line buffered by MaskWriter after which
+// it is written without waiting for the end of the line.
+const maxLineLength = 64 * 1024
+
+// Masker replaces the values of the secrets in text with the mask.
+type Masker struct {
+	mu       sync.RWMutex
+	values   map[string]bool
+	replacer *strings.Replacer
+	// short is the values shorter than minMaskLength, which are matched
+	// by the pattern as whole words.
+	short   map[string]bool
+	pattern *regexp.Regexp
+}
+
+// NewMasker creates a new masker with no values.
+func NewMasker() *Masker {
+	return &Masker{values: make(map[string]bool), short: make(map[string]bool)}
+}
+
+// Add adds the values to mask. Each line of a value of multiple lines is
+// masked as well as the logs are written line by line.
+func (m *Masker) Add(values ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, value := range values {
+		m.add(value)
+		if strings.ContainsAny(value, "\r\n") {
+			for _, line := range strings.FieldsFunc(value, func(r rune) bool { return r == '\r' || r == '\n' }) {
+				m.add(strings.TrimSpace(line))
+			}
+		}
+	}
+
+	var oldnew []string
+	for _, value := range sortValues(m.values) {
+		oldnew = append(oldnew, value, Mask)
+	}
+	m.replacer = strings.NewReplacer(oldnew...)
+
+	var words []string
+	for _, value := range sortValues(m.short) {
+		words = append(words, wordPattern(value))
+	}
+	if len(words) > 0 {
+		m.pattern = regexp.MustCompile(strings.Join(words, "|"))
+	}
+}
+
+func (m *Masker) add(value string) {
+	switch {
+	case value == "":
+	case len(value) < minMaskLength:
+		m.short[value] = true
+	default:
+		m.values[value] = true
+	}
+}
+
+// sortValues returns the values with the longer ones first as they are
+// replaced first when they contain the others.
+func sortValues(values map[string]bool) []string {
+	var sorted []string
+	for value := range values {
+		sorted = append(sorted, value)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+// wordPattern returns the pattern matching the value as a whole word, i.e.
+// not preceded or followed by a letter, a digit or an underscore.
+func wordPattern(value string) string {
+	pattern := regexp.QuoteMeta(value)
+	if isWordChar(value[0]) {
+		pattern = `\b` + pattern
+	}
+	if isWordChar(value[len(value)-1]) {
+		pattern += `\b`
+	}
+	return pattern
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// Mask replaces the values of the secrets in the string with the mask.
+func (m *Masker) Mask(s string) string {
+	if m == nil {
+		return s
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.replacer != nil {
+		s = m.replacer.Replace(s)
+	}
+	if m.pattern != nil {
+		s = m.pattern.ReplaceAllLiteralString(s, Mask)
+	}
+	return s
+}
+
+// MaskJSON replaces the values of the secrets in the strings of the JSON
+// document with the mask.
+func (m *Masker) MaskJSON(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m.maskValue(v))
+}
+
+func (m *Masker) maskValue(v any) any {
+	switch v := v.(type) {
+	case string:
+		return m.Mask(v)
+	case []any:
+		for i := range v {
+			v[i] = m.maskValue(v[i])
+		}
+	case map[string]any:
+		for k := range v {
+			v[k] = m.maskValue(v[k])
+		}
+	}
+	return v
+}
+
+// MaskWriter is a writer masking the values of the secrets in the text
+// written to the underlying writer. It buffers the text until the end of
+// the line so that a value split across writes is masked; Flush must be
+// called to write the rest.
+type MaskWriter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	masker *Masker
+	buf    []byte
+}
+
+// NewMaskWriter creates a new writer masking the text written to w.
+func NewMaskWriter(w io.Writer, masker *Masker) *MaskWriter {
+	return &MaskWriter{w: w, masker: masker}
+}
+
+func (w *MaskWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+
+	n := bytes.LastIndexAny(w.buf, "\r\n") + 1
+	if len(w.buf) > maxLineLength {
+		n = len(w.buf)
+	}
+	if n == 0 {
+		return len(p), nil
+	}
+	if err := w.writeMasked(w.buf[:n]); err != nil {
+		return 0, err
+	}
+	w.buf = append(w.buf[:0], w.buf[n:]...)
+	return len(p), nil
+}
+
+// Flush writes the buffered text to the underlying writer.
+func (w *MaskWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.writeMasked(w.buf)
+	w.buf = w.buf[:0]
+	return err
+}
+
+func (w *MaskWriter) writeMasked(p []byte) error {
+	_, err := io.WriteString(w.w, w.masker.Mask(string(p)))
+	return err
+}
@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMasker(t *testing.T) {
+	masker := NewMasker()
+	require.Equal(t, "password", masker.Mask("password"))
+
+	masker.Add("password", "pass-phrase", "abc", "line-one\nline-two")
+
+	t.Run("Mask", func(t *testing.T) {
+		require.Equal(t, "user=admin pw=*****", masker.Mask("user=admin pw=password"))
+		require.Equal(t, "*****", masker.Mask("pass-phrase"))
+	})
+	t.Run("ShortValue", func(t *testing.T) {
+		// A short value is masked only as a whole word.
+		require.Equal(t, "*****", masker.Mask("abc"))
+		require.Equal(t, "pw=***** user=abcd", masker.Mask("pw=abc user=abcd"))
+		require.Equal(t, "xabc abc_1", masker.Mask("xabc abc_1"))
+
+		short := NewMasker()
+		short.Add("1+", "")
+		require.Equal(t, "n=***** 11+ x", short.Mask("n=1+ 11+ x"))
+	})
+	t.Run("MultipleLines", func(t *testing.T) {
+		require.Equal(t, "*****", masker.Mask("line-one\nline-two"))
+		require.Equal(t, "***** and *****", masker.Mask("line-one and line-two"))
+	})
+	t.Run("JSON", func(t *testing.T) {
+		data, err := masker.MaskJSON([]byte(`{"Args":["-p","password"],"Count":10,"Output":"a\"password\""}`))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"Args":["-p","*****"],"Count":10,"Output":"a\"*****\""}`, string(data))
+	})
+	t.Run("Nil", func(t *testing.T) {
+		var masker *Masker
+		require.Equal(t, "password", masker.Mask("password"))
+	})
+}
+
+func TestMaskWriter(t *testing.T) {
+	masker := NewMasker()
+	masker.Add("password")
+
+	var buf bytes.Buffer
+	w := NewMaskWriter(&buf, masker)
+
+	// The value split across the writes is masked
+	_, err := w.Write([]byte("first pass"))
+	require.NoError(t, err)
+	require.Empty(t, buf.String())
+
+	_, err = w.Write([]byte("word\nsecond "))
+	require.NoError(t, err)
+	require.Equal(t, "first *****\n", buf.String())
+
+	_, err = w.Write([]byte("password"))
+	require.NoError(t, err)
+	require.NoError(t, w.Flush())
+	require.Equal(t, "first *****\nsecond *****", buf.String())
+}
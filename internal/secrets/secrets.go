@@ -0,0 +1,136 @@
+// Package secrets resolves references to secrets such as
+// ${secret:vault/db#password} at runtime and masks the resolved values in
+// the logs and statuses of the DAG runs.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+
+	"github.com/dagu-org/dagu/internal/logger"
+)
+
+// Errors returned when resolving a secret.
+var (
+	ErrUnknownProvider = errors.New("unknown secret provider")
+	ErrNotFound        = errors.New("secret not found")
+)
+
+// Provider retrieves the value of a secret by its path.
+type Provider interface {
+	Get(ctx context.Context, path string) (string, error)
+}
+
+// reference matches a reference to a secret, e.g. ${secret:env/DB_PASSWORD}.
+var reference = regexp.MustCompile(`\$\{secret:([^/}]+)/([^}]+)\}`)
+
+// HasReference returns true if the string contains a reference to a secret.
+func HasReference(s string) bool {
+	return reference.MatchString(s)
+}
+
+// ReplaceReferences replaces the references to secrets in the string with
+// the result of the function.
+func ReplaceReferences(s string, fn func(ref string) string) string {
+	return reference.ReplaceAllStringFunc(s, fn)
+}
+
+// Resolver resolves the references to secrets with the providers and
+// records the resolved values to mask them.
+type Resolver struct {
+	providers map[string]Provider
+	masker    *Masker
+
+	mu     sync.Mutex
+	values map[string]string
+}
+
+// NewResolver creates a new resolver with the providers by name.
+func NewResolver(providers map[string]Provider) *Resolver {
+	return &Resolver{
+		providers: providers,
+		masker:    NewMasker(),
+		values:    make(map[string]string),
+	}
+}
+
+// Masker returns the masker of the values resolved by the resolver.
+func (r *Resolver) Masker() *Masker {
+	return r.masker
+}
+
+// Resolve replaces the references to secrets in the string with their values.
+func (r *Resolver) Resolve(ctx context.Context, s string) (string, error) {
+	var lastErr error
+	ret := reference.ReplaceAllStringFunc(s, func(ref string) string {
+		m := reference.FindStringSubmatch(ref)
+		value, err := r.Get(ctx, m[1], m[2])
+		if err != nil {
+			lastErr = err
+			return ref
+		}
+		return value
+	})
+	if lastErr != nil {
+		return "", lastErr
+	}
+	return ret, nil
+}
+
+// Get returns the value of the secret at the path of the provider.
+// The values are cached for the lifetime of the resolver so that a secret
+// keeps the same value during a run.
+func (r *Resolver) Get(ctx context.Context, provider, path string) (string, error) {
+	key := provider + "/" + path
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if value, ok := r.values[key]; ok {
+		return value, nil
+	}
+
+	p, ok := r.providers[provider]
+	if !ok {
+		return "", fmt.Errorf("%w: %q in ${secret:%s}", ErrUnknownProvider, provider, key)
+	}
+	value, err := p.Get(ctx, path)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve ${secret:%s}: %w", key, err)
+	}
+
+	if value != "" && len(value) < minMaskLength {
+		logger.Warn(ctx, "The secret is too short to be masked except as a whole word", "secret", key)
+	}
+	r.values[key] = value
+	r.masker.Add(value)
+	return value, nil
+}
+
+type ctxKey struct{}
+
+// WithResolver returns a context with the resolver.
+func WithResolver(ctx context.Context, r *Resolver) context.Context {
+	return context.WithValue(ctx, ctxKey{}, r)
+}
+
+// FromContext returns the resolver of the context, or nil if it has none.
+func FromContext(ctx context.Context) *Resolver {
+	if ctx == nil {
+		return nil
+	}
+	r, _ := ctx.Value(ctxKey{}).(*Resolver)
+	return r
+}
+
+// MaskerFromContext returns the masker of the resolver of the context, or
+// nil if it has no resolver.
+func MaskerFromContext(ctx context.Context) *Masker {
+	if r := FromContext(ctx); r != nil {
+		return r.masker
+	}
+	return nil
+}
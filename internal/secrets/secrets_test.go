@@ -0,0 +1,259 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver(t *testing.T) {
+	t.Setenv("SECRETS_TEST_TOKEN", "token-value")
+
+	resolver := NewResolver(map[string]Provider{"env": NewEnvProvider()})
+	ctx := context.Background()
+
+	t.Run("Resolve", func(t *testing.T) {
+		value, err := resolver.Resolve(ctx, "Bearer ${secret:env/SECRETS_TEST_TOKEN}")
+		require.NoError(t, err)
+		require.Equal(t, "Bearer token-value", value)
+		require.Equal(t, "Bearer *****", resolver.Masker().Mask(value))
+	})
+	t.Run("UnknownProvider", func(t *testing.T) {
+		_, err := resolver.Resolve(ctx, "${secret:aws/token}")
+		require.ErrorIs(t, err, ErrUnknownProvider)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := resolver.Resolve(ctx, "${secret:env/SECRETS_TEST_NOT_SET}")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("Context", func(t *testing.T) {
+		require.Nil(t, FromContext(ctx))
+		require.Nil(t, MaskerFromContext(ctx))
+		require.Same(t, resolver, FromContext(WithResolver(ctx, resolver)))
+	})
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "db"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db", "password"), []byte("password\n"), 0600))
+
+	t.Run("BaseDir", func(t *testing.T) {
+		value, err := NewFileProvider(dir).Get(context.Background(), "db/password")
+		require.NoError(t, err)
+		require.Equal(t, "password", value)
+	})
+	t.Run("OutsideBaseDir", func(t *testing.T) {
+		_, err := NewFileProvider(filepath.Join(dir, "db")).Get(context.Background(), "../db/password")
+		require.Error(t, err)
+	})
+	t.Run("AbsolutePath", func(t *testing.T) {
+		value, err := NewFileProvider("").Get(context.Background(), filepath.ToSlash(filepath.Join(dir, "db", "password")))
+		require.NoError(t, err)
+		require.Equal(t, "password", value)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewFileProvider(dir).Get(context.Background(), "db/user")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestStore(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secrets.enc")
+	store := NewStore(file, []byte("key"))
+
+	require.NoError(t, store.Set(ScopeGlobal, "db_password", "global-password"))
+	require.NoError(t, store.Set(DAGScope("team-a/etl"), "db_password", "etl-password"))
+	require.NoError(t, store.Set(GroupScope("reports"), "api_key", "reports-key"))
+
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "password")
+
+	t.Run("Get", func(t *testing.T) {
+		value, err := store.Get(DAGScope("team-a/etl"), "db_password")
+		require.NoError(t, err)
+		require.Equal(t, "etl-password", value)
+
+		_, err = store.Get(DAGScope("team-a/etl"), "api_key")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("List", func(t *testing.T) {
+		entries, err := store.List("")
+		require.NoError(t, err)
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Scope+" "+e.Name)
+			require.False(t, e.UpdatedAt.IsZero())
+		}
+		require.Equal(t, []string{"dag:team-a/etl db_password", "global db_password", "group:reports api_key"}, names)
+
+		entries, err = store.List(ScopeGlobal)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		require.ErrorIs(t, store.Set("team", "db_password", "x"), ErrInvalidScope)
+		require.ErrorIs(t, store.Set(ScopeGlobal, "db/password", "x"), ErrInvalidName)
+		_, err := store.Get("dag:", "db_password")
+		require.ErrorIs(t, err, ErrInvalidScope)
+	})
+	t.Run("WrongKey", func(t *testing.T) {
+		_, err := NewStore(file, []byte("wrong")).Get(ScopeGlobal, "db_password")
+		require.Error(t, err)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		require.NoError(t, store.Delete(GroupScope("reports"), "api_key"))
+		require.ErrorIs(t, store.Delete(GroupScope("reports"), "api_key"), ErrNotFound)
+	})
+	t.Run("ConcurrentSet", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "secrets.enc")
+
+		// The stores stand in for the processes changing the file.
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, NewStore(file, []byte("key")).Set(ScopeGlobal, fmt.Sprintf("secret%d", i), "value"))
+			}()
+		}
+		wg.Wait()
+
+		entries, err := NewStore(file, []byte("key")).List(ScopeGlobal)
+		require.NoError(t, err)
+		require.Len(t, entries, 8)
+	})
+	t.Run("DerivedKeyCache", func(t *testing.T) {
+		salt := []byte("0123456789abcdef")
+		first, err := deriveKey([]byte("key"), salt)
+		require.NoError(t, err)
+		second, err := deriveKey([]byte("key"), salt)
+		require.NoError(t, err)
+		require.Same(t, first, second)
+
+		other, err := deriveKey([]byte("other"), salt)
+		require.NoError(t, err)
+		require.NotEqual(t, *first, *other)
+	})
+}
+
+func TestLocalProvider(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secrets.enc")
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("key\n"), 0600))
+
+	key, err := LoadKey(keyFile)
+	require.NoError(t, err)
+	require.Equal(t, []byte("key"), key)
+
+	store := NewStore(file, key)
+	require.NoError(t, store.Set(ScopeGlobal, "db_password", "global-password"))
+	require.NoError(t, store.Set(GroupScope("reports"), "db_password", "reports-password"))
+	require.NoError(t, store.Set(DAGScope("etl"), "db_password", "etl-password"))
+
+	ctx := context.Background()
+
+	t.Run("Scopes", func(t *testing.T) {
+		value, err := NewLocalProvider(file, keyFile, DAGScope("etl"), GroupScope("reports")).Get(ctx, "db_password")
+		require.NoError(t, err)
+		require.Equal(t, "etl-password", value)
+
+		value, err = NewLocalProvider(file, keyFile, DAGScope("report"), GroupScope("reports")).Get(ctx, "db_password")
+		require.NoError(t, err)
+		require.Equal(t, "reports-password", value)
+
+		value, err = NewLocalProvider(file, keyFile, DAGScope("other")).Get(ctx, "db_password")
+		require.NoError(t, err)
+		require.Equal(t, "global-password", value)
+	})
+	t.Run("KeyFromEnv", func(t *testing.T) {
+		t.Setenv(EnvKeyLocalKey, "key")
+		value, err := NewLocalProvider(file, "").Get(ctx, "db_password")
+		require.NoError(t, err)
+		require.Equal(t, "global-password", value)
+	})
+	t.Run("TakeKeyEnv", func(t *testing.T) {
+		t.Setenv(EnvKeyLocalKey, "key")
+		t.Setenv(EnvKeyLocalKeyFile, keyFile)
+		t.Cleanup(func() {
+			takenEnvs = map[string]string{}
+		})
+		TakeKeyEnv()
+
+		_, ok := os.LookupEnv(EnvKeyLocalKey)
+		require.False(t, ok)
+		_, ok = os.LookupEnv(EnvKeyLocalKeyFile)
+		require.False(t, ok)
+		require.Equal(t, []string{EnvKeyLocalKey + "=key", EnvKeyLocalKeyFile + "=" + keyFile}, KeyEnv())
+		require.Equal(t, []string{"HOME=/root"}, WithoutKeyEnv([]string{EnvKeyLocalKey + "=key", "HOME=/root"}))
+
+		value, err := NewLocalProvider(file, "").Get(ctx, "db_password")
+		require.NoError(t, err)
+		require.Equal(t, "global-password", value)
+	})
+	t.Run("NoKey", func(t *testing.T) {
+		t.Setenv(EnvKeyLocalKey, "")
+		_, err := NewLocalProvider(file, "").Get(ctx, "db_password")
+		require.ErrorIs(t, err, ErrNoKey)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewLocalProvider(file, keyFile).Get(ctx, "api_key")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/db":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"kv2-password","port":5432},"metadata":{"version":1}}}`))
+		case "/v1/kv/db":
+			_, _ = w.Write([]byte(`{"data":{"value":"kv1-password"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewVaultProvider(VaultConfig{Address: server.URL, Token: "token"})
+	ctx := context.Background()
+
+	t.Run("KV2", func(t *testing.T) {
+		value, err := provider.Get(ctx, "secret/data/db#password")
+		require.NoError(t, err)
+		require.Equal(t, "kv2-password", value)
+
+		value, err = provider.Get(ctx, "secret/data/db#port")
+		require.NoError(t, err)
+		require.Equal(t, "5432", value)
+	})
+	t.Run("KV1", func(t *testing.T) {
+		value, err := provider.Get(ctx, "kv/db")
+		require.NoError(t, err)
+		require.Equal(t, "kv1-password", value)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := provider.Get(ctx, "secret/data/api")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		_, err = provider.Get(ctx, "secret/data/db#user")
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("Forbidden", func(t *testing.T) {
+		_, err := NewVaultProvider(VaultConfig{Address: server.URL, Token: "wrong"}).Get(ctx, "kv/db")
+		require.Error(t, err)
+	})
+}
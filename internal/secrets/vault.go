@@ -0,0 +1,126 @@
+package secrets
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+var _ Provider = (*VaultProvider)(nil)
+
+// VaultConfig is the configuration of the provider reading secrets from a
+// HashiCorp Vault compatible HTTP API.
+type VaultConfig struct {
+	// Address is the URL of the server (default VAULT_ADDR).
+	Address string
+	// Token is the token to authenticate with (default VAULT_TOKEN).
+	Token string
+	// Namespace is the namespace of Vault Enterprise (default VAULT_NAMESPACE).
+	Namespace     string
+	SkipTLSVerify bool
+}
+
+// VaultProvider reads secrets from a HashiCorp Vault compatible HTTP API.
+// The path is the path of the API after /v1/ followed by the field of the
+// secret, e.g. ${secret:vault/secret/data/db#password}. The field defaults
+// to "value". Both the KV version 1 and 2 secrets engines are supported.
+type VaultProvider struct {
+	cfg    VaultConfig
+	client *http.Client
+}
+
+// NewVaultProvider creates a new provider reading secrets from the server.
+func NewVaultProvider(cfg VaultConfig) *VaultProvider {
+	if cfg.Address == "" {
+		cfg.Address = os.Getenv("VAULT_ADDR")
+	}
+	if cfg.Token == "" {
+		cfg.Token = os.Getenv("VAULT_TOKEN")
+	}
+	if cfg.Namespace == "" {
+		cfg.Namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	if cfg.SkipTLSVerify {
+		client.Transport = &http.Transport{
+			// nolint: gosec
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+	return &VaultProvider{cfg: cfg, client: client}
+}
+
+func (p *VaultProvider) Get(ctx context.Context, path string) (string, error) {
+	if p.cfg.Address == "" {
+		return "", fmt.Errorf("the address of the vault server is not set")
+	}
+
+	path, field, _ := strings.Cut(path, "#")
+	if field == "" {
+		field = "value"
+	}
+
+	url := strings.TrimRight(p.cfg.Address, "/") + "/v1/" + strings.TrimLeft(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	if p.cfg.Token != "" {
+		req.Header.Set("X-Vault-Token", p.cfg.Token)
+	}
+	if p.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.cfg.Namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request the vault server: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("failed to read the response of the vault server: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("%w: %s", ErrNotFound, path)
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("the vault server returned %s", resp.Status)
+	}
+
+	var result struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse the response of the vault server: %w", err)
+	}
+
+	data := result.Data
+	// The KV version 2 secrets engine nests the secret with its metadata.
+	if nested, ok := data["data"].(map[string]any); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("%w: field %q of %s", ErrNotFound, field, path)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
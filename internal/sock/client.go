@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -28,36 +29,67 @@ const (
 	defaultTimeout = time.Millisecond * 3000
 )
 
+// ResponseError is the error of a response with a status code other than
+// 2xx.
+type ResponseError struct {
+	StatusCode int
+	Message    string
+}
+
+// Error implements error interface.
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
 // Request sends a request to the frontend and returns the response.
 func (cl *Client) Request(method, url string) (string, error) {
-	conn, err := net.DialTimeout("unix", cl.addr, defaultTimeout)
+	body, _, err := cl.request(method, url, defaultTimeout)
+	return body, err
+}
+
+// Do sends a request to the frontend and returns the response, waiting for
+// it until the timeout, e.g. of a long poll. It returns a *ResponseError if
+// the status code of the response is not 2xx.
+func (cl *Client) Do(method, url string, timeout time.Duration) (string, error) {
+	body, statusCode, err := cl.request(method, url, timeout)
+	if err != nil {
+		return "", err
+	}
+	if statusCode/100 != 2 {
+		return "", &ResponseError{StatusCode: statusCode, Message: strings.TrimSpace(body)}
+	}
+	return body, nil
+}
+
+func (cl *Client) request(method, url string, timeout time.Duration) (string, int, error) {
+	conn, err := net.DialTimeout("unix", cl.addr, timeout)
 	if err != nil {
-		return "", fmt.Errorf("dial failed: %w", err)
+		return "", 0, fmt.Errorf("dial failed: %w", err)
 	}
 
 	defer func() {
 		_ = conn.Close()
 	}()
 
-	if err := conn.SetDeadline((time.Now().Add(defaultTimeout))); err != nil {
-		return "", fmt.Errorf("set deadline failed: %w", err)
+	if err := conn.SetDeadline((time.Now().Add(timeout))); err != nil {
+		return "", 0, fmt.Errorf("set deadline failed: %w", err)
 	}
 
 	request, err := http.NewRequest(method, url, nil)
 	if err != nil {
-		return "", fmt.Errorf("create request failed: %w", err)
+		return "", 0, fmt.Errorf("create request failed: %w", err)
 	}
 
 	if err := request.Write(conn); err != nil {
-		return "", fmt.Errorf("write request failed: %w", err)
+		return "", 0, fmt.Errorf("write request failed: %w", err)
 	}
 
 	response, err := http.ReadResponse(bufio.NewReader(conn), request)
 	if err != nil {
 		if err, ok := err.(net.Error); ok && err.Timeout() {
-			return "", fmt.Errorf("request timeout: %w", ErrTimeout)
+			return "", 0, fmt.Errorf("request timeout: %w", ErrTimeout)
 		}
-		return "", fmt.Errorf("read response failed: %w", err)
+		return "", 0, fmt.Errorf("read response failed: %w", err)
 	}
 	defer func() {
 		_ = response.Body.Close()
@@ -65,8 +97,8 @@ func (cl *Client) Request(method, url string) (string, error) {
 
 	body, err := io.ReadAll(response.Body)
 	if err != nil {
-		return "", fmt.Errorf("read body failed: %w", err)
+		return "", 0, fmt.Errorf("read body failed: %w", err)
 	}
 
-	return string(body), nil
+	return string(body), response.StatusCode, nil
 }
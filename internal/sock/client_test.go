@@ -53,3 +53,47 @@ func TestDialTimeout(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, errors.Is(err, sock.ErrTimeout))
 }
+
+func TestDo(t *testing.T) {
+	f, err := os.CreateTemp("", "sock_client_do")
+	require.NoError(t, err)
+	defer func() {
+		_ = os.Remove(f.Name())
+	}()
+
+	srv, err := sock.NewServer(
+		f.Name(),
+		func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/wait":
+				// Longer than the timeout of Request.
+				time.Sleep(time.Millisecond * 3500)
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte("OK"))
+			default:
+				http.Error(w, "Not found", http.StatusNotFound)
+			}
+		},
+	)
+	require.NoError(t, err)
+
+	go func() {
+		_ = srv.Serve(context.Background(), nil)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Shutdown(context.Background())
+	})
+
+	time.Sleep(time.Millisecond * 500)
+
+	client := sock.NewClient(f.Name())
+	body, err := client.Do("GET", "/wait", time.Second*5)
+	require.NoError(t, err)
+	require.Equal(t, "OK", body)
+
+	_, err = client.Do("GET", "/unknown", time.Second)
+	var respErr *sock.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	require.Equal(t, http.StatusNotFound, respErr.StatusCode)
+	require.Equal(t, "Not found", respErr.Message)
+}
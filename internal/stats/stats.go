@@ -0,0 +1,137 @@
+// Package stats aggregates the durations and the resource usage of the
+// steps of a DAG over the history of its runs.
+package stats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stringutil"
+)
+
+// Step is the statistics of a step over the runs.
+type Step struct {
+	// Name is the name of the step.
+	Name string `json:"name"`
+	// Runs is the number of the runs in which the step finished or failed.
+	Runs int `json:"runs"`
+	// Failures is the number of the runs in which the step failed.
+	Failures int `json:"failures"`
+	// Duration is the wall time of the step.
+	Duration *Summary `json:"duration,omitempty"`
+	// CPUTime is the sum of the user and the system CPU time of the step.
+	CPUTime *Summary `json:"cpuTime,omitempty"`
+	// PeakRSS is the peak resident set size of the step in bytes.
+	PeakRSS *Summary `json:"peakRSS,omitempty"`
+}
+
+// Summary is the percentiles and the maximum of the values of a step. The
+// durations are in nanoseconds. It is nil if no values are recorded, e.g.
+// the usage of the runs before it was recorded.
+type Summary struct {
+	P50 int64 `json:"p50"`
+	P95 int64 `json:"p95"`
+	Max int64 `json:"max"`
+}
+
+// Steps returns the statistics of the steps over the statuses of the runs,
+// in the order of the steps of the most recent run. The steps which did not
+// run, e.g. skipped or canceled ones, are not counted.
+func Steps(statuses []*model.Status) []Step {
+	type samples struct {
+		runs, failures      int
+		durations, cpu, rss []int64
+	}
+
+	var names []string
+	steps := map[string]*samples{}
+	for _, status := range statuses {
+		for _, node := range allNodes(status) {
+			if node.Status != scheduler.NodeStatusSuccess && node.Status != scheduler.NodeStatusError {
+				continue
+			}
+			name := node.Step.Name
+			s, ok := steps[name]
+			if !ok {
+				s = &samples{}
+				steps[name] = s
+				names = append(names, name)
+			}
+
+			s.runs++
+			if node.Status == scheduler.NodeStatusError {
+				s.failures++
+			}
+			if d, ok := duration(node); ok {
+				s.durations = append(s.durations, int64(d))
+			}
+			if node.UserTime > 0 || node.SystemTime > 0 {
+				s.cpu = append(s.cpu, int64(time.Duration(node.UserTime+node.SystemTime)*time.Millisecond))
+			}
+			if node.PeakRSS > 0 {
+				s.rss = append(s.rss, node.PeakRSS)
+			}
+		}
+	}
+
+	var ret []Step
+	for _, name := range names {
+		s := steps[name]
+		ret = append(ret, Step{
+			Name:     name,
+			Runs:     s.runs,
+			Failures: s.failures,
+			Duration: summarize(s.durations),
+			CPUTime:  summarize(s.cpu),
+			PeakRSS:  summarize(s.rss),
+		})
+	}
+	return ret
+}
+
+func allNodes(status *model.Status) []*model.Node {
+	nodes := append([]*model.Node{}, status.Nodes...)
+	for _, node := range []*model.Node{status.OnExit, status.OnSuccess, status.OnFailure, status.OnCancel} {
+		if node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+func duration(node *model.Node) (time.Duration, bool) {
+	startedAt, err := stringutil.ParseTime(node.StartedAt)
+	if err != nil || startedAt.IsZero() {
+		return 0, false
+	}
+	finishedAt, err := stringutil.ParseTime(node.FinishedAt)
+	if err != nil || finishedAt.Before(startedAt) {
+		return 0, false
+	}
+	return finishedAt.Sub(startedAt), true
+}
+
+func summarize(values []int64) *Summary {
+	if len(values) == 0 {
+		return nil
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return &Summary{
+		P50: Percentile(values, 50),
+		P95: Percentile(values, 95),
+		Max: values[len(values)-1],
+	}
+}
+
+// Percentile returns the p-th percentile of the sorted values by the
+// nearest-rank method.
+func Percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank, 1), len(sorted))-1]
+}
@@ -0,0 +1,60 @@
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dagu-org/dagu/internal/digraph"
+	"github.com/dagu-org/dagu/internal/digraph/scheduler"
+	"github.com/dagu-org/dagu/internal/persistence/model"
+	"github.com/dagu-org/dagu/internal/stringutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSteps(t *testing.T) {
+	startedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	node := func(name string, status scheduler.NodeStatus, seconds int, cpuMillis, rss int64) *model.Node {
+		return &model.Node{
+			Step:       digraph.Step{Name: name},
+			Status:     status,
+			StartedAt:  stringutil.FormatTime(startedAt),
+			FinishedAt: stringutil.FormatTime(startedAt.Add(time.Duration(seconds) * time.Second)),
+			UserTime:   cpuMillis,
+			PeakRSS:    rss,
+		}
+	}
+
+	var statuses []*model.Status
+	for i := 1; i <= 20; i++ {
+		statuses = append(statuses, &model.Status{Nodes: []*model.Node{
+			node("build", scheduler.NodeStatusSuccess, i, int64(i*100), int64(i<<20)),
+			node("deploy", scheduler.NodeStatusSkipped, 0, 0, 0),
+		}})
+	}
+	statuses[0].Nodes[0].Status = scheduler.NodeStatusError
+	statuses[0].OnExit = node("cleanup", scheduler.NodeStatusSuccess, 1, 0, 0)
+
+	require.Equal(t, []Step{
+		{
+			Name:     "build",
+			Runs:     20,
+			Failures: 1,
+			Duration: &Summary{P50: int64(10 * time.Second), P95: int64(19 * time.Second), Max: int64(20 * time.Second)},
+			CPUTime:  &Summary{P50: int64(time.Second), P95: int64(1900 * time.Millisecond), Max: int64(2 * time.Second)},
+			PeakRSS:  &Summary{P50: 10 << 20, P95: 19 << 20, Max: 20 << 20},
+		},
+		{
+			Name:     "cleanup",
+			Runs:     1,
+			Duration: &Summary{P50: int64(time.Second), P95: int64(time.Second), Max: int64(time.Second)},
+		},
+	}, Steps(statuses))
+}
+
+func TestPercentile(t *testing.T) {
+	require.Equal(t, int64(0), Percentile(nil, 50))
+	require.Equal(t, int64(3), Percentile([]int64{3}, 95))
+	require.Equal(t, int64(2), Percentile([]int64{1, 2, 3, 4}, 50))
+	require.Equal(t, int64(4), Percentile([]int64{1, 2, 3, 4}, 95))
+	require.Equal(t, int64(1), Percentile([]int64{1, 2, 3, 4}, 0))
+}
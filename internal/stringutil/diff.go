@@ -0,0 +1,20 @@
+package stringutil
+
+import (
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// UnifiedDiff returns a unified diff between two texts.
+// It returns an empty string when the texts are identical.
+func UnifiedDiff(from, to, fromName, toName string) (string, error) {
+	if from == to {
+		return "", nil
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(from),
+		B:        difflib.SplitLines(to),
+		FromFile: fromName,
+		ToFile:   toName,
+		Context:  3,
+	})
+}
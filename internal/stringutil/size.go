@@ -0,0 +1,34 @@
+package stringutil
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// sizeUnits are the units of the sizes, e.g. 512M or 1Gi.
+var sizeUnits = map[string]int64{
+	"":   1,
+	"k":  1000,
+	"m":  1000 * 1000,
+	"g":  1000 * 1000 * 1000,
+	"ki": 1 << 10,
+	"mi": 1 << 20,
+	"gi": 1 << 30,
+}
+
+// ParseSize parses a positive size in bytes, or with a unit, e.g. 512M or
+// 1Gi. The unit is case-insensitive and may be followed by "B".
+func ParseSize(value string) (int64, error) {
+	s := strings.TrimSpace(value)
+	i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if i < 0 {
+		i = len(s)
+	}
+	n, err := strconv.ParseInt(s[:i], 10, 64)
+	unit, ok := sizeUnits[strings.TrimSuffix(strings.ToLower(s[i:]), "b")]
+	if err != nil || !ok || n <= 0 {
+		return 0, errors.New("must be a positive size, e.g. 512M or 1Gi")
+	}
+	return n * unit, nil
+}
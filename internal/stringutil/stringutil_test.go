@@ -58,3 +58,37 @@ func TestTruncString(t *testing.T) {
 		require.Equal(t, "12345678", stringutil.TruncString("123456789", 8))
 	})
 }
+
+func TestUnifiedDiff(t *testing.T) {
+	t.Run("Changed", func(t *testing.T) {
+		diff, err := stringutil.UnifiedDiff("a\nb\n", "a\nc\n", "before", "after")
+		require.NoError(t, err)
+		require.Contains(t, diff, "--- before")
+		require.Contains(t, diff, "+++ after")
+		require.Contains(t, diff, "-b")
+		require.Contains(t, diff, "+c")
+	})
+	t.Run("Identical", func(t *testing.T) {
+		diff, err := stringutil.UnifiedDiff("a\n", "a\n", "before", "after")
+		require.NoError(t, err)
+		require.Empty(t, diff)
+	})
+}
+
+func TestParseSize(t *testing.T) {
+	for input, want := range map[string]int64{
+		"512":   512,
+		"10k":   10 * 1000,
+		"512M":  512 * 1000 * 1000,
+		"1Gi":   1 << 30,
+		"64KiB": 64 << 10,
+	} {
+		got, err := stringutil.ParseSize(input)
+		require.NoError(t, err, input)
+		require.Equal(t, want, got, input)
+	}
+	for _, input := range []string{"", "0", "-1", "1T", "M"} {
+		_, err := stringutil.ParseSize(input)
+		require.Error(t, err, input)
+	}
+}
@@ -81,7 +81,9 @@ func Setup(t *testing.T, opts ...TestHelperOption) Helper {
 		cfg.Paths.DAGsDir = options.DAGsDir
 	}
 
-	dagStore := local.NewDAGStore(cfg.Paths.DAGsDir)
+	dagStore := local.NewDAGStore(cfg.Paths.DAGsDir,
+		local.WithRevisionsDir(filepath.Join(cfg.Paths.DataDir, "revisions")),
+	)
 	historyStore := jsondb.New(cfg.Paths.DataDir)
 	flagStore := local.NewFlagStore(
 		storage.NewStorage(cfg.Paths.SuspendFlagsDir),
@@ -0,0 +1,9 @@
+// Package schemas embeds the JSON schemas of the files of Dagu.
+package schemas
+
+import _ "embed"
+
+// DAG is the JSON schema of the DAG files.
+//
+//go:embed dag.schema.json
+var DAG []byte
@@ -1,4 +1,14 @@
-import { Box, Stack } from '@mui/material';
+import {
+  Box,
+  Stack,
+  Tab,
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableRow,
+  Tabs,
+} from '@mui/material';
 import React from 'react';
 import { LogFile } from '../../models/api';
 import BorderedBox from '../atoms/BorderedBox';
@@ -16,7 +26,14 @@ const ANSI_CODES_REGEX = [
   '(?:(?:\\d{1,4}(?:;\\d{0,4})*)?[\\dA-PR-TZcf-nq-uy=><~]))',
 ].join('|');
 
+const levelColors: { [level: string]: string } = {
+  warn: 'orange',
+  error: 'red',
+  fatal: 'red',
+};
+
 function ExecutionLog({ log }: Props) {
+  const [view, setView] = React.useState('text');
   if (!log) {
     return <LoadingIndicator />;
   }
@@ -42,26 +59,65 @@ function ExecutionLog({ log }: Props) {
           </React.Fragment>
         ) : null}
       </Stack>
-      <BorderedBox
-        sx={{
-          mt: 2,
-          py: 2,
-          px: 2,
-          height: '60vh',
-          overflow: 'auto',
-          backgroundColor: 'black',
-        }}
-      >
-        <pre
-          style={{
-            color: 'white',
-            height: '100%',
-            fontFamily: 'Courier New, Courier, monospace',
+      {log.Entries ? (
+        <Tabs value={view} onChange={(_, v) => setView(v)} sx={{ mt: 1 }}>
+          <Tab label="Text" value="text" />
+          <Tab label="Table" value="table" />
+        </Tabs>
+      ) : null}
+      {log.Entries && view == 'table' ? (
+        <BorderedBox sx={{ mt: 2, height: '60vh', overflow: 'auto' }}>
+          <Table size="small" stickyHeader>
+            <TableHead>
+              <TableRow>
+                <TableCell>Time</TableCell>
+                <TableCell>Level</TableCell>
+                <TableCell>Message</TableCell>
+                <TableCell>Fields</TableCell>
+              </TableRow>
+            </TableHead>
+            <TableBody>
+              {log.Entries.map((entry, i) => (
+                <TableRow key={i}>
+                  <TableCell sx={{ whiteSpace: 'nowrap' }}>
+                    {entry.time}
+                  </TableCell>
+                  <TableCell
+                    sx={{ color: levelColors[entry.level || ''] || 'inherit' }}
+                  >
+                    {entry.level}
+                  </TableCell>
+                  <TableCell>{entry.message}</TableCell>
+                  <TableCell sx={{ fontFamily: 'monospace' }}>
+                    {entry.fields ? JSON.stringify(entry.fields) : ''}
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        </BorderedBox>
+      ) : (
+        <BorderedBox
+          sx={{
+            mt: 2,
+            py: 2,
+            px: 2,
+            height: '60vh',
+            overflow: 'auto',
+            backgroundColor: 'black',
           }}
         >
-          {log.Content || '<No log output>'}
-        </pre>
-      </BorderedBox>
+          <pre
+            style={{
+              color: 'white',
+              height: '100%',
+              fontFamily: 'Courier New, Courier, monospace',
+            }}
+          >
+            {log.Content || '<No log output>'}
+          </pre>
+        </BorderedBox>
+      )}
     </Box>
   );
 }
@@ -47,6 +47,14 @@ export type LogFile = {
   Step?: Node;
   LogFile: string;
   Content: string;
+  Entries?: LogEntry[];
+};
+
+export type LogEntry = {
+  time?: string;
+  level?: string;
+  message?: string;
+  fields?: { [key: string]: unknown };
 };
 
 export type GridData = {
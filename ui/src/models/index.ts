@@ -198,6 +198,7 @@ export type Node = {
   DoneCount: number;
   Error: string;
   StatusText: string;
+  LogLevels?: { [level: string]: number };
 };
 
 export type StatusFile = {